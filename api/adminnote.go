@@ -0,0 +1,263 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailNotes(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailNotesRequestObject) (PostEventsV1EventIdRegistrationsEmailNotesResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailNotes")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var author string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		author = jwt.UserEmail()
+	}
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	reg, note, err := registration.AddAdminNote(ctx, registration.AddAdminNoteParams{
+		EventID: request.EventId,
+		Email:   strings.ToLower(string(request.Email)),
+		Text:    request.Body.Text,
+		Author:  author,
+	}, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to add admin note", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_INVALID_ADMIN_ANNOTATION:
+				return PostEventsV1EventIdRegistrationsEmailNotes400JSONResponse{
+					Code:    InputValidationError,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailNotes404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailNotes500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to add note",
+		}, nil
+	}
+
+	logger.Info("Added admin note",
+		slog.String("eventId", request.EventId.String()),
+		slog.String("email", reg.GetEmail()),
+		slog.String("noteId", note.ID.String()),
+		slog.String("author", author))
+
+	respReg, err := registrationToAdminApiRegistration(reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailNotes500JSONResponse{
+			Code:    InternalError,
+			Message: "Note was added but failed to build the response",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsEmailNotes200JSONResponse{
+		Registration: respReg,
+		Note:         adminNoteToApiAdminNote(note),
+	}, nil
+}
+
+func (a *API) DeleteEventsV1EventIdRegistrationsEmailNotesNoteId(ctx context.Context, request DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdRequestObject) (DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "DeleteEventsV1EventIdRegistrationsEmailNotesNoteId")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	reg, err := registration.RemoveAdminNote(ctx, request.EventId, strings.ToLower(string(request.Email)), request.NoteId, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to remove admin note", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return DeleteEventsV1EventIdRegistrationsEmailNotesNoteId404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			case registration.REASON_ADMIN_NOTE_DOES_NOT_EXIST:
+				return DeleteEventsV1EventIdRegistrationsEmailNotesNoteId404JSONResponse{
+					Code:    NotFound,
+					Message: "Note was not found",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return DeleteEventsV1EventIdRegistrationsEmailNotesNoteId500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to remove note",
+		}, nil
+	}
+
+	logger.Info("Removed admin note",
+		slog.String("eventId", request.EventId.String()),
+		slog.String("email", reg.GetEmail()),
+		slog.String("noteId", request.NoteId.String()))
+
+	respReg, err := registrationToAdminApiRegistration(reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return DeleteEventsV1EventIdRegistrationsEmailNotesNoteId500JSONResponse{
+			Code:    InternalError,
+			Message: "Note was removed but failed to build the response",
+		}, nil
+	}
+
+	return DeleteEventsV1EventIdRegistrationsEmailNotesNoteId200JSONResponse{Registration: respReg}, nil
+}
+
+func (a *API) PutEventsV1EventIdRegistrationsEmailTagsTag(ctx context.Context, request PutEventsV1EventIdRegistrationsEmailTagsTagRequestObject) (PutEventsV1EventIdRegistrationsEmailTagsTagResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PutEventsV1EventIdRegistrationsEmailTagsTag")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	reg, err := registration.AddTag(ctx, request.EventId, strings.ToLower(string(request.Email)), request.Tag, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to tag registration", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_INVALID_ADMIN_ANNOTATION:
+				return PutEventsV1EventIdRegistrationsEmailTagsTag400JSONResponse{
+					Code:    InputValidationError,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PutEventsV1EventIdRegistrationsEmailTagsTag404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PutEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to tag registration",
+		}, nil
+	}
+
+	respReg, err := registrationToAdminApiRegistration(reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PutEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse{
+			Code:    InternalError,
+			Message: "Registration was tagged but failed to build the response",
+		}, nil
+	}
+
+	return PutEventsV1EventIdRegistrationsEmailTagsTag200JSONResponse{Registration: respReg}, nil
+}
+
+func (a *API) DeleteEventsV1EventIdRegistrationsEmailTagsTag(ctx context.Context, request DeleteEventsV1EventIdRegistrationsEmailTagsTagRequestObject) (DeleteEventsV1EventIdRegistrationsEmailTagsTagResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "DeleteEventsV1EventIdRegistrationsEmailTagsTag")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	reg, err := registration.RemoveTag(ctx, request.EventId, strings.ToLower(string(request.Email)), request.Tag, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to untag registration", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) && registrationErr.Reason == registration.REASON_REGISTRATION_DOES_NOT_EXIST {
+			return DeleteEventsV1EventIdRegistrationsEmailTagsTag404JSONResponse{
+				Code:    NotFound,
+				Message: "Registration was not found",
+			}, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return DeleteEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to untag registration",
+		}, nil
+	}
+
+	respReg, err := registrationToAdminApiRegistration(reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return DeleteEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse{
+			Code:    InternalError,
+			Message: "Registration was untagged but failed to build the response",
+		}, nil
+	}
+
+	return DeleteEventsV1EventIdRegistrationsEmailTagsTag200JSONResponse{Registration: respReg}, nil
+}
+
+func adminNoteToApiAdminNote(note registration.AdminNote) AdminNote {
+	return AdminNote{
+		Id:        note.ID,
+		Text:      note.Text,
+		Author:    note.Author,
+		CreatedAt: note.CreatedAt,
+	}
+}
+
+func adminNotesToApiAdminNotes(notes []registration.AdminNote) *[]AdminNote {
+	if len(notes) == 0 {
+		return nil
+	}
+	apiNotes := slices.Map(notes, adminNoteToApiAdminNote)
+	return &apiNotes
+}
+
+func tagsToApiTags(tags []string) *[]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	return &tags
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEventsV1EventIdRegistrationsEmailNotes(t *testing.T) {
+	eventId := uuid.New()
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+
+	t.Run("author is the signed in admin", func(t *testing.T) {
+		var saved registration.Registration
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.IndividualRegistration{EventID: eventId, Version: 1, Email: email}, nil
+			},
+			UpdateRegistrationFunc: func(ctx context.Context, reg registration.Registration) error {
+				saved = reg
+				return nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailNotes(ctx, PostEventsV1EventIdRegistrationsEmailNotesRequestObject{
+			EventId: eventId,
+			Email:   "Player@Test.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailNotesJSONRequestBody{Text: "Pending waiver"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailNotes200JSONResponse:
+			assert.Equal(t, "admin@example.com", r.Note.Author)
+			assert.Equal(t, "Pending waiver", r.Note.Text)
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			require.NoError(t, err)
+			require.NotNil(t, indivReg.AdminNotes)
+			require.Len(t, *indivReg.AdminNotes, 1)
+			assert.Equal(t, r.Note.Id, (*indivReg.AdminNotes)[0].Id)
+			assert.Len(t, saved.GetAdminNotes(), 1)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("registration not found", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return nil, registration.NewRegistrationDoesNotExistsError("not found", nil)
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailNotes(ctx, PostEventsV1EventIdRegistrationsEmailNotesRequestObject{
+			EventId: eventId,
+			Email:   "missing@test.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailNotesJSONRequestBody{Text: "Pending waiver"},
+		})
+		assert.NoError(t, err)
+		assert.IsType(t, PostEventsV1EventIdRegistrationsEmailNotes404JSONResponse{}, resp)
+	})
+}
+
+func TestAdminFieldsAreNotPublic(t *testing.T) {
+	reg := &registration.TeamRegistration{
+		EventID:      uuid.New(),
+		CaptainEmail: "captain@test.com",
+		AdminNotes:   []registration.AdminNote{{ID: uuid.New(), Text: "VIP guest", Author: "admin@example.com", CreatedAt: time.Now()}},
+		Tags:         []string{"vip"},
+	}
+
+	publicReg, err := registrationToApiRegistration(reg)
+	require.NoError(t, err)
+	publicTeamReg, err := publicReg.AsTeamRegistration()
+	require.NoError(t, err)
+	assert.Nil(t, publicTeamReg.AdminNotes)
+	assert.Nil(t, publicTeamReg.Tags)
+
+	adminReg, err := registrationToAdminApiRegistration(reg)
+	require.NoError(t, err)
+	adminTeamReg, err := adminReg.AsTeamRegistration()
+	require.NoError(t, err)
+	assert.Len(t, *adminTeamReg.AdminNotes, 1)
+	assert.Equal(t, []string{"vip"}, *adminTeamReg.Tags)
+}
@@ -13,10 +13,10 @@ import (
 	"github.com/International-Combat-Archery-Alliance/captcha"
 	"github.com/International-Combat-Archery-Alliance/email"
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/redact"
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
 	"github.com/International-Combat-Archery-Alliance/middleware"
 	"github.com/International-Combat-Archery-Alliance/payments"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -30,6 +30,14 @@ const (
 type DB interface {
 	events.Repository
 	registration.Repository
+	registration.WebhookEventRepository
+	registration.OutboxRepository
+}
+
+// SubscriberManager is the mailing list, which also has to be able to erase people for privacy requests.
+type SubscriberManager interface {
+	email.SubscriberManager
+	registration.SubscriberForgetter
 }
 
 type API struct {
@@ -41,35 +49,52 @@ type API struct {
 	tokenService      *token.TokenService
 	captchaValidator  captcha.Validator
 	emailSender       email.Sender
-	subscriberManager email.SubscriberManager
+	subscriberManager SubscriberManager
 	checkoutManager   payments.CheckoutManager
-	flushTraces       func(context.Context) error
+	paymentQuerier    payments.PaymentQuerier
+	refunder          registration.Refunder
+	checkInSigner     *registration.CheckInSigner
+	// How long after an event ends players' personal data is kept on its registrations
+	personalDataRetentionMonths int
+	flushTraces                 func(context.Context) error
 }
 
 var _ StrictServerInterface = (*API)(nil)
 
-func NewAPI(
-	db DB,
-	logger *slog.Logger,
-	env Environment,
-	tokenService *token.TokenService,
-	captchaValidator captcha.Validator,
-	emailSender email.Sender,
-	subscriberManager email.SubscriberManager,
-	checkoutManager payments.CheckoutManager,
-	flushTraces func(context.Context) error,
-) *API {
+// Config is everything the API depends on.
+type Config struct {
+	DB                DB
+	Logger            *slog.Logger
+	Env               Environment
+	TokenService      *token.TokenService
+	CaptchaValidator  captcha.Validator
+	EmailSender       email.Sender
+	SubscriberManager SubscriberManager
+	CheckoutManager   payments.CheckoutManager
+	PaymentQuerier    payments.PaymentQuerier
+	Refunder          registration.Refunder
+	CheckInSigner     *registration.CheckInSigner
+	// How long after an event ends players' personal data is kept on its registrations
+	PersonalDataRetentionMonths int
+	FlushTraces                 func(context.Context) error
+}
+
+func NewAPI(cfg Config) *API {
 	return &API{
-		db:                db,
-		logger:            logger,
-		env:               env,
-		tracer:            otel.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/api"),
-		tokenService:      tokenService,
-		captchaValidator:  captchaValidator,
-		emailSender:       emailSender,
-		subscriberManager: subscriberManager,
-		checkoutManager:   checkoutManager,
-		flushTraces:       flushTraces,
+		db:                          cfg.DB,
+		logger:                      cfg.Logger,
+		env:                         cfg.Env,
+		tracer:                      redact.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/api"),
+		tokenService:                cfg.TokenService,
+		captchaValidator:            cfg.CaptchaValidator,
+		emailSender:                 cfg.EmailSender,
+		subscriberManager:           cfg.SubscriberManager,
+		checkoutManager:             cfg.CheckoutManager,
+		paymentQuerier:              cfg.PaymentQuerier,
+		refunder:                    cfg.Refunder,
+		checkInSigner:               cfg.CheckInSigner,
+		personalDataRetentionMonths: cfg.PersonalDataRetentionMonths,
+		flushTraces:                 cfg.FlushTraces,
 	}
 }
 
@@ -130,3 +155,11 @@ func (a *API) getLoggerOrBaseLogger(ctx context.Context) *slog.Logger {
 	}
 	return logger
 }
+
+// frontendBaseURL is where the website that links in emails and redirects should point to lives.
+func (a *API) frontendBaseURL() string {
+	if a.env == LOCAL {
+		return "http://localhost:5173"
+	}
+	return "https://icaa.world"
+}
@@ -0,0 +1,225 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdCheckIn(ctx context.Context, request PostEventsV1EventIdCheckInRequestObject) (PostEventsV1EventIdCheckInResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdCheckIn")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	reg, event, err := registration.CheckInWithToken(ctx, a.checkInSigner, request.EventId, request.Body.Token, checkInOperator(ctx), a.db, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to check in with token", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_INVALID_CHECK_IN_TOKEN:
+				return PostEventsV1EventIdCheckIn400JSONResponse{
+					Code:    InvalidCheckInToken,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_CAN_NOT_CHECK_IN:
+				return PostEventsV1EventIdCheckIn400JSONResponse{
+					Code:    CanNotCheckIn,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdCheckIn404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdCheckIn500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to check in",
+		}, nil
+	}
+
+	logger.Info("Checked in with token",
+		slog.String("eventId", reg.GetEventID().String()),
+		slog.String("email", reg.GetEmail()),
+		slog.String("checkedInBy", checkInOperator(ctx)))
+
+	result, err := checkInResult(reg, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdCheckIn500JSONResponse{
+			Code:    InternalError,
+			Message: "Checked in but failed to build the response",
+		}, nil
+	}
+	return PostEventsV1EventIdCheckIn200JSONResponse(result), nil
+}
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailCheckIn(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailCheckInRequestObject) (PostEventsV1EventIdRegistrationsEmailCheckInResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailCheckIn")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	params := registration.CheckInParams{
+		EventID:     request.EventId,
+		Email:       strings.ToLower(string(request.Email)),
+		CheckedInBy: checkInOperator(ctx),
+	}
+	if request.Body != nil && request.Body.PlayerIndexes != nil {
+		params.PlayerIndexes = *request.Body.PlayerIndexes
+	}
+
+	reg, event, err := registration.CheckIn(ctx, params, a.db, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to check in registration", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_CAN_NOT_CHECK_IN:
+				return PostEventsV1EventIdRegistrationsEmailCheckIn400JSONResponse{
+					Code:    CanNotCheckIn,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailCheckIn404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailCheckIn500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to check in registration",
+		}, nil
+	}
+
+	logger.Info("Checked in registration",
+		slog.String("eventId", reg.GetEventID().String()),
+		slog.String("email", reg.GetEmail()),
+		slog.String("checkedInBy", checkInOperator(ctx)))
+
+	result, err := checkInResult(reg, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailCheckIn500JSONResponse{
+			Code:    InternalError,
+			Message: "Checked in but failed to build the response",
+		}, nil
+	}
+	return PostEventsV1EventIdRegistrationsEmailCheckIn200JSONResponse(result), nil
+}
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailCheckInUndo(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailCheckInUndoRequestObject) (PostEventsV1EventIdRegistrationsEmailCheckInUndoResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailCheckInUndo")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	params := registration.CheckInParams{
+		EventID: request.EventId,
+		Email:   strings.ToLower(string(request.Email)),
+	}
+	if request.Body != nil && request.Body.PlayerIndexes != nil {
+		params.PlayerIndexes = *request.Body.PlayerIndexes
+	}
+
+	reg, event, err := registration.UndoCheckIn(ctx, params, a.db, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to undo check-in", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_CAN_NOT_CHECK_IN:
+				return PostEventsV1EventIdRegistrationsEmailCheckInUndo400JSONResponse{
+					Code:    InputValidationError,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailCheckInUndo404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailCheckInUndo500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to undo check-in",
+		}, nil
+	}
+
+	logger.Info("Undid check-in",
+		slog.String("eventId", reg.GetEventID().String()),
+		slog.String("email", reg.GetEmail()),
+		slog.String("undoneBy", checkInOperator(ctx)))
+
+	result, err := checkInResult(reg, event)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailCheckInUndo500JSONResponse{
+			Code:    InternalError,
+			Message: "Undid the check-in but failed to build the response",
+		}, nil
+	}
+	return PostEventsV1EventIdRegistrationsEmailCheckInUndo200JSONResponse(result), nil
+}
+
+// checkInOperator is the email of the admin doing the check-in.
+func checkInOperator(ctx context.Context) string {
+	jwt, ok := middleware.GetJWTFromCtx(ctx)
+	if !ok {
+		return ""
+	}
+	return jwt.UserEmail()
+}
+
+func checkInResult(reg registration.Registration, event events.Event) (CheckInResult, error) {
+	apiReg, err := registrationToAdminApiRegistration(reg)
+	if err != nil {
+		return CheckInResult{}, err
+	}
+	return CheckInResult{
+		Registration: apiReg,
+		SignUpStats:  eventToApiSignUpStats(event),
+	}, nil
+}
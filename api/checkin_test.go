@@ -0,0 +1,101 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEventsV1EventIdCheckIn(t *testing.T) {
+	eventId := uuid.New()
+	newMock := func() *mockDB {
+		return &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.TeamRegistration{
+					EventID:      eventId,
+					Version:      1,
+					Status:       registration.STATUS_PAID,
+					TeamName:     "Arrows",
+					CaptainEmail: email,
+					Players:      []registration.PlayerInfo{{FirstName: "Cap", Email: ptr.String(email)}, {FirstName: "Play"}},
+				}, nil
+			},
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{ID: id, Version: 1, TimeZone: time.UTC}, nil
+			},
+			UpdateRegistrationWithEventFunc: func(ctx context.Context, reg registration.Registration, event events.Event) error {
+				return nil
+			},
+		}
+	}
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+
+	t.Run("scan a player's code", func(t *testing.T) {
+		api := newTestAPI(newMock())
+		token, err := testCheckInSigner.Sign(registration.CheckInClaims{EventID: eventId, Email: "captain@example.com", Player: ptr.Int(1)})
+		require.NoError(t, err)
+
+		resp, err := api.PostEventsV1EventIdCheckIn(ctx, PostEventsV1EventIdCheckInRequestObject{
+			EventId: eventId,
+			Body:    &PostEventsV1EventIdCheckInJSONRequestBody{Token: token},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdCheckIn200JSONResponse:
+			assert.Equal(t, 1, r.SignUpStats.NumCheckedInPlayers)
+			assert.Equal(t, 1, r.SignUpStats.NumCheckedInTeams)
+			teamReg, err := r.Registration.AsTeamRegistration()
+			require.NoError(t, err)
+			assert.Nil(t, teamReg.Players[0].CheckedInAt)
+			assert.NotNil(t, teamReg.Players[1].CheckedInAt)
+			assert.Equal(t, "admin@example.com", *teamReg.Players[1].CheckedInBy)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("forged code", func(t *testing.T) {
+		api := newTestAPI(newMock())
+		token, err := registration.NewCheckInSigner([]byte("wrong-key")).Sign(registration.CheckInClaims{EventID: eventId, Email: "captain@example.com"})
+		require.NoError(t, err)
+
+		resp, err := api.PostEventsV1EventIdCheckIn(ctx, PostEventsV1EventIdCheckInRequestObject{
+			EventId: eventId,
+			Body:    &PostEventsV1EventIdCheckInJSONRequestBody{Token: token},
+		})
+		assert.NoError(t, err)
+
+		r, ok := resp.(PostEventsV1EventIdCheckIn400JSONResponse)
+		require.True(t, ok, "unexpected response type: %T", resp)
+		assert.Equal(t, InvalidCheckInToken, r.Code)
+	})
+}
+
+func TestPostEventsV1EventIdRegistrationsEmailCheckIn(t *testing.T) {
+	eventId := uuid.New()
+
+	t.Run("registration not found", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return nil, registration.NewRegistrationDoesNotExistsError("not found", nil)
+			},
+		}
+		api := newTestAPI(mock)
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailCheckIn(ctx, PostEventsV1EventIdRegistrationsEmailCheckInRequestObject{
+			EventId: eventId,
+			Email:   "missing@example.com",
+		})
+		assert.NoError(t, err)
+		assert.IsType(t, PostEventsV1EventIdRegistrationsEmailCheckIn404JSONResponse{}, resp)
+	})
+}
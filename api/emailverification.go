@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailVerifyEmail(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailVerifyEmailRequestObject) (PostEventsV1EventIdRegistrationsEmailVerifyEmailResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailVerifyEmail")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	reg, err := registration.VerifyRegistrationEmail(ctx, a.db, request.EventId, strings.ToLower(string(request.Email)), request.Body.Token, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to verify registration email", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_INVALID_EMAIL_VERIFICATION:
+				return PostEventsV1EventIdRegistrationsEmailVerifyEmail404JSONResponse{
+					Code:    NotFound,
+					Message: "This verification link is invalid or was already used",
+				}, nil
+			case registration.REASON_REGISTRATION_EXPIRED:
+				return PostEventsV1EventIdRegistrationsEmailVerifyEmail410JSONResponse{
+					Code:    VerificationExpired,
+					Message: "This verification link expired, please sign up again",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailVerifyEmail500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to verify email",
+		}, nil
+	}
+
+	a.sendConfirmationEmailNow(ctx, logger, reg)
+
+	respReg, err := registrationToApiRegistration(reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailVerifyEmail500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to verify email",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsEmailVerifyEmail200JSONResponse{Registration: respReg}, nil
+}
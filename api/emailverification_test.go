@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEventsV1EventIdRegisterWithEmailVerification(t *testing.T) {
+	var savedIntent registration.RegistrationIntent
+	mock := &mockDB{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{
+				ID:                       id,
+				RegistrationOptions:      []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(0, "USD")}},
+				RegistrationCloseTime:    time.Now().Add(time.Hour),
+				RequireEmailVerification: true,
+			}, nil
+		},
+		CreateRegistrationWithPaymentFunc: func(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent, event events.Event) error {
+			savedIntent = intent
+			return nil
+		},
+	}
+	api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
+	reg := Registration{}
+	reg.FromIndividualRegistration(IndividualRegistration{
+		HomeCity:   "test city",
+		Email:      types.Email("test@test.com"),
+		PlayerInfo: PlayerInfo{FirstName: "first", LastName: "last"},
+		Experience: Novice,
+	})
+
+	resp, err := api.PostEventsV1EventIdRegister(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegisterRequestObject{
+		EventId: uuid.New(),
+		Body:    &reg,
+	})
+	assert.NoError(t, err)
+
+	switch r := resp.(type) {
+	case PostEventsV1EventIdRegister202JSONResponse:
+		assert.True(t, savedIntent.IsEmailVerification())
+		assert.Equal(t, savedIntent.ExpiresAt, r.ExpiresAt)
+		indiv, err := r.Registration.AsIndividualRegistration()
+		require.NoError(t, err)
+		assert.Equal(t, types.Email("test@test.com"), indiv.Email)
+	default:
+		t.Fatalf("unexpected response type: %T", resp)
+	}
+}
+
+func TestPostEventsV1EventIdRegistrationsEmailVerifyEmail(t *testing.T) {
+	ctx := ctxWithLogger(context.Background(), noopLogger)
+	eventId := uuid.New()
+	newMock := func(intent registration.RegistrationIntent, confirmed *bool) *mockDB {
+		return &mockDB{
+			GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.RegistrationIntent, error) {
+				return intent, nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.IndividualRegistration{
+					EventID:    eventId,
+					Version:    1,
+					Email:      email,
+					PlayerInfo: registration.PlayerInfo{FirstName: "first", LastName: "last"},
+				}, nil
+			},
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{ID: id}, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, reg registration.Registration, outbox []registration.OutboxItem) error {
+				*confirmed = true
+				return nil
+			},
+		}
+	}
+	request := func(token string) PostEventsV1EventIdRegistrationsEmailVerifyEmailRequestObject {
+		return PostEventsV1EventIdRegistrationsEmailVerifyEmailRequestObject{
+			EventId: eventId,
+			Email:   "Test@test.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONRequestBody{Token: token},
+		}
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		var confirmed bool
+		intent := registration.RegistrationIntent{EventId: eventId, Email: "test@test.com", VerificationToken: "token", ExpiresAt: time.Now().Add(time.Hour)}
+		api := newTestAPI(newMock(intent, &confirmed))
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailVerifyEmail(ctx, request("token"))
+		assert.NoError(t, err)
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailVerifyEmail200JSONResponse:
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, RegistrationStatusConfirmed, *indivReg.Status)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+		assert.True(t, confirmed)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		var confirmed bool
+		intent := registration.RegistrationIntent{EventId: eventId, Email: "test@test.com", VerificationToken: "token", ExpiresAt: time.Now().Add(time.Hour)}
+		api := newTestAPI(newMock(intent, &confirmed))
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailVerifyEmail(ctx, request("guess"))
+		assert.NoError(t, err)
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailVerifyEmail404JSONResponse:
+			assert.Equal(t, NotFound, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+		assert.False(t, confirmed)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		var confirmed bool
+		intent := registration.RegistrationIntent{EventId: eventId, Email: "test@test.com", VerificationToken: "token", ExpiresAt: time.Now().Add(-time.Minute)}
+		api := newTestAPI(newMock(intent, &confirmed))
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailVerifyEmail(ctx, request("token"))
+		assert.NoError(t, err)
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailVerifyEmail410JSONResponse:
+			assert.Equal(t, VerificationExpired, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+		assert.False(t, confirmed)
+	})
+}
@@ -84,9 +84,11 @@ func (a *API) PostEventsV1(ctx context.Context, request PostEventsV1RequestObjec
 	request.Body.Id = &id
 	request.Body.Version = ptr.Int(1)
 	request.Body.SignUpStats = &SignUpStats{
-		NumTeams:           0,
-		NumRosteredPlayers: 0,
-		NumTotalPlayers:    0,
+		NumTeams:            0,
+		NumRosteredPlayers:  0,
+		NumTotalPlayers:     0,
+		NumCheckedInPlayers: 0,
+		NumCheckedInTeams:   0,
 	}
 	// request.Body is guaranteed to be non-nil from openapi doc
 	event, err := apiEventToEvent(*request.Body)
@@ -255,16 +257,28 @@ func eventToApiEvent(event events.Event) (Event, error) {
 			Min: event.AllowedTeamSizeRange.Min,
 			Max: event.AllowedTeamSizeRange.Max,
 		},
-		SignUpStats: &SignUpStats{
-			NumTeams:           event.NumTeams,
-			NumRosteredPlayers: event.NumRosteredPlayers,
-			NumTotalPlayers:    event.NumTotalPlayers,
-		},
-		RulesDocLink: event.RulesDocLink,
-		ImageName:    event.ImageName,
+		SignUpStats:              eventToApiSignUpStats(event),
+		RulesDocLink:             event.RulesDocLink,
+		ImageName:                event.ImageName,
+		SplitPaymentWindowHours:  durationToHours(event.SplitPaymentWindow),
+		RequireEmailVerification: &event.RequireEmailVerification,
+		CheckoutHoldMinutes:      ptr.Int(int(event.CheckoutHold().Minutes())),
+		CheckoutItemDescription:  event.CheckoutItemDescription,
+		AllowAdaptivePricing:     ptr.Bool(!event.DisableAdaptivePricing),
+		PersonalDataPurgedAt:     event.PersonalDataPurgedAt,
 	}, nil
 }
 
+func eventToApiSignUpStats(event events.Event) *SignUpStats {
+	return &SignUpStats{
+		NumTeams:            event.NumTeams,
+		NumRosteredPlayers:  event.NumRosteredPlayers,
+		NumTotalPlayers:     event.NumTotalPlayers,
+		NumCheckedInPlayers: event.NumCheckedInPlayers,
+		NumCheckedInTeams:   event.NumCheckedInTeams,
+	}
+}
+
 func apiEventToEvent(event Event) (events.Event, error) {
 	regOptions := []events.EventRegistrationOption{}
 	for _, t := range event.RegistrationOptions {
@@ -284,7 +298,7 @@ func apiEventToEvent(event Event) (events.Event, error) {
 		}
 	}
 
-	return events.Event{
+	coreEvent := events.Event{
 		ID:                    *event.Id,
 		Version:               *event.Version,
 		Name:                  event.Name,
@@ -297,13 +311,46 @@ func apiEventToEvent(event Event) (events.Event, error) {
 		NumTotalPlayers:       event.SignUpStats.NumTotalPlayers,
 		NumRosteredPlayers:    event.SignUpStats.NumRosteredPlayers,
 		NumTeams:              event.SignUpStats.NumTeams,
+		NumCheckedInPlayers:   event.SignUpStats.NumCheckedInPlayers,
+		NumCheckedInTeams:     event.SignUpStats.NumCheckedInTeams,
 		AllowedTeamSizeRange: events.Range{
 			Min: event.AllowedTeamSizeRange.Min,
 			Max: event.AllowedTeamSizeRange.Max,
 		},
-		RulesDocLink: event.RulesDocLink,
-		ImageName:    event.ImageName,
-	}, nil
+		RulesDocLink:             event.RulesDocLink,
+		ImageName:                event.ImageName,
+		SplitPaymentWindow:       hoursToDuration(event.SplitPaymentWindowHours),
+		RequireEmailVerification: event.RequireEmailVerification != nil && *event.RequireEmailVerification,
+		CheckoutHoldDuration:     minutesToDuration(event.CheckoutHoldMinutes),
+		CheckoutItemDescription:  event.CheckoutItemDescription,
+		DisableAdaptivePricing:   event.AllowAdaptivePricing != nil && !*event.AllowAdaptivePricing,
+	}
+	if err := events.ValidateCheckoutSettings(coreEvent); err != nil {
+		return events.Event{}, err
+	}
+
+	return coreEvent, nil
+}
+
+func durationToHours(d *time.Duration) *int {
+	if d == nil {
+		return nil
+	}
+	return ptr.Int(int(d.Hours()))
+}
+
+func hoursToDuration(hours *int) *time.Duration {
+	if hours == nil {
+		return nil
+	}
+	return ptr.Duration(time.Duration(*hours) * time.Hour)
+}
+
+func minutesToDuration(minutes *int) *time.Duration {
+	if minutes == nil {
+		return nil
+	}
+	return ptr.Duration(time.Duration(*minutes) * time.Minute)
 }
 
 func locationToApiLocation(location events.Location) Location {
@@ -11,6 +11,7 @@ import (
 	"github.com/Rhymond/go-money"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGetEvents(t *testing.T) {
@@ -38,7 +39,7 @@ func TestGetEvents(t *testing.T) {
 				}, nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := GetEventsV1RequestObject{
 			Params: GetEventsV1Params{
@@ -80,7 +81,7 @@ func TestPostEvents(t *testing.T) {
 				return nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := PostEventsV1RequestObject{
 			Body: &reqBody,
@@ -123,7 +124,7 @@ func TestGetEventsId(t *testing.T) {
 				return expectedEvent, nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := GetEventsV1IdRequestObject{
 			Id: id,
@@ -151,7 +152,7 @@ func TestGetEventsId(t *testing.T) {
 				return events.Event{}, &events.Error{Reason: events.REASON_EVENT_DOES_NOT_EXIST}
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := GetEventsV1IdRequestObject{
 			Id: id,
@@ -175,7 +176,7 @@ func TestGetEventsId(t *testing.T) {
 				return events.Event{}, errors.New("some error")
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := GetEventsV1IdRequestObject{
 			Id: id,
@@ -219,7 +220,7 @@ func TestPatchEventsV1Id(t *testing.T) {
 			},
 		}
 
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		reqBody := Event{
 			Name:                  "Updated Event Name",
@@ -278,7 +279,7 @@ func TestPatchEventsV1Id(t *testing.T) {
 	t.Run("invalid request body", func(t *testing.T) {
 		eventID := uuid.New()
 		mock := &mockDB{}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		// Create invalid request body with invalid registration type
 		reqBody := Event{
@@ -310,6 +311,61 @@ func TestPatchEventsV1Id(t *testing.T) {
 		}
 	})
 
+	t.Run("checkout settings", func(t *testing.T) {
+		eventID := uuid.New()
+		var saved events.Event
+		mock := &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{ID: eventID, Version: 1}, nil
+			},
+			UpdateEventFunc: func(ctx context.Context, event events.Event) error {
+				saved = event
+				return nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		reqBody := Event{
+			Name: "Test Event",
+			RegistrationOptions: []EventRegistrationOption{
+				{RegistrationType: ByIndividual, Price: Money{Amount: 5000, Currency: "USD"}},
+			},
+			CheckoutHoldMinutes:     ptr.Int(90),
+			CheckoutItemDescription: ptr.String("Test Event Entry"),
+			AllowAdaptivePricing:    ptr.Bool(false),
+		}
+
+		resp, err := api.PatchEventsV1Id(ctxWithLogger(context.Background(), noopLogger), PatchEventsV1IdRequestObject{Id: eventID, Body: &reqBody})
+		require.NoError(t, err)
+
+		r, ok := resp.(PatchEventsV1Id200JSONResponse)
+		require.True(t, ok, "unexpected response %T", resp)
+		assert.Equal(t, 90*time.Minute, saved.CheckoutHold())
+		assert.Equal(t, "Test Event Entry", *saved.CheckoutItemDescription)
+		assert.True(t, saved.DisableAdaptivePricing)
+		assert.Equal(t, 90, *r.Event.CheckoutHoldMinutes)
+		assert.False(t, *r.Event.AllowAdaptivePricing)
+	})
+
+	t.Run("checkout hold the payment provider doesn't allow", func(t *testing.T) {
+		mock := &mockDB{}
+		api := newTestAPI(mock)
+
+		reqBody := Event{
+			Name: "Test Event",
+			RegistrationOptions: []EventRegistrationOption{
+				{RegistrationType: ByIndividual, Price: Money{Amount: 5000, Currency: "USD"}},
+			},
+			CheckoutHoldMinutes: ptr.Int(10),
+		}
+
+		resp, err := api.PatchEventsV1Id(ctxWithLogger(context.Background(), noopLogger), PatchEventsV1IdRequestObject{Id: uuid.New(), Body: &reqBody})
+		require.NoError(t, err)
+
+		_, ok := resp.(PatchEventsV1Id400JSONResponse)
+		assert.True(t, ok, "unexpected response %T", resp)
+	})
+
 	t.Run("event not found", func(t *testing.T) {
 		eventID := uuid.New()
 		mock := &mockDB{
@@ -317,7 +373,7 @@ func TestPatchEventsV1Id(t *testing.T) {
 				return events.Event{}, &events.Error{Reason: events.REASON_EVENT_DOES_NOT_EXIST}
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		reqBody := Event{
 			Name: "Test Event",
@@ -364,7 +420,7 @@ func TestPatchEventsV1Id(t *testing.T) {
 				return errors.New("database connection failed")
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		reqBody := Event{
 			Name: "Updated Event",
@@ -413,7 +469,7 @@ func TestTimeZoneHandling(t *testing.T) {
 				return nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := PostEventsV1RequestObject{
 			Body: &reqBody,
@@ -441,7 +497,7 @@ func TestTimeZoneHandling(t *testing.T) {
 			RegistrationOptions:   []EventRegistrationOption{{RegistrationType: ByIndividual, Price: Money{Amount: 5000, Currency: "USD"}}},
 		}
 		mock := &mockDB{}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := PostEventsV1RequestObject{
 			Body: &reqBody,
@@ -474,7 +530,7 @@ func TestTimeZoneHandling(t *testing.T) {
 				return nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		req := PostEventsV1RequestObject{
 			Body: &reqBody,
@@ -513,7 +569,7 @@ func TestTimeZoneHandling(t *testing.T) {
 			},
 		}
 
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		reqBody := Event{
 			Name:                  "Updated Event",
@@ -562,7 +618,7 @@ func TestTimeZoneHandling(t *testing.T) {
 			},
 		}
 
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManager{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock)
 
 		reqBody := Event{
 			Name:                  "Updated Event",
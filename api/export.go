@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) GetEventsV1EventIdRegistrationsExport(ctx context.Context, request GetEventsV1EventIdRegistrationsExportRequestObject) (GetEventsV1EventIdRegistrationsExportResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "GetEventsV1EventIdRegistrationsExport")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	getEventCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	event, err := a.db.GetEvent(getEventCtx, request.EventId)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to get event for export", "error", err, "eventId", request.EventId)
+
+		var eventErr *events.Error
+		if errors.As(err, &eventErr) && eventErr.Reason == events.REASON_EVENT_DOES_NOT_EXIST {
+			return GetEventsV1EventIdRegistrationsExport404JSONResponse{
+				Code:    NotFound,
+				Message: "Event not found",
+			}, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return GetEventsV1EventIdRegistrationsExport500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to export registrations",
+		}, nil
+	}
+
+	rows := registration.EXPORT_ROW_PER_PLAYER
+	if request.Params.Rows != nil && *request.Params.Rows == PerRegistration {
+		rows = registration.EXPORT_ROW_PER_REGISTRATION
+	}
+
+	// The rows are written as they're read from the DB. The status has already been sent by the
+	// time anything could fail, so the best that can be done is cutting the download short.
+	pr, pw := io.Pipe()
+	go func() {
+		exportCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		err := registration.ExportRegistrationsCSV(exportCtx, pw, a.db, event, rows)
+		if err != nil {
+			logger.Error("Failed to export registrations", "error", err, "eventId", request.EventId)
+		}
+		pw.CloseWithError(err)
+	}()
+
+	return GetEventsV1EventIdRegistrationsExport200TextcsvResponse{
+		Body: pr,
+		Headers: GetEventsV1EventIdRegistrationsExport200ResponseHeaders{
+			ContentDisposition: fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("registrations-%s.csv", event.ID)),
+		},
+	}, nil
+}
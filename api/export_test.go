@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEventsV1EventIdRegistrationsExport(t *testing.T) {
+	t.Run("event not found", func(t *testing.T) {
+		mock := &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{}, &events.Error{Reason: events.REASON_EVENT_DOES_NOT_EXIST}
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1EventIdRegistrationsExport(ctxWithLogger(context.Background(), noopLogger), GetEventsV1EventIdRegistrationsExportRequestObject{
+			EventId: uuid.New(),
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1EventIdRegistrationsExport404JSONResponse:
+			assert.Equal(t, NotFound, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		mock := &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{ID: id}, nil
+			},
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, eventID uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
+				return registration.GetAllRegistrationsResponse{
+					Data: []registration.Registration{
+						&registration.IndividualRegistration{Email: "test@test.com", PlayerInfo: registration.PlayerInfo{FirstName: "Test", LastName: "User"}},
+					},
+				}, nil
+			},
+		}
+		api := newTestAPI(mock)
+		rows := PerRegistration
+
+		resp, err := api.GetEventsV1EventIdRegistrationsExport(ctxWithLogger(context.Background(), noopLogger), GetEventsV1EventIdRegistrationsExportRequestObject{
+			EventId: uuid.New(),
+			Params:  GetEventsV1EventIdRegistrationsExportParams{Rows: &rows},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1EventIdRegistrationsExport200TextcsvResponse:
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Contains(t, string(body), "Test User,test@test.com,1")
+			assert.Contains(t, r.Headers.ContentDisposition, "attachment")
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
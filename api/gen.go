@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
@@ -32,17 +33,31 @@ const (
 
 // Defines values for ErrorCode.
 const (
-	AlreadyExists        ErrorCode = "AlreadyExists"
-	AuthError            ErrorCode = "AuthError"
-	CaptchaInvalid       ErrorCode = "CaptchaInvalid"
-	EmptyBody            ErrorCode = "EmptyBody"
-	InputValidationError ErrorCode = "InputValidationError"
-	InternalError        ErrorCode = "InternalError"
-	InvalidBody          ErrorCode = "InvalidBody"
-	InvalidCursor        ErrorCode = "InvalidCursor"
-	LimitOutOfBounds     ErrorCode = "LimitOutOfBounds"
-	NotFound             ErrorCode = "NotFound"
-	RegistrationClosed   ErrorCode = "RegistrationClosed"
+	AlreadyExists           ErrorCode = "AlreadyExists"
+	AlreadyPaid             ErrorCode = "AlreadyPaid"
+	AlreadyReconciled       ErrorCode = "AlreadyReconciled"
+	AuthError               ErrorCode = "AuthError"
+	CanNotCheckIn           ErrorCode = "CanNotCheckIn"
+	CaptchaInvalid          ErrorCode = "CaptchaInvalid"
+	CheckoutInProgress      ErrorCode = "CheckoutInProgress"
+	EmptyBody               ErrorCode = "EmptyBody"
+	Forbidden               ErrorCode = "Forbidden"
+	InputValidationError    ErrorCode = "InputValidationError"
+	InternalError           ErrorCode = "InternalError"
+	InvalidBody             ErrorCode = "InvalidBody"
+	InvalidCheckInToken     ErrorCode = "InvalidCheckInToken"
+	InvalidCursor           ErrorCode = "InvalidCursor"
+	InvalidOfflinePayment   ErrorCode = "InvalidOfflinePayment"
+	InvalidRefundAmount     ErrorCode = "InvalidRefundAmount"
+	LimitOutOfBounds        ErrorCode = "LimitOutOfBounds"
+	NotFound                ErrorCode = "NotFound"
+	NotPaid                 ErrorCode = "NotPaid"
+	NotTransferable         ErrorCode = "NotTransferable"
+	PlayerAlreadyRegistered ErrorCode = "PlayerAlreadyRegistered"
+	RegistrationClosed      ErrorCode = "RegistrationClosed"
+	ShareExpired            ErrorCode = "ShareExpired"
+	SplitPaymentNotAllowed  ErrorCode = "SplitPaymentNotAllowed"
+	VerificationExpired     ErrorCode = "VerificationExpired"
 )
 
 // Defines values for ExperienceLevel.
@@ -52,12 +67,85 @@ const (
 	Novice       ExperienceLevel = "Novice"
 )
 
+// Defines values for OutboxItemKind.
+const (
+	ConfirmationEmail OutboxItemKind = "ConfirmationEmail"
+	MailingList       OutboxItemKind = "MailingList"
+	RosterInvitations OutboxItemKind = "RosterInvitations"
+)
+
+// Defines values for OutboxItemStatus.
+const (
+	OutboxItemStatusDead    OutboxItemStatus = "Dead"
+	OutboxItemStatusPending OutboxItemStatus = "Pending"
+)
+
+// Defines values for PaymentMethod.
+const (
+	BankTransfer PaymentMethod = "BankTransfer"
+	Cash         PaymentMethod = "Cash"
+	Comp         PaymentMethod = "Comp"
+	Online       PaymentMethod = "Online"
+)
+
+// Defines values for PaymentMismatchKind.
+const (
+	MarkedPaidWithoutPayment   PaymentMismatchKind = "MarkedPaidWithoutPayment"
+	OrphanIntent               PaymentMismatchKind = "OrphanIntent"
+	PaidNotMarkedPaid          PaymentMismatchKind = "PaidNotMarkedPaid"
+	PaymentWithoutRegistration PaymentMismatchKind = "PaymentWithoutRegistration"
+)
+
+// Defines values for RegistrationStatus.
+const (
+	RegistrationStatusCancelled RegistrationStatus = "Cancelled"
+	RegistrationStatusComped    RegistrationStatus = "Comped"
+	RegistrationStatusConfirmed RegistrationStatus = "Confirmed"
+	RegistrationStatusExpired   RegistrationStatus = "Expired"
+	RegistrationStatusPaid      RegistrationStatus = "Paid"
+	RegistrationStatusPending   RegistrationStatus = "Pending"
+	RegistrationStatusRefunded  RegistrationStatus = "Refunded"
+)
+
 // Defines values for RegistrationType.
 const (
 	ByIndividual RegistrationType = "ByIndividual"
 	ByTeam       RegistrationType = "ByTeam"
 )
 
+// Defines values for RosterStatus.
+const (
+	Confirmed  RosterStatus = "Confirmed"
+	Invited    RosterStatus = "Invited"
+	NotInvited RosterStatus = "NotInvited"
+)
+
+// Defines values for ShareStatus.
+const (
+	ShareStatusExpired ShareStatus = "Expired"
+	ShareStatusPaid    ShareStatus = "Paid"
+	ShareStatusUnpaid  ShareStatus = "Unpaid"
+)
+
+// Defines values for GetEventsV1EventIdRegistrationsParamsSortBy.
+const (
+	Email        GetEventsV1EventIdRegistrationsParamsSortBy = "email"
+	Name         GetEventsV1EventIdRegistrationsParamsSortBy = "name"
+	RegisteredAt GetEventsV1EventIdRegistrationsParamsSortBy = "registeredAt"
+)
+
+// Defines values for GetEventsV1EventIdRegistrationsParamsSortOrder.
+const (
+	Asc  GetEventsV1EventIdRegistrationsParamsSortOrder = "asc"
+	Desc GetEventsV1EventIdRegistrationsParamsSortOrder = "desc"
+)
+
+// Defines values for GetEventsV1EventIdRegistrationsExportParamsRows.
+const (
+	PerPlayer       GetEventsV1EventIdRegistrationsExportParamsRows = "perPlayer"
+	PerRegistration GetEventsV1EventIdRegistrationsExportParamsRows = "perRegistration"
+)
+
 // Address defines model for Address.
 type Address struct {
 	// City City or town
@@ -76,6 +164,37 @@ type Address struct {
 	Street string `json:"street"`
 }
 
+// AdminNote defines model for AdminNote.
+type AdminNote struct {
+	// Author Email of the admin that wrote the note
+	Author    string             `json:"author"`
+	CreatedAt time.Time          `json:"createdAt"`
+	Id        openapi_types.UUID `json:"id"`
+	Text      string             `json:"text"`
+}
+
+// CheckInResult defines model for CheckInResult.
+type CheckInResult struct {
+	Registration Registration `json:"registration"`
+	SignUpStats  *SignUpStats `json:"signUpStats,omitempty"`
+}
+
+// EmergencyContact defines model for EmergencyContact.
+type EmergencyContact struct {
+	Name         string  `json:"name"`
+	Phone        string  `json:"phone"`
+	Relationship *string `json:"relationship,omitempty"`
+}
+
+// ErasureResult defines model for ErasureResult.
+type ErasureResult struct {
+	// AnonymizedPlayerEntries Spots on other people's teams that are now anonymous
+	AnonymizedPlayerEntries int `json:"anonymizedPlayerEntries"`
+
+	// AnonymizedRegistrations Registrations the email was the registrant of, which are now anonymous
+	AnonymizedRegistrations int `json:"anonymizedRegistrations"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    ErrorCode `json:"code"`
@@ -87,19 +206,36 @@ type ErrorCode string
 
 // Event defines model for Event.
 type Event struct {
-	AllowedTeamSizeRange Range               `json:"allowedTeamSizeRange"`
-	EndTime              time.Time           `json:"endTime"`
-	Id                   *openapi_types.UUID `json:"id,omitempty"`
+	// AllowAdaptivePricing If the payment provider can offer to convert the price to the registrant's local currency on checkout.
+	AllowAdaptivePricing *bool `json:"allowAdaptivePricing,omitempty"`
+	AllowedTeamSizeRange Range `json:"allowedTeamSizeRange"`
+
+	// CheckoutHoldMinutes How many minutes a checkout holds the registrant's spot before it expires. The payment provider only allows between 30 minutes and 24 hours.
+	CheckoutHoldMinutes *int `json:"checkoutHoldMinutes,omitempty"`
+
+	// CheckoutItemDescription Shown as the item the registrant is paying for on checkout. Defaults to the event's name and the kind of sign up, e.g. "ICAA Cup 2025 Team Sign Up".
+	CheckoutItemDescription *string             `json:"checkoutItemDescription,omitempty"`
+	EndTime                 time.Time           `json:"endTime"`
+	Id                      *openapi_types.UUID `json:"id,omitempty"`
 
 	// ImageName A file name that exists in the UI assets to use as the logo.
-	ImageName             *string                   `json:"imageName,omitempty"`
-	Location              Location                  `json:"location"`
-	Name                  string                    `json:"name"`
+	ImageName *string  `json:"imageName,omitempty"`
+	Location  Location `json:"location"`
+	Name      string   `json:"name"`
+
+	// PersonalDataPurgedAt When the players' names, emails and home cities on the event's registrations were anonymized by the retention policy. Unset until then.
+	PersonalDataPurgedAt  *time.Time                `json:"personalDataPurgedAt,omitempty"`
 	RegistrationCloseTime time.Time                 `json:"registrationCloseTime"`
 	RegistrationOptions   []EventRegistrationOption `json:"registrationOptions"`
-	RulesDocLink          *string                   `json:"rulesDocLink,omitempty"`
-	SignUpStats           *SignUpStats              `json:"signUpStats,omitempty"`
-	StartTime             time.Time                 `json:"startTime"`
+
+	// RequireEmailVerification If set, free sign ups only hold their spot until the registrant clicks a verification link emailed to them. Unverified sign ups are released after an hour.
+	RequireEmailVerification *bool        `json:"requireEmailVerification,omitempty"`
+	RulesDocLink             *string      `json:"rulesDocLink,omitempty"`
+	SignUpStats              *SignUpStats `json:"signUpStats,omitempty"`
+
+	// SplitPaymentWindowHours If set, teams can split their fee between players. Each player has this many hours after the team signs up to pay their share.
+	SplitPaymentWindowHours *int      `json:"splitPaymentWindowHours,omitempty"`
+	StartTime               time.Time `json:"startTime"`
 
 	// TimeZone Time zone of the event. Defaults to UTC if not set.
 	TimeZone *string `json:"timeZone,omitempty"`
@@ -112,21 +248,67 @@ type EventRegistrationOption struct {
 	RegistrationType RegistrationType `json:"registrationType"`
 }
 
+// EventSummary defines model for EventSummary.
+type EventSummary struct {
+	EndTime   time.Time          `json:"endTime"`
+	Id        openapi_types.UUID `json:"id"`
+	ImageName *string            `json:"imageName,omitempty"`
+	Location  Location           `json:"location"`
+	Name      string             `json:"name"`
+	StartTime time.Time          `json:"startTime"`
+	TimeZone  *string            `json:"timeZone,omitempty"`
+}
+
 // ExperienceLevel defines model for ExperienceLevel.
 type ExperienceLevel string
 
+// ImportResult defines model for ImportResult.
+type ImportResult struct {
+	DryRun bool             `json:"dryRun"`
+	Errors []ImportRowError `json:"errors"`
+
+	// NumImported Registrations that were saved, always 0 for a dry run
+	NumImported int `json:"numImported"`
+
+	// NumValid Registrations that passed validation
+	NumValid int `json:"numValid"`
+}
+
+// ImportRowError defines model for ImportRowError.
+type ImportRowError struct {
+	Email   *string `json:"email,omitempty"`
+	Message string  `json:"message"`
+
+	// Row Line of the file, the header being row 1
+	Row int `json:"row"`
+}
+
 // IndividualRegistration defines model for IndividualRegistration.
 type IndividualRegistration struct {
-	Email            openapi_types.Email `json:"email"`
-	EventId          *openapi_types.UUID `json:"eventId,omitempty"`
-	Experience       ExperienceLevel     `json:"experience"`
-	HomeCity         string              `json:"homeCity"`
-	Id               *openapi_types.UUID `json:"id,omitempty"`
-	Paid             *bool               `json:"paid,omitempty"`
-	PlayerInfo       PlayerInfo          `json:"playerInfo"`
-	RegisteredAt     *time.Time          `json:"registeredAt,omitempty"`
-	RegistrationType RegistrationType    `json:"registrationType"`
-	Version          *int                `json:"version,omitempty"`
+	// AdminNotes Internal notes from organizers, only included for admins
+	AdminNotes     *[]AdminNote        `json:"adminNotes,omitempty"`
+	Email          openapi_types.Email `json:"email"`
+	EventId        *openapi_types.UUID `json:"eventId,omitempty"`
+	Experience     ExperienceLevel     `json:"experience"`
+	HomeCity       string              `json:"homeCity"`
+	Id             *openapi_types.UUID `json:"id,omitempty"`
+	OfflinePayment *OfflinePayment     `json:"offlinePayment,omitempty"`
+
+	// Paid Same as status being Paid, kept for older clients.
+	Paid             *bool            `json:"paid,omitempty"`
+	PlayerInfo       PlayerInfo       `json:"playerInfo"`
+	Refunds          *[]Refund        `json:"refunds,omitempty"`
+	RegisteredAt     *time.Time       `json:"registeredAt,omitempty"`
+	RegistrationType RegistrationType `json:"registrationType"`
+
+	// Status Free sign ups are Confirmed once they're saved, or once their email is verified if the event asks for it.
+	Status        *RegistrationStatus `json:"status,omitempty"`
+	StatusHistory *[]StatusChange     `json:"statusHistory,omitempty"`
+
+	// Tags Organizer tags, only included for admins
+	Tags      *[]string   `json:"tags,omitempty"`
+	Transfers *[]Transfer `json:"transfers,omitempty"`
+	Version   *int        `json:"version,omitempty"`
 }
 
 // Location defines model for Location.
@@ -142,12 +324,162 @@ type Money struct {
 	Currency string `json:"currency"`
 }
 
+// OfflinePayment defines model for OfflinePayment.
+type OfflinePayment struct {
+	Amount     *Money        `json:"amount,omitempty"`
+	Method     PaymentMethod `json:"method"`
+	Note       string        `json:"note"`
+	RecordedAt *time.Time    `json:"recordedAt,omitempty"`
+
+	// RecordedBy Email of the admin that recorded the payment
+	RecordedBy *string `json:"recordedBy,omitempty"`
+}
+
+// OutboxItem defines model for OutboxItem.
+type OutboxItem struct {
+	// Attempts Delivery attempts made so far
+	Attempts  int       `json:"attempts"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Email Email of the registration the item is for
+	Email   openapi_types.Email `json:"email"`
+	EventId openapi_types.UUID  `json:"eventId"`
+	Id      openapi_types.UUID  `json:"id"`
+
+	// Kind What the item does:
+	//  * `ConfirmationEmail` - Emails the registrant that they're signed up
+	//  * `RosterInvitations` - Emails a team's players to confirm their roster spot
+	//  * `MailingList` - Adds everyone on the registration to the event's mailing list, if it has one
+	Kind OutboxItemKind `json:"kind"`
+
+	// LastError Why the last attempt failed
+	LastError *string `json:"lastError,omitempty"`
+
+	// NextAttemptAt When it's next due, only used while pending
+	NextAttemptAt time.Time        `json:"nextAttemptAt"`
+	Status        OutboxItemStatus `json:"status"`
+}
+
+// OutboxItemKind What the item does:
+//   - `ConfirmationEmail` - Emails the registrant that they're signed up
+//   - `RosterInvitations` - Emails a team's players to confirm their roster spot
+//   - `MailingList` - Adds everyone on the registration to the event's mailing list, if it has one
+type OutboxItemKind string
+
+// OutboxItemStatus defines model for OutboxItemStatus.
+type OutboxItemStatus string
+
+// PaymentMethod defines model for PaymentMethod.
+type PaymentMethod string
+
+// PaymentMismatch defines model for PaymentMismatch.
+type PaymentMismatch struct {
+	Amount            *Money              `json:"amount,omitempty"`
+	CheckoutSessionId *string             `json:"checkoutSessionId,omitempty"`
+	Email             openapi_types.Email `json:"email"`
+
+	// Fixable If it can be fixed with the fix endpoint. The rest need the payment refunded or recorded offline.
+	Fixable bool `json:"fixable"`
+
+	// IntentExpiresAt Only set for orphan intents
+	IntentExpiresAt *time.Time `json:"intentExpiresAt,omitempty"`
+
+	// Kind PaidNotMarkedPaid: the payment provider took the payment but the registration is still pending.
+	// MarkedPaidWithoutPayment: marked paid without a payment at the payment provider or one recorded offline.
+	// PaymentWithoutRegistration: paid for a registration that doesn't exist, usually one that expired first.
+	// OrphanIntent: a checkout intent without a pending registration to go with it.
+	Kind PaymentMismatchKind `json:"kind"`
+
+	// PaymentId Only set for the mismatches found from a payment
+	PaymentId *string `json:"paymentId,omitempty"`
+
+	// RegistrationStatus Free sign ups are Confirmed once they're saved, or once their email is verified if the event asks for it.
+	RegistrationStatus *RegistrationStatus `json:"registrationStatus,omitempty"`
+}
+
+// PaymentMismatchKind PaidNotMarkedPaid: the payment provider took the payment but the registration is still pending.
+// MarkedPaidWithoutPayment: marked paid without a payment at the payment provider or one recorded offline.
+// PaymentWithoutRegistration: paid for a registration that doesn't exist, usually one that expired first.
+// OrphanIntent: a checkout intent without a pending registration to go with it.
+type PaymentMismatchKind string
+
+// PaymentReconciliation defines model for PaymentReconciliation.
+type PaymentReconciliation struct {
+	EventId          openapi_types.UUID `json:"eventId"`
+	Mismatches       []PaymentMismatch  `json:"mismatches"`
+	NumIntents       int                `json:"numIntents"`
+	NumPayments      int                `json:"numPayments"`
+	NumRegistrations int                `json:"numRegistrations"`
+}
+
+// PersonalData defines model for PersonalData.
+type PersonalData struct {
+	Email openapi_types.Email `json:"email"`
+
+	// Intents Checkouts still open for those registrations
+	Intents []PersonalDataIntent `json:"intents"`
+
+	// PlayerEntries Every spot on a roster with the email, including the registrant's own
+	PlayerEntries []PersonalDataPlayerEntry `json:"playerEntries"`
+
+	// Registrations Registrations the email is the registrant of
+	Registrations []Registration `json:"registrations"`
+}
+
+// PersonalDataIntent defines model for PersonalDataIntent.
+type PersonalDataIntent struct {
+	EventId          openapi_types.UUID `json:"eventId"`
+	ExpiresAt        time.Time          `json:"expiresAt"`
+	PaymentSessionId string             `json:"paymentSessionId"`
+}
+
+// PersonalDataPlayerEntry defines model for PersonalDataPlayerEntry.
+type PersonalDataPlayerEntry struct {
+	EventId     openapi_types.UUID `json:"eventId"`
+	MedicalInfo PlayerMedicalInfo  `json:"medicalInfo"`
+	Player      PlayerInfo         `json:"player"`
+
+	// RegistrationEmail Email the registration is stored under
+	RegistrationEmail openapi_types.Email `json:"registrationEmail"`
+
+	// TeamName Only set for teams
+	TeamName *string `json:"teamName,omitempty"`
+}
+
 // PlayerInfo defines model for PlayerInfo.
 type PlayerInfo struct {
+	CheckedInAt *time.Time `json:"checkedInAt,omitempty"`
+
+	// CheckedInBy Admin that checked the player in
+	CheckedInBy *string    `json:"checkedInBy,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmedAt,omitempty"`
+
 	// Email Optional email for each player
-	Email     *openapi_types.Email `json:"email,omitempty"`
-	FirstName string               `json:"firstName"`
-	LastName  string               `json:"lastName"`
+	Email *openapi_types.Email `json:"email,omitempty"`
+
+	// EmergencyContact Encrypted when stored and only returned by the medical info endpoint
+	EmergencyContact *EmergencyContact `json:"emergencyContact,omitempty"`
+	FirstName        string            `json:"firstName"`
+	InvitedAt        *time.Time        `json:"invitedAt,omitempty"`
+	LastName         string            `json:"lastName"`
+
+	// MedicalNotes Allergies, conditions or anything else medics should know. Encrypted when stored and only returned by the medical info endpoint
+	MedicalNotes *string `json:"medicalNotes,omitempty"`
+
+	// RosterStatus Whether a player has confirmed they are on a team's roster
+	RosterStatus *RosterStatus `json:"rosterStatus,omitempty"`
+	SharePaidAt  *time.Time    `json:"sharePaidAt,omitempty"`
+
+	// ShareStatus Whether a player has paid their share of a team that is splitting the payment
+	ShareStatus *ShareStatus `json:"shareStatus,omitempty"`
+}
+
+// PlayerMedicalInfo defines model for PlayerMedicalInfo.
+type PlayerMedicalInfo struct {
+	EmergencyContact *EmergencyContact `json:"emergencyContact,omitempty"`
+	FirstName        string            `json:"firstName"`
+	LastName         string            `json:"lastName"`
+	MedicalNotes     *string           `json:"medicalNotes,omitempty"`
 }
 
 // Range defines model for Range.
@@ -156,11 +488,35 @@ type Range struct {
 	Min int `json:"min"`
 }
 
+// Refund defines model for Refund.
+type Refund struct {
+	Amount     Money              `json:"amount"`
+	Id         openapi_types.UUID `json:"id"`
+	Reason     string             `json:"reason"`
+	RefundedAt time.Time          `json:"refundedAt"`
+
+	// RefundedBy Email of the admin that made the refund
+	RefundedBy   string `json:"refundedBy"`
+	ReleasedSpot bool   `json:"releasedSpot"`
+}
+
 // Registration defines model for Registration.
 type Registration struct {
 	union json.RawMessage
 }
 
+// RegistrationCounts Totals across every registration matching the filters, not just the current page
+type RegistrationCounts struct {
+	// ByStatus Number of registrations for each status
+	ByStatus    map[string]int `json:"byStatus"`
+	Individuals int            `json:"individuals"`
+
+	// Players Individuals plus every player on a team
+	Players int `json:"players"`
+	Teams   int `json:"teams"`
+	Total   int `json:"total"`
+}
+
 // RegistrationPaymentInfo defines model for RegistrationPaymentInfo.
 type RegistrationPaymentInfo struct {
 	ClientSecret string       `json:"clientSecret"`
@@ -168,28 +524,110 @@ type RegistrationPaymentInfo struct {
 	Registration Registration `json:"registration"`
 }
 
+// RegistrationStatus Free sign ups are Confirmed once they're saved, or once their email is verified if the event asks for it.
+type RegistrationStatus string
+
 // RegistrationType defines model for RegistrationType.
 type RegistrationType string
 
+// RegistrationWithEvent defines model for RegistrationWithEvent.
+type RegistrationWithEvent struct {
+	Event        EventSummary `json:"event"`
+	Registration Registration `json:"registration"`
+}
+
+// RosterStatus Whether a player has confirmed they are on a team's roster
+type RosterStatus string
+
+// ShareCheckout defines model for ShareCheckout.
+type ShareCheckout struct {
+	Amount       Money     `json:"amount"`
+	ClientSecret string    `json:"clientSecret"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+// ShareStatus Whether a player has paid their share of a team that is splitting the payment
+type ShareStatus string
+
 // SignUpStats defines model for SignUpStats.
 type SignUpStats struct {
+	// NumCheckedInPlayers Players checked in at the event
+	NumCheckedInPlayers int `json:"numCheckedInPlayers"`
+
+	// NumCheckedInTeams Teams with at least one player checked in at the event
+	NumCheckedInTeams  int `json:"numCheckedInTeams"`
 	NumRosteredPlayers int `json:"numRosteredPlayers"`
 	NumTeams           int `json:"numTeams"`
 	NumTotalPlayers    int `json:"numTotalPlayers"`
 }
 
+// StatusChange defines model for StatusChange.
+type StatusChange struct {
+	ChangedAt time.Time `json:"changedAt"`
+
+	// ChangedBy Email of whoever made the change, or what made it (e.g. payment_provider)
+	ChangedBy string `json:"changedBy"`
+
+	// From Free sign ups are Confirmed once they're saved, or once their email is verified if the event asks for it.
+	From   *RegistrationStatus `json:"from,omitempty"`
+	Reason *string             `json:"reason,omitempty"`
+
+	// To Free sign ups are Confirmed once they're saved, or once their email is verified if the event asks for it.
+	To *RegistrationStatus `json:"to,omitempty"`
+}
+
 // TeamRegistration defines model for TeamRegistration.
 type TeamRegistration struct {
-	CaptainEmail     openapi_types.Email `json:"captainEmail"`
-	EventId          *openapi_types.UUID `json:"eventId,omitempty"`
-	HomeCity         string              `json:"homeCity"`
-	Id               *openapi_types.UUID `json:"id,omitempty"`
-	Paid             *bool               `json:"paid,omitempty"`
-	Players          []PlayerInfo        `json:"players"`
-	RegisteredAt     *time.Time          `json:"registeredAt,omitempty"`
-	RegistrationType RegistrationType    `json:"registrationType"`
-	TeamName         string              `json:"teamName"`
-	Version          *int                `json:"version,omitempty"`
+	// AdminNotes Internal notes from organizers, only included for admins
+	AdminNotes     *[]AdminNote        `json:"adminNotes,omitempty"`
+	CaptainEmail   openapi_types.Email `json:"captainEmail"`
+	EventId        *openapi_types.UUID `json:"eventId,omitempty"`
+	HomeCity       string              `json:"homeCity"`
+	Id             *openapi_types.UUID `json:"id,omitempty"`
+	OfflinePayment *OfflinePayment     `json:"offlinePayment,omitempty"`
+
+	// Paid Same as status being Paid, kept for older clients.
+	Paid *bool `json:"paid,omitempty"`
+
+	// PaymentDeadline When unpaid shares expire, only set when splitting the payment.
+	PaymentDeadline  *time.Time       `json:"paymentDeadline,omitempty"`
+	Players          []PlayerInfo     `json:"players"`
+	Refunds          *[]Refund        `json:"refunds,omitempty"`
+	RegisteredAt     *time.Time       `json:"registeredAt,omitempty"`
+	RegistrationType RegistrationType `json:"registrationType"`
+
+	// SplitPayment If every player pays their own share of the team fee. Every player needs an email and the captain has to be on the roster.
+	SplitPayment *bool `json:"splitPayment,omitempty"`
+
+	// Status Free sign ups are Confirmed once they're saved, or once their email is verified if the event asks for it.
+	Status        *RegistrationStatus `json:"status,omitempty"`
+	StatusHistory *[]StatusChange     `json:"statusHistory,omitempty"`
+
+	// Tags Organizer tags, only included for admins
+	Tags      *[]string   `json:"tags,omitempty"`
+	TeamName  string      `json:"teamName"`
+	Transfers *[]Transfer `json:"transfers,omitempty"`
+	Version   *int        `json:"version,omitempty"`
+}
+
+// Transfer defines model for Transfer.
+type Transfer struct {
+	// ChargePaid If the checkout for a positive price difference has been paid
+	ChargePaid      bool               `json:"chargePaid"`
+	FromEmail       string             `json:"fromEmail"`
+	FromEventId     openapi_types.UUID `json:"fromEventId"`
+	Id              openapi_types.UUID `json:"id"`
+	PriceDifference *Money             `json:"priceDifference,omitempty"`
+	Reason          string             `json:"reason"`
+
+	// RefundId Refund that gave back a negative price difference
+	RefundId      *openapi_types.UUID `json:"refundId,omitempty"`
+	ToEmail       string              `json:"toEmail"`
+	ToEventId     openapi_types.UUID  `json:"toEventId"`
+	TransferredAt time.Time           `json:"transferredAt"`
+
+	// TransferredBy Email of whoever made the transfer
+	TransferredBy string `json:"transferredBy"`
 }
 
 // GetEventsV1Params defines parameters for GetEventsV1.
@@ -201,6 +639,12 @@ type GetEventsV1Params struct {
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
+// GetEventsV1AdminOutboxParams defines parameters for GetEventsV1AdminOutbox.
+type GetEventsV1AdminOutboxParams struct {
+	// Status Status of the items to list. Defaults to Dead.
+	Status *OutboxItemStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
 // PostEventsV1AdminTestEmailJSONBody defines parameters for PostEventsV1AdminTestEmail.
 type PostEventsV1AdminTestEmailJSONBody struct {
 	Email openapi_types.Email `json:"email"`
@@ -218,6 +662,22 @@ type PostEventsV1AdminTestMailerliteJSONBody struct {
 	TeamName *string `json:"teamName,omitempty"`
 }
 
+// PostEventsV1EventIdCheckInJSONBody defines parameters for PostEventsV1EventIdCheckIn.
+type PostEventsV1EventIdCheckInJSONBody struct {
+	Token string `json:"token"`
+}
+
+// PostEventsV1EventIdPaymentsReconciliationFixJSONBody defines parameters for PostEventsV1EventIdPaymentsReconciliationFix.
+type PostEventsV1EventIdPaymentsReconciliationFixJSONBody struct {
+	Email openapi_types.Email `json:"email"`
+
+	// Kind PaidNotMarkedPaid: the payment provider took the payment but the registration is still pending.
+	// MarkedPaidWithoutPayment: marked paid without a payment at the payment provider or one recorded offline.
+	// PaymentWithoutRegistration: paid for a registration that doesn't exist, usually one that expired first.
+	// OrphanIntent: a checkout intent without a pending registration to go with it.
+	Kind PaymentMismatchKind `json:"kind"`
+}
+
 // PostEventsV1EventIdRegisterParams defines parameters for PostEventsV1EventIdRegister.
 type PostEventsV1EventIdRegisterParams struct {
 	// CfTurnstileResponse Cloudflare turnstile CAPTCHA
@@ -231,14 +691,130 @@ type GetEventsV1EventIdRegistrationsParams struct {
 
 	// Limit Max amount of registrations to fetch
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Type Only registrations of this type
+	Type *RegistrationType `form:"type,omitempty" json:"type,omitempty"`
+
+	// Status Only registrations with this status
+	Status *RegistrationStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Experience Only individual registrations with this experience level
+	Experience *ExperienceLevel `form:"experience,omitempty" json:"experience,omitempty"`
+
+	// HomeCity Only registrations from this home city, case insensitive
+	HomeCity *string `form:"homeCity,omitempty" json:"homeCity,omitempty"`
+
+	// RegisteredAfter Only registrations made after this time
+	RegisteredAfter *time.Time `form:"registeredAfter,omitempty" json:"registeredAfter,omitempty"`
+
+	// Search Searches team names, player names and emails, case insensitive
+	Search *string `form:"search,omitempty" json:"search,omitempty"`
+
+	// CheckedIn Only registrations where someone has (true) or nobody has (false) checked in at the event
+	CheckedIn *bool `form:"checkedIn,omitempty" json:"checkedIn,omitempty"`
+
+	// Tag Only registrations an admin tagged with this, case insensitive
+	Tag *string `form:"tag,omitempty" json:"tag,omitempty"`
+
+	// SortBy What to sort the registrations by
+	SortBy *GetEventsV1EventIdRegistrationsParamsSortBy `form:"sortBy,omitempty" json:"sortBy,omitempty"`
+
+	// SortOrder Direction to sort the registrations in
+	SortOrder *GetEventsV1EventIdRegistrationsParamsSortOrder `form:"sortOrder,omitempty" json:"sortOrder,omitempty"`
 }
 
+// GetEventsV1EventIdRegistrationsParamsSortBy defines parameters for GetEventsV1EventIdRegistrations.
+type GetEventsV1EventIdRegistrationsParamsSortBy string
+
+// GetEventsV1EventIdRegistrationsParamsSortOrder defines parameters for GetEventsV1EventIdRegistrations.
+type GetEventsV1EventIdRegistrationsParamsSortOrder string
+
 // PostEventsV1EventIdRegistrationsParams defines parameters for PostEventsV1EventIdRegistrations.
 type PostEventsV1EventIdRegistrationsParams struct {
 	// CfTurnstileResponse Cloudflare turnstile CAPTCHA
 	CfTurnstileResponse string `json:"cf-turnstile-response"`
 }
 
+// GetEventsV1EventIdRegistrationsExportParams defines parameters for GetEventsV1EventIdRegistrationsExport.
+type GetEventsV1EventIdRegistrationsExportParams struct {
+	// Rows Whether to have one row for each player, or one for each registration
+	Rows *GetEventsV1EventIdRegistrationsExportParamsRows `form:"rows,omitempty" json:"rows,omitempty"`
+}
+
+// GetEventsV1EventIdRegistrationsExportParamsRows defines parameters for GetEventsV1EventIdRegistrationsExport.
+type GetEventsV1EventIdRegistrationsExportParamsRows string
+
+// PostEventsV1EventIdRegistrationsImportParams defines parameters for PostEventsV1EventIdRegistrationsImport.
+type PostEventsV1EventIdRegistrationsImportParams struct {
+	// DryRun Only check the file and report what would be imported
+	DryRun *bool `form:"dryRun,omitempty" json:"dryRun,omitempty"`
+
+	// AllowDuplicatePlayers Import players even if they are already on another registration for the event
+	AllowDuplicatePlayers *bool `form:"allowDuplicatePlayers,omitempty" json:"allowDuplicatePlayers,omitempty"`
+}
+
+// PostEventsV1EventIdRegistrationsManualJSONBody defines parameters for PostEventsV1EventIdRegistrationsManual.
+type PostEventsV1EventIdRegistrationsManualJSONBody struct {
+	// IgnoreCloseTime Lets the registration in after registration has closed.
+	IgnoreCloseTime *bool           `json:"ignoreCloseTime,omitempty"`
+	Payment         *OfflinePayment `json:"payment,omitempty"`
+	Registration    Registration    `json:"registration"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailCheckInJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailCheckIn.
+type PostEventsV1EventIdRegistrationsEmailCheckInJSONBody struct {
+	// PlayerIndexes Positions of the players on the registration. Everyone on it if left out.
+	PlayerIndexes *[]int `json:"playerIndexes,omitempty"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailCheckInUndoJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailCheckInUndo.
+type PostEventsV1EventIdRegistrationsEmailCheckInUndoJSONBody struct {
+	// PlayerIndexes Positions of the players on the registration. Everyone on it if left out.
+	PlayerIndexes *[]int `json:"playerIndexes,omitempty"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailNotesJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailNotes.
+type PostEventsV1EventIdRegistrationsEmailNotesJSONBody struct {
+	Text string `json:"text"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailRefundJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailRefund.
+type PostEventsV1EventIdRegistrationsEmailRefundJSONBody struct {
+	Amount *Money `json:"amount,omitempty"`
+	Reason string `json:"reason"`
+
+	// ReleaseSpot Removes the registration from the event's player and team counts.
+	ReleaseSpot *bool `json:"releaseSpot,omitempty"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailRosterConfirm.
+type PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONBody struct {
+	Token string `json:"token"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailRosterInvitations.
+type PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONBody struct {
+	// PlayerEmails Only resend to these players. Resends to every unconfirmed player if not set.
+	PlayerEmails *[]openapi_types.Email `json:"playerEmails,omitempty"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailSharesCheckout.
+type PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONBody struct {
+	Token string `json:"token"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailTransferJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailTransfer.
+type PostEventsV1EventIdRegistrationsEmailTransferJSONBody struct {
+	Reason    *string             `json:"reason,omitempty"`
+	ToEventId *openapi_types.UUID `json:"toEventId,omitempty"`
+	ToPlayer  *PlayerInfo         `json:"toPlayer,omitempty"`
+}
+
+// PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONBody defines parameters for PostEventsV1EventIdRegistrationsEmailVerifyEmail.
+type PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONBody struct {
+	Token string `json:"token"`
+}
+
 // PostEventsV1JSONRequestBody defines body for PostEventsV1 for application/json ContentType.
 type PostEventsV1JSONRequestBody = Event
 
@@ -248,12 +824,51 @@ type PostEventsV1AdminTestEmailJSONRequestBody PostEventsV1AdminTestEmailJSONBod
 // PostEventsV1AdminTestMailerliteJSONRequestBody defines body for PostEventsV1AdminTestMailerlite for application/json ContentType.
 type PostEventsV1AdminTestMailerliteJSONRequestBody PostEventsV1AdminTestMailerliteJSONBody
 
+// PostEventsV1EventIdCheckInJSONRequestBody defines body for PostEventsV1EventIdCheckIn for application/json ContentType.
+type PostEventsV1EventIdCheckInJSONRequestBody PostEventsV1EventIdCheckInJSONBody
+
+// PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody defines body for PostEventsV1EventIdPaymentsReconciliationFix for application/json ContentType.
+type PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody PostEventsV1EventIdPaymentsReconciliationFixJSONBody
+
 // PostEventsV1EventIdRegisterJSONRequestBody defines body for PostEventsV1EventIdRegister for application/json ContentType.
 type PostEventsV1EventIdRegisterJSONRequestBody = Registration
 
 // PostEventsV1EventIdRegistrationsJSONRequestBody defines body for PostEventsV1EventIdRegistrations for application/json ContentType.
 type PostEventsV1EventIdRegistrationsJSONRequestBody = Registration
 
+// PostEventsV1EventIdRegistrationsManualJSONRequestBody defines body for PostEventsV1EventIdRegistrationsManual for application/json ContentType.
+type PostEventsV1EventIdRegistrationsManualJSONRequestBody PostEventsV1EventIdRegistrationsManualJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailCheckInJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailCheckIn for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailCheckInJSONRequestBody PostEventsV1EventIdRegistrationsEmailCheckInJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailCheckInUndoJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailCheckInUndo for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailCheckInUndoJSONRequestBody PostEventsV1EventIdRegistrationsEmailCheckInUndoJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailNotesJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailNotes for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailNotesJSONRequestBody PostEventsV1EventIdRegistrationsEmailNotesJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailPaidOfflineJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailPaidOffline for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailPaidOfflineJSONRequestBody = OfflinePayment
+
+// PostEventsV1EventIdRegistrationsEmailRefundJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailRefund for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailRefundJSONRequestBody PostEventsV1EventIdRegistrationsEmailRefundJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailRosterConfirm for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONRequestBody PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailRosterInvitations for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONRequestBody PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailSharesCheckout for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONRequestBody PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailTransferJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailTransfer for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailTransferJSONRequestBody PostEventsV1EventIdRegistrationsEmailTransferJSONBody
+
+// PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONRequestBody defines body for PostEventsV1EventIdRegistrationsEmailVerifyEmail for application/json ContentType.
+type PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONRequestBody PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONBody
+
 // PatchEventsV1IdJSONRequestBody defines body for PatchEventsV1Id for application/json ContentType.
 type PatchEventsV1IdJSONRequestBody = Event
 
@@ -354,12 +969,36 @@ type ServerInterface interface {
 	// Create a new event
 	// (POST /events/v1)
 	PostEventsV1(w http.ResponseWriter, r *http.Request)
+	// List outbox items
+	// (GET /events/v1/admin/outbox)
+	GetEventsV1AdminOutbox(w http.ResponseWriter, r *http.Request, params GetEventsV1AdminOutboxParams)
+	// Retry an outbox item
+	// (POST /events/v1/admin/outbox/{id}/retry)
+	PostEventsV1AdminOutboxIdRetry(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
 	// Test email sending
 	// (POST /events/v1/admin/test-email)
 	PostEventsV1AdminTestEmail(w http.ResponseWriter, r *http.Request)
 	// Test MailerLite integration
 	// (POST /events/v1/admin/test-mailerlite)
 	PostEventsV1AdminTestMailerlite(w http.ResponseWriter, r *http.Request)
+	// Erase everything stored about an email
+	// (DELETE /events/v1/personal-data/{email})
+	DeleteEventsV1PersonalDataEmail(w http.ResponseWriter, r *http.Request, email openapi_types.Email)
+	// Export everything stored about an email
+	// (GET /events/v1/personal-data/{email})
+	GetEventsV1PersonalDataEmail(w http.ResponseWriter, r *http.Request, email openapi_types.Email)
+	// Get my registrations
+	// (GET /events/v1/registrations/me)
+	GetEventsV1RegistrationsMe(w http.ResponseWriter, r *http.Request)
+	// Check in with a scanned QR code
+	// (POST /events/v1/{eventId}/check-in)
+	PostEventsV1EventIdCheckIn(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID)
+	// Reconcile payments with the payment provider
+	// (GET /events/v1/{eventId}/payments/reconciliation)
+	GetEventsV1EventIdPaymentsReconciliation(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID)
+	// Fix a payment mismatch
+	// (POST /events/v1/{eventId}/payments/reconciliation/fix)
+	PostEventsV1EventIdPaymentsReconciliationFix(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID)
 	// Sign up for an event
 	// (POST /events/v1/{eventId}/register)
 	PostEventsV1EventIdRegister(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegisterParams)
@@ -369,6 +1008,57 @@ type ServerInterface interface {
 	// Sign up for an event
 	// (POST /events/v1/{eventId}/registrations)
 	PostEventsV1EventIdRegistrations(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegistrationsParams)
+	// Export registrations as CSV
+	// (GET /events/v1/{eventId}/registrations/export)
+	GetEventsV1EventIdRegistrationsExport(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params GetEventsV1EventIdRegistrationsExportParams)
+	// Import registrations from CSV
+	// (POST /events/v1/{eventId}/registrations/import)
+	PostEventsV1EventIdRegistrationsImport(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegistrationsImportParams)
+	// Register someone by hand
+	// (POST /events/v1/{eventId}/registrations/manual)
+	PostEventsV1EventIdRegistrationsManual(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID)
+	// Check in a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/check-in)
+	PostEventsV1EventIdRegistrationsEmailCheckIn(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Undo a check-in
+	// (POST /events/v1/{eventId}/registrations/{email}/check-in/undo)
+	PostEventsV1EventIdRegistrationsEmailCheckInUndo(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Get a registration's emergency contacts and medical notes
+	// (GET /events/v1/{eventId}/registrations/{email}/medical-info)
+	GetEventsV1EventIdRegistrationsEmailMedicalInfo(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Add an admin note to a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/notes)
+	PostEventsV1EventIdRegistrationsEmailNotes(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Remove an admin note from a registration
+	// (DELETE /events/v1/{eventId}/registrations/{email}/notes/{noteId})
+	DeleteEventsV1EventIdRegistrationsEmailNotesNoteId(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email, noteId openapi_types.UUID)
+	// Mark a registration as paid offline
+	// (POST /events/v1/{eventId}/registrations/{email}/paid-offline)
+	PostEventsV1EventIdRegistrationsEmailPaidOffline(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Refund a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/refund)
+	PostEventsV1EventIdRegistrationsEmailRefund(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Confirm a roster spot
+	// (POST /events/v1/{eventId}/registrations/{email}/roster/confirm)
+	PostEventsV1EventIdRegistrationsEmailRosterConfirm(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Resend roster invitations
+	// (POST /events/v1/{eventId}/registrations/{email}/roster/invitations)
+	PostEventsV1EventIdRegistrationsEmailRosterInvitations(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Pay a share of a team's fee
+	// (POST /events/v1/{eventId}/registrations/{email}/shares/checkout)
+	PostEventsV1EventIdRegistrationsEmailSharesCheckout(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Untag a registration
+	// (DELETE /events/v1/{eventId}/registrations/{email}/tags/{tag})
+	DeleteEventsV1EventIdRegistrationsEmailTagsTag(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email, tag string)
+	// Tag a registration
+	// (PUT /events/v1/{eventId}/registrations/{email}/tags/{tag})
+	PutEventsV1EventIdRegistrationsEmailTagsTag(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email, tag string)
+	// Transfer a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/transfer)
+	PostEventsV1EventIdRegistrationsEmailTransfer(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
+	// Verify a sign up's email
+	// (POST /events/v1/{eventId}/registrations/{email}/verify-email)
+	PostEventsV1EventIdRegistrationsEmailVerifyEmail(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email)
 	// Get an event
 	// (GET /events/v1/{id})
 	GetEventsV1Id(w http.ResponseWriter, r *http.Request, id openapi_types.UUID)
@@ -443,6 +1133,74 @@ func (siw *ServerInterfaceWrapper) PostEventsV1(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// GetEventsV1AdminOutbox operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsV1AdminOutbox(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsV1AdminOutboxParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventsV1AdminOutbox(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEventsV1AdminOutboxIdRetry operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1AdminOutboxIdRetry(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1AdminOutboxIdRetry(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // PostEventsV1AdminTestEmail operation middleware
 func (siw *ServerInterfaceWrapper) PostEventsV1AdminTestEmail(w http.ResponseWriter, r *http.Request) {
 
@@ -487,24 +1245,211 @@ func (siw *ServerInterfaceWrapper) PostEventsV1AdminTestMailerlite(w http.Respon
 	handler.ServeHTTP(w, r)
 }
 
-// PostEventsV1EventIdRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegister(w http.ResponseWriter, r *http.Request) {
+// DeleteEventsV1PersonalDataEmail operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEventsV1PersonalDataEmail(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "eventId" -------------
-	var eventId openapi_types.UUID
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
 		return
 	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params PostEventsV1EventIdRegisterParams
+	ctx := r.Context()
 
-	headers := r.Header
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEventsV1PersonalDataEmail(w, r, email)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventsV1PersonalDataEmail operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsV1PersonalDataEmail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin", "medical"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin", "medical"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventsV1PersonalDataEmail(w, r, email)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventsV1RegistrationsMe operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsV1RegistrationsMe(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventsV1RegistrationsMe(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEventsV1EventIdCheckIn operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdCheckIn(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdCheckIn(w, r, eventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventsV1EventIdPaymentsReconciliation operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsV1EventIdPaymentsReconciliation(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventsV1EventIdPaymentsReconciliation(w, r, eventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEventsV1EventIdPaymentsReconciliationFix operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdPaymentsReconciliationFix(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdPaymentsReconciliationFix(w, r, eventId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEventsV1EventIdRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegister(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEventsV1EventIdRegisterParams
+
+	headers := r.Header
 
 	// ------------- Required header parameter "cf-turnstile-response" -------------
 	if valueList, found := headers[http.CanonicalHeaderKey("cf-turnstile-response")]; found {
@@ -581,6 +1526,86 @@ func (siw *ServerInterfaceWrapper) GetEventsV1EventIdRegistrations(w http.Respon
 		return
 	}
 
+	// ------------- Optional query parameter "type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "experience" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "experience", r.URL.Query(), &params.Experience)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "experience", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "homeCity" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "homeCity", r.URL.Query(), &params.HomeCity)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "homeCity", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "registeredAfter" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "registeredAfter", r.URL.Query(), &params.RegisteredAfter)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "registeredAfter", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "search" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "search", r.URL.Query(), &params.Search)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "search", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "checkedIn" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "checkedIn", r.URL.Query(), &params.CheckedIn)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "checkedIn", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tag" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "tag", r.URL.Query(), &params.Tag)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortBy" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortBy", r.URL.Query(), &params.SortBy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortBy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sortOrder" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sortOrder", r.URL.Query(), &params.SortOrder)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sortOrder", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.GetEventsV1EventIdRegistrations(w, r, eventId, params)
 	}))
@@ -645,22 +1670,41 @@ func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrations(w http.Respo
 	handler.ServeHTTP(w, r)
 }
 
-// GetEventsV1Id operation middleware
-func (siw *ServerInterfaceWrapper) GetEventsV1Id(w http.ResponseWriter, r *http.Request) {
+// GetEventsV1EventIdRegistrationsExport operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsV1EventIdRegistrationsExport(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsV1EventIdRegistrationsExportParams
+
+	// ------------- Optional query parameter "rows" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "rows", r.URL.Query(), &params.Rows)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "rows", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetEventsV1Id(w, r, id)
+		siw.Handler.GetEventsV1EventIdRegistrationsExport(w, r, eventId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -670,17 +1714,17 @@ func (siw *ServerInterfaceWrapper) GetEventsV1Id(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// PatchEventsV1Id operation middleware
-func (siw *ServerInterfaceWrapper) PatchEventsV1Id(w http.ResponseWriter, r *http.Request) {
+// PostEventsV1EventIdRegistrationsImport operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsImport(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
 		return
 	}
 
@@ -692,640 +1736,3303 @@ func (siw *ServerInterfaceWrapper) PatchEventsV1Id(w http.ResponseWriter, r *htt
 
 	r = r.WithContext(ctx)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PatchEventsV1Id(w, r, id)
-	}))
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostEventsV1EventIdRegistrationsImportParams
 
-	for _, middleware := range siw.HandlerMiddlewares {
+	// ------------- Optional query parameter "dryRun" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dryRun", r.URL.Query(), &params.DryRun)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "dryRun", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "allowDuplicatePlayers" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "allowDuplicatePlayers", r.URL.Query(), &params.AllowDuplicatePlayers)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "allowDuplicatePlayers", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsImport(w, r, eventId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
 		handler = middleware(handler)
 	}
 
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// PostEventsV1EventIdRegistrationsManual operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsManual(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	ctx := r.Context()
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
 
-type RequiredParamError struct {
-	ParamName string
-}
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	r = r.WithContext(ctx)
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsManual(w, r, eventId)
+	}))
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
+	handler.ServeHTTP(w, r)
 }
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+// PostEventsV1EventIdRegistrationsEmailCheckIn operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailCheckIn(w http.ResponseWriter, r *http.Request) {
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+	var err error
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
-}
+	ctx := r.Context()
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
-}
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
-}
+	r = r.WithContext(ctx)
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailCheckIn(w, r, eventId, email)
+	}))
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	handler.ServeHTTP(w, r)
+}
 
-	m.HandleFunc("GET "+options.BaseURL+"/events/v1", wrapper.GetEventsV1)
-	m.HandleFunc("POST "+options.BaseURL+"/events/v1", wrapper.PostEventsV1)
-	m.HandleFunc("POST "+options.BaseURL+"/events/v1/admin/test-email", wrapper.PostEventsV1AdminTestEmail)
-	m.HandleFunc("POST "+options.BaseURL+"/events/v1/admin/test-mailerlite", wrapper.PostEventsV1AdminTestMailerlite)
-	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/register", wrapper.PostEventsV1EventIdRegister)
-	m.HandleFunc("GET "+options.BaseURL+"/events/v1/{eventId}/registrations", wrapper.GetEventsV1EventIdRegistrations)
-	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations", wrapper.PostEventsV1EventIdRegistrations)
-	m.HandleFunc("GET "+options.BaseURL+"/events/v1/{id}", wrapper.GetEventsV1Id)
-	m.HandleFunc("PATCH "+options.BaseURL+"/events/v1/{id}", wrapper.PatchEventsV1Id)
+// PostEventsV1EventIdRegistrationsEmailCheckInUndo operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailCheckInUndo(w http.ResponseWriter, r *http.Request) {
 
-	return m
-}
+	var err error
 
-type GetEventsV1RequestObject struct {
-	Params GetEventsV1Params
-}
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-type GetEventsV1ResponseObject interface {
-	VisitGetEventsV1Response(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-type GetEventsV1200JSONResponse struct {
-	Cursor      *string `json:"cursor,omitempty"`
-	Data        []Event `json:"data"`
-	HasNextPage bool    `json:"hasNextPage"`
-}
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-func (response GetEventsV1200JSONResponse) VisitGetEventsV1Response(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type GetEventsV1400JSONResponse Error
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
 
-func (response GetEventsV1400JSONResponse) VisitGetEventsV1Response(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type GetEventsV1500JSONResponse Error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailCheckInUndo(w, r, eventId, email)
+	}))
 
-func (response GetEventsV1500JSONResponse) VisitGetEventsV1Response(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type PostEventsV1RequestObject struct {
-	Body *PostEventsV1JSONRequestBody
-}
+// GetEventsV1EventIdRegistrationsEmailMedicalInfo operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsV1EventIdRegistrationsEmailMedicalInfo(w http.ResponseWriter, r *http.Request) {
 
-type PostEventsV1ResponseObject interface {
-	VisitPostEventsV1Response(w http.ResponseWriter) error
-}
+	var err error
 
-type PostEventsV1200JSONResponse Event
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-func (response PostEventsV1200JSONResponse) VisitPostEventsV1Response(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-type PostEventsV1400JSONResponse Error
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-func (response PostEventsV1400JSONResponse) VisitPostEventsV1Response(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin", "medical"})
 
-type PostEventsV1500JSONResponse Error
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin", "medical"})
 
-func (response PostEventsV1500JSONResponse) VisitPostEventsV1Response(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	r = r.WithContext(ctx)
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventsV1EventIdRegistrationsEmailMedicalInfo(w, r, eventId, email)
+	}))
 
-type PostEventsV1AdminTestEmailRequestObject struct {
-	Body *PostEventsV1AdminTestEmailJSONRequestBody
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type PostEventsV1AdminTestEmailResponseObject interface {
-	VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type PostEventsV1AdminTestEmail200Response struct {
-}
+// PostEventsV1EventIdRegistrationsEmailNotes operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailNotes(w http.ResponseWriter, r *http.Request) {
 
-func (response PostEventsV1AdminTestEmail200Response) VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
-}
+	var err error
 
-type PostEventsV1AdminTestEmail400JSONResponse Error
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-func (response PostEventsV1AdminTestEmail400JSONResponse) VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-type PostEventsV1AdminTestEmail500JSONResponse Error
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-func (response PostEventsV1AdminTestEmail500JSONResponse) VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
 
-type PostEventsV1AdminTestMailerliteRequestObject struct {
-	Body *PostEventsV1AdminTestMailerliteJSONRequestBody
-}
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
 
-type PostEventsV1AdminTestMailerliteResponseObject interface {
-	VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type PostEventsV1AdminTestMailerlite200JSONResponse struct {
-	GroupId   *string `json:"groupId,omitempty"`
-	GroupName *string `json:"groupName,omitempty"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailNotes(w, r, eventId, email)
+	}))
 
-func (response PostEventsV1AdminTestMailerlite200JSONResponse) VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type PostEventsV1AdminTestMailerlite400JSONResponse Error
-
-func (response PostEventsV1AdminTestMailerlite400JSONResponse) VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
-
-	return json.NewEncoder(w).Encode(response)
-}
+// DeleteEventsV1EventIdRegistrationsEmailNotesNoteId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEventsV1EventIdRegistrationsEmailNotesNoteId(w http.ResponseWriter, r *http.Request) {
 
-type PostEventsV1AdminTestMailerlite500JSONResponse Error
+	var err error
 
-func (response PostEventsV1AdminTestMailerlite500JSONResponse) VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-type PostEventsV1EventIdRegisterRequestObject struct {
-	EventId openapi_types.UUID `json:"eventId"`
-	Params  PostEventsV1EventIdRegisterParams
-	Body    *PostEventsV1EventIdRegisterJSONRequestBody
-}
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-type PostEventsV1EventIdRegisterResponseObject interface {
-	VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-type PostEventsV1EventIdRegister200JSONResponse struct {
-	Registration Registration `json:"registration"`
-}
+	// ------------- Path parameter "noteId" -------------
+	var noteId openapi_types.UUID
 
-func (response PostEventsV1EventIdRegister200JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "noteId", r.PathValue("noteId"), &noteId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "noteId", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx := r.Context()
 
-type PostEventsV1EventIdRegister400JSONResponse Error
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
 
-func (response PostEventsV1EventIdRegister400JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	r = r.WithContext(ctx)
 
-type PostEventsV1EventIdRegister403JSONResponse Error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEventsV1EventIdRegistrationsEmailNotesNoteId(w, r, eventId, email, noteId)
+	}))
 
-func (response PostEventsV1EventIdRegister403JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type PostEventsV1EventIdRegister404JSONResponse Error
+// PostEventsV1EventIdRegistrationsEmailPaidOffline operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailPaidOffline(w http.ResponseWriter, r *http.Request) {
 
-func (response PostEventsV1EventIdRegister404JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-type PostEventsV1EventIdRegister409JSONResponse Error
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-func (response PostEventsV1EventIdRegister409JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-type PostEventsV1EventIdRegister500JSONResponse Error
+	ctx := r.Context()
 
-func (response PostEventsV1EventIdRegister500JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
 
-type GetEventsV1EventIdRegistrationsRequestObject struct {
-	EventId openapi_types.UUID `json:"eventId"`
-	Params  GetEventsV1EventIdRegistrationsParams
-}
+	r = r.WithContext(ctx)
 
-type GetEventsV1EventIdRegistrationsResponseObject interface {
-	VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailPaidOffline(w, r, eventId, email)
+	}))
 
-type GetEventsV1EventIdRegistrations200JSONResponse struct {
-	Cursor      *string        `json:"cursor,omitempty"`
-	Data        []Registration `json:"data"`
-	HasNextPage bool           `json:"hasNextPage"`
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-func (response GetEventsV1EventIdRegistrations200JSONResponse) VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// PostEventsV1EventIdRegistrationsEmailRefund operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailRefund(w http.ResponseWriter, r *http.Request) {
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var err error
 
-type GetEventsV1EventIdRegistrations400JSONResponse Error
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-func (response GetEventsV1EventIdRegistrations400JSONResponse) VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-type GetEventsV1EventIdRegistrations500JSONResponse Error
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-func (response GetEventsV1EventIdRegistrations500JSONResponse) VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx := r.Context()
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
 
-type PostEventsV1EventIdRegistrationsRequestObject struct {
-	EventId openapi_types.UUID `json:"eventId"`
-	Params  PostEventsV1EventIdRegistrationsParams
-	Body    *PostEventsV1EventIdRegistrationsJSONRequestBody
-}
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
 
-type PostEventsV1EventIdRegistrationsResponseObject interface {
-	VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error
-}
+	r = r.WithContext(ctx)
 
-type PostEventsV1EventIdRegistrations200JSONResponse struct {
-	Info RegistrationPaymentInfo `json:"info"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailRefund(w, r, eventId, email)
+	}))
 
-func (response PostEventsV1EventIdRegistrations200JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type PostEventsV1EventIdRegistrations400JSONResponse Error
+// PostEventsV1EventIdRegistrationsEmailRosterConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailRosterConfirm(w http.ResponseWriter, r *http.Request) {
 
-func (response PostEventsV1EventIdRegistrations400JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
 
-type PostEventsV1EventIdRegistrations403JSONResponse Error
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
 
-func (response PostEventsV1EventIdRegistrations403JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(403)
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
 
-type PostEventsV1EventIdRegistrations404JSONResponse Error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailRosterConfirm(w, r, eventId, email)
+	}))
 
-func (response PostEventsV1EventIdRegistrations404JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type PostEventsV1EventIdRegistrations409JSONResponse Error
+// PostEventsV1EventIdRegistrationsEmailRosterInvitations operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailRosterInvitations(w http.ResponseWriter, r *http.Request) {
 
-func (response PostEventsV1EventIdRegistrations409JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailRosterInvitations(w, r, eventId, email)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEventsV1EventIdRegistrationsEmailSharesCheckout operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailSharesCheckout(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailSharesCheckout(w, r, eventId, email)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteEventsV1EventIdRegistrationsEmailTagsTag operation middleware
+func (siw *ServerInterfaceWrapper) DeleteEventsV1EventIdRegistrationsEmailTagsTag(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "tag" -------------
+	var tag string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", r.PathValue("tag"), &tag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteEventsV1EventIdRegistrationsEmailTagsTag(w, r, eventId, email, tag)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutEventsV1EventIdRegistrationsEmailTagsTag operation middleware
+func (siw *ServerInterfaceWrapper) PutEventsV1EventIdRegistrationsEmailTagsTag(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "tag" -------------
+	var tag string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "tag", r.PathValue("tag"), &tag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tag", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutEventsV1EventIdRegistrationsEmailTagsTag(w, r, eventId, email, tag)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEventsV1EventIdRegistrationsEmailTransfer operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailTransfer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailTransfer(w, r, eventId, email)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostEventsV1EventIdRegistrationsEmailVerifyEmail operation middleware
+func (siw *ServerInterfaceWrapper) PostEventsV1EventIdRegistrationsEmailVerifyEmail(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "eventId" -------------
+	var eventId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "eventId", r.PathValue("eventId"), &eventId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "eventId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "email" -------------
+	var email openapi_types.Email
+
+	err = runtime.BindStyledParameterWithOptions("simple", "email", r.PathValue("email"), &email, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "email", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostEventsV1EventIdRegistrationsEmailVerifyEmail(w, r, eventId, email)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetEventsV1Id operation middleware
+func (siw *ServerInterfaceWrapper) GetEventsV1Id(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetEventsV1Id(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchEventsV1Id operation middleware
+func (siw *ServerInterfaceWrapper) PatchEventsV1Id(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, IcaaCookieAuthScopes, []string{"admin"})
+
+	ctx = context.WithValue(ctx, IcaaBearerAuthScopes, []string{"admin"})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchEventsV1Id(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1", wrapper.GetEventsV1)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1", wrapper.PostEventsV1)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/admin/outbox", wrapper.GetEventsV1AdminOutbox)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/admin/outbox/{id}/retry", wrapper.PostEventsV1AdminOutboxIdRetry)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/admin/test-email", wrapper.PostEventsV1AdminTestEmail)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/admin/test-mailerlite", wrapper.PostEventsV1AdminTestMailerlite)
+	m.HandleFunc("DELETE "+options.BaseURL+"/events/v1/personal-data/{email}", wrapper.DeleteEventsV1PersonalDataEmail)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/personal-data/{email}", wrapper.GetEventsV1PersonalDataEmail)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/registrations/me", wrapper.GetEventsV1RegistrationsMe)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/check-in", wrapper.PostEventsV1EventIdCheckIn)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/{eventId}/payments/reconciliation", wrapper.GetEventsV1EventIdPaymentsReconciliation)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/payments/reconciliation/fix", wrapper.PostEventsV1EventIdPaymentsReconciliationFix)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/register", wrapper.PostEventsV1EventIdRegister)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/{eventId}/registrations", wrapper.GetEventsV1EventIdRegistrations)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations", wrapper.PostEventsV1EventIdRegistrations)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/{eventId}/registrations/export", wrapper.GetEventsV1EventIdRegistrationsExport)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/import", wrapper.PostEventsV1EventIdRegistrationsImport)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/manual", wrapper.PostEventsV1EventIdRegistrationsManual)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/check-in", wrapper.PostEventsV1EventIdRegistrationsEmailCheckIn)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/check-in/undo", wrapper.PostEventsV1EventIdRegistrationsEmailCheckInUndo)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/medical-info", wrapper.GetEventsV1EventIdRegistrationsEmailMedicalInfo)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/notes", wrapper.PostEventsV1EventIdRegistrationsEmailNotes)
+	m.HandleFunc("DELETE "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/notes/{noteId}", wrapper.DeleteEventsV1EventIdRegistrationsEmailNotesNoteId)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/paid-offline", wrapper.PostEventsV1EventIdRegistrationsEmailPaidOffline)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/refund", wrapper.PostEventsV1EventIdRegistrationsEmailRefund)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/roster/confirm", wrapper.PostEventsV1EventIdRegistrationsEmailRosterConfirm)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/roster/invitations", wrapper.PostEventsV1EventIdRegistrationsEmailRosterInvitations)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/shares/checkout", wrapper.PostEventsV1EventIdRegistrationsEmailSharesCheckout)
+	m.HandleFunc("DELETE "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/tags/{tag}", wrapper.DeleteEventsV1EventIdRegistrationsEmailTagsTag)
+	m.HandleFunc("PUT "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/tags/{tag}", wrapper.PutEventsV1EventIdRegistrationsEmailTagsTag)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/transfer", wrapper.PostEventsV1EventIdRegistrationsEmailTransfer)
+	m.HandleFunc("POST "+options.BaseURL+"/events/v1/{eventId}/registrations/{email}/verify-email", wrapper.PostEventsV1EventIdRegistrationsEmailVerifyEmail)
+	m.HandleFunc("GET "+options.BaseURL+"/events/v1/{id}", wrapper.GetEventsV1Id)
+	m.HandleFunc("PATCH "+options.BaseURL+"/events/v1/{id}", wrapper.PatchEventsV1Id)
+
+	return m
+}
+
+type GetEventsV1RequestObject struct {
+	Params GetEventsV1Params
+}
+
+type GetEventsV1ResponseObject interface {
+	VisitGetEventsV1Response(w http.ResponseWriter) error
+}
+
+type GetEventsV1200JSONResponse struct {
+	Cursor      *string `json:"cursor,omitempty"`
+	Data        []Event `json:"data"`
+	HasNextPage bool    `json:"hasNextPage"`
+}
+
+func (response GetEventsV1200JSONResponse) VisitGetEventsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1400JSONResponse Error
+
+func (response GetEventsV1400JSONResponse) VisitGetEventsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1500JSONResponse Error
+
+func (response GetEventsV1500JSONResponse) VisitGetEventsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1RequestObject struct {
+	Body *PostEventsV1JSONRequestBody
+}
+
+type PostEventsV1ResponseObject interface {
+	VisitPostEventsV1Response(w http.ResponseWriter) error
+}
+
+type PostEventsV1200JSONResponse Event
+
+func (response PostEventsV1200JSONResponse) VisitPostEventsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1400JSONResponse Error
+
+func (response PostEventsV1400JSONResponse) VisitPostEventsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1500JSONResponse Error
+
+func (response PostEventsV1500JSONResponse) VisitPostEventsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1AdminOutboxRequestObject struct {
+	Params GetEventsV1AdminOutboxParams
+}
+
+type GetEventsV1AdminOutboxResponseObject interface {
+	VisitGetEventsV1AdminOutboxResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1AdminOutbox200JSONResponse struct {
+	Items []OutboxItem `json:"items"`
+}
+
+func (response GetEventsV1AdminOutbox200JSONResponse) VisitGetEventsV1AdminOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1AdminOutbox500JSONResponse Error
+
+func (response GetEventsV1AdminOutbox500JSONResponse) VisitGetEventsV1AdminOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminOutboxIdRetryRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type PostEventsV1AdminOutboxIdRetryResponseObject interface {
+	VisitPostEventsV1AdminOutboxIdRetryResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1AdminOutboxIdRetry200JSONResponse OutboxItem
+
+func (response PostEventsV1AdminOutboxIdRetry200JSONResponse) VisitPostEventsV1AdminOutboxIdRetryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminOutboxIdRetry404JSONResponse Error
+
+func (response PostEventsV1AdminOutboxIdRetry404JSONResponse) VisitPostEventsV1AdminOutboxIdRetryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminOutboxIdRetry500JSONResponse Error
+
+func (response PostEventsV1AdminOutboxIdRetry500JSONResponse) VisitPostEventsV1AdminOutboxIdRetryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminTestEmailRequestObject struct {
+	Body *PostEventsV1AdminTestEmailJSONRequestBody
+}
+
+type PostEventsV1AdminTestEmailResponseObject interface {
+	VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1AdminTestEmail200Response struct {
+}
+
+func (response PostEventsV1AdminTestEmail200Response) VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PostEventsV1AdminTestEmail400JSONResponse Error
+
+func (response PostEventsV1AdminTestEmail400JSONResponse) VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminTestEmail500JSONResponse Error
+
+func (response PostEventsV1AdminTestEmail500JSONResponse) VisitPostEventsV1AdminTestEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminTestMailerliteRequestObject struct {
+	Body *PostEventsV1AdminTestMailerliteJSONRequestBody
+}
+
+type PostEventsV1AdminTestMailerliteResponseObject interface {
+	VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1AdminTestMailerlite200JSONResponse struct {
+	GroupId   *string `json:"groupId,omitempty"`
+	GroupName *string `json:"groupName,omitempty"`
+}
+
+func (response PostEventsV1AdminTestMailerlite200JSONResponse) VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminTestMailerlite400JSONResponse Error
+
+func (response PostEventsV1AdminTestMailerlite400JSONResponse) VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1AdminTestMailerlite500JSONResponse Error
+
+func (response PostEventsV1AdminTestMailerlite500JSONResponse) VisitPostEventsV1AdminTestMailerliteResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1PersonalDataEmailRequestObject struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+type DeleteEventsV1PersonalDataEmailResponseObject interface {
+	VisitDeleteEventsV1PersonalDataEmailResponse(w http.ResponseWriter) error
+}
+
+type DeleteEventsV1PersonalDataEmail200JSONResponse ErasureResult
+
+func (response DeleteEventsV1PersonalDataEmail200JSONResponse) VisitDeleteEventsV1PersonalDataEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1PersonalDataEmail409JSONResponse Error
+
+func (response DeleteEventsV1PersonalDataEmail409JSONResponse) VisitDeleteEventsV1PersonalDataEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1PersonalDataEmail500JSONResponse Error
+
+func (response DeleteEventsV1PersonalDataEmail500JSONResponse) VisitDeleteEventsV1PersonalDataEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1PersonalDataEmailRequestObject struct {
+	Email openapi_types.Email `json:"email"`
+}
+
+type GetEventsV1PersonalDataEmailResponseObject interface {
+	VisitGetEventsV1PersonalDataEmailResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1PersonalDataEmail200JSONResponse PersonalData
+
+func (response GetEventsV1PersonalDataEmail200JSONResponse) VisitGetEventsV1PersonalDataEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1PersonalDataEmail500JSONResponse Error
+
+func (response GetEventsV1PersonalDataEmail500JSONResponse) VisitGetEventsV1PersonalDataEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1RegistrationsMeRequestObject struct {
+}
+
+type GetEventsV1RegistrationsMeResponseObject interface {
+	VisitGetEventsV1RegistrationsMeResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1RegistrationsMe200JSONResponse struct {
+	Data []RegistrationWithEvent `json:"data"`
+}
+
+func (response GetEventsV1RegistrationsMe200JSONResponse) VisitGetEventsV1RegistrationsMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1RegistrationsMe401JSONResponse Error
+
+func (response GetEventsV1RegistrationsMe401JSONResponse) VisitGetEventsV1RegistrationsMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1RegistrationsMe500JSONResponse Error
+
+func (response GetEventsV1RegistrationsMe500JSONResponse) VisitGetEventsV1RegistrationsMeResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdCheckInRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Body    *PostEventsV1EventIdCheckInJSONRequestBody
+}
+
+type PostEventsV1EventIdCheckInResponseObject interface {
+	VisitPostEventsV1EventIdCheckInResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdCheckIn200JSONResponse CheckInResult
+
+func (response PostEventsV1EventIdCheckIn200JSONResponse) VisitPostEventsV1EventIdCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdCheckIn400JSONResponse Error
+
+func (response PostEventsV1EventIdCheckIn400JSONResponse) VisitPostEventsV1EventIdCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdCheckIn404JSONResponse Error
+
+func (response PostEventsV1EventIdCheckIn404JSONResponse) VisitPostEventsV1EventIdCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdCheckIn500JSONResponse Error
+
+func (response PostEventsV1EventIdCheckIn500JSONResponse) VisitPostEventsV1EventIdCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdPaymentsReconciliationRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+}
+
+type GetEventsV1EventIdPaymentsReconciliationResponseObject interface {
+	VisitGetEventsV1EventIdPaymentsReconciliationResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1EventIdPaymentsReconciliation200JSONResponse PaymentReconciliation
+
+func (response GetEventsV1EventIdPaymentsReconciliation200JSONResponse) VisitGetEventsV1EventIdPaymentsReconciliationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdPaymentsReconciliation500JSONResponse Error
+
+func (response GetEventsV1EventIdPaymentsReconciliation500JSONResponse) VisitGetEventsV1EventIdPaymentsReconciliationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdPaymentsReconciliationFixRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Body    *PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody
+}
+
+type PostEventsV1EventIdPaymentsReconciliationFixResponseObject interface {
+	VisitPostEventsV1EventIdPaymentsReconciliationFixResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdPaymentsReconciliationFix200JSONResponse PaymentReconciliation
+
+func (response PostEventsV1EventIdPaymentsReconciliationFix200JSONResponse) VisitPostEventsV1EventIdPaymentsReconciliationFixResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdPaymentsReconciliationFix400JSONResponse Error
+
+func (response PostEventsV1EventIdPaymentsReconciliationFix400JSONResponse) VisitPostEventsV1EventIdPaymentsReconciliationFixResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdPaymentsReconciliationFix404JSONResponse Error
+
+func (response PostEventsV1EventIdPaymentsReconciliationFix404JSONResponse) VisitPostEventsV1EventIdPaymentsReconciliationFixResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdPaymentsReconciliationFix409JSONResponse Error
+
+func (response PostEventsV1EventIdPaymentsReconciliationFix409JSONResponse) VisitPostEventsV1EventIdPaymentsReconciliationFixResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdPaymentsReconciliationFix500JSONResponse Error
+
+func (response PostEventsV1EventIdPaymentsReconciliationFix500JSONResponse) VisitPostEventsV1EventIdPaymentsReconciliationFixResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegisterRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Params  PostEventsV1EventIdRegisterParams
+	Body    *PostEventsV1EventIdRegisterJSONRequestBody
+}
+
+type PostEventsV1EventIdRegisterResponseObject interface {
+	VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegister200JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegister200JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegister202JSONResponse struct {
+	ExpiresAt    time.Time    `json:"expiresAt"`
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegister202JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegister400JSONResponse Error
+
+func (response PostEventsV1EventIdRegister400JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegister403JSONResponse Error
+
+func (response PostEventsV1EventIdRegister403JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegister404JSONResponse Error
+
+func (response PostEventsV1EventIdRegister404JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegister409JSONResponse Error
+
+func (response PostEventsV1EventIdRegister409JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegister500JSONResponse Error
+
+func (response PostEventsV1EventIdRegister500JSONResponse) VisitPostEventsV1EventIdRegisterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrationsRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Params  GetEventsV1EventIdRegistrationsParams
+}
+
+type GetEventsV1EventIdRegistrationsResponseObject interface {
+	VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1EventIdRegistrations200JSONResponse struct {
+	// Counts Totals across every registration matching the filters, not just the current page
+	Counts      RegistrationCounts `json:"counts"`
+	Cursor      *string            `json:"cursor,omitempty"`
+	Data        []Registration     `json:"data"`
+	HasNextPage bool               `json:"hasNextPage"`
+}
+
+func (response GetEventsV1EventIdRegistrations200JSONResponse) VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrations400JSONResponse Error
+
+func (response GetEventsV1EventIdRegistrations400JSONResponse) VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrations500JSONResponse Error
+
+func (response GetEventsV1EventIdRegistrations500JSONResponse) VisitGetEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Params  PostEventsV1EventIdRegistrationsParams
+	Body    *PostEventsV1EventIdRegistrationsJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrations200JSONResponse struct {
+	Info RegistrationPaymentInfo `json:"info"`
+}
+
+func (response PostEventsV1EventIdRegistrations200JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrations201JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegistrations201JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrations202JSONResponse struct {
+	ExpiresAt    time.Time    `json:"expiresAt"`
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegistrations202JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrations400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrations400JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrations403JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrations403JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrations404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrations404JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrations409JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrations409JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrations500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrations500JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrationsExportRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Params  GetEventsV1EventIdRegistrationsExportParams
+}
+
+type GetEventsV1EventIdRegistrationsExportResponseObject interface {
+	VisitGetEventsV1EventIdRegistrationsExportResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1EventIdRegistrationsExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetEventsV1EventIdRegistrationsExport200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetEventsV1EventIdRegistrationsExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetEventsV1EventIdRegistrationsExport200TextcsvResponse) VisitGetEventsV1EventIdRegistrationsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetEventsV1EventIdRegistrationsExport404JSONResponse Error
+
+func (response GetEventsV1EventIdRegistrationsExport404JSONResponse) VisitGetEventsV1EventIdRegistrationsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrationsExport500JSONResponse Error
+
+func (response GetEventsV1EventIdRegistrationsExport500JSONResponse) VisitGetEventsV1EventIdRegistrationsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsImportRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Params  PostEventsV1EventIdRegistrationsImportParams
+	Body    io.Reader
+}
+
+type PostEventsV1EventIdRegistrationsImportResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsImportResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsImport200JSONResponse ImportResult
+
+func (response PostEventsV1EventIdRegistrationsImport200JSONResponse) VisitPostEventsV1EventIdRegistrationsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsImport400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsImport400JSONResponse) VisitPostEventsV1EventIdRegistrationsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsImport404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsImport404JSONResponse) VisitPostEventsV1EventIdRegistrationsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsImport500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsImport500JSONResponse) VisitPostEventsV1EventIdRegistrationsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsManualRequestObject struct {
+	EventId openapi_types.UUID `json:"eventId"`
+	Body    *PostEventsV1EventIdRegistrationsManualJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsManualResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsManualResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsManual200JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegistrationsManual200JSONResponse) VisitPostEventsV1EventIdRegistrationsManualResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsManual400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsManual400JSONResponse) VisitPostEventsV1EventIdRegistrationsManualResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsManual404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsManual404JSONResponse) VisitPostEventsV1EventIdRegistrationsManualResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsManual409JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsManual409JSONResponse) VisitPostEventsV1EventIdRegistrationsManualResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsManual500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsManual500JSONResponse) VisitPostEventsV1EventIdRegistrationsManualResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailCheckInJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailCheckInResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckIn200JSONResponse CheckInResult
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckIn200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckIn400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckIn400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckIn404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckIn404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckIn500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckIn500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInUndoRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailCheckInUndoJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInUndoResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailCheckInUndoResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInUndo200JSONResponse CheckInResult
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckInUndo200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInUndoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInUndo400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckInUndo400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInUndoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInUndo404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckInUndo404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInUndoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailCheckInUndo500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailCheckInUndo500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailCheckInUndoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrationsEmailMedicalInfoRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+}
+
+type GetEventsV1EventIdRegistrationsEmailMedicalInfoResponseObject interface {
+	VisitGetEventsV1EventIdRegistrationsEmailMedicalInfoResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1EventIdRegistrationsEmailMedicalInfo200JSONResponse struct {
+	Players []PlayerMedicalInfo `json:"players"`
+}
+
+func (response GetEventsV1EventIdRegistrationsEmailMedicalInfo200JSONResponse) VisitGetEventsV1EventIdRegistrationsEmailMedicalInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrationsEmailMedicalInfo404JSONResponse Error
+
+func (response GetEventsV1EventIdRegistrationsEmailMedicalInfo404JSONResponse) VisitGetEventsV1EventIdRegistrationsEmailMedicalInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1EventIdRegistrationsEmailMedicalInfo500JSONResponse Error
+
+func (response GetEventsV1EventIdRegistrationsEmailMedicalInfo500JSONResponse) VisitGetEventsV1EventIdRegistrationsEmailMedicalInfoResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailNotesRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailNotesJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailNotesResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailNotesResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailNotes200JSONResponse struct {
+	Note         AdminNote    `json:"note"`
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegistrationsEmailNotes200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailNotesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailNotes400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailNotes400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailNotesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailNotes404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailNotes404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailNotesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailNotes500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailNotes500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailNotesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	NoteId  openapi_types.UUID  `json:"noteId"`
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponseObject interface {
+	VisitDeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailNotesNoteId200JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response DeleteEventsV1EventIdRegistrationsEmailNotesNoteId200JSONResponse) VisitDeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailNotesNoteId404JSONResponse Error
+
+func (response DeleteEventsV1EventIdRegistrationsEmailNotesNoteId404JSONResponse) VisitDeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailNotesNoteId500JSONResponse Error
+
+func (response DeleteEventsV1EventIdRegistrationsEmailNotesNoteId500JSONResponse) VisitDeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailPaidOfflineRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailPaidOfflineJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailPaidOfflineResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailPaidOfflineResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailPaidOffline200JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegistrationsEmailPaidOffline200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailPaidOfflineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailPaidOffline400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailPaidOffline400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailPaidOfflineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailPaidOffline404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailPaidOffline404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailPaidOfflineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailPaidOffline500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailPaidOffline500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailPaidOfflineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRefundRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailRefundJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailRefundResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailRefundResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailRefund200JSONResponse struct {
+	Refunds      []Refund     `json:"refunds"`
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegistrationsEmailRefund200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRefund400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRefund400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRefund404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRefund404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRefund500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRefund500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterConfirmRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterConfirmResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailRosterConfirmResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterConfirm200JSONResponse struct {
+	Player   PlayerInfo `json:"player"`
+	TeamName string     `json:"teamName"`
+}
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterConfirm200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterConfirm400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterConfirm400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterConfirm404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterConfirm404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterConfirm500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterConfirm500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterInvitationsRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterInvitationsResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailRosterInvitationsResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterInvitations200JSONResponse struct {
+	NumInvited int `json:"numInvited"`
+}
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterInvitations200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterInvitations400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterInvitations400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterInvitations403JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterInvitations403JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterInvitations404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterInvitations404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailRosterInvitations500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailRosterInvitations500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailRosterInvitationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckoutResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckout200JSONResponse ShareCheckout
+
+func (response PostEventsV1EventIdRegistrationsEmailSharesCheckout200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckout400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailSharesCheckout400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckout403JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailSharesCheckout403JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckout404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailSharesCheckout404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckout409JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailSharesCheckout409JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailSharesCheckout500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailSharesCheckout500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailTagsTagRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Tag     string              `json:"tag"`
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailTagsTagResponseObject interface {
+	VisitDeleteEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailTagsTag200JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response DeleteEventsV1EventIdRegistrationsEmailTagsTag200JSONResponse) VisitDeleteEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailTagsTag404JSONResponse Error
+
+func (response DeleteEventsV1EventIdRegistrationsEmailTagsTag404JSONResponse) VisitDeleteEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse Error
+
+func (response DeleteEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse) VisitDeleteEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutEventsV1EventIdRegistrationsEmailTagsTagRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Tag     string              `json:"tag"`
+}
+
+type PutEventsV1EventIdRegistrationsEmailTagsTagResponseObject interface {
+	VisitPutEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error
+}
+
+type PutEventsV1EventIdRegistrationsEmailTagsTag200JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response PutEventsV1EventIdRegistrationsEmailTagsTag200JSONResponse) VisitPutEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutEventsV1EventIdRegistrationsEmailTagsTag400JSONResponse Error
+
+func (response PutEventsV1EventIdRegistrationsEmailTagsTag400JSONResponse) VisitPutEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutEventsV1EventIdRegistrationsEmailTagsTag404JSONResponse Error
+
+func (response PutEventsV1EventIdRegistrationsEmailTagsTag404JSONResponse) VisitPutEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse Error
+
+func (response PutEventsV1EventIdRegistrationsEmailTagsTag500JSONResponse) VisitPutEventsV1EventIdRegistrationsEmailTagsTagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransferRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailTransferJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransferResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransfer200JSONResponse struct {
+	// ClientSecret Checkout for the price difference, only set when the new event costs more. The transfer is dropped if it expires.
+	ClientSecret *string      `json:"clientSecret,omitempty"`
+	Registration Registration `json:"registration"`
+	Transfer     Transfer     `json:"transfer"`
+}
+
+func (response PostEventsV1EventIdRegistrationsEmailTransfer200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransfer400JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailTransfer400JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransfer401JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailTransfer401JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransfer403JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailTransfer403JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransfer404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailTransfer404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransfer409JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailTransfer409JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailTransfer500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailTransfer500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailVerifyEmailRequestObject struct {
+	EventId openapi_types.UUID  `json:"eventId"`
+	Email   openapi_types.Email `json:"email"`
+	Body    *PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONRequestBody
+}
+
+type PostEventsV1EventIdRegistrationsEmailVerifyEmailResponseObject interface {
+	VisitPostEventsV1EventIdRegistrationsEmailVerifyEmailResponse(w http.ResponseWriter) error
+}
+
+type PostEventsV1EventIdRegistrationsEmailVerifyEmail200JSONResponse struct {
+	Registration Registration `json:"registration"`
+}
+
+func (response PostEventsV1EventIdRegistrationsEmailVerifyEmail200JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailVerifyEmail404JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailVerifyEmail404JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailVerifyEmail410JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailVerifyEmail410JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(410)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostEventsV1EventIdRegistrationsEmailVerifyEmail500JSONResponse Error
+
+func (response PostEventsV1EventIdRegistrationsEmailVerifyEmail500JSONResponse) VisitPostEventsV1EventIdRegistrationsEmailVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1IdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetEventsV1IdResponseObject interface {
+	VisitGetEventsV1IdResponse(w http.ResponseWriter) error
+}
+
+type GetEventsV1Id200JSONResponse struct {
+	Event Event `json:"event"`
+}
+
+func (response GetEventsV1Id200JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1Id400JSONResponse Error
+
+func (response GetEventsV1Id400JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1Id404JSONResponse Error
+
+func (response GetEventsV1Id404JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetEventsV1Id500JSONResponse Error
+
+func (response GetEventsV1Id500JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchEventsV1IdRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PatchEventsV1IdJSONRequestBody
+}
+
+type PatchEventsV1IdResponseObject interface {
+	VisitPatchEventsV1IdResponse(w http.ResponseWriter) error
+}
+
+type PatchEventsV1Id200JSONResponse struct {
+	Event Event `json:"event"`
+}
+
+func (response PatchEventsV1Id200JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchEventsV1Id400JSONResponse Error
+
+func (response PatchEventsV1Id400JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchEventsV1Id404JSONResponse Error
+
+func (response PatchEventsV1Id404JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchEventsV1Id500JSONResponse Error
+
+func (response PatchEventsV1Id500JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type PostEventsV1EventIdRegistrations500JSONResponse Error
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get all events
+	// (GET /events/v1)
+	GetEventsV1(ctx context.Context, request GetEventsV1RequestObject) (GetEventsV1ResponseObject, error)
+	// Create a new event
+	// (POST /events/v1)
+	PostEventsV1(ctx context.Context, request PostEventsV1RequestObject) (PostEventsV1ResponseObject, error)
+	// List outbox items
+	// (GET /events/v1/admin/outbox)
+	GetEventsV1AdminOutbox(ctx context.Context, request GetEventsV1AdminOutboxRequestObject) (GetEventsV1AdminOutboxResponseObject, error)
+	// Retry an outbox item
+	// (POST /events/v1/admin/outbox/{id}/retry)
+	PostEventsV1AdminOutboxIdRetry(ctx context.Context, request PostEventsV1AdminOutboxIdRetryRequestObject) (PostEventsV1AdminOutboxIdRetryResponseObject, error)
+	// Test email sending
+	// (POST /events/v1/admin/test-email)
+	PostEventsV1AdminTestEmail(ctx context.Context, request PostEventsV1AdminTestEmailRequestObject) (PostEventsV1AdminTestEmailResponseObject, error)
+	// Test MailerLite integration
+	// (POST /events/v1/admin/test-mailerlite)
+	PostEventsV1AdminTestMailerlite(ctx context.Context, request PostEventsV1AdminTestMailerliteRequestObject) (PostEventsV1AdminTestMailerliteResponseObject, error)
+	// Erase everything stored about an email
+	// (DELETE /events/v1/personal-data/{email})
+	DeleteEventsV1PersonalDataEmail(ctx context.Context, request DeleteEventsV1PersonalDataEmailRequestObject) (DeleteEventsV1PersonalDataEmailResponseObject, error)
+	// Export everything stored about an email
+	// (GET /events/v1/personal-data/{email})
+	GetEventsV1PersonalDataEmail(ctx context.Context, request GetEventsV1PersonalDataEmailRequestObject) (GetEventsV1PersonalDataEmailResponseObject, error)
+	// Get my registrations
+	// (GET /events/v1/registrations/me)
+	GetEventsV1RegistrationsMe(ctx context.Context, request GetEventsV1RegistrationsMeRequestObject) (GetEventsV1RegistrationsMeResponseObject, error)
+	// Check in with a scanned QR code
+	// (POST /events/v1/{eventId}/check-in)
+	PostEventsV1EventIdCheckIn(ctx context.Context, request PostEventsV1EventIdCheckInRequestObject) (PostEventsV1EventIdCheckInResponseObject, error)
+	// Reconcile payments with the payment provider
+	// (GET /events/v1/{eventId}/payments/reconciliation)
+	GetEventsV1EventIdPaymentsReconciliation(ctx context.Context, request GetEventsV1EventIdPaymentsReconciliationRequestObject) (GetEventsV1EventIdPaymentsReconciliationResponseObject, error)
+	// Fix a payment mismatch
+	// (POST /events/v1/{eventId}/payments/reconciliation/fix)
+	PostEventsV1EventIdPaymentsReconciliationFix(ctx context.Context, request PostEventsV1EventIdPaymentsReconciliationFixRequestObject) (PostEventsV1EventIdPaymentsReconciliationFixResponseObject, error)
+	// Sign up for an event
+	// (POST /events/v1/{eventId}/register)
+	PostEventsV1EventIdRegister(ctx context.Context, request PostEventsV1EventIdRegisterRequestObject) (PostEventsV1EventIdRegisterResponseObject, error)
+	// Get all registrations for an event
+	// (GET /events/v1/{eventId}/registrations)
+	GetEventsV1EventIdRegistrations(ctx context.Context, request GetEventsV1EventIdRegistrationsRequestObject) (GetEventsV1EventIdRegistrationsResponseObject, error)
+	// Sign up for an event
+	// (POST /events/v1/{eventId}/registrations)
+	PostEventsV1EventIdRegistrations(ctx context.Context, request PostEventsV1EventIdRegistrationsRequestObject) (PostEventsV1EventIdRegistrationsResponseObject, error)
+	// Export registrations as CSV
+	// (GET /events/v1/{eventId}/registrations/export)
+	GetEventsV1EventIdRegistrationsExport(ctx context.Context, request GetEventsV1EventIdRegistrationsExportRequestObject) (GetEventsV1EventIdRegistrationsExportResponseObject, error)
+	// Import registrations from CSV
+	// (POST /events/v1/{eventId}/registrations/import)
+	PostEventsV1EventIdRegistrationsImport(ctx context.Context, request PostEventsV1EventIdRegistrationsImportRequestObject) (PostEventsV1EventIdRegistrationsImportResponseObject, error)
+	// Register someone by hand
+	// (POST /events/v1/{eventId}/registrations/manual)
+	PostEventsV1EventIdRegistrationsManual(ctx context.Context, request PostEventsV1EventIdRegistrationsManualRequestObject) (PostEventsV1EventIdRegistrationsManualResponseObject, error)
+	// Check in a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/check-in)
+	PostEventsV1EventIdRegistrationsEmailCheckIn(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailCheckInRequestObject) (PostEventsV1EventIdRegistrationsEmailCheckInResponseObject, error)
+	// Undo a check-in
+	// (POST /events/v1/{eventId}/registrations/{email}/check-in/undo)
+	PostEventsV1EventIdRegistrationsEmailCheckInUndo(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailCheckInUndoRequestObject) (PostEventsV1EventIdRegistrationsEmailCheckInUndoResponseObject, error)
+	// Get a registration's emergency contacts and medical notes
+	// (GET /events/v1/{eventId}/registrations/{email}/medical-info)
+	GetEventsV1EventIdRegistrationsEmailMedicalInfo(ctx context.Context, request GetEventsV1EventIdRegistrationsEmailMedicalInfoRequestObject) (GetEventsV1EventIdRegistrationsEmailMedicalInfoResponseObject, error)
+	// Add an admin note to a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/notes)
+	PostEventsV1EventIdRegistrationsEmailNotes(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailNotesRequestObject) (PostEventsV1EventIdRegistrationsEmailNotesResponseObject, error)
+	// Remove an admin note from a registration
+	// (DELETE /events/v1/{eventId}/registrations/{email}/notes/{noteId})
+	DeleteEventsV1EventIdRegistrationsEmailNotesNoteId(ctx context.Context, request DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdRequestObject) (DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponseObject, error)
+	// Mark a registration as paid offline
+	// (POST /events/v1/{eventId}/registrations/{email}/paid-offline)
+	PostEventsV1EventIdRegistrationsEmailPaidOffline(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailPaidOfflineRequestObject) (PostEventsV1EventIdRegistrationsEmailPaidOfflineResponseObject, error)
+	// Refund a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/refund)
+	PostEventsV1EventIdRegistrationsEmailRefund(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailRefundRequestObject) (PostEventsV1EventIdRegistrationsEmailRefundResponseObject, error)
+	// Confirm a roster spot
+	// (POST /events/v1/{eventId}/registrations/{email}/roster/confirm)
+	PostEventsV1EventIdRegistrationsEmailRosterConfirm(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailRosterConfirmRequestObject) (PostEventsV1EventIdRegistrationsEmailRosterConfirmResponseObject, error)
+	// Resend roster invitations
+	// (POST /events/v1/{eventId}/registrations/{email}/roster/invitations)
+	PostEventsV1EventIdRegistrationsEmailRosterInvitations(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailRosterInvitationsRequestObject) (PostEventsV1EventIdRegistrationsEmailRosterInvitationsResponseObject, error)
+	// Pay a share of a team's fee
+	// (POST /events/v1/{eventId}/registrations/{email}/shares/checkout)
+	PostEventsV1EventIdRegistrationsEmailSharesCheckout(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject) (PostEventsV1EventIdRegistrationsEmailSharesCheckoutResponseObject, error)
+	// Untag a registration
+	// (DELETE /events/v1/{eventId}/registrations/{email}/tags/{tag})
+	DeleteEventsV1EventIdRegistrationsEmailTagsTag(ctx context.Context, request DeleteEventsV1EventIdRegistrationsEmailTagsTagRequestObject) (DeleteEventsV1EventIdRegistrationsEmailTagsTagResponseObject, error)
+	// Tag a registration
+	// (PUT /events/v1/{eventId}/registrations/{email}/tags/{tag})
+	PutEventsV1EventIdRegistrationsEmailTagsTag(ctx context.Context, request PutEventsV1EventIdRegistrationsEmailTagsTagRequestObject) (PutEventsV1EventIdRegistrationsEmailTagsTagResponseObject, error)
+	// Transfer a registration
+	// (POST /events/v1/{eventId}/registrations/{email}/transfer)
+	PostEventsV1EventIdRegistrationsEmailTransfer(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailTransferRequestObject) (PostEventsV1EventIdRegistrationsEmailTransferResponseObject, error)
+	// Verify a sign up's email
+	// (POST /events/v1/{eventId}/registrations/{email}/verify-email)
+	PostEventsV1EventIdRegistrationsEmailVerifyEmail(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailVerifyEmailRequestObject) (PostEventsV1EventIdRegistrationsEmailVerifyEmailResponseObject, error)
+	// Get an event
+	// (GET /events/v1/{id})
+	GetEventsV1Id(ctx context.Context, request GetEventsV1IdRequestObject) (GetEventsV1IdResponseObject, error)
+	// Update an event
+	// (PATCH /events/v1/{id})
+	PatchEventsV1Id(ctx context.Context, request PatchEventsV1IdRequestObject) (PatchEventsV1IdResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetEventsV1 operation middleware
+func (sh *strictHandler) GetEventsV1(w http.ResponseWriter, r *http.Request, params GetEventsV1Params) {
+	var request GetEventsV1RequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1(ctx, request.(GetEventsV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1ResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1 operation middleware
+func (sh *strictHandler) PostEventsV1(w http.ResponseWriter, r *http.Request) {
+	var request PostEventsV1RequestObject
+
+	var body PostEventsV1JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1(ctx, request.(PostEventsV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1ResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEventsV1AdminOutbox operation middleware
+func (sh *strictHandler) GetEventsV1AdminOutbox(w http.ResponseWriter, r *http.Request, params GetEventsV1AdminOutboxParams) {
+	var request GetEventsV1AdminOutboxRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1AdminOutbox(ctx, request.(GetEventsV1AdminOutboxRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1AdminOutbox")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1AdminOutboxResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1AdminOutboxResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1AdminOutboxIdRetry operation middleware
+func (sh *strictHandler) PostEventsV1AdminOutboxIdRetry(w http.ResponseWriter, r *http.Request, id openapi_types.UUID) {
+	var request PostEventsV1AdminOutboxIdRetryRequestObject
+
+	request.Id = id
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1AdminOutboxIdRetry(ctx, request.(PostEventsV1AdminOutboxIdRetryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1AdminOutboxIdRetry")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1AdminOutboxIdRetryResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1AdminOutboxIdRetryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1AdminTestEmail operation middleware
+func (sh *strictHandler) PostEventsV1AdminTestEmail(w http.ResponseWriter, r *http.Request) {
+	var request PostEventsV1AdminTestEmailRequestObject
+
+	var body PostEventsV1AdminTestEmailJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1AdminTestEmail(ctx, request.(PostEventsV1AdminTestEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1AdminTestEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1AdminTestEmailResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1AdminTestEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1AdminTestMailerlite operation middleware
+func (sh *strictHandler) PostEventsV1AdminTestMailerlite(w http.ResponseWriter, r *http.Request) {
+	var request PostEventsV1AdminTestMailerliteRequestObject
+
+	var body PostEventsV1AdminTestMailerliteJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1AdminTestMailerlite(ctx, request.(PostEventsV1AdminTestMailerliteRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1AdminTestMailerlite")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1AdminTestMailerliteResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1AdminTestMailerliteResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteEventsV1PersonalDataEmail operation middleware
+func (sh *strictHandler) DeleteEventsV1PersonalDataEmail(w http.ResponseWriter, r *http.Request, email openapi_types.Email) {
+	var request DeleteEventsV1PersonalDataEmailRequestObject
+
+	request.Email = email
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteEventsV1PersonalDataEmail(ctx, request.(DeleteEventsV1PersonalDataEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteEventsV1PersonalDataEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteEventsV1PersonalDataEmailResponseObject); ok {
+		if err := validResponse.VisitDeleteEventsV1PersonalDataEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEventsV1PersonalDataEmail operation middleware
+func (sh *strictHandler) GetEventsV1PersonalDataEmail(w http.ResponseWriter, r *http.Request, email openapi_types.Email) {
+	var request GetEventsV1PersonalDataEmailRequestObject
+
+	request.Email = email
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1PersonalDataEmail(ctx, request.(GetEventsV1PersonalDataEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1PersonalDataEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1PersonalDataEmailResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1PersonalDataEmailResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEventsV1RegistrationsMe operation middleware
+func (sh *strictHandler) GetEventsV1RegistrationsMe(w http.ResponseWriter, r *http.Request) {
+	var request GetEventsV1RegistrationsMeRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1RegistrationsMe(ctx, request.(GetEventsV1RegistrationsMeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1RegistrationsMe")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1RegistrationsMeResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1RegistrationsMeResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1EventIdCheckIn operation middleware
+func (sh *strictHandler) PostEventsV1EventIdCheckIn(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID) {
+	var request PostEventsV1EventIdCheckInRequestObject
+
+	request.EventId = eventId
+
+	var body PostEventsV1EventIdCheckInJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdCheckIn(ctx, request.(PostEventsV1EventIdCheckInRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdCheckIn")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdCheckInResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdCheckInResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetEventsV1EventIdPaymentsReconciliation operation middleware
+func (sh *strictHandler) GetEventsV1EventIdPaymentsReconciliation(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID) {
+	var request GetEventsV1EventIdPaymentsReconciliationRequestObject
 
-func (response PostEventsV1EventIdRegistrations500JSONResponse) VisitPostEventsV1EventIdRegistrationsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.EventId = eventId
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1EventIdPaymentsReconciliation(ctx, request.(GetEventsV1EventIdPaymentsReconciliationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1EventIdPaymentsReconciliation")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1EventIdPaymentsReconciliationResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1EventIdPaymentsReconciliationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetEventsV1IdRequestObject struct {
-	Id openapi_types.UUID `json:"id"`
+// PostEventsV1EventIdPaymentsReconciliationFix operation middleware
+func (sh *strictHandler) PostEventsV1EventIdPaymentsReconciliationFix(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID) {
+	var request PostEventsV1EventIdPaymentsReconciliationFixRequestObject
+
+	request.EventId = eventId
+
+	var body PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdPaymentsReconciliationFix(ctx, request.(PostEventsV1EventIdPaymentsReconciliationFixRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdPaymentsReconciliationFix")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdPaymentsReconciliationFixResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdPaymentsReconciliationFixResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetEventsV1IdResponseObject interface {
-	VisitGetEventsV1IdResponse(w http.ResponseWriter) error
+// PostEventsV1EventIdRegister operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegister(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegisterParams) {
+	var request PostEventsV1EventIdRegisterRequestObject
+
+	request.EventId = eventId
+	request.Params = params
+
+	var body PostEventsV1EventIdRegisterJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegister(ctx, request.(PostEventsV1EventIdRegisterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegister")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegisterResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegisterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetEventsV1Id200JSONResponse struct {
-	Event Event `json:"event"`
+// GetEventsV1EventIdRegistrations operation middleware
+func (sh *strictHandler) GetEventsV1EventIdRegistrations(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params GetEventsV1EventIdRegistrationsParams) {
+	var request GetEventsV1EventIdRegistrationsRequestObject
+
+	request.EventId = eventId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1EventIdRegistrations(ctx, request.(GetEventsV1EventIdRegistrationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1EventIdRegistrations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1EventIdRegistrationsResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1EventIdRegistrationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response GetEventsV1Id200JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// PostEventsV1EventIdRegistrations operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrations(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegistrationsParams) {
+	var request PostEventsV1EventIdRegistrationsRequestObject
 
-	return json.NewEncoder(w).Encode(response)
+	request.EventId = eventId
+	request.Params = params
+
+	var body PostEventsV1EventIdRegistrationsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegistrations(ctx, request.(PostEventsV1EventIdRegistrationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegistrations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetEventsV1Id400JSONResponse Error
+// GetEventsV1EventIdRegistrationsExport operation middleware
+func (sh *strictHandler) GetEventsV1EventIdRegistrationsExport(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params GetEventsV1EventIdRegistrationsExportParams) {
+	var request GetEventsV1EventIdRegistrationsExportRequestObject
 
-func (response GetEventsV1Id400JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	request.EventId = eventId
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1EventIdRegistrationsExport(ctx, request.(GetEventsV1EventIdRegistrationsExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1EventIdRegistrationsExport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1EventIdRegistrationsExportResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1EventIdRegistrationsExportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetEventsV1Id404JSONResponse Error
+// PostEventsV1EventIdRegistrationsImport operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsImport(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegistrationsImportParams) {
+	var request PostEventsV1EventIdRegistrationsImportRequestObject
 
-func (response GetEventsV1Id404JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.EventId = eventId
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	request.Body = r.Body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegistrationsImport(ctx, request.(PostEventsV1EventIdRegistrationsImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsImport")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsImportResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsImportResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type GetEventsV1Id500JSONResponse Error
+// PostEventsV1EventIdRegistrationsManual operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsManual(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID) {
+	var request PostEventsV1EventIdRegistrationsManualRequestObject
 
-func (response GetEventsV1Id500JSONResponse) VisitGetEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.EventId = eventId
 
-	return json.NewEncoder(w).Encode(response)
+	var body PostEventsV1EventIdRegistrationsManualJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegistrationsManual(ctx, request.(PostEventsV1EventIdRegistrationsManualRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsManual")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsManualResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsManualResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1EventIdRegistrationsEmailCheckIn operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailCheckIn(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailCheckInRequestObject
+
+	request.EventId = eventId
+	request.Email = email
+
+	var body PostEventsV1EventIdRegistrationsEmailCheckInJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailCheckIn(ctx, request.(PostEventsV1EventIdRegistrationsEmailCheckInRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailCheckIn")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailCheckInResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailCheckInResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchEventsV1IdRequestObject struct {
-	Id   openapi_types.UUID `json:"id"`
-	Body *PatchEventsV1IdJSONRequestBody
-}
+// PostEventsV1EventIdRegistrationsEmailCheckInUndo operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailCheckInUndo(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailCheckInUndoRequestObject
+
+	request.EventId = eventId
+	request.Email = email
+
+	var body PostEventsV1EventIdRegistrationsEmailCheckInUndoJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailCheckInUndo(ctx, request.(PostEventsV1EventIdRegistrationsEmailCheckInUndoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailCheckInUndo")
+	}
 
-type PatchEventsV1IdResponseObject interface {
-	VisitPatchEventsV1IdResponse(w http.ResponseWriter) error
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type PatchEventsV1Id200JSONResponse struct {
-	Event Event `json:"event"`
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailCheckInUndoResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailCheckInUndoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-func (response PatchEventsV1Id200JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// GetEventsV1EventIdRegistrationsEmailMedicalInfo operation middleware
+func (sh *strictHandler) GetEventsV1EventIdRegistrationsEmailMedicalInfo(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request GetEventsV1EventIdRegistrationsEmailMedicalInfoRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.EventId = eventId
+	request.Email = email
 
-type PatchEventsV1Id400JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetEventsV1EventIdRegistrationsEmailMedicalInfo(ctx, request.(GetEventsV1EventIdRegistrationsEmailMedicalInfoRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetEventsV1EventIdRegistrationsEmailMedicalInfo")
+	}
 
-func (response PatchEventsV1Id400JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetEventsV1EventIdRegistrationsEmailMedicalInfoResponseObject); ok {
+		if err := validResponse.VisitGetEventsV1EventIdRegistrationsEmailMedicalInfoResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type PatchEventsV1Id404JSONResponse Error
+// PostEventsV1EventIdRegistrationsEmailNotes operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailNotes(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailNotesRequestObject
 
-func (response PatchEventsV1Id404JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.EventId = eventId
+	request.Email = email
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PostEventsV1EventIdRegistrationsEmailNotesJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type PatchEventsV1Id500JSONResponse Error
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailNotes(ctx, request.(PostEventsV1EventIdRegistrationsEmailNotesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailNotes")
+	}
 
-func (response PatchEventsV1Id500JSONResponse) VisitPatchEventsV1IdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailNotesResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailNotesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Get all events
-	// (GET /events/v1)
-	GetEventsV1(ctx context.Context, request GetEventsV1RequestObject) (GetEventsV1ResponseObject, error)
-	// Create a new event
-	// (POST /events/v1)
-	PostEventsV1(ctx context.Context, request PostEventsV1RequestObject) (PostEventsV1ResponseObject, error)
-	// Test email sending
-	// (POST /events/v1/admin/test-email)
-	PostEventsV1AdminTestEmail(ctx context.Context, request PostEventsV1AdminTestEmailRequestObject) (PostEventsV1AdminTestEmailResponseObject, error)
-	// Test MailerLite integration
-	// (POST /events/v1/admin/test-mailerlite)
-	PostEventsV1AdminTestMailerlite(ctx context.Context, request PostEventsV1AdminTestMailerliteRequestObject) (PostEventsV1AdminTestMailerliteResponseObject, error)
-	// Sign up for an event
-	// (POST /events/v1/{eventId}/register)
-	PostEventsV1EventIdRegister(ctx context.Context, request PostEventsV1EventIdRegisterRequestObject) (PostEventsV1EventIdRegisterResponseObject, error)
-	// Get all registrations for an event
-	// (GET /events/v1/{eventId}/registrations)
-	GetEventsV1EventIdRegistrations(ctx context.Context, request GetEventsV1EventIdRegistrationsRequestObject) (GetEventsV1EventIdRegistrationsResponseObject, error)
-	// Sign up for an event
-	// (POST /events/v1/{eventId}/registrations)
-	PostEventsV1EventIdRegistrations(ctx context.Context, request PostEventsV1EventIdRegistrationsRequestObject) (PostEventsV1EventIdRegistrationsResponseObject, error)
-	// Get an event
-	// (GET /events/v1/{id})
-	GetEventsV1Id(ctx context.Context, request GetEventsV1IdRequestObject) (GetEventsV1IdResponseObject, error)
-	// Update an event
-	// (PATCH /events/v1/{id})
-	PatchEventsV1Id(ctx context.Context, request PatchEventsV1IdRequestObject) (PatchEventsV1IdResponseObject, error)
-}
+// DeleteEventsV1EventIdRegistrationsEmailNotesNoteId operation middleware
+func (sh *strictHandler) DeleteEventsV1EventIdRegistrationsEmailNotesNoteId(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email, noteId openapi_types.UUID) {
+	var request DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdRequestObject
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+	request.EventId = eventId
+	request.Email = email
+	request.NoteId = noteId
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteEventsV1EventIdRegistrationsEmailNotesNoteId(ctx, request.(DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteEventsV1EventIdRegistrationsEmailNotesNoteId")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponseObject); ok {
+		if err := validResponse.VisitDeleteEventsV1EventIdRegistrationsEmailNotesNoteIdResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+// PostEventsV1EventIdRegistrationsEmailPaidOffline operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailPaidOffline(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailPaidOfflineRequestObject
 
-// GetEventsV1 operation middleware
-func (sh *strictHandler) GetEventsV1(w http.ResponseWriter, r *http.Request, params GetEventsV1Params) {
-	var request GetEventsV1RequestObject
+	request.EventId = eventId
+	request.Email = email
 
-	request.Params = params
+	var body PostEventsV1EventIdRegistrationsEmailPaidOfflineJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetEventsV1(ctx, request.(GetEventsV1RequestObject))
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailPaidOffline(ctx, request.(PostEventsV1EventIdRegistrationsEmailPaidOfflineRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetEventsV1")
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailPaidOffline")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetEventsV1ResponseObject); ok {
-		if err := validResponse.VisitGetEventsV1Response(w); err != nil {
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailPaidOfflineResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailPaidOfflineResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1333,11 +5040,14 @@ func (sh *strictHandler) GetEventsV1(w http.ResponseWriter, r *http.Request, par
 	}
 }
 
-// PostEventsV1 operation middleware
-func (sh *strictHandler) PostEventsV1(w http.ResponseWriter, r *http.Request) {
-	var request PostEventsV1RequestObject
+// PostEventsV1EventIdRegistrationsEmailRefund operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailRefund(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailRefundRequestObject
 
-	var body PostEventsV1JSONRequestBody
+	request.EventId = eventId
+	request.Email = email
+
+	var body PostEventsV1EventIdRegistrationsEmailRefundJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1345,18 +5055,18 @@ func (sh *strictHandler) PostEventsV1(w http.ResponseWriter, r *http.Request) {
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostEventsV1(ctx, request.(PostEventsV1RequestObject))
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailRefund(ctx, request.(PostEventsV1EventIdRegistrationsEmailRefundRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostEventsV1")
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailRefund")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostEventsV1ResponseObject); ok {
-		if err := validResponse.VisitPostEventsV1Response(w); err != nil {
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailRefundResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailRefundResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1364,11 +5074,14 @@ func (sh *strictHandler) PostEventsV1(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// PostEventsV1AdminTestEmail operation middleware
-func (sh *strictHandler) PostEventsV1AdminTestEmail(w http.ResponseWriter, r *http.Request) {
-	var request PostEventsV1AdminTestEmailRequestObject
+// PostEventsV1EventIdRegistrationsEmailRosterConfirm operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailRosterConfirm(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailRosterConfirmRequestObject
 
-	var body PostEventsV1AdminTestEmailJSONRequestBody
+	request.EventId = eventId
+	request.Email = email
+
+	var body PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1376,18 +5089,18 @@ func (sh *strictHandler) PostEventsV1AdminTestEmail(w http.ResponseWriter, r *ht
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostEventsV1AdminTestEmail(ctx, request.(PostEventsV1AdminTestEmailRequestObject))
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailRosterConfirm(ctx, request.(PostEventsV1EventIdRegistrationsEmailRosterConfirmRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostEventsV1AdminTestEmail")
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailRosterConfirm")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostEventsV1AdminTestEmailResponseObject); ok {
-		if err := validResponse.VisitPostEventsV1AdminTestEmailResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailRosterConfirmResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailRosterConfirmResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1395,11 +5108,14 @@ func (sh *strictHandler) PostEventsV1AdminTestEmail(w http.ResponseWriter, r *ht
 	}
 }
 
-// PostEventsV1AdminTestMailerlite operation middleware
-func (sh *strictHandler) PostEventsV1AdminTestMailerlite(w http.ResponseWriter, r *http.Request) {
-	var request PostEventsV1AdminTestMailerliteRequestObject
+// PostEventsV1EventIdRegistrationsEmailRosterInvitations operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailRosterInvitations(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailRosterInvitationsRequestObject
 
-	var body PostEventsV1AdminTestMailerliteJSONRequestBody
+	request.EventId = eventId
+	request.Email = email
+
+	var body PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1407,18 +5123,18 @@ func (sh *strictHandler) PostEventsV1AdminTestMailerlite(w http.ResponseWriter,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostEventsV1AdminTestMailerlite(ctx, request.(PostEventsV1AdminTestMailerliteRequestObject))
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailRosterInvitations(ctx, request.(PostEventsV1EventIdRegistrationsEmailRosterInvitationsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostEventsV1AdminTestMailerlite")
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailRosterInvitations")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostEventsV1AdminTestMailerliteResponseObject); ok {
-		if err := validResponse.VisitPostEventsV1AdminTestMailerliteResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailRosterInvitationsResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailRosterInvitationsResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1426,14 +5142,14 @@ func (sh *strictHandler) PostEventsV1AdminTestMailerlite(w http.ResponseWriter,
 	}
 }
 
-// PostEventsV1EventIdRegister operation middleware
-func (sh *strictHandler) PostEventsV1EventIdRegister(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegisterParams) {
-	var request PostEventsV1EventIdRegisterRequestObject
+// PostEventsV1EventIdRegistrationsEmailSharesCheckout operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailSharesCheckout(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject
 
 	request.EventId = eventId
-	request.Params = params
+	request.Email = email
 
-	var body PostEventsV1EventIdRegisterJSONRequestBody
+	var body PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1441,18 +5157,18 @@ func (sh *strictHandler) PostEventsV1EventIdRegister(w http.ResponseWriter, r *h
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostEventsV1EventIdRegister(ctx, request.(PostEventsV1EventIdRegisterRequestObject))
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailSharesCheckout(ctx, request.(PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostEventsV1EventIdRegister")
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailSharesCheckout")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostEventsV1EventIdRegisterResponseObject); ok {
-		if err := validResponse.VisitPostEventsV1EventIdRegisterResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailSharesCheckoutResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailSharesCheckoutResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1460,26 +5176,27 @@ func (sh *strictHandler) PostEventsV1EventIdRegister(w http.ResponseWriter, r *h
 	}
 }
 
-// GetEventsV1EventIdRegistrations operation middleware
-func (sh *strictHandler) GetEventsV1EventIdRegistrations(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params GetEventsV1EventIdRegistrationsParams) {
-	var request GetEventsV1EventIdRegistrationsRequestObject
+// DeleteEventsV1EventIdRegistrationsEmailTagsTag operation middleware
+func (sh *strictHandler) DeleteEventsV1EventIdRegistrationsEmailTagsTag(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email, tag string) {
+	var request DeleteEventsV1EventIdRegistrationsEmailTagsTagRequestObject
 
 	request.EventId = eventId
-	request.Params = params
+	request.Email = email
+	request.Tag = tag
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetEventsV1EventIdRegistrations(ctx, request.(GetEventsV1EventIdRegistrationsRequestObject))
+		return sh.ssi.DeleteEventsV1EventIdRegistrationsEmailTagsTag(ctx, request.(DeleteEventsV1EventIdRegistrationsEmailTagsTagRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetEventsV1EventIdRegistrations")
+		handler = middleware(handler, "DeleteEventsV1EventIdRegistrationsEmailTagsTag")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetEventsV1EventIdRegistrationsResponseObject); ok {
-		if err := validResponse.VisitGetEventsV1EventIdRegistrationsResponse(w); err != nil {
+	} else if validResponse, ok := response.(DeleteEventsV1EventIdRegistrationsEmailTagsTagResponseObject); ok {
+		if err := validResponse.VisitDeleteEventsV1EventIdRegistrationsEmailTagsTagResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1487,14 +5204,42 @@ func (sh *strictHandler) GetEventsV1EventIdRegistrations(w http.ResponseWriter,
 	}
 }
 
-// PostEventsV1EventIdRegistrations operation middleware
-func (sh *strictHandler) PostEventsV1EventIdRegistrations(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, params PostEventsV1EventIdRegistrationsParams) {
-	var request PostEventsV1EventIdRegistrationsRequestObject
+// PutEventsV1EventIdRegistrationsEmailTagsTag operation middleware
+func (sh *strictHandler) PutEventsV1EventIdRegistrationsEmailTagsTag(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email, tag string) {
+	var request PutEventsV1EventIdRegistrationsEmailTagsTagRequestObject
 
 	request.EventId = eventId
-	request.Params = params
+	request.Email = email
+	request.Tag = tag
 
-	var body PostEventsV1EventIdRegistrationsJSONRequestBody
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PutEventsV1EventIdRegistrationsEmailTagsTag(ctx, request.(PutEventsV1EventIdRegistrationsEmailTagsTagRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutEventsV1EventIdRegistrationsEmailTagsTag")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PutEventsV1EventIdRegistrationsEmailTagsTagResponseObject); ok {
+		if err := validResponse.VisitPutEventsV1EventIdRegistrationsEmailTagsTagResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1EventIdRegistrationsEmailTransfer operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailTransfer(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailTransferRequestObject
+
+	request.EventId = eventId
+	request.Email = email
+
+	var body PostEventsV1EventIdRegistrationsEmailTransferJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -1502,18 +5247,52 @@ func (sh *strictHandler) PostEventsV1EventIdRegistrations(w http.ResponseWriter,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.PostEventsV1EventIdRegistrations(ctx, request.(PostEventsV1EventIdRegistrationsRequestObject))
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailTransfer(ctx, request.(PostEventsV1EventIdRegistrationsEmailTransferRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostEventsV1EventIdRegistrations")
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailTransfer")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsResponseObject); ok {
-		if err := validResponse.VisitPostEventsV1EventIdRegistrationsResponse(w); err != nil {
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailTransferResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailTransferResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PostEventsV1EventIdRegistrationsEmailVerifyEmail operation middleware
+func (sh *strictHandler) PostEventsV1EventIdRegistrationsEmailVerifyEmail(w http.ResponseWriter, r *http.Request, eventId openapi_types.UUID, email openapi_types.Email) {
+	var request PostEventsV1EventIdRegistrationsEmailVerifyEmailRequestObject
+
+	request.EventId = eventId
+	request.Email = email
+
+	var body PostEventsV1EventIdRegistrationsEmailVerifyEmailJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PostEventsV1EventIdRegistrationsEmailVerifyEmail(ctx, request.(PostEventsV1EventIdRegistrationsEmailVerifyEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostEventsV1EventIdRegistrationsEmailVerifyEmail")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PostEventsV1EventIdRegistrationsEmailVerifyEmailResponseObject); ok {
+		if err := validResponse.VisitPostEventsV1EventIdRegistrationsEmailVerifyEmailResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1583,60 +5362,195 @@ func (sh *strictHandler) PatchEventsV1Id(w http.ResponseWriter, r *http.Request,
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xbfW/bNrf/KgTv88e9gGLLSbO1Bgpcx0m7bEka1EnXNQsGRjq22UqkRlJ23MLf/cEh",
-	"JVtvfkmbpF3XYFgjiuQ5PK+/c6h8ooGMEylAGE27n6gOxhAz+2svDBVo+2uiZALKcLBPATcz/DcEHSie",
-	"GC4F7dI+NzMiFTFyKqhH4ZbFSQS0S3tilo3F7PYExMiMaXff92jMRf6451EzS3C2NoqLEZ17NJCpMKqJ",
-	"UvaiSORy0FtLYLeBQCK1YVFfhlCncW7fkQBfFuk883c7fpnS7uajaMNMA5EBDqPMEiUnXARlUv27n0gb",
-	"BWCaCOE4YZlGi1Q6u3vklHFBBqZyrP39Deeae1TB3ylXENLuVU7cc/aRH7ok5qVSrxe7yZv3EBjk/kgp",
-	"qRrMLVPQfxQMaZf+T3tpse3MXNt2qSUx92gMWrORXVO0QpIKuE0gMBASwPlEBkGqFIQtuulsmR3kO6/k",
-	"PjcmEGmM646FASVY5I7m0RMec/MqNa+GBzIVIariWExYxMN+qrSdcibNC3xHPXoUJ2Z2IMPZclr21IsU",
-	"sHB2dMu1wU1ew4hroxjqux9JDaFdkqTmDa6y4zkPvdSM89/7LDHBmGWbF861tKmjCQhT1wqLIjmF8AJY",
-	"POAf4TUTo41acpPmHgURXvC4oqFdf3d/x3+603l2sbvb9f2u77d8339HPTqUKmaGdmnIDOwYXNrAKQ/L",
-	"G/rZz07D//Kf4uZpylFsKNlXIprRrlEpNNGJ2QjOWNzg0T0y5BEQwWIgZswMAashwgUxYyCXx4RpDUYT",
-	"I0mqgTBtxyM5kq2SW95IbaTYMTJVuJkwrffJqBoP/AbmIhkwx8x6XZzk8+YeFayqi+N+r0f6aUJQKXeN",
-	"CyjCijmu0fbPF7t73f1n3f1nd9N2kcYrK39rl9xArDeGC7Tp17UNbPDg4tht0VkQZUqxmaWZRqAPZXDC",
-	"xYfyccbGJLrbbocy0K2RlKMIWoGM8TlF9bXDNgv1cMiGGv8Lh2F7wmG6jUY1H4nLBHPFxnMNClNd2lFm",
-	"raN1nnaf/NTd32t1nu5vL3ocfydFg/0jMfJRCiByaC0bUNItcghDlkbO7i8v+oQPiZCGaDBls+/FoHjA",
-	"2mcw/esPqT40UZ+A0pmBLxZ2VrotFwZGoGoR3bp6vlXmAgXvKQpvGa9WWXazNXrNQbKs0cZUssI8a0E4",
-	"UTzYGHVPpYBZ1WMuLM0N4bo6vyrD2oZexlHjoW4TUBxEACcwgaiYJc/khFvwY9NlDCF3yKEXTpgIwOal",
-	"QmwqT6rZx7EI+YSHKYuKB6gLD2LGo7JnvGcCWqGE/8+G0IWLXuGWlHy242+Gf9YJjh8pO8FCzhujYEUj",
-	"c4+OZQz9DN7XELxHaih7m9M/VlpOWIWSm7di3Y2UETAb8pOIzUAdi6HcJLHz5cyFP4GCsGe+LMJuPNuX",
-	"u+59xc0Gn6+E0tzaKwLyFt6zMLKS6EuWm2mzKY6cFABOBZIuC9Z1ssnr2kbgk5k66cs4TgWWtH3AeHNX",
-	"s69ILUsvOYdN53Jhun6oGCumeqY95UIqgixqzLUxrib/y1vQIh3fJ8+fk/90EHZeDg7/r5hiOwWMsdCx",
-	"R20hJIKK418ODosmy7XcebLb+XlzuZTv5uX8N534vOR3K4Jz+dQuEbKI2PdkKBUBFoyJs6MSlHBD9xzJ",
-	"h1xpc1azml+ZgLXFeqcJqbOmrQ7lXXeqyH7JYoFEk/gXJVtZ8jG7LXHk0D6PMVV3mkwn5rWYsljgb4wn",
-	"uNqet5nHSgYPOVpDzAUzrlUQsyRBKXQ/0YPZMvOv8v0V2MCjBzMEaauW4bvSAswaTmozp8J6XJx7VAp4",
-	"NaTdq/XxaAVPc2/9sjpP1xWBnbMZlh7NDhZEHIQZQKBcw6gJR3AF2qW2u5dkd8lQ9fhRZK7ISoXGJpPJ",
-	"U2UONEsmslB6CV1WptTOOChXY2WhijR+LV3Gc8FNl13JX+8aHm6ALJWXdbZaJg2LGonub+GPZQhQylw5",
-	"R17T6eqkm1RSM9W6NbLEMC6O6pA8e/NPRuT/YlC9fUumDK3Xd2H+WbjbAIvref5iDOQFH40NFyNyKsVI",
-	"Sg367obw1VH94nglYF9y6KU1rMT1c49qCFLFzWyAsnRhgQeMHQBToHopCuATvbFPL3Jl/vr7BfUqGNH2",
-	"LlkQgNbEyA8gEAbjeqn4R3tCMgYWWrho9WYt1+67DPhjYxLrZwFjfSk/cMg52EQssLNRgPh+8eRKDTv/",
-	"r16/fzQY/HXx6rejsyVJlvDfYEbnKAue5exKd1mQ3vmxRb0xE2yEpmP1ogkTIdF8JHAoTRwwtm/s3QY3",
-	"y66ubS6RCvxZWBHttPyWb8FLAoIlnHbpnh1C1ZmxVUvbbd2edPBp1HTj9BqM4jABTRiJuDZYo7Aoypii",
-	"dntHHaMwfQnG8qXfdCwhxWIwNnxc1W7/7EUJ7jcdgwJiJLGtOjJUNjVYsf+dgr0gzKQe5Jcrzk/LnvjH",
-	"7rP03d6v4/CXU338SzQJBwfxzd6b9F3/wGcvL0fvfn/xMXz5Znb88o14N33+vKn6qVVn7Ja4ygcZzXRk",
-	"JBmCCcYrmIx4zE2Jx9B1TR0CKMMBdusSeglSNMDz+TU6rE6k0M6ldn3fXaoJk93rsCSJuKup2++1CyVL",
-	"Hip52gnynuXnYVBmd+ve28haSQxjps/g1pxXL/+ac1QlBFoWyns0hKm5V7vxyc0797e5R5/cUcgbrzab",
-	"KB+wkOABQBtLdP8xiF6KD0JOBdGgJqDcPWqrFL5p9+raozqNY6ZmzrWLnp/duzcEtzDmgoAIE8mFQWcJ",
-	"FDADhBEBU7e8FjfOpS4Gjkwc9pL03kThrK0uChdIjSQ3kLEa0qJJodXNv9D7PosxhBYZQ9n9yw+bvPpU",
-	"S+VXlKHJ0WsM31WksXxZMuZ+3SSRzjIhtu2ytgFtdhY9rGaDH4AIMT3iXFIEYCSQYsgR4eCD63QZaa/T",
-	"dAIBH3II8285WsT5jRTRrLXWPey8C9Amx2Sf6ywbb1HwQJvqtfU9LDdrm/CLtu4klAnE4gEQYS7YXHzb",
-	"umZl++UWU6ZxY0N0auHeMI2i2ddwrEfzqxeMRxAuBLoU58P4VkHWSC+3i2bfwmmgIu4+q2p2MOesCxcb",
-	"KZkmWAuc2rUnHB1ZWE9yX2GM+AQyf7NmxE2LvJCKFFtCnus3Oza5xsXojAjACV/MIjq9QU5uQOVbXACL",
-	"PUvGNmiXO+BQVi1ZdvBZIb8MwS2wOGtu6xbBMkvbcZYauTMCgc4OoYW+2Y6JgiG/hc8JDKdLmd5rdCj3",
-	"o67cLStTwbjWnn8vx6J2/3rtLWHhpjiyoXNgTaD5yx0ctWUTyn9pL+VPF/6kBdux9voSJ/1Jy18xLN/Q",
-	"R+gpVCMWi933R3m4wzpJkCpVND1nlRXex0B6Vjd6Y5hu6BpkCt8mdB85R0ObL2U/XGjzHUpxys343lFV",
-	"2Uatnqu9wM7u3pP9n35++qxJgyUz2k7t8y0EMigkFlvMQ4i1/DIg2SBl9/93pB1rActIT2xVW7gyeJgU",
-	"VHDxKsFCLvqUNcTm7bwfVk5EiYLAlgRZ9Vk+4+HiPSakIZu4jgYqOHG3NtYAbCsnklOPTHkUYaGhIJYT",
-	"t8rmktSkCtbH9yPH6OuczQ39lePD0rdbebMiYdYRs15FsRtY9MzmDstnNqc3dln6kUzDYWQTZaqENjwC",
-	"0u+dX/R/6eV8L1p9eStouLOYu5NHkS3P8fbt27etw8vT0z9atnfXwoEGRq8fphKtXJnVHOd1KYo+aF1a",
-	"jqD3du+34XJvfalbXNz6WhHyib/38DTPpG2pyKkLk3kAyg795OEZcK0Pru1HnEOZihIfLmlbXp49PC8D",
-	"GYMUFs8w91V8IXemIgSEdFznlcs32ycb5PFeKiwmGpsL1aTjjF2v7MLnvbfS7DKFNZ34UuLISX1H2eO+",
-	"LxIsZrz77UBZOd/pJcH+k73dzhd3/qvf4HxbFwAlRf7oud4XKN8iiK28VBigQ+vFxBKo3h40f4ex7wdy",
-	"/iaQM9/io/NVn/TV/qIFB78ENFvctqg/cbsfMPoHjP4eYTQP52sxMwrCJYybGbERdSVItqH8y9MCf+SM",
-	"cL+ID/K/Ed7mvrpy1WdHt77qy9P9V0FWjxgUFhHhm/+0o4jBGBYuNY+6TEJ7aS7W+tQ5Lv4evOorfYKS",
-	"Wik/NGB5NE/PjvPD47+PIq4SAxw366k0b+/2tQw3hYVzJcM0sGjWTaIeTVVU+At9lvAW7tqaShWFbVov",
-	"jk5kwCISwqRpi267HeH7sdSmu+f7fpvOr+f/DQAA//8ak0bbR0cAAA==",
+	"H4sIAAAAAAAC/+y9C3PcNpYo/FdQ/LbKM9+lWw/bO4luTdWVZTvRrGXrWnKSTZzKQiS6GxEb4ACgWp2U",
+	"/vutcwCQBInuZutlW9HU1sZqkngcnBfO888kk7NSCiaMTvb+THQ2ZTOK/9zPc8U0/rNUsmTKcIZ/Zdws",
+	"4L8505nipeFSJHvJATcLIhUxci6SNGGXdFYWLNlL9sXC/Tajl2+ZmJhpsvdiO01mXPg/n6WJWZTwtjaK",
+	"i0lylSaZrIRRsZncg/YkH0/2V06wG5mglNrQ4kDmrD/HMT4jGTxsz/Pt9u7OdjjT7vqtaENNZJIT+Blg",
+	"Vip5wUUWTnWw+Y60UYyZ2ETwO6HuRNuz7Ow+I0eUC3JiOtt68WLNvq7SRLF/V1yxPNn7xU+eWvzwmw7A",
+	"3Bzqr/Vo8ux3lhlY/X4+4+KdNKyPcrQyU6n6G3s9o7wgckzMlBEK3xMzpYbMlTQMfxTShGDFt/6P+3uU",
+	"yVkSwz3FqGH5PsKy+XZ3e/fF0+1vnu58e7rzzd7z/9x78Wy0882Ln5M0GUs1oybZS3Jq2FPDZyw2Ls/D",
+	"Abfd/55G/p//X3vwquJ5bFzDLjtLfcdYrgklhaSCKXIm58m6A7RDw0ipB3gbErEjO5iy7PxQfGC6Kkz/",
+	"2BSbcG0Utaf1Z/Ifio2TveT/22qYzpbjOFsf2u8CLvOJ+FgCieh1n560Xu1uKlhCOGpsQ69nTE2YyBYH",
+	"UhiaRfYk6IyFoP6XnArySrKQfna2Q3rdiXGgqRSdwf7XDnnx4gXZ3tkm7vBbYz5bP6RiBe5VT3kZjnxM",
+	"FROmz1NWIwVu1680CjFFdaXYMhSgQorFjP/B8uOCLph6LYxyjzo8qpRGEymINFOmSMlkWbAnmhhGZ9pS",
+	"NVVAz3Nix5RVwMkafsiFYROmYHHN7G30iswePEbGwZC1zKn9y6ORMESOUzKf8my6ej07/fV0QLtscelS",
+	"oMXhr6Tqwz1zYm0V3eCnyJiv0mTGtKaTDjbuC1IJdlmyzLCcMHifyCyrlGL5aC1DcdLTj7x09V4EM1HN",
+	"4LtDYZgStMCHSZq85TNu3lfm/filrEQOEDoUF7Tg+UGlNL7yTpo38CwBEi7N4qXMF81r7q/9QjGaL15f",
+	"cm1gkDbYDwqpWY6flJX5Ab7C3/0a9isz9f8+oKXJptQNnqTJG6nOeJ4zYVdyTHneTP6BjSuR789A8iVp",
+	"clIW3BzTxYwJ806a/aKQc5z4ZEoVe31ZIvTqxbqxLB643+y6mX3vnTSnigo9ZoqeFawFG8ubT+U5ruuA",
+	"gmx1PzZvvR+PCy6YW1DiWLqszKE4VnLi9IUfmOJjnlmIdJf4gWVSZLxgefJrDyXS5PUFDNxnDLDx/ZyW",
+	"hl+wY8UzeB3pckyRkxhVsbRDpodW0pd2tVZvypkiGRVEjscM1E+SSXHBlLFvKp4x+DEk4ieaFDIDBQ9Q",
+	"WWQLYDyZ23kLsc+kLBhFeUTtQZ0yOjvhf7APVEzWEph9CfQJN/T3ssiPuKgM08Fen213d/q9nJMZFQsy",
+	"s68TWq+PTGWR6/6OdCkNOWNjqRjhhjA8Jz0ipzGISVEsCO5JkzNm5owJ8my7mU3kZPc5mcpK6VGbrf3n",
+	"NgoQPgNS3Xn+3Eok++ez7RgP9ss+NGz2qr3HngSYyrkgjuFyw2Zdzss17IOLCRlLFZwYeWUhqf1RM0C6",
+	"J5qA+MLNwI/nXOSgLIISQKoyJWw0GZFPyeHB/j45qEoC+h2BEyagUpCP5ack2HznzdfuGhJozetFNBP5",
+	"Ke8qES3Vcnd3b3t7b3t7tL29fe+qJVD0e1EsPP3155nRCXvnlKDwAPfJmBfMwhwFNkNOS1ArZ+TjIaFa",
+	"M3tIlWb+qAs5kSGYz6Q2Ujw1slIwmDCj38tJV3PZjiwOiHqIsvnWv3eVRhS64JQ3vRWlScmUloIWr6ih",
+	"x5Wa+HtECKwfp8yCpUTWrp8g3HRqFQ9LgVM5YyTjwDKJtG97xFaBwjJnipFGayBnC0c7hgl4g5Sy4Nli",
+	"RD4KzQyphOEFvCFGy/BrLR6orvBcgdP/ON19tvfi270X326G0+053pe16ga8Ye2lAMXOh94AMOyMi0M7",
+	"REOeVCm6SBoFBm+WbbEX8OsxLXRMOGlmUjJWjHkeoy2jBY4N8ObKcun6ANrsLSt4dg6M/qI1LSm4OLc4",
+	"wXLH3WZwjvYlljczUQXDFYxqlhM6NkwRKpCFxyWaqgqmX8nsLRfn4cFNjSn13tZWLjM9mkg5sTdl+LsC",
+	"ctzKt2iux2M61vB/+TjfuuBsPoRCr3utSxPdUpx+5CKX8+9BOvUpy5+DvTmAaoCfOviPGasFniO9EXlN",
+	"s6n7i0yRLXFtxS9KQAdNOC8YFEGuSVXCeZR04U8WFLiAkf1jtyUdd2LCURuqzEppcD1DA/z+s7tchtCB",
+	"ycgfUjBvNkGOEkrQj6cHhI+JkAYgOQrNeTOmeEa33rH5b/8t1Xls9gumtCOZ9n1oCU9Zdj9CeeSHSv1N",
+	"tGbxbeA1QnUZY4ozkyVq3QA7wRLu0lNzUQFdh+lHUrBFl+GdLsq1H37ovr/K/IEvpG5FSzd1Us1mVC36",
+	"O/mi1ZbVasp6zeKeNImYZfjO6X8T2o2R4DDCiyLUZckUZyJjb9kFK9p3/Hfygmf2tmqYmrGcW2vxfn5B",
+	"RWavki04hi/1tns4K6Uyy2xQuVp8qEJu5AR4XyiijWO4luEmlnNrG7jq6xOimtmXWL7e7ESNVeU0vWB5",
+	"SmgxpwtNtvG+Q0muFkRVgXdlJ3rlEtUMLRiDJixBKc/JRW3yWDd+B0ccdFuzhpuuYRrDkA78+kwHFJ8Q",
+	"iX+ngo1yydbZ8KMGrfb+4T6ZebNP73Ml533wveWN3IS7Tor/mjIKV+ozBldTJedkpw3CZ2shqNA4v8pM",
+	"dihyfsHzihYfOib1EFzUu1BiapGzqqFLRJOxkjMi1YQK/gdTOrWKKhdZUeUstxgHo+kkHUYKjfvmaqmo",
+	"r6li+LnW/M5+stLGHruNoXpzeE+XY1YzvLXXkw5rvEoTuO0dON9qz32akp6Lc8ju78sqIEMzorXvvR8n",
+	"e7+sBkPH/Hj1a3cuuE/TGCc7QcuOJtpQU2lHfGAtTck5K401ERVoHCw4zBmosXYbS7bVEgf2TnAoxnLd",
+	"gR43byL+j9FSPVSOWCPxEMpRtfH3pt7Jja7419NGrRe40pt8eWK/qL/9nmsj1WIwKO33B1Nve10HUEMn",
+	"EW753nNGAs+HccfmKC54GbdnrFmKM+QPxxtv+h8y+u3cyiI3is5FzXPcDq6mNQevGV1AXwH3dFQfk4Vv",
+	"ZbZU+tURK6sFlX0tqrE7dksO5GxWCW4W5IAJw9SmrDfuRvUrjO3LXgL7m7KOox6CHnEhFYElatBIZvA1",
+	"+RsfsRHZ2d4m//wn+Y8dwgX5ePLq76FGF7fSO0dICI2PJ6/a3INr+fT57s4/1nv+/GipX39sx+97QmPZ",
+	"1gddnmfMTGW+lk/b2Y7sy4ADLuKk7SnnOcmonhJqvUi5lCpi3Frris+kyu+FT9uJXi6GR8j4b9r+tLVx",
+	"MmtW0kECdx4OwlEEqMyZvARbbOTwjWGz0kRY8ytW8AumFsS/QmY0Z0RLMqZqjea9PrTnm9Odnb1nYMgY",
+	"ftuutdkVsG/zzcbDxTXIkiS9hh58p5ruvdlpwCe3jmQbNPkvbpWkgmpT3xi7fhXr+4BXPIaQMZrPAzCP",
+	"a4u6ZiK3FvY98mL7GTlhCmwT5KOgF5QXzqveW7lgl2bfjr/Uv8PRB8kuDckr5pSICm7b8yl4ykomchgt",
+	"XYKIzqI2HBGHaVsNPL2uFTP74Mm0pblHPDdJ2pBoFxjrwsY6BxqBHTUNjeSS6b1Pgvz/5H8OpBhzNbNx",
+	"CLCc/yFPyWvrMOt4U4wbY/FEWW8My0lV2mE+SG1A67jgBofSrWEomvifaO8ccBEFMK2z8yv8Gh05drgj",
+	"ygsuJm+5NjDQfp5rsKqrBVrZRYT8Qyf1zH5PCq5NCoZ3btALIQX7hAqVs5n1dg8xLN2tJGnSWk80IqN3",
+	"/i273HGNkq8YjQR0pMnlU3j36QVFC6qGj7oDNoN0n9hBr9IkFMOtBRxQPYUwFDkrkzR5ScV5reOmyXsB",
+	"CkNoG3Qf9HbpZ+B6Rk02vbF64YMNTpgGRbfLaDP9m2Ha/EZ3znazZyulxC3w+jG/RL4Uc35xg06vMzBR",
+	"XQKv4WbqLFaXhIm8lFwYGxaimDZEsFANIPbyynIiVaMnuBt+5BbdvzVzYZgwNlBIx5gjKBFEM3dLV+WU",
+	"CmI/0kl6A1XpWuKlgyhexjhoHOZrlg+Qm7lvwa4GYWjWukajilXJf3t2ZOYv52/P88P599U/+OXuN/SZ",
+	"+fcxXecDP7n2ZbrD4D1vd+jlsSnGq2PQ6YfLU56/k+aIqnOWwx978TgtI+V58OSsMn32yMGqw4vCy8fR",
+	"J9GM/CM3U1l5R/AemeETAtdFxHQIkKoB7xX43kIA7QTrY/cnUXuYcag2LPfsJNYW31HnqEExJZ64oJeU",
+	"VLqiRbHAaVwwDMbNkTFX2ow+ifeI9oeI9Xvt8C5LCO3NWDD0ZMhEWtrmMFxLTvROA2VCHIBJfcKRHQPH",
+	"ba0y5LuxWZYxYR8iyJfc3O9UdW2Ic7BZpSs7lvh1HMfqGFSi/hg3Yvjy890lb/eilZtP1rsTGo2tPW9k",
+	"3GATAZyijKAV1nQTR8164cYbuHYyfxyJeP4gSyYcD5Y6ZCKD3RbtbTk0j5x2uTp4/TXeRzG0RwrgDlZF",
+	"rCUvbjV19kMg5V7wps2Q2njFTXz4IrZsdb2odx4Jeh+6um4eR7ikLqo6FOidXI2U5doA+MgB3i9/YW09",
+	"Z5ha4uTRNVXJpfTeG7a9uHWwa6PSPTNolvOMFsO9LEetD2ri3NRB02Dc61Xmm7h2IkGSg5Ic2m4yWhra",
+	"M5ut53iG0Vk8pjZUNRmdhQoyKPH7KpsypTfAk/7mayCGpxHFmcAl1sk6AQbN8kNx5zbPeqaY0XO/sXO6",
+	"91qRtoSLmxo608RZBO7BuLvEtmjjzWjhODZgB2uCGMMLB/502271SKLcMJdvL8UOnL4dyhOZWpQG7WRM",
+	"eHKjEL0P5KCYqZRowpwdxhIuxrK+4CZpMlfcsMCVjLp3PyrsX1Swlcm2O1Et5YKbe0CAgsaW/EpuvmIH",
+	"pyXxIftFwdSEM52CvSvnVimAy45YmCkoLazQDtia6KmsipycCzkfkVs6r5YXzq4lg1vOGWO6m3HdDy2O",
+	"nbbVwgZemtvvgiV1ShWDW82dny/ONGyRJ61Xu9y9Qe0Wyizn30ehzO0q833i3oyk15HaRli+FpFXIs5q",
+	"oTgYbHXCVwiqGb0MVvBiXcT3jPc88PUH64PtZtwVUoiv0UaR3NTSeVe+HsWo7sQfJIfi9wqYhctbq83i",
+	"8e+tXfLW0/L9wJs4UNHnaJXDsU083bDGgE/VgMTnIbGpMR8N9VmlDrTBVgKAdeaLY08YWphzgMOMC2qs",
+	"o21Gy9Jlab5cNCGJS2Nj40GLafJyAeH2yz6DZ91LpEPnheUQ/RiUqzSRgg1QPpas6Spd/Vl/Tb92AIZF",
+	"SSJS9VQaCl6lTEntvELhdQJNLt4kMOaFwWBMIQ35vdLWemmjKQwp6YQlNTAcbZ8tGtlBcyu2aXEcvNPn",
+	"QuEi31WzM6YAycO0slqrrF1+NZL+ifY/GyDs3T17u1cRtOI1yDuWrBh3dE63WPRqPQopi8qD0r5vjS4G",
+	"kKq1xOfRGewdqr2Qb6KvwbmFjHp3LXe2H4Vb9jM2e0ubM1tHhs56t+SyhXGNJyxTtubLbRgmrl+xoxsB",
+	"1F5ceymdOdZBoEHuEB/eBEl2VDFy4K9kRIqMNa5fG0ovVf0zV42Zq86i461kKEL1uUV+bkZtu3rt1nTm",
+	"bnBSYljBARUZK2yIwQfHdBPMf3Cp8vXi+jb0tTpi1OXah1GzusgzO0//Qb2DyKNgT92nrV32Hzb7js1Y",
+	"Q6Jz1D7E1IM7kDG11Ajg13mlh87t4cG/saQWAfM/r00o9dlRt0kqKpSOdjFRuujcZ3oBJ1g5hbYTGWs7",
+	"BdID0knNKyGLGEdsofg7aQ7tvdaWiHD/WoK+zQtrbzl4dfH2+5v74m+d9Q1nXysCGk/Cq9yA80GPYit9",
+	"FGSwPR2rZXJtk1eN1xBaHmV3ZB9F6WqE2P944gtOqn7pGqymtat6mNZvx71f6hUASMJ83/DURTU78Ha8",
+	"42WS3z2oLXlceK+uvyg0En979WUqDWY89ZpAR2ODn63rhhoCOrNBJ647tQHL+GbAKiwt+wI/oULyYsg2",
+	"Tnt6zM6gz0BFiU76YsBFNMSdNsHUK4rurj91Gj382AFFCa0d4x+xQsPvt39NdOOuvCXOpxI00+Z6aD9C",
+	"BWRe3xu5IX/D4iOOnH/zgQl/Dy23nafRSCAlZ9dLr4hdxz2HJjBKwUw8N87IW4hBwYXjWG3Ipq3Ti518",
+	"7yb29ebAOWfR677nfKAb6UvOhHtMZvsCk9nswiAGE9a5JGa5Qhlv1RHtopYcZWhmnIU/ppOMbhS6t/ZU",
+	"WqaBYWESgb93Tc2Xx2w91q2xEo0tDawuJSSnW+UVUqZqBbaukzJmbERetz8RtiypcDdwX5nLMTxbewUs",
+	"93XYNCoSAWYtTd1/TDXcJNWwFXEQhhO84ZMpkvaRFBMpNdObc+oHnshYAy/IZQzkedvctzSVsd50TIVV",
+	"E3YcFRauCGIdL2oDUkupueEXvuRhzqEcIgOTFxDVGZY7sje19ZQEetHr61dewM+/xmQkBN2rGnIblO2J",
+	"aNIUgoFn9By1fSoWM6lWOJ8Oo8U64Ik1BEzoBSNnNDsnlAg2odGjDgTwbQHFyBgqyKkYggpG3ikieD6j",
+	"7uC21xp7sxufafJVNqWdmI+vTU1t0mwDtzmltM06Wn7BcDddyPWZE8hEllWKm8UJoLsLns4ofcmoYgrq",
+	"4cIvZ/jXGw/Rf/14mvTq4kERJJplTINwP2eCcEH2scA4/8M6wWwFlSS1/QeQG+G4DYSmxpRI+hmlB1Ke",
+	"c+ZXsG6yDN9Gr0yyl9R/2dRvfP+3/YOD1ycnv52+/6/X75opacn/C+gbYMGdB6YTMiPI/vEhMuAZFXSC",
+	"QTJwJLZ8I7gn4KeqxFfsE6zFx01THgrPkHRcpLWIS3ZG26NtvJWUTNCSJ3vJM/wJ5IqZ4rFs2aG3Lnbg",
+	"r0msBcAHZhRnF1hItuBg0hpD+Ve3qASHt7MDrSbfMYPr0j/s4ESKzphBef5LLygbazBbSmAKi+1ifSji",
+	"LvgI9n9XTC0aqGe+brNlpSHl/vfut9XPz/41zb8/0offFxf5ycvZ2bMfqp8PXm7T7z5Ofv7xzR/5dz8s",
+	"Dr/7Qfw8/+c/Y2TUy5anl8QabmGh7oyMJGNmsumSRRZ8xk2wxrr+I5jaQrubL4kb2O5iNcDxUqhLKbQl",
+	"qd3t7QTrddehy7QsC1f9cet3J1eaNXT0BAvIW4ZfCpyRblZqMxZ+PqX6Hbs0x90yTPELareuFCwhHCPC",
+	"pnq+7P0avT29XaXJ8w2BvLZqemzmlzQnsAGmDU764j4m/SggBE8QzRQIISy1NQrYd7L3y69pon1ZPyDt",
+	"NuW7RijLgmh9eB6mwWJSLyog89rqHfIN6JvSYhwOHFh//dZAYbGtDwp84K6Pdql50kYpHxV4E+q71sJO",
+	"p/WCXLXNR5z85c+eKP/Fmm7BbdTXNJqHATIf9FES5mkE4hZ+tiUxD3mpdOzjOhe6ZJl18NiPfdcHW30d",
+	"bfnOXDGlZcmEjS+g/WQBn3uekoKfs08CL2+tVO6WLQQCeMSkSR53ieLtBPERQTsWoT62gdBCikmTaxTM",
+	"T0X+SeS2YIYNwKV4i5goTFP9XZ75bSlUEDSWSqgUcx4weFeOx1AeluaYjq9dMQW7SF9hYUT2se7xJ+Fi",
+	"Mp7o2B4BHD630S3WEiPA2zCapwgG7somF2zc5Nj+Ls9slAa19Ry0TXpcqrfgmdr883UqjLXjeNOV3aWR",
+	"DtztwrgAhdESdaGOkRpGPZEiDLerGtSie5AMb5azNmnLjjdEFp/WxOOAWp+7BZYtn4j1MSzbdthUZ8k+",
+	"RJb1FtWTFlRWcaytP3l+tQW0aZOyBklqW98HK+1T0Z7qiW5q5gCdgXVCE47VSaCTTUq0bI4k93V2VCVI",
+	"ibXJuSFVGTCDibRWCSPrbO2VSkGLKg/zD8w2UFhJnIevPGG2duJpEK5ADQnyvsCPXzFux/5wY4IdSpBr",
+	"KSslc8qtPwbpqOH4dEK5cPrG87uno/fNmrB3kpDGVkRIAXdmdEGmaMhiTLQXaRvJPERqRxTvkGGc4A3T",
+	"5mmd7RWn9BMmcluiRptQ0EeErVMeQI+xMY6u+tyIWIYBInYAsZ4ybV7XebrX0+fX5orDhjbMmoymEg+V",
+	"Sk7lsgCpi0A5wHrwDb09dIZvhphTp+/oCi1S46ooFp9D9783ynoTVtVqwHk31NWCtbbSZwVtwWtMFdyw",
+	"5QRm7xM1iYGaXBIuoEVmwdRbbpjX022K/IRfMOFbtsC1wYzIG6lIOzA1tTH0tQZMc/DrURTOTaQ40dUZ",
+	"rOSMKT8ExLqkLi9AaRMm53uXqXehYikfqpznte5sAW4qe1mglZFPJ0wAsTNXocaOWCoGlYKuwxiOGpje",
+	"KncInZy/WPM5xUTnDpuIeiJ+bblK12dfr4wNQBSI52bDr/X1pMGX8MrwKWnhDuLrd/BSt7NU8yS5I9/+",
+	"8hxzwDPbtcmzOxvl0Z0VUM9i5ejaSegRr6s78CGs+3XTGymQfvAhyjuAIlwybt3wE+IonnPXqbWz++z5",
+	"i//8xzffxk4wQKNhx341ACAnLcHSWBpaDAmZFI7/1xA7iAENp8diSpNW4P3diKAWiXcnbMki3xjsaU4N",
+	"3foTD+nKkmPBYg2iOxe7MbaK5hc0W3gIA8/2bb+i6WVAKi72hgmDT62z2AdvOU3I5qc1dmFHYzN5YW+I",
+	"KC76tqheBZmZZsWFkzgQuoZXShySYO9nDLN0AWN+Egy+ItrQBUCPZiBDEX/5mLQbFcHUvdZnthLQ1IpT",
+	"LAjU7uMYirBXCGcvxNrFT+qgjVU30dderWaKauvrhZN0xXH611HW0prX3UivWSzpTq+gYWvfVZr0E20h",
+	"AQovAid3V85v7+HKeX0MeYgXzteIm8gJbNUGX43hDIvJCa+Op4Ms4XGW8wELOsT4TRoC+NrsZ0QObQCc",
+	"DqpFIDfhxoURtmtJ6EyWNmpQlujbhn1Ya7JdJbsspcKEmkJOJizvc4eW7fgGrMFO8+B5QxtCS5xxUQSs",
+	"z3xEsFEzyhHtus3h6x5DUO58NRbguijFamJtvxaSrcWb9XQbqhQBp9uy2mWUrr9jJqofoIXI6o1ckEoz",
+	"Zb0vfZIMyte5puwupZA2edipy5wibmvoiMfrr20zeIrW5Tne23FYyDRh3ty/giQDTeOIJTfE7U6HsE3C",
+	"HeJJpeu8JjjFUPMUnENXjjmJunP3pPBOmgYnvnwKjBPcr1e9qIdZiPs9x8ufLuD3agvl11MulhuKItER",
+	"8A0QkQ8IBNL6vx8IdMH39YDjLtGxVCPbdhre1c1IMMJ8Kgtr1EnrFER8qy4PYX9dbbBxgYJN+/eBLhcf",
+	"5RGTYK3o6Pv1uNyGlQljA10Bn1Zk+7oiWr2CDzDKUKrWGRVAVHB+9xqZ4o59tSLfuza2S9RTY5jIqcjY",
+	"/cWunGKcRI5mJy6wP2Fqg91BVWAuoBIzK3txDxkV6Hs6Y62E3XvzggVdBgM32IOMwakZn5P8Ds0d71vO",
+	"Zb0ZYEv1ykNP2EA/N8AEE9Xa2FpfPxpDw5I64K5+dkfUfhKA/52C3NreJqy+2tLTguLfBRcMXOSrQ1Mc",
+	"N/a1mTvVsb9u5nxX941oIfGlrKz9FlEMdOvRw3Qv252yBtXrGJsusm9MiFtjfrmJBgT9JaRouiE0RsPo",
+	"gaSurTrSGVASN85WgyX0Ib5rP1Jnf+7LZoAogP4B3XYBBHu3110BLNlSEfaZgJes2TUffRLeoGHXijcx",
+	"DJwIe2cgqrA8RtwRXStO3W/45aP2davV4q/d4mNVT4yhKl2N6hb971WruzlLdATo8Pt+NbsachkmytXd",
+	"asBNPaMGbCTF4gvR2O7Fjh0AJWgmUmcPPkAB9gb4ai2qZnXDjWWiymfihnKpVCyjxhNd2msY6J+Dljqm",
+	"FzZdqS0jXdQyGRcQBTkHaXLGnP8r91fxcWUqxQZdtD/4ZX61vL6XQnVQyCofFxhiAsLSgNZxsH98evD9",
+	"vl93ncfnVp6Nn9bvPvWMcOA+fvrpp59Grz4eHf33CBPzRj/99NNPtyuUNijZtppj3G3SSSg776bu3FCB",
+	"5/YY6GUoOHa3d2+wqc9ZqrJbf29Fx4w4TKx72w2pnV3PVpa02/fN1loYY7NI4OaJXWMqYXhB6qnDNIis",
+	"V9kS7pnndiIX9hC2a4EvSyZqN+z9h3083352P4ZqWhRyboHgZcP9hTvblDPeUhfa67CRSPelP5zIGYPb",
+	"F9c+nroVEIStSoiZcu17EclWJcLWJ1LU5rUAX32YG/POji80u/HEC3LYn4inhHW1iVZ/pGW+M/SD9Ysj",
+	"1zOsN/Z0W289GLXgttO/MYxu85zu8HA+S2p3Gm3eEy4Mj5VrjFhcsjj3aHM1xUZ6DlqGs2tw3ZT2voWc",
+	"tnjVv+hyWoHPy1bGLkumOJawKdgFK5assXlt8Dpf15+8xYGHwcxZs7gmU3BZZ9wsUpJRm7zIhC2/s2SR",
+	"rTJBMZw/mPKMTmRKDt8OwfzI4rD6iL/OA37x2bK1tIoZjQ1bRoY3rJ/SX/QJw/htbQPUYS269mjiXzZY",
+	"ByNXB8NV46BLtoDzqUj1qusA2HI37YQsRJP9DZjt30GQCnkm84X9EYsq/X1FmdgoQ/QlT+M7WdoRYsC6",
+	"qfBdKyiEPTX0NRjGhk6WANjWHus0IVoLXNvdWxLtA6XCBZ8tlp21VOblIs61a/Ogr4ocdhasK3cJ196l",
+	"2UPnhbWrf8UVy/ylb8kWuFixhfcqZ2rJLqjOWnuwf8H04ZLxl9t3v3Qqi9QNLYZyfNcC4yqNViV58fzZ",
+	"7s6NS42s7i55+xVHUg+HzUqPxAJ3Hqs93NhYOEARX1rO5MRQZXT9YmDxG2GtVf+TJhOYp/H/Ggl2QuSc",
+	"KVY5IFK4EPfmhuXbNUuxiYXwAd4HHs2EX4SZkA/oprqs1UyXMfJ4L9DhFkKrdXhjOwznbIY7X6Uh9KQp",
+	"7Ea11bf76WgQ2KcY8zUVFLN2ohoKTeLmknoxf2Wz6mYQPqtaKvajIfbREPtoiP2aDbFbNolmg2pduZyL",
+	"QtI8luHQXgAwk4OTH0bklPu0eOfb9aGDwBbJH1Elbo1N12ZwPCBNzjcswgpnF6j0EiXn3V7Trrcaa37v",
+	"cP6oDUrO9ZI7cMnUcd3E2t2E27+VTH3oCOwGFu0XN78fG3ZptjJ9ERJMd5y10dPao1mSOlUX5zuwEz19",
+	"xbUt1t2lzGYb1BiaTWdMmP8NPSEZQO2fn5LwWpnpi09JZJ9X98t0H3x8s0vNip3wUIbGZ56hDY2lRG4W",
+	"TmkolDN2zUmsJnNw8gNaHT1plkzVVOlDFz8JTcFcLYtqZheOgS/1my5lcS/I5SZY7+FvTRETIHJIUfl7",
+	"iv/BuiLpJ3Fgq5Ck5A1WKMFfyVta//O1lXGNwT0l34PxHGzhqFq50nt/c90LU9f1xPYlhEnr1nN/hzhN",
+	"Ode1BMRt1cZkW2q9KhEW8KvvPAFw4U37sPrLOV0AMJoizakN+4YXMNmgdcunF4PDPgOZcDh7YDIBDcwI",
+	"St+vlrkkfqQRrIo5x87wZ4xYrGe531BHBuRq8aEScSkw2OJtIexQGePyhevl6VITN1Kn4gtFzfZVZXkY",
+	"a3qVbbDuldaHWxM7RjqY32scqj2C5clFP/qobY8P9lI1nwIHQPrUZM4UAylyvzGoiL4ZoqtLFgJkSR7l",
+	"563KT0eiEWfqBhJ0RkVFi00kKFpzvb+uZaDNaGmyKQXZ4i28tjIvgfoVxfnTqgQbDciEjOqpd9/lErI0",
+	"3zJ6AU98Krs35pwzVuq+OcdnJFirAjet1ASXxbC5rfjIQuIxiYBPhFTsoJCawXUuxodDmLxlJnJGXDi/",
+	"efArNs+FofNRs4d+L7W1VTw7LeHSz2i1/ItGrPYiVT+PFe7zyZN7NXr5vm0do7ZXwxpv+5dsyLqt3Dy7",
+	"11oInUGIiMiHijxXIOxmxQi8Yoxdv4NTcavxKdVYb97LSNvUzyXwjohvwYz5d22tuhXlAhLQfScVn3BB",
+	"C+JXvrmQw3vjV1+tIF3aNKpLI5+5SNBtiOPS9dvM2WWs9e6xM3nVZfZbmNmFhru6O37CDdzosBeAq+rW",
+	"FAbdTnfbtT5Xt7/u1WdZKz9+nMo2NSWPRRrWLuqx8sJnqLwQsvbrCpitSuRyIylTMKqaZpRPOZgnV4gc",
+	"m7/NVBs1QA7NuAbj5s3ExEdY/KOoeBQVn1VUAAmFFDGW6lFufBH3o7+WcAB+6KMY4fawoVBwdQqf+jiy",
+	"gZ54uBdYH4uPKg8KeLq+fE1D8J6QGJF3mxf3dA0/hxX3XCpIjux0GAL3KEi+4MKkMWkyvNWVvc22D3td",
+	"7UY/wxCT01Eb23uhErwvwZ7omhZUfp8xU18/O7xp0VWMJ+8eBpsxNWEiw3A/QzPfK8udq5CG6U2Zqf1o",
+	"E/c/g4gbV61ICTdvjFuCdIdnIMexp7JvENJUdUUojMg7aZw/W2B9Sj0F0PdiFq+phePoj2zzq9C/weEc",
+	"LsnKXAphJ4IpcibnYWLX7ualMWGOoTZ6QOA7dkLgFGv4FNIhIPJdBkLjQq7jvKj1duh1CqM8ausPR1vf",
+	"z/MmVRN5vZE3NOug0Nn6E/5zmG/SZwQCavHNIQJoTZON1fLiHa7tUWrcpdRIl4PTsd3IwoQ/mK+ofOgX",
+	"7nS+b5aJeemGPXzW+QGrtHW4pyu4fhP+WVKeP3Xhtpvo7oplUuXOWVqHAKmuOKtrmMrKaJ5jE5smIAmi",
+	"kzAG9prqOITRuuCTR/b6hSrlmwQO9Snse4hrDsvhPsbxPGq+Xx37PqLqvOso9Djtue+GnNu2lNuMZ8MX",
+	"WFxAKlJSZWxFq2696a715QBbisG/oVwttoOFWNOIjQ+zS9ulYK7J2D/YvT3y9K/B0GLLk62jwiMp2MKa",
+	"HKgbsNnDofi9UgzyKcZSseBIg8I768wzMHrBqGYnpTTrY2U/uNaP3UNparp776EzXqNxwvawqaCBW7K2",
+	"3Irb7XD+P3ZpvZDpc+eiDibTG9SlGTv23K1Ic1dmJL/CzcCnWzEYM5qzNMzc9OHs+NIM0BLZn+1yfUaz",
+	"c4ImZ+z+pcuCG+P6+HFFxoxZPomJZxpMzLTwI+q/kMSWCssJeFj+Be5fVnbe6LKlpDZMbbkKCysatNsX",
+	"HB6jfeqCG5a3MujRRgWsCDqo4bCk0g5PCXZsqpkYV/b7VkOu64plnMit7qFKZ8vfbcrnzaWzG+jzeUF8",
+	"C7CNoditJ3h3LcMsdnewtoezdywMLWkNc6vX/vRWt/mwU/wbPpmi2DiSYiKlZuubxteDpX4tQ+FnX28Y",
+	"AWrhkA3ss4oftlx6Jy3Nqq58CpkmiKgvUDyFsa72xAhtH+U1pUxDPyuc8h+YZsIFIrkpu3SHleHC2EQE",
+	"N8omrJkBgr/GNbJgZkQwhbrLTYktUmINmBkVBKMIub6RNDpsbfNRIn0FEsniEm4wEhbrirsCVrqoDV2H",
+	"xkJPboeu0sXEVaJBPK8cYZdlolknNNZNHOy7HSu7vgnTdeJleR0Xpa0JBnfWok1i5F0HBVSzQ8sKA0TY",
+	"jRX3DiRS68ONRLkvJswUsxs2D78wWJdO788fdtqTfV/XZWxQZ2NL+H0RtXGQmp5SxfSW90Etl4y+lGpT",
+	"IXXcKmIGtk4YCQN1W/KQd0wG/oKcrrqduXd8y3igI4dF7TaftNCSZPLCVQBRC7cCnNfW8svr3MYzBrNd",
+	"L/ceWfMJwunAg+lRsj7e9W7lrte55FkUDgjgXsu6IJrXWL5kzQEHqFf9sGXaaX04nrWA7VvIORaNsIUV",
+	"0BB6ZvX8exd7y259sdvevbU2tBADCPnk8TPGBALqC751HtMFoV159kSDsXtT8WroRG/9aehkw1A85cJb",
+	"iKGTWFgLqP4ziVVp7Ds9l41vJok3UviDCIm9q68dvHdKJ/qUTh6q7PtCA/dQSNDJ8sYd4Rpt344hK4z2",
+	"8Vgt6B6D9R6jPDbLRgTW1PURpUlZDYrP6H88IsCEMJ0F6k4rJAoriqHTDRfdD4iZ86zD/5oxNk2Jqcwj",
+	"k3xkkn9tJvkYfvcAGPNphC1vqNgqKvS425I7CFWlArPLVKcCna/IWjKlpUiJDTnipv0MJ8d46DNppi33",
+	"ScOee84TvyLCzYgced0Y72S9JZBS8Yz7iYhi2Imma9yCCXM+HjOF/QiX9e7ALhG2Zzh2iOAYIIUVjZ2K",
+	"blxOPi2ZrZrexOW0Z7imaerUH8WjzPkawgNj8X4HFK5qWMyaN53/+8F+fReMdGhxF+cCwx9vHAYwtG6K",
+	"LQfsusfWxNsjsDt3B2UFZ8KcsEzFil0cdBkCcI420aaW/jWDQthM1LmalrNkUhtN4DStHbthUprkSpYl",
+	"y8FBx73FWo9ix3B9JSBN2ox61Xc1F1kTgliPN9jC6T5QnfZQyN57x60NLwqHFy7RgTvbdAt0c8rRowAM",
+	"2DQ+gc+oouzcj1utzu8f3ZvlcyPZa76YNDj2GeuinjoeEPaE+urLoQ7yTHo+ckP9Eht4LZ7iXwMCQ6lt",
+	"0agj/YesQ3BFf7Dlvsg1jb4sS8d3UAbMpTrXqANeU5P7ATf92mkgj8rcV1BU4ytwMbZxvYvg9xFJ+uWY",
+	"MGJBoPfjmvOMKdRdbNN2OJHUJhCgvPJywp4bs2Jr557KulqO13Jxon/XBdDaKAqXUpSnXa3AeUL9XumE",
+	"cvFlCbVAUlluS6hf8BMnHHqiiudXS4vhQTUpKXwXyrMFQZ64tAzdLdW94F91lQa7sXUHXfcObLMA++lQ",
+	"2q/Vqs/SiPsey//XKu7oC6Y3IJWgZTc12bRPUR/LnBpWv7mEpo7h44dAVbdfRcBTzhJcsbEqFUL5rpuA",
+	"3Bulu+08UvwDcRyHPCC5Wj/LqsspLjjGFo6VzKvMGp7wpSRNKlUke8nUmFLvbW3Rko9g1NFcqiLfSvrX",
+	"m7cSKkTm7CI2xN7WVgHPp1KbvWfb29tbydWvV/9vAFjzYYeBKwEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Plenty for a few thousand players
+const maxImportFileBytes = 5 << 20
+
+func (a *API) PostEventsV1EventIdRegistrationsImport(ctx context.Context, request PostEventsV1EventIdRegistrationsImportRequestObject) (PostEventsV1EventIdRegistrationsImportResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsImport")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	// Big files are written over several transactions
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	var importedBy string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		importedBy = jwt.UserEmail()
+	}
+
+	dryRun := request.Params.DryRun != nil && *request.Params.DryRun
+	allowDuplicatePlayers := request.Params.AllowDuplicatePlayers != nil && *request.Params.AllowDuplicatePlayers
+
+	result, err := registration.ImportRegistrations(ctx, a.db, a.db, request.EventId, io.LimitReader(request.Body, maxImportFileBytes), importedBy, dryRun, allowDuplicatePlayers)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to import registrations", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_INVALID_IMPORT:
+				return PostEventsV1EventIdRegistrationsImport400JSONResponse{
+					Code:    InvalidBody,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_ASSOCIATED_EVENT_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsImport404JSONResponse{
+					Code:    NotFound,
+					Message: "Event not found",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsImport500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to import registrations",
+		}, nil
+	}
+
+	logger.Info("Imported registrations", "eventId", request.EventId, "dryRun", dryRun, "numValid", result.NumValid, "numImported", result.NumImported, "numErrors", len(result.Errors))
+
+	rowErrs := []ImportRowError{}
+	if len(result.Errors) > 0 {
+		rowErrs = slices.Map(result.Errors, importRowErrorToApiImportRowError)
+	}
+
+	return PostEventsV1EventIdRegistrationsImport200JSONResponse{
+		DryRun:      dryRun,
+		NumValid:    result.NumValid,
+		NumImported: result.NumImported,
+		Errors:      rowErrs,
+	}, nil
+}
+
+func importRowErrorToApiImportRowError(rowErr registration.ImportRowError) ImportRowError {
+	apiRowErr := ImportRowError{
+		Row:     rowErr.Row,
+		Message: rowErr.Message,
+	}
+	if rowErr.Email != "" {
+		apiRowErr.Email = &rowErr.Email
+	}
+	return apiRowErr
+}
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEventsV1EventIdRegistrationsImport(t *testing.T) {
+	newMockDB := func() *mockDB {
+		return &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{
+					ID:                    id,
+					RegistrationCloseTime: time.Now().Add(time.Hour),
+					RegistrationOptions:   []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL}},
+				}, nil
+			},
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, eventID uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
+				return registration.GetAllRegistrationsResponse{}, nil
+			},
+		}
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		api := newTestAPI(newMockDB())
+		dryRun := true
+
+		resp, err := api.PostEventsV1EventIdRegistrationsImport(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsImportRequestObject{
+			EventId: uuid.New(),
+			Params:  PostEventsV1EventIdRegistrationsImportParams{DryRun: &dryRun},
+			Body:    strings.NewReader("Registration Type,First Name,Last Name,Email,Experience\nIndividual,Test,User,test@test.com,Novice\nTeam,No,Team,team@test.com,\n"),
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsImport200JSONResponse:
+			assert.True(t, r.DryRun)
+			assert.Equal(t, 1, r.NumValid)
+			assert.Equal(t, 0, r.NumImported)
+			require.Len(t, r.Errors, 1)
+			assert.Equal(t, 3, r.Errors[0].Row)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("unreadable file", func(t *testing.T) {
+		api := newTestAPI(newMockDB())
+
+		resp, err := api.PostEventsV1EventIdRegistrationsImport(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsImportRequestObject{
+			EventId: uuid.New(),
+			Body:    strings.NewReader("Name\nTest\n"),
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsImport400JSONResponse:
+			assert.Equal(t, InvalidBody, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// How long after an event ends its payments keep getting reconciled
+const paymentReconciliationLookback = 30 * 24 * time.Hour
+
+// Job is background work that runs on a schedule instead of from a user's request.
+type Job func(ctx context.Context, logger *slog.Logger) error
+
+type runJobRequest struct {
+	Job string `json:"job"`
+}
+
+func (a *API) jobs() map[string]Job {
+	return map[string]Job{
+		"deliver-outbox":                      a.deliverOutboxJob,
+		"expire-unpaid-shares":                a.expireUnpaidSharesJob,
+		"purge-expired-personal-data":         a.purgeExpiredPersonalDataJob(false),
+		"purge-expired-personal-data-dry-run": a.purgeExpiredPersonalDataJob(true),
+		"reconcile-payments":                  a.reconcilePaymentsJob,
+		"rewrite-registrations":               a.rewriteRegistrationsJob,
+		"sweep-expired-registration-intents":  a.sweepExpiredRegistrationIntentsJob,
+	}
+}
+
+// ListenAndServeJobs serves the scheduled jobs instead of the API. It is not exposed publicly,
+// the scheduler posts {"job": "<name>"} to /jobs/run.
+func (a *API) ListenAndServeJobs(host string, port string) error {
+	r := http.NewServeMux()
+	r.HandleFunc("POST /jobs/run", a.runJobHandler)
+
+	h := middleware.UseMiddlewares(r,
+		// Executes from the bottom up
+		middleware.AccessLogging(a.logger),
+		middleware.OTELHandler,
+		middleware.FlushTraces(a.flushTraces, a.logger, 3*time.Second),
+	)
+
+	s := &http.Server{
+		Handler: h,
+		Addr:    net.JoinHostPort(host, port),
+	}
+
+	return s.ListenAndServe()
+}
+
+func (a *API) runJobHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, span := a.tracer.Start(r.Context(), "RunJob")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	var req runJobRequest
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 65536)).Decode(&req)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Invalid job request", slog.String("error", err.Error()))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	span.SetAttributes(attribute.String("job", req.Job))
+
+	job, ok := a.jobs()[req.Job]
+	if !ok {
+		logger.Error("Unknown job", slog.String("job", req.Job))
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = job(ctx, logger.With(slog.String("job", req.Job)))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Job failed", slog.String("job", req.Job), slog.String("error", err.Error()))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *API) expireUnpaidSharesJob(ctx context.Context, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	numExpired, err := registration.ExpireUnpaidShares(ctx, a.db, a.db, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, a.frontendBaseURL(), time.Now())
+	logger.Info("Expired unpaid shares", slog.Int("numTeams", numExpired))
+	return err
+}
+
+// deliverOutboxJob delivers what's due in the outbox. Failed items are logged, the dead ones as errors so they alert.
+func (a *API) deliverOutboxJob(ctx context.Context, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	report, err := registration.DeliverDueOutboxItems(ctx, a.db, a.db, a.db, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, a.subscriberManager, a.checkInSigner, a.frontendBaseURL(), time.Now())
+	for _, item := range report.Failed {
+		logArgs := []any{
+			slog.String("outboxItemId", item.ID.String()),
+			slog.String("kind", item.Kind.String()),
+			slog.String("eventId", item.EventID.String()),
+			slog.String("email", item.Email),
+			slog.Int("attempts", item.Attempts),
+			slog.String("error", item.LastError),
+		}
+		if item.Status == registration.OUTBOX_DEAD {
+			logger.Error("Gave up on outbox item", logArgs...)
+		} else {
+			logger.Warn("Failed to deliver outbox item", append(logArgs, slog.Time("nextAttemptAt", item.NextAttemptAt))...)
+		}
+	}
+	logger.Info("Delivered outbox", slog.Int("numDelivered", report.NumDelivered), slog.Int("numFailed", len(report.Failed)))
+	return err
+}
+
+func (a *API) sweepExpiredRegistrationIntentsJob(ctx context.Context, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	numDeleted, err := registration.SweepExpiredRegistrationIntents(ctx, a.db, a.db, a.paymentQuerier, time.Now())
+	logger.Info("Swept expired registration intents", slog.Int("numRegistrations", numDeleted))
+	return err
+}
+
+func (a *API) rewriteRegistrationsJob(ctx context.Context, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	numRewritten, err := registration.RewriteRegistrations(ctx, a.db, a.db)
+	logger.Info("Rewrote registrations", slog.Int("numRegistrations", numRewritten))
+	return err
+}
+
+// purgeExpiredPersonalDataJob anonymizes past events' registrations. The dry run only logs what would be purged.
+func (a *API) purgeExpiredPersonalDataJob(dryRun bool) Job {
+	return func(ctx context.Context, logger *slog.Logger) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		defer cancel()
+
+		report, err := registration.PurgeExpiredPersonalData(ctx, a.db, a.db, a.paymentQuerier, a.personalDataRetentionMonths, dryRun, time.Now())
+		msg := "Purged personal data for event"
+		if report.DryRun {
+			msg = "Would purge personal data for event"
+		}
+		for _, event := range report.Events {
+			logger.Info(msg,
+				slog.String("eventId", event.EventID.String()),
+				slog.String("event", event.Name),
+				slog.Time("endTime", event.EndTime),
+				slog.Int("numRegistrations", event.NumRegistrations))
+		}
+		logger.Info("Purged expired personal data", slog.Bool("dryRun", report.DryRun), slog.Int("numEvents", len(report.Events)))
+		return err
+	}
+}
+
+// reconcilePaymentsJob logs every payment mismatch for recent events, so they show up in alerts
+// before anyone has to go looking at the report.
+func (a *API) reconcilePaymentsJob(ctx context.Context, logger *slog.Logger) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	reports, err := registration.ReconcileRecentPayments(ctx, a.db, a.db, a.paymentQuerier, paymentReconciliationLookback, time.Now())
+	var numMismatches int
+	for _, report := range reports {
+		for _, mismatch := range report.Mismatches {
+			logger.Warn("Payment mismatch",
+				slog.String("eventId", report.EventID.String()),
+				slog.String("email", mismatch.Email),
+				slog.String("kind", mismatch.Kind.String()),
+				slog.String("paymentId", mismatch.PaymentID),
+				slog.Bool("fixable", mismatch.Kind.Fixable()))
+		}
+		numMismatches += len(report.Mismatches)
+	}
+	logger.Info("Reconciled payments", slog.Int("numEvents", len(reports)), slog.Int("numMismatches", numMismatches))
+	return err
+}
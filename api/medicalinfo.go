@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) GetEventsV1EventIdRegistrationsEmailMedicalInfo(ctx context.Context, request GetEventsV1EventIdRegistrationsEmailMedicalInfoRequestObject) (GetEventsV1EventIdRegistrationsEmailMedicalInfoResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "GetEventsV1EventIdRegistrationsEmailMedicalInfo")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var accessor string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		accessor = jwt.UserEmail()
+	}
+	email := strings.ToLower(string(request.Email))
+
+	// Every read of medical info is logged, whether it works or not
+	logger.Info("Medical info accessed",
+		slog.String("eventId", request.EventId.String()),
+		slog.String("email", email),
+		slog.String("accessedBy", accessor))
+
+	players, err := registration.GetMedicalInfo(ctx, request.EventId, email, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to get medical info", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) && registrationErr.Reason == registration.REASON_REGISTRATION_DOES_NOT_EXIST {
+			return GetEventsV1EventIdRegistrationsEmailMedicalInfo404JSONResponse{
+				Code:    NotFound,
+				Message: "Registration was not found",
+			}, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return GetEventsV1EventIdRegistrationsEmailMedicalInfo500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to get medical info",
+		}, nil
+	}
+
+	return GetEventsV1EventIdRegistrationsEmailMedicalInfo200JSONResponse{
+		Players: slices.Map(players, playerInfoToApiPlayerMedicalInfo),
+	}, nil
+}
+
+func playerInfoToApiPlayerMedicalInfo(playerInfo registration.PlayerInfo) PlayerMedicalInfo {
+	info := PlayerMedicalInfo{
+		FirstName: playerInfo.FirstName,
+		LastName:  playerInfo.LastName,
+	}
+	if playerInfo.EmergencyContact != nil {
+		info.EmergencyContact = &EmergencyContact{
+			Name:  playerInfo.EmergencyContact.Name,
+			Phone: playerInfo.EmergencyContact.Phone,
+		}
+		if playerInfo.EmergencyContact.Relationship != "" {
+			info.EmergencyContact.Relationship = &playerInfo.EmergencyContact.Relationship
+		}
+	}
+	if playerInfo.MedicalNotes != "" {
+		info.MedicalNotes = &playerInfo.MedicalNotes
+	}
+	return info
+}
+
+func apiEmergencyContactToEmergencyContact(contact *EmergencyContact) *registration.EmergencyContact {
+	if contact == nil {
+		return nil
+	}
+	converted := &registration.EmergencyContact{
+		Name:  contact.Name,
+		Phone: contact.Phone,
+	}
+	if contact.Relationship != nil {
+		converted.Relationship = *contact.Relationship
+	}
+	return converted
+}
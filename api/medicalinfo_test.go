@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/auth"
+	"github.com/International-Combat-Archery-Alliance/auth/token"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEventsV1EventIdRegistrationsEmailMedicalInfo(t *testing.T) {
+	eventId := uuid.New()
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "medic@example.com", true)
+
+	t.Run("returns every player's medical info", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				assert.Equal(t, "captain@test.com", email)
+				return &registration.TeamRegistration{
+					EventID:      eventId,
+					CaptainEmail: email,
+					Players: []registration.PlayerInfo{
+						{
+							FirstName:        "Hurt",
+							LastName:         "Player",
+							EmergencyContact: &registration.EmergencyContact{Name: "Parent", Phone: "555-0100"},
+							MedicalNotes:     "Allergic to bees",
+						},
+						{FirstName: "Healthy", LastName: "Player"},
+					},
+				}, nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1EventIdRegistrationsEmailMedicalInfo(ctx, GetEventsV1EventIdRegistrationsEmailMedicalInfoRequestObject{
+			EventId: eventId,
+			Email:   "Captain@Test.com",
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1EventIdRegistrationsEmailMedicalInfo200JSONResponse:
+			require.Len(t, r.Players, 2)
+			require.NotNil(t, r.Players[0].EmergencyContact)
+			assert.Equal(t, "Parent", r.Players[0].EmergencyContact.Name)
+			assert.Nil(t, r.Players[0].EmergencyContact.Relationship)
+			assert.Equal(t, "Allergic to bees", *r.Players[0].MedicalNotes)
+			assert.Nil(t, r.Players[1].EmergencyContact)
+			assert.Nil(t, r.Players[1].MedicalNotes)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("registration not found", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return nil, registration.NewRegistrationDoesNotExistsError("not found", nil)
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1EventIdRegistrationsEmailMedicalInfo(ctx, GetEventsV1EventIdRegistrationsEmailMedicalInfoRequestObject{
+			EventId: eventId,
+			Email:   "missing@test.com",
+		})
+		assert.NoError(t, err)
+		assert.IsType(t, GetEventsV1EventIdRegistrationsEmailMedicalInfo404JSONResponse{}, resp)
+	})
+}
+
+func TestMedicalInfoIsNotInRegistrations(t *testing.T) {
+	reg := &registration.IndividualRegistration{
+		EventID: uuid.New(),
+		Email:   "player@test.com",
+		PlayerInfo: registration.PlayerInfo{
+			FirstName:        "Hurt",
+			LastName:         "Player",
+			EmergencyContact: &registration.EmergencyContact{Name: "Parent", Phone: "555-0100"},
+			MedicalNotes:     "Allergic to bees",
+		},
+	}
+
+	adminReg, err := registrationToAdminApiRegistration(reg)
+	require.NoError(t, err)
+	body, err := json.Marshal(adminReg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(body), "bees")
+	assert.NotContains(t, string(body), "555-0100")
+}
+
+func TestMedicalScope(t *testing.T) {
+	tokenWithRoles := func(roles ...auth.Role) auth.AuthToken {
+		ts := newTestTokenService()
+		tokenStr, err := ts.GenerateAccessToken("admin@example.com", "", roles)
+		require.NoError(t, err)
+		claims, err := ts.ValidateAccessToken(tokenStr)
+		require.NoError(t, err)
+		return token.NewICAAAuthToken(claims)
+	}
+
+	assert.Error(t, validateScopes(tokenWithRoles(auth.RoleAdmin), []string{"admin", "medical"}))
+	assert.Error(t, validateScopes(tokenWithRoles(roleMedicalInfo), []string{"admin", "medical"}))
+	assert.NoError(t, validateScopes(tokenWithRoles(auth.RoleAdmin, roleMedicalInfo), []string{"admin", "medical"}))
+}
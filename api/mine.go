@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) GetEventsV1RegistrationsMe(ctx context.Context, request GetEventsV1RegistrationsMeRequestObject) (GetEventsV1RegistrationsMeResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "GetEventsV1RegistrationsMe")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	jwt, ok := middleware.GetJWTFromCtx(ctx)
+	if !ok || jwt.UserEmail() == "" {
+		logger.Warn("Tried to get registrations without a user email")
+
+		return GetEventsV1RegistrationsMe401JSONResponse{
+			Code:    AuthError,
+			Message: "Must be signed in to see your registrations",
+		}, nil
+	}
+
+	// One event lookup per registration
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	regs, err := registration.GetRegistrationsForEmail(ctx, a.db, a.db, jwt.UserEmail())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to get registrations for user", "error", err)
+
+		return GetEventsV1RegistrationsMe500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to get registrations",
+		}, nil
+	}
+
+	respRegs := []RegistrationWithEvent{}
+	for _, v := range regs {
+		convReg, err := registrationToApiRegistration(v.Registration)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			logger.Error("Failed to convert registration to api registration", "error", err)
+
+			return GetEventsV1RegistrationsMe500JSONResponse{
+				Code:    InternalError,
+				Message: "Failed to get registrations",
+			}, nil
+		}
+		respRegs = append(respRegs, RegistrationWithEvent{
+			Registration: convReg,
+			Event:        eventToApiEventSummary(v.Event),
+		})
+	}
+
+	return GetEventsV1RegistrationsMe200JSONResponse{
+		Data: respRegs,
+	}, nil
+}
+
+func eventToApiEventSummary(event events.Event) EventSummary {
+	return EventSummary{
+		Id:        event.ID,
+		Name:      event.Name,
+		Location:  locationToApiLocation(event.EventLocation),
+		TimeZone:  ptr.String(event.TimeZone.String()),
+		StartTime: event.StartTime,
+		EndTime:   event.EndTime,
+		ImageName: event.ImageName,
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEventsV1RegistrationsMe(t *testing.T) {
+	eventId := uuid.New()
+	mock := &mockDB{
+		GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]registration.Registration, error) {
+			assert.Equal(t, "player@example.com", email)
+			return []registration.Registration{newRosterTeamRegistration(eventId)}, nil
+		},
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Name: "Event", TimeZone: time.UTC, StartTime: time.Now()}, nil
+		},
+	}
+
+	t.Run("lists the user's registrations with their events", func(t *testing.T) {
+		api := newTestAPI(mock)
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "player@example.com", false)
+
+		resp, err := api.GetEventsV1RegistrationsMe(ctx, GetEventsV1RegistrationsMeRequestObject{})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1RegistrationsMe200JSONResponse:
+			require.Len(t, r.Data, 1)
+			assert.Equal(t, eventId, r.Data[0].Event.Id)
+			assert.Equal(t, "Event", r.Data[0].Event.Name)
+			teamReg, err := r.Data[0].Registration.AsTeamRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, "captain@example.com", string(teamReg.CaptainEmail))
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("not signed in", func(t *testing.T) {
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1RegistrationsMe(ctxWithLogger(context.Background(), noopLogger), GetEventsV1RegistrationsMeRequestObject{})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1RegistrationsMe401JSONResponse:
+			assert.Equal(t, AuthError, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"iter"
 	"log/slog"
 	"time"
 
@@ -13,11 +14,39 @@ import (
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
 	"github.com/International-Combat-Archery-Alliance/middleware"
 	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
 	"github.com/google/uuid"
 )
 
 var noopLogger = slog.New(slog.DiscardHandler)
 
+var testCheckInSigner = registration.NewCheckInSigner([]byte("test-check-in-key"))
+
+const testPersonalDataRetentionMonths = 24
+
+// newTestAPI creates an API with mocks for everything but the DB, which the configure funcs can swap out.
+func newTestAPI(db DB, configure ...func(*Config)) *API {
+	cfg := Config{
+		DB:                          db,
+		Logger:                      noopLogger,
+		Env:                         LOCAL,
+		TokenService:                newTestTokenService(),
+		CaptchaValidator:            &mockCaptchaValidator{},
+		EmailSender:                 &mockEmailSender{},
+		SubscriberManager:           &mockSubscriberManager{},
+		CheckoutManager:             &mockCheckoutManager{},
+		PaymentQuerier:              &mockPaymentQuerier{},
+		Refunder:                    &mockRefunder{},
+		CheckInSigner:               testCheckInSigner,
+		PersonalDataRetentionMonths: testPersonalDataRetentionMonths,
+		FlushTraces:                 func(context.Context) error { return nil },
+	}
+	for _, c := range configure {
+		c(&cfg)
+	}
+	return NewAPI(cfg)
+}
+
 // newTestTokenService creates a token service for testing with a test signing key
 func newTestTokenService() *token.TokenService {
 	testKey := token.SigningKey{
@@ -65,6 +94,7 @@ type mockSubscriberManager struct {
 	CreateGroupFunc          func(ctx context.Context, name string) (string, error)
 	FindOrCreateGroupFunc    func(ctx context.Context, name string) (string, error)
 	AddSubscriberToGroupFunc func(ctx context.Context, email, name, groupID string) error
+	ForgetSubscriberFunc     func(ctx context.Context, email string) error
 }
 
 func (m *mockSubscriberManager) CreateGroup(ctx context.Context, name string) (string, error) {
@@ -88,6 +118,13 @@ func (m *mockSubscriberManager) AddSubscriberToGroup(ctx context.Context, email,
 	return nil
 }
 
+func (m *mockSubscriberManager) ForgetSubscriber(ctx context.Context, email string) error {
+	if m.ForgetSubscriberFunc != nil {
+		return m.ForgetSubscriberFunc(ctx, email)
+	}
+	return nil
+}
+
 type mockCheckoutManager struct {
 	CreateCheckoutFunc  func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error)
 	ConfirmCheckoutFunc func(ctx context.Context, payload []byte, signature string) (map[string]string, error)
@@ -107,24 +144,80 @@ func (m *mockCheckoutManager) ConfirmCheckout(ctx context.Context, payload []byt
 	return map[string]string{}, nil
 }
 
+type mockPaymentQuerier struct {
+	ListChargesFunc func(ctx context.Context, params payments.ChargeListParams) iter.Seq2[payments.Payment, error]
+}
+
+func (m *mockPaymentQuerier) ListCharges(ctx context.Context, params payments.ChargeListParams) iter.Seq2[payments.Payment, error] {
+	if m.ListChargesFunc != nil {
+		return m.ListChargesFunc(ctx, params)
+	}
+	return func(yield func(payments.Payment, error) bool) {}
+}
+
+func (m *mockPaymentQuerier) ListChargesPaginated(ctx context.Context, params payments.ChargeListPaginatedParams) (payments.ChargesPage, error) {
+	return payments.ChargesPage{}, nil
+}
+
+type mockRefunder struct {
+	RefundPaymentFunc func(ctx context.Context, paymentId string, amount *money.Money, reason string, idempotencyKey string) (string, error)
+}
+
+func (m *mockRefunder) RefundPayment(ctx context.Context, paymentId string, amount *money.Money, reason string, idempotencyKey string) (string, error) {
+	if m.RefundPaymentFunc != nil {
+		return m.RefundPaymentFunc(ctx, paymentId, amount, reason, idempotencyKey)
+	}
+	return "re_test", nil
+}
+
 func ctxWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
 	return middleware.CtxWithLogger(ctx, logger)
 }
 
+// ctxWithJWT puts a valid test token for the user in the context, like the auth middleware does
+func ctxWithJWT(ctx context.Context, email string, isAdmin bool) context.Context {
+	claims, err := newTestTokenService().ValidateAccessToken(generateTestToken(email, isAdmin))
+	if err != nil {
+		panic(err)
+	}
+	return middleware.CtxWithJWT(ctx, token.NewICAAAuthToken(claims))
+}
+
 var _ DB = &mockDB{}
 
 type mockDB struct {
-	GetEventsFunc                     func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error)
-	CreateEventFunc                   func(ctx context.Context, event events.Event) error
-	GetEventFunc                      func(ctx context.Context, id uuid.UUID) (events.Event, error)
-	UpdateEventFunc                   func(ctx context.Context, event events.Event) error
-	CreateRegistrationFunc            func(ctx context.Context, registration registration.Registration, event events.Event) error
-	GetAllRegistrationsForEventFunc   func(ctx context.Context, eventID uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error)
-	CreateRegistrationWithPaymentFunc func(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent, event events.Event) error
-	GetRegistrationFunc               func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error)
-	UpdateRegistrationToPaidFunc      func(ctx context.Context, reg registration.Registration) error
-	DeleteExpiredRegistrationFunc     func(ctx context.Context, registration registration.Registration, intent registration.RegistrationIntent, event events.Event) error
-	GetRegistrationIntentFunc         func(ctx context.Context, eventId uuid.UUID, email string) (registration.RegistrationIntent, error)
+	GetEventsFunc                             func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error)
+	CreateEventFunc                           func(ctx context.Context, event events.Event) error
+	GetEventFunc                              func(ctx context.Context, id uuid.UUID) (events.Event, error)
+	UpdateEventFunc                           func(ctx context.Context, event events.Event) error
+	CreateRegistrationFunc                    func(ctx context.Context, registration registration.Registration, event events.Event, outbox []registration.OutboxItem) error
+	CreateRegistrationsFunc                   func(ctx context.Context, registrations []registration.Registration, event events.Event) error
+	GetAllRegistrationsForEventFunc           func(ctx context.Context, eventID uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error)
+	GetRegistrationsByEmailFunc               func(ctx context.Context, email string) ([]registration.Registration, error)
+	CreateRegistrationWithPaymentFunc         func(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent, event events.Event) error
+	ReplaceExpiredRegistrationWithPaymentFunc func(ctx context.Context, expired registration.Registration, expiredIntent registration.RegistrationIntent, reg registration.Registration, intent registration.RegistrationIntent, event events.Event) error
+	GetRegistrationFunc                       func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error)
+	UpdateRegistrationToPaidFunc              func(ctx context.Context, reg registration.Registration, outbox []registration.OutboxItem) error
+	DeleteExpiredRegistrationFunc             func(ctx context.Context, registration registration.Registration, intent registration.RegistrationIntent, event events.Event) error
+	GetRegistrationIntentFunc                 func(ctx context.Context, eventId uuid.UUID, email string) (registration.RegistrationIntent, error)
+	GetExpiredRegistrationIntentsFunc         func(ctx context.Context, eventId uuid.UUID, now time.Time) ([]registration.RegistrationIntent, error)
+	GetRegistrationIntentsForEventFunc        func(ctx context.Context, eventId uuid.UUID) ([]registration.RegistrationIntent, error)
+	DeleteRegistrationIntentFunc              func(ctx context.Context, intent registration.RegistrationIntent) error
+	UpdateRegistrationFunc                    func(ctx context.Context, reg registration.Registration) error
+	UpdateRegistrationWithEventFunc           func(ctx context.Context, reg registration.Registration, event events.Event) error
+	TransferRegistrationFunc                  func(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event) error
+	AnonymizeRegistrationFunc                 func(ctx context.Context, from registration.Registration, to registration.Registration) error
+	CreateTransferWithPaymentFunc             func(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent) error
+	TransferRegistrationToPaidFunc            func(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event, outbox []registration.OutboxItem) error
+	DeleteExpiredTransferFunc                 func(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent) error
+	ClaimWebhookEventFunc                     func(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error
+	CompleteWebhookEventFunc                  func(ctx context.Context, webhookEventId string, completedAt time.Time) error
+	ReleaseWebhookEventFunc                   func(ctx context.Context, webhookEventId string) error
+	GetDueOutboxItemsFunc                     func(ctx context.Context, now time.Time) ([]registration.OutboxItem, error)
+	GetOutboxItemsWithStatusFunc              func(ctx context.Context, status registration.OutboxStatus) ([]registration.OutboxItem, error)
+	GetOutboxItemFunc                         func(ctx context.Context, id uuid.UUID) (registration.OutboxItem, error)
+	UpdateOutboxItemFunc                      func(ctx context.Context, item registration.OutboxItem) error
+	DeleteOutboxItemFunc                      func(ctx context.Context, item registration.OutboxItem) error
 }
 
 func (m *mockDB) DeleteExpiredRegistration(ctx context.Context, registration registration.Registration, intent registration.RegistrationIntent, event events.Event) error {
@@ -135,6 +228,18 @@ func (m *mockDB) GetRegistrationIntent(ctx context.Context, eventId uuid.UUID, e
 	return m.GetRegistrationIntentFunc(ctx, eventId, email)
 }
 
+func (m *mockDB) GetExpiredRegistrationIntents(ctx context.Context, eventId uuid.UUID, now time.Time) ([]registration.RegistrationIntent, error) {
+	return m.GetExpiredRegistrationIntentsFunc(ctx, eventId, now)
+}
+
+func (m *mockDB) GetRegistrationIntentsForEvent(ctx context.Context, eventId uuid.UUID) ([]registration.RegistrationIntent, error) {
+	return m.GetRegistrationIntentsForEventFunc(ctx, eventId)
+}
+
+func (m *mockDB) DeleteRegistrationIntent(ctx context.Context, intent registration.RegistrationIntent) error {
+	return m.DeleteRegistrationIntentFunc(ctx, intent)
+}
+
 func (m *mockDB) GetEvents(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
 	return m.GetEventsFunc(ctx, limit, cursor)
 }
@@ -151,14 +256,26 @@ func (m *mockDB) UpdateEvent(ctx context.Context, event events.Event) error {
 	return m.UpdateEventFunc(ctx, event)
 }
 
-func (m *mockDB) CreateRegistration(ctx context.Context, reg registration.Registration, event events.Event) error {
-	return m.CreateRegistrationFunc(ctx, reg, event)
+func (m *mockDB) CreateRegistrations(ctx context.Context, registrations []registration.Registration, event events.Event) error {
+	return m.CreateRegistrationsFunc(ctx, registrations, event)
+}
+
+func (m *mockDB) CreateRegistration(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
+	return m.CreateRegistrationFunc(ctx, reg, event, outbox)
 }
 
 func (m *mockDB) GetAllRegistrationsForEvent(ctx context.Context, eventID uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
 	return m.GetAllRegistrationsForEventFunc(ctx, eventID, limit, cursor)
 }
 
+func (m *mockDB) GetRegistrationsByEmail(ctx context.Context, email string) ([]registration.Registration, error) {
+	return m.GetRegistrationsByEmailFunc(ctx, email)
+}
+
+func (m *mockDB) ReplaceExpiredRegistrationWithPayment(ctx context.Context, expired registration.Registration, expiredIntent registration.RegistrationIntent, reg registration.Registration, intent registration.RegistrationIntent, event events.Event) error {
+	return m.ReplaceExpiredRegistrationWithPaymentFunc(ctx, expired, expiredIntent, reg, intent, event)
+}
+
 func (m *mockDB) CreateRegistrationWithPayment(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent, event events.Event) error {
 	if m.CreateRegistrationWithPaymentFunc != nil {
 		return m.CreateRegistrationWithPaymentFunc(ctx, reg, intent, event)
@@ -173,9 +290,111 @@ func (m *mockDB) GetRegistration(ctx context.Context, eventId uuid.UUID, email s
 	return nil, nil
 }
 
-func (m *mockDB) UpdateRegistrationToPaid(ctx context.Context, reg registration.Registration) error {
+func (m *mockDB) UpdateRegistrationToPaid(ctx context.Context, reg registration.Registration, outbox []registration.OutboxItem) error {
 	if m.UpdateRegistrationToPaidFunc != nil {
-		return m.UpdateRegistrationToPaidFunc(ctx, reg)
+		return m.UpdateRegistrationToPaidFunc(ctx, reg, outbox)
+	}
+	return nil
+}
+
+func (m *mockDB) UpdateRegistration(ctx context.Context, reg registration.Registration) error {
+	if m.UpdateRegistrationFunc != nil {
+		return m.UpdateRegistrationFunc(ctx, reg)
+	}
+	return nil
+}
+
+func (m *mockDB) UpdateRegistrationWithEvent(ctx context.Context, reg registration.Registration, event events.Event) error {
+	if m.UpdateRegistrationWithEventFunc != nil {
+		return m.UpdateRegistrationWithEventFunc(ctx, reg, event)
+	}
+	return nil
+}
+
+func (m *mockDB) TransferRegistration(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event) error {
+	if m.TransferRegistrationFunc != nil {
+		return m.TransferRegistrationFunc(ctx, from, to, eventUpdates)
+	}
+	return nil
+}
+
+func (m *mockDB) CreateTransferWithPayment(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent) error {
+	if m.CreateTransferWithPaymentFunc != nil {
+		return m.CreateTransferWithPaymentFunc(ctx, reg, intent)
+	}
+	return nil
+}
+
+func (m *mockDB) TransferRegistrationToPaid(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event, outbox []registration.OutboxItem) error {
+	if m.TransferRegistrationToPaidFunc != nil {
+		return m.TransferRegistrationToPaidFunc(ctx, from, to, eventUpdates, outbox)
+	}
+	return nil
+}
+
+func (m *mockDB) DeleteExpiredTransfer(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent) error {
+	if m.DeleteExpiredTransferFunc != nil {
+		return m.DeleteExpiredTransferFunc(ctx, reg, intent)
+	}
+	return nil
+}
+
+func (m *mockDB) AnonymizeRegistration(ctx context.Context, from registration.Registration, to registration.Registration) error {
+	if m.AnonymizeRegistrationFunc != nil {
+		return m.AnonymizeRegistrationFunc(ctx, from, to)
+	}
+	return nil
+}
+
+func (m *mockDB) ClaimWebhookEvent(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error {
+	if m.ClaimWebhookEventFunc != nil {
+		return m.ClaimWebhookEventFunc(ctx, webhookEventId, claimedAt, leasedUntil)
+	}
+	return nil
+}
+
+func (m *mockDB) CompleteWebhookEvent(ctx context.Context, webhookEventId string, completedAt time.Time) error {
+	if m.CompleteWebhookEventFunc != nil {
+		return m.CompleteWebhookEventFunc(ctx, webhookEventId, completedAt)
+	}
+	return nil
+}
+
+func (m *mockDB) ReleaseWebhookEvent(ctx context.Context, webhookEventId string) error {
+	if m.ReleaseWebhookEventFunc != nil {
+		return m.ReleaseWebhookEventFunc(ctx, webhookEventId)
+	}
+	return nil
+}
+
+func (m *mockDB) GetDueOutboxItems(ctx context.Context, now time.Time) ([]registration.OutboxItem, error) {
+	if m.GetDueOutboxItemsFunc != nil {
+		return m.GetDueOutboxItemsFunc(ctx, now)
+	}
+	return nil, nil
+}
+
+func (m *mockDB) GetOutboxItemsWithStatus(ctx context.Context, status registration.OutboxStatus) ([]registration.OutboxItem, error) {
+	if m.GetOutboxItemsWithStatusFunc != nil {
+		return m.GetOutboxItemsWithStatusFunc(ctx, status)
+	}
+	return nil, nil
+}
+
+func (m *mockDB) GetOutboxItem(ctx context.Context, id uuid.UUID) (registration.OutboxItem, error) {
+	return m.GetOutboxItemFunc(ctx, id)
+}
+
+func (m *mockDB) UpdateOutboxItem(ctx context.Context, item registration.OutboxItem) error {
+	if m.UpdateOutboxItemFunc != nil {
+		return m.UpdateOutboxItemFunc(ctx, item)
+	}
+	return nil
+}
+
+func (m *mockDB) DeleteOutboxItem(ctx context.Context, item registration.OutboxItem) error {
+	if m.DeleteOutboxItemFunc != nil {
+		return m.DeleteOutboxItemFunc(ctx, item)
 	}
 	return nil
 }
@@ -0,0 +1,277 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"github.com/Rhymond/go-money"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdRegistrationsManual(ctx context.Context, request PostEventsV1EventIdRegistrationsManualRequestObject) (PostEventsV1EventIdRegistrationsManualResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsManual")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var registeredBy string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		registeredBy = jwt.UserEmail()
+	}
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	reg, err := apiRegistrationToRegistration(request.Body.Registration, request.EventId)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("Invalid body for manual registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsManual400JSONResponse{
+			Code:    InvalidBody,
+			Message: "Invalid body",
+		}, nil
+	}
+
+	params := registration.ManualRegistrationParams{
+		Registration:    reg,
+		IgnoreCloseTime: request.Body.IgnoreCloseTime != nil && *request.Body.IgnoreCloseTime,
+	}
+	if request.Body.Payment != nil {
+		payment, err := apiOfflinePaymentToOfflinePayment(*request.Body.Payment, registeredBy)
+		if err != nil {
+			span.RecordError(err)
+			logger.Warn("Invalid payment for manual registration", "error", err)
+
+			return PostEventsV1EventIdRegistrationsManual400JSONResponse{
+				Code:    InvalidBody,
+				Message: "Invalid payment method",
+			}, nil
+		}
+		params.Payment = &payment
+	}
+
+	signedUpReg, _, err := registration.RegisterManually(ctx, params, a.db, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to register manually", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_ASSOCIATED_EVENT_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsManual404JSONResponse{
+					Code:    NotFound,
+					Message: "Event to register with was not found",
+				}, nil
+			case registration.REASON_REGISTRATION_IS_CLOSED:
+				return PostEventsV1EventIdRegistrationsManual400JSONResponse{
+					Code:    RegistrationClosed,
+					Message: "Registration has closed for this event",
+				}, nil
+			case registration.REASON_INVALID_OFFLINE_PAYMENT:
+				return PostEventsV1EventIdRegistrationsManual400JSONResponse{
+					Code:    InvalidOfflinePayment,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_NOT_ALLOWED_TO_SIGN_UP_AS_TYPE, registration.REASON_TEAM_SIZE_NOT_ALLOWED:
+				return PostEventsV1EventIdRegistrationsManual400JSONResponse{
+					Code:    InputValidationError,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_SPLIT_PAYMENT_NOT_ALLOWED:
+				return PostEventsV1EventIdRegistrationsManual400JSONResponse{
+					Code:    SplitPaymentNotAllowed,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_ALREADY_EXISTS:
+				return PostEventsV1EventIdRegistrationsManual409JSONResponse{
+					Code:    AlreadyExists,
+					Message: "Registration already exists for this email",
+				}, nil
+			case registration.REASON_PLAYER_ALREADY_REGISTERED:
+				return PostEventsV1EventIdRegistrationsManual409JSONResponse{
+					Code:    PlayerAlreadyRegistered,
+					Message: registrationErr.Message,
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsManual500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to register",
+		}, nil
+	}
+
+	logger.Info("Registered manually",
+		slog.String("eventId", request.EventId.String()),
+		slog.String("email", signedUpReg.GetEmail()),
+		slog.String("status", signedUpReg.GetStatus().String()),
+		slog.String("registeredBy", registeredBy))
+
+	respReg, err := registrationToAdminApiRegistration(signedUpReg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsManual500JSONResponse{
+			Code:    InternalError,
+			Message: "Registration was made but failed to build the response",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsManual200JSONResponse{Registration: respReg}, nil
+}
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailPaidOffline(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailPaidOfflineRequestObject) (PostEventsV1EventIdRegistrationsEmailPaidOfflineResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailPaidOffline")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var recordedBy string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		recordedBy = jwt.UserEmail()
+	}
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	payment, err := apiOfflinePaymentToOfflinePayment(*request.Body, recordedBy)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("Invalid offline payment", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailPaidOffline400JSONResponse{
+			Code:    InvalidBody,
+			Message: "Invalid payment method",
+		}, nil
+	}
+
+	reg, err := registration.MarkPaidOffline(ctx, registration.MarkPaidOfflineParams{
+		EventID: request.EventId,
+		Email:   strings.ToLower(string(request.Email)),
+		Payment: payment,
+	}, a.db)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to mark registration as paid offline", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailPaidOffline404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			case registration.REASON_INVALID_OFFLINE_PAYMENT:
+				return PostEventsV1EventIdRegistrationsEmailPaidOffline400JSONResponse{
+					Code:    InvalidOfflinePayment,
+					Message: registrationErr.Message,
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailPaidOffline500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to mark registration as paid",
+		}, nil
+	}
+
+	logger.Info("Marked registration as paid offline",
+		slog.String("eventId", request.EventId.String()),
+		slog.String("email", reg.GetEmail()),
+		slog.String("method", payment.Method.String()),
+		slog.String("recordedBy", recordedBy))
+
+	respReg, err := registrationToAdminApiRegistration(reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailPaidOffline500JSONResponse{
+			Code:    InternalError,
+			Message: "Registration was marked as paid but failed to build the response",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsEmailPaidOffline200JSONResponse{Registration: respReg}, nil
+}
+
+func apiOfflinePaymentToOfflinePayment(payment OfflinePayment, recordedBy string) (registration.OfflinePayment, error) {
+	method, err := apiPaymentMethodToPaymentMethod(payment.Method)
+	if err != nil {
+		return registration.OfflinePayment{}, err
+	}
+
+	result := registration.OfflinePayment{
+		Method:     method,
+		Note:       payment.Note,
+		RecordedBy: recordedBy,
+	}
+	if payment.Amount != nil {
+		result.Amount = money.New(int64(payment.Amount.Amount), payment.Amount.Currency)
+	}
+	return result, nil
+}
+
+func offlinePaymentToApiOfflinePayment(payment *registration.OfflinePayment) *OfflinePayment {
+	if payment == nil {
+		return nil
+	}
+
+	apiPayment := &OfflinePayment{
+		Method:     paymentMethodToApiPaymentMethod(payment.Method),
+		Note:       payment.Note,
+		RecordedBy: &payment.RecordedBy,
+		RecordedAt: &payment.RecordedAt,
+	}
+	if payment.Amount != nil {
+		apiPayment.Amount = &Money{
+			Amount:   int(payment.Amount.Amount()),
+			Currency: payment.Amount.Currency().Code,
+		}
+	}
+	return apiPayment
+}
+
+func apiPaymentMethodToPaymentMethod(method PaymentMethod) (registration.PaymentMethod, error) {
+	switch method {
+	case Cash:
+		return registration.PAYMENT_METHOD_CASH, nil
+	case Comp:
+		return registration.PAYMENT_METHOD_COMP, nil
+	case BankTransfer:
+		return registration.PAYMENT_METHOD_BANK_TRANSFER, nil
+	case Online:
+		return registration.PAYMENT_METHOD_ONLINE, nil
+	default:
+		return 0, fmt.Errorf("Unknown payment method: %s", method)
+	}
+}
+
+func paymentMethodToApiPaymentMethod(method registration.PaymentMethod) PaymentMethod {
+	switch method {
+	case registration.PAYMENT_METHOD_COMP:
+		return Comp
+	case registration.PAYMENT_METHOD_BANK_TRANSFER:
+		return BankTransfer
+	case registration.PAYMENT_METHOD_ONLINE:
+		return Online
+	default:
+		return Cash
+	}
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEventsV1EventIdRegistrationsManual(t *testing.T) {
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+	newBody := func(payment *OfflinePayment, ignoreCloseTime bool) *PostEventsV1EventIdRegistrationsManualJSONRequestBody {
+		reg := Registration{}
+		require.NoError(t, reg.FromIndividualRegistration(IndividualRegistration{
+			HomeCity:   "test city",
+			Email:      types.Email("walkup@test.com"),
+			PlayerInfo: PlayerInfo{FirstName: "first", LastName: "last"},
+			Experience: Novice,
+		}))
+		return &PostEventsV1EventIdRegistrationsManualJSONRequestBody{
+			Registration:    reg,
+			Payment:         payment,
+			IgnoreCloseTime: &ignoreCloseTime,
+		}
+	}
+	newMock := func() *mockDB {
+		return &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{
+					ID:                    id,
+					TimeZone:              time.UTC,
+					RegistrationCloseTime: time.Now().Add(-time.Hour),
+					RegistrationOptions:   []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(2000, "USD")}},
+				}, nil
+			},
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
+				return nil
+			},
+		}
+	}
+
+	t.Run("cash at the door after close", func(t *testing.T) {
+		api := newTestAPI(newMock())
+
+		resp, err := api.PostEventsV1EventIdRegistrationsManual(ctx, PostEventsV1EventIdRegistrationsManualRequestObject{
+			EventId: uuid.New(),
+			Body:    newBody(&OfflinePayment{Method: Cash, Amount: &Money{Amount: 2000, Currency: "USD"}, Note: "At the door"}, true),
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsManual200JSONResponse:
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, RegistrationStatusPaid, *indivReg.Status)
+			require.NotNil(t, indivReg.OfflinePayment)
+			assert.Equal(t, Cash, indivReg.OfflinePayment.Method)
+			assert.Equal(t, 2000, indivReg.OfflinePayment.Amount.Amount)
+			assert.Equal(t, "admin@example.com", *indivReg.OfflinePayment.RecordedBy)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("registration closed", func(t *testing.T) {
+		api := newTestAPI(newMock())
+
+		resp, err := api.PostEventsV1EventIdRegistrationsManual(ctx, PostEventsV1EventIdRegistrationsManualRequestObject{
+			EventId: uuid.New(),
+			Body:    newBody(&OfflinePayment{Method: Comp}, false),
+		})
+		assert.NoError(t, err)
+
+		r, ok := resp.(PostEventsV1EventIdRegistrationsManual400JSONResponse)
+		require.True(t, ok, "unexpected response type: %T", resp)
+		assert.Equal(t, RegistrationClosed, r.Code)
+	})
+}
+
+func TestPostEventsV1EventIdRegistrationsEmailPaidOffline(t *testing.T) {
+	eventId := uuid.New()
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+
+	t.Run("comp a pending registration", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.IndividualRegistration{EventID: eventId, Version: 1, Email: email}, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, reg registration.Registration, outbox []registration.OutboxItem) error {
+				return nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailPaidOffline(ctx, PostEventsV1EventIdRegistrationsEmailPaidOfflineRequestObject{
+			EventId: eventId,
+			Email:   "Player@Test.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailPaidOfflineJSONRequestBody{Method: Comp, Note: "Volunteer"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailPaidOffline200JSONResponse:
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, types.Email("player@test.com"), indivReg.Email)
+			assert.Equal(t, RegistrationStatusComped, *indivReg.Status)
+			assert.Equal(t, "Volunteer", indivReg.OfflinePayment.Note)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("missing amount", func(t *testing.T) {
+		api := newTestAPI(&mockDB{})
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailPaidOffline(ctx, PostEventsV1EventIdRegistrationsEmailPaidOfflineRequestObject{
+			EventId: eventId,
+			Email:   "player@test.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailPaidOfflineJSONRequestBody{Method: BankTransfer},
+		})
+		assert.NoError(t, err)
+
+		r, ok := resp.(PostEventsV1EventIdRegistrationsEmailPaidOffline400JSONResponse)
+		require.True(t, ok, "unexpected response type: %T", resp)
+		assert.Equal(t, InvalidOfflinePayment, r.Code)
+	})
+}
@@ -20,6 +20,9 @@ import (
 
 const (
 	accessTokenCookieKey = "ICAA_ACCESS_TOKEN"
+
+	// roleMedicalInfo lets an admin read players' emergency contacts and medical notes
+	roleMedicalInfo auth.Role = "MEDICAL_INFO"
 )
 
 var scopeValidators map[string]func(token auth.AuthToken) error = map[string]func(token auth.AuthToken) error{
@@ -29,6 +32,12 @@ var scopeValidators map[string]func(token auth.AuthToken) error = map[string]fun
 		}
 		return nil
 	},
+	"medical": func(tok auth.AuthToken) error {
+		if !slices.Contains(tok.Roles(), roleMedicalInfo) {
+			return fmt.Errorf("user can not see medical info")
+		}
+		return nil
+	},
 }
 
 func validateScopes(tok auth.AuthToken, scopes []string) error {
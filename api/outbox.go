@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"github.com/oapi-codegen/runtime/types"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) GetEventsV1AdminOutbox(ctx context.Context, request GetEventsV1AdminOutboxRequestObject) (GetEventsV1AdminOutboxResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "GetEventsV1AdminOutbox")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	status := registration.OUTBOX_DEAD
+	if request.Params.Status != nil && *request.Params.Status == OutboxItemStatusPending {
+		status = registration.OUTBOX_PENDING
+	}
+
+	items, err := a.db.GetOutboxItemsWithStatus(ctx, status)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to get outbox items", "error", err)
+
+		return GetEventsV1AdminOutbox500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to get outbox items",
+		}, nil
+	}
+
+	return GetEventsV1AdminOutbox200JSONResponse{Items: slices.Map(items, outboxItemToApiOutboxItem)}, nil
+}
+
+func (a *API) PostEventsV1AdminOutboxIdRetry(ctx context.Context, request PostEventsV1AdminOutboxIdRetryRequestObject) (PostEventsV1AdminOutboxIdRetryResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1AdminOutboxIdRetry")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	item, err := registration.RetryOutboxItem(ctx, a.db, request.Id, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to retry outbox item", "error", err, "outboxItemId", request.Id)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) && registrationErr.Reason == registration.REASON_OUTBOX_ITEM_DOES_NOT_EXIST {
+			return PostEventsV1AdminOutboxIdRetry404JSONResponse{
+				Code:    NotFound,
+				Message: "Outbox item was not found",
+			}, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1AdminOutboxIdRetry500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to retry outbox item",
+		}, nil
+	}
+
+	var retriedBy string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		retriedBy = jwt.UserEmail()
+	}
+	logger.Info("Retrying outbox item",
+		slog.String("outboxItemId", item.ID.String()),
+		slog.String("kind", item.Kind.String()),
+		slog.String("retriedBy", retriedBy))
+
+	return PostEventsV1AdminOutboxIdRetry200JSONResponse(outboxItemToApiOutboxItem(item)), nil
+}
+
+// sendConfirmationEmailNow sends a just signed up registration's confirmation email inline. It stays in the
+// outbox if it fails, so the delivery job retries it, which is why failures are only logged.
+func (a *API) sendConfirmationEmailNow(ctx context.Context, logger *slog.Logger, reg registration.Registration) {
+	report, err := registration.DeliverConfirmationEmailNow(ctx, a.db, a.db, a.db, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, a.checkInSigner, a.frontendBaseURL(), reg, time.Now())
+	if err != nil {
+		logger.Error("failed to send confirmation email now, leaving it to the outbox", slog.String("error", err.Error()), slog.String("email", reg.GetEmail()))
+	}
+	for _, item := range report.Failed {
+		logger.Warn("failed to send confirmation email now, leaving it to the outbox", slog.String("error", item.LastError), slog.String("email", reg.GetEmail()))
+	}
+}
+
+func outboxItemToApiOutboxItem(item registration.OutboxItem) OutboxItem {
+	apiItem := OutboxItem{
+		Id:            item.ID,
+		Kind:          outboxKindToApiOutboxItemKind(item.Kind),
+		EventId:       item.EventID,
+		Email:         types.Email(item.Email),
+		Status:        OutboxItemStatusPending,
+		Attempts:      item.Attempts,
+		NextAttemptAt: item.NextAttemptAt,
+		CreatedAt:     item.CreatedAt,
+	}
+	if item.Status == registration.OUTBOX_DEAD {
+		apiItem.Status = OutboxItemStatusDead
+	}
+	if item.LastError != "" {
+		apiItem.LastError = &item.LastError
+	}
+	return apiItem
+}
+
+func outboxKindToApiOutboxItemKind(kind registration.OutboxKind) OutboxItemKind {
+	switch kind {
+	case registration.OUTBOX_ROSTER_INVITATIONS:
+		return RosterInvitations
+	case registration.OUTBOX_MAILING_LIST:
+		return MailingList
+	default:
+		return ConfirmationEmail
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetEventsV1AdminOutbox(t *testing.T) {
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+	dead := registration.OutboxItem{
+		ID:        uuid.New(),
+		Version:   10,
+		Kind:      registration.OUTBOX_ROSTER_INVITATIONS,
+		EventID:   uuid.New(),
+		Email:     "captain@example.com",
+		Status:    registration.OUTBOX_DEAD,
+		Attempts:  8,
+		LastError: "mail server is down",
+	}
+
+	t.Run("dead items by default", func(t *testing.T) {
+		var requested registration.OutboxStatus
+		mock := &mockDB{
+			GetOutboxItemsWithStatusFunc: func(ctx context.Context, status registration.OutboxStatus) ([]registration.OutboxItem, error) {
+				requested = status
+				return []registration.OutboxItem{dead}, nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1AdminOutbox(ctx, GetEventsV1AdminOutboxRequestObject{})
+		require.NoError(t, err)
+
+		r, ok := resp.(GetEventsV1AdminOutbox200JSONResponse)
+		require.True(t, ok, "unexpected response %T", resp)
+		assert.Equal(t, registration.OUTBOX_DEAD, requested)
+		require.Len(t, r.Items, 1)
+		assert.Equal(t, RosterInvitations, r.Items[0].Kind)
+		assert.Equal(t, OutboxItemStatusDead, r.Items[0].Status)
+		assert.Equal(t, "mail server is down", *r.Items[0].LastError)
+	})
+
+	t.Run("pending items", func(t *testing.T) {
+		pending := OutboxItemStatusPending
+		var requested registration.OutboxStatus
+		mock := &mockDB{
+			GetOutboxItemsWithStatusFunc: func(ctx context.Context, status registration.OutboxStatus) ([]registration.OutboxItem, error) {
+				requested = status
+				return nil, nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1AdminOutbox(ctx, GetEventsV1AdminOutboxRequestObject{Params: GetEventsV1AdminOutboxParams{Status: &pending}})
+		require.NoError(t, err)
+
+		_, ok := resp.(GetEventsV1AdminOutbox200JSONResponse)
+		require.True(t, ok, "unexpected response %T", resp)
+		assert.Equal(t, registration.OUTBOX_PENDING, requested)
+	})
+}
+
+func TestPostEventsV1AdminOutboxIdRetry(t *testing.T) {
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+
+	t.Run("retried", func(t *testing.T) {
+		item := registration.OutboxItem{ID: uuid.New(), Version: 10, Status: registration.OUTBOX_DEAD, Attempts: 8}
+		var saved registration.OutboxItem
+		mock := &mockDB{
+			GetOutboxItemFunc: func(ctx context.Context, id uuid.UUID) (registration.OutboxItem, error) {
+				return item, nil
+			},
+			UpdateOutboxItemFunc: func(ctx context.Context, item registration.OutboxItem) error {
+				saved = item
+				return nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1AdminOutboxIdRetry(ctx, PostEventsV1AdminOutboxIdRetryRequestObject{Id: item.ID})
+		require.NoError(t, err)
+
+		r, ok := resp.(PostEventsV1AdminOutboxIdRetry200JSONResponse)
+		require.True(t, ok, "unexpected response %T", resp)
+		assert.Equal(t, OutboxItemStatusPending, r.Status)
+		assert.Equal(t, 0, r.Attempts)
+		assert.Equal(t, registration.OUTBOX_PENDING, saved.Status)
+		assert.WithinDuration(t, time.Now(), saved.NextAttemptAt, time.Minute)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mock := &mockDB{
+			GetOutboxItemFunc: func(ctx context.Context, id uuid.UUID) (registration.OutboxItem, error) {
+				return registration.OutboxItem{}, registration.NewOutboxItemDoesNotExistError("not found")
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1AdminOutboxIdRetry(ctx, PostEventsV1AdminOutboxIdRetryRequestObject{Id: uuid.New()})
+		require.NoError(t, err)
+
+		_, ok := resp.(PostEventsV1AdminOutboxIdRetry404JSONResponse)
+		assert.True(t, ok, "unexpected response %T", resp)
+	})
+}
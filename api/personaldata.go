@@ -0,0 +1,144 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"github.com/oapi-codegen/runtime/types"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) GetEventsV1PersonalDataEmail(ctx context.Context, request GetEventsV1PersonalDataEmailRequestObject) (GetEventsV1PersonalDataEmailResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "GetEventsV1PersonalDataEmail")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	var accessor string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		accessor = jwt.UserEmail()
+	}
+	email := strings.ToLower(string(request.Email))
+
+	// Exports have medical info in them, so they are logged like reading it is
+	logger.Info("Personal data exported",
+		slog.String("email", email),
+		slog.String("accessedBy", accessor))
+
+	data, err := registration.ExportPersonalData(ctx, email, a.db)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to export personal data", "error", err)
+
+		return GetEventsV1PersonalDataEmail500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to export personal data",
+		}, nil
+	}
+
+	apiRegs := make([]Registration, 0, len(data.Registrations))
+	for _, reg := range data.Registrations {
+		apiReg, err := registrationToAdminApiRegistration(reg)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			logger.Error("Failed to convert registration to api type", "error", err)
+
+			return GetEventsV1PersonalDataEmail500JSONResponse{
+				Code:    InternalError,
+				Message: "Failed to export personal data",
+			}, nil
+		}
+		apiRegs = append(apiRegs, apiReg)
+	}
+
+	return GetEventsV1PersonalDataEmail200JSONResponse{
+		Email:         types.Email(data.Email),
+		Registrations: apiRegs,
+		Intents:       slices.Map(data.Intents, intentToApiPersonalDataIntent),
+		PlayerEntries: slices.Map(data.PlayerEntries, playerEntryToApiPersonalDataPlayerEntry),
+	}, nil
+}
+
+func (a *API) DeleteEventsV1PersonalDataEmail(ctx context.Context, request DeleteEventsV1PersonalDataEmailRequestObject) (DeleteEventsV1PersonalDataEmailResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "DeleteEventsV1PersonalDataEmail")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	// Goes through every registration of the email and the mailing list
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var erasedBy string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		erasedBy = jwt.UserEmail()
+	}
+	email := strings.ToLower(string(request.Email))
+
+	logger.Info("Erasing personal data",
+		slog.String("email", email),
+		slog.String("erasedBy", erasedBy))
+
+	result, err := registration.ErasePersonalData(ctx, email, erasedBy, a.db, a.paymentQuerier, a.subscriberManager, time.Now())
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to erase personal data", "error", err)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) && registrationErr.Reason == registration.REASON_CHECKOUT_IN_PROGRESS {
+			return DeleteEventsV1PersonalDataEmail409JSONResponse{
+				Code:    CheckoutInProgress,
+				Message: "A registration for this email still has an open checkout, try again once it has expired",
+			}, nil
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return DeleteEventsV1PersonalDataEmail500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to erase personal data",
+		}, nil
+	}
+
+	logger.Info("Erased personal data",
+		slog.String("email", email),
+		slog.String("erasedBy", erasedBy),
+		slog.Int("anonymizedRegistrations", result.AnonymizedRegistrations),
+		slog.Int("anonymizedPlayerEntries", result.AnonymizedPlayerEntries))
+
+	return DeleteEventsV1PersonalDataEmail200JSONResponse{
+		AnonymizedRegistrations: result.AnonymizedRegistrations,
+		AnonymizedPlayerEntries: result.AnonymizedPlayerEntries,
+	}, nil
+}
+
+func intentToApiPersonalDataIntent(intent registration.RegistrationIntent) PersonalDataIntent {
+	return PersonalDataIntent{
+		EventId:          intent.EventId,
+		PaymentSessionId: intent.PaymentSessionId,
+		ExpiresAt:        intent.ExpiresAt,
+	}
+}
+
+func playerEntryToApiPersonalDataPlayerEntry(entry registration.PlayerEntry) PersonalDataPlayerEntry {
+	apiEntry := PersonalDataPlayerEntry{
+		EventId:           entry.EventID,
+		RegistrationEmail: types.Email(entry.RegistrationEmail),
+		Player:            playerInfoToApiPlayerInfo(entry.Player),
+		MedicalInfo:       playerInfoToApiPlayerMedicalInfo(entry.Player),
+	}
+	if entry.TeamName != "" {
+		apiEntry.TeamName = &entry.TeamName
+	}
+	return apiEntry
+}
@@ -0,0 +1,159 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noRegistrationIntent(ctx context.Context, eventId uuid.UUID, email string) (registration.RegistrationIntent, error) {
+	return registration.RegistrationIntent{}, registration.NewRegistrationDoesNotExistsError("not found", nil)
+}
+
+func TestGetEventsV1PersonalDataEmail(t *testing.T) {
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+	eventId := uuid.New()
+
+	t.Run("exports registrations and player entries", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]registration.Registration, error) {
+				assert.Equal(t, "jane@test.com", email)
+				return []registration.Registration{
+					&registration.TeamRegistration{
+						EventID:      eventId,
+						CaptainEmail: "captain@test.com",
+						TeamName:     "The Archers",
+						Players: []registration.PlayerInfo{
+							{FirstName: "Jane", LastName: "Doe", Email: ptr.String("jane@test.com"), MedicalNotes: "Allergic to bees"},
+						},
+					},
+				}, nil
+			},
+			GetRegistrationIntentFunc: noRegistrationIntent,
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1PersonalDataEmail(ctx, GetEventsV1PersonalDataEmailRequestObject{Email: "Jane@Test.com"})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1PersonalDataEmail200JSONResponse:
+			assert.Empty(t, r.Registrations)
+			assert.Empty(t, r.Intents)
+			require.Len(t, r.PlayerEntries, 1)
+			assert.Equal(t, "captain@test.com", string(r.PlayerEntries[0].RegistrationEmail))
+			assert.Equal(t, "The Archers", *r.PlayerEntries[0].TeamName)
+			assert.Equal(t, "Jane", r.PlayerEntries[0].Player.FirstName)
+			assert.Equal(t, "Allergic to bees", *r.PlayerEntries[0].MedicalInfo.MedicalNotes)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("db error", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]registration.Registration, error) {
+				return nil, errors.New("db down")
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.GetEventsV1PersonalDataEmail(ctx, GetEventsV1PersonalDataEmailRequestObject{Email: "jane@test.com"})
+		assert.NoError(t, err)
+		assert.IsType(t, GetEventsV1PersonalDataEmail500JSONResponse{}, resp)
+	})
+}
+
+func TestDeleteEventsV1PersonalDataEmail(t *testing.T) {
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+	eventId := uuid.New()
+	ownRegistration := func() []registration.Registration {
+		return []registration.Registration{
+			&registration.IndividualRegistration{
+				EventID:    eventId,
+				Version:    1,
+				Email:      "jane@test.com",
+				PlayerInfo: registration.PlayerInfo{FirstName: "Jane", LastName: "Doe", Email: ptr.String("jane@test.com")},
+			},
+		}
+	}
+
+	t.Run("erases and forgets the subscriber", func(t *testing.T) {
+		var anonymized registration.Registration
+		mock := &mockDB{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]registration.Registration, error) {
+				return ownRegistration(), nil
+			},
+			GetRegistrationIntentFunc: noRegistrationIntent,
+			AnonymizeRegistrationFunc: func(ctx context.Context, from registration.Registration, to registration.Registration) error {
+				anonymized = to
+				return nil
+			},
+		}
+		var forgotten string
+		subscribers := &mockSubscriberManager{
+			ForgetSubscriberFunc: func(ctx context.Context, email string) error {
+				forgotten = email
+				return nil
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.SubscriberManager = subscribers })
+
+		resp, err := api.DeleteEventsV1PersonalDataEmail(ctx, DeleteEventsV1PersonalDataEmailRequestObject{Email: "Jane@Test.com"})
+		assert.NoError(t, err)
+		assert.Equal(t, DeleteEventsV1PersonalDataEmail200JSONResponse{AnonymizedRegistrations: 1}, resp)
+		assert.Equal(t, "jane@test.com", forgotten)
+
+		require.NotNil(t, anonymized)
+		assert.True(t, strings.HasSuffix(anonymized.GetEmail(), "@erased.invalid"))
+		require.Len(t, anonymized.GetAdminNotes(), 1)
+		assert.Equal(t, "admin@example.com", anonymized.GetAdminNotes()[0].Author)
+	})
+
+	t.Run("checkout in progress", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]registration.Registration, error) {
+				return ownRegistration(), nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.RegistrationIntent, error) {
+				return registration.RegistrationIntent{EventId: eventId, Email: email}, nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.DeleteEventsV1PersonalDataEmail(ctx, DeleteEventsV1PersonalDataEmailRequestObject{Email: "jane@test.com"})
+		assert.NoError(t, err)
+		switch r := resp.(type) {
+		case DeleteEventsV1PersonalDataEmail409JSONResponse:
+			assert.Equal(t, CheckoutInProgress, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("mailing list error", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]registration.Registration, error) {
+				return ownRegistration(), nil
+			},
+			GetRegistrationIntentFunc: noRegistrationIntent,
+		}
+		subscribers := &mockSubscriberManager{
+			ForgetSubscriberFunc: func(ctx context.Context, email string) error {
+				return errors.New("mailerlite down")
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.SubscriberManager = subscribers })
+
+		resp, err := api.DeleteEventsV1PersonalDataEmail(ctx, DeleteEventsV1PersonalDataEmailRequestObject{Email: "jane@test.com"})
+		assert.NoError(t, err)
+		assert.IsType(t, DeleteEventsV1PersonalDataEmail500JSONResponse{}, resp)
+	})
+}
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"github.com/oapi-codegen/runtime/types"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) GetEventsV1EventIdPaymentsReconciliation(ctx context.Context, request GetEventsV1EventIdPaymentsReconciliationRequestObject) (GetEventsV1EventIdPaymentsReconciliationResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "GetEventsV1EventIdPaymentsReconciliation")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	// Searching the payment provider is a lot slower than the database
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	report, err := registration.ReconcilePayments(ctx, request.EventId, a.db, a.paymentQuerier)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to reconcile payments", "error", err, "eventId", request.EventId)
+
+		return GetEventsV1EventIdPaymentsReconciliation500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to reconcile payments",
+		}, nil
+	}
+
+	return GetEventsV1EventIdPaymentsReconciliation200JSONResponse(reconciliationReportToApiPaymentReconciliation(report)), nil
+}
+
+func (a *API) PostEventsV1EventIdPaymentsReconciliationFix(ctx context.Context, request PostEventsV1EventIdPaymentsReconciliationFixRequestObject) (PostEventsV1EventIdPaymentsReconciliationFixResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdPaymentsReconciliationFix")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	// Fixes and then reconciles the whole event again
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var fixedBy string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		fixedBy = jwt.UserEmail()
+	}
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	kind, err := apiPaymentMismatchKindToMismatchKind(request.Body.Kind)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("Invalid mismatch kind", "error", err)
+
+		return PostEventsV1EventIdPaymentsReconciliationFix400JSONResponse{
+			Code:    InvalidBody,
+			Message: "Invalid mismatch kind",
+		}, nil
+	}
+	email := strings.ToLower(string(request.Body.Email))
+
+	err = registration.FixPaymentMismatch(ctx, registration.FixPaymentMismatchParams{
+		EventID: request.EventId,
+		Email:   email,
+		Kind:    kind,
+		FixedBy: fixedBy,
+	}, a.db, a.paymentQuerier)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to fix payment mismatch", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_MISMATCH_NOT_FIXABLE:
+				return PostEventsV1EventIdPaymentsReconciliationFix400JSONResponse{
+					Code:    InvalidBody,
+					Message: "This mismatch needs the payment refunded or recorded offline instead",
+				}, nil
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdPaymentsReconciliationFix404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			case registration.REASON_MISMATCH_NOT_FOUND:
+				return PostEventsV1EventIdPaymentsReconciliationFix409JSONResponse{
+					Code:    AlreadyReconciled,
+					Message: registrationErr.Message,
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdPaymentsReconciliationFix500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to fix payment mismatch",
+		}, nil
+	}
+
+	logger.Info("Fixed payment mismatch",
+		slog.String("eventId", request.EventId.String()),
+		slog.String("email", email),
+		slog.String("kind", kind.String()),
+		slog.String("fixedBy", fixedBy))
+
+	report, err := registration.ReconcilePayments(ctx, request.EventId, a.db, a.paymentQuerier)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to reconcile payments", "error", err, "eventId", request.EventId)
+
+		return PostEventsV1EventIdPaymentsReconciliationFix500JSONResponse{
+			Code:    InternalError,
+			Message: "Mismatch was fixed but failed to reconcile payments again",
+		}, nil
+	}
+
+	return PostEventsV1EventIdPaymentsReconciliationFix200JSONResponse(reconciliationReportToApiPaymentReconciliation(report)), nil
+}
+
+func reconciliationReportToApiPaymentReconciliation(report registration.ReconciliationReport) PaymentReconciliation {
+	return PaymentReconciliation{
+		EventId:          report.EventID,
+		NumPayments:      report.NumPayments,
+		NumRegistrations: report.NumRegistrations,
+		NumIntents:       report.NumIntents,
+		Mismatches:       slices.Map(report.Mismatches, mismatchToApiPaymentMismatch),
+	}
+}
+
+func mismatchToApiPaymentMismatch(mismatch registration.Mismatch) PaymentMismatch {
+	apiMismatch := PaymentMismatch{
+		Kind:            mismatchKindToApiPaymentMismatchKind(mismatch.Kind),
+		Email:           types.Email(mismatch.Email),
+		Fixable:         mismatch.Kind.Fixable(),
+		IntentExpiresAt: mismatch.IntentExpiresAt,
+	}
+	if mismatch.PaymentID != "" {
+		apiMismatch.PaymentId = &mismatch.PaymentID
+	}
+	if mismatch.CheckoutSessionID != "" {
+		apiMismatch.CheckoutSessionId = &mismatch.CheckoutSessionID
+	}
+	if mismatch.Amount != nil {
+		apiMismatch.Amount = &Money{
+			Amount:   int(mismatch.Amount.Amount()),
+			Currency: mismatch.Amount.Currency().Code,
+		}
+	}
+	if mismatch.RegistrationStatus != nil {
+		apiMismatch.RegistrationStatus = statusToApiStatus(*mismatch.RegistrationStatus)
+	}
+	return apiMismatch
+}
+
+func mismatchKindToApiPaymentMismatchKind(kind registration.MismatchKind) PaymentMismatchKind {
+	switch kind {
+	case registration.MISMATCH_MARKED_PAID_WITHOUT_PAYMENT:
+		return MarkedPaidWithoutPayment
+	case registration.MISMATCH_PAYMENT_WITHOUT_REGISTRATION:
+		return PaymentWithoutRegistration
+	case registration.MISMATCH_ORPHAN_INTENT:
+		return OrphanIntent
+	default:
+		return PaidNotMarkedPaid
+	}
+}
+
+func apiPaymentMismatchKindToMismatchKind(kind PaymentMismatchKind) (registration.MismatchKind, error) {
+	switch kind {
+	case PaidNotMarkedPaid:
+		return registration.MISMATCH_PAID_NOT_MARKED_PAID, nil
+	case MarkedPaidWithoutPayment:
+		return registration.MISMATCH_MARKED_PAID_WITHOUT_PAYMENT, nil
+	case PaymentWithoutRegistration:
+		return registration.MISMATCH_PAYMENT_WITHOUT_REGISTRATION, nil
+	case OrphanIntent:
+		return registration.MISMATCH_ORPHAN_INTENT, nil
+	default:
+		return 0, fmt.Errorf("Unknown payment mismatch kind: %s", kind)
+	}
+}
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func paymentsFor(found ...payments.Payment) *mockPaymentQuerier {
+	return &mockPaymentQuerier{
+		ListChargesFunc: func(ctx context.Context, params payments.ChargeListParams) iter.Seq2[payments.Payment, error] {
+			return func(yield func(payments.Payment, error) bool) {
+				for _, p := range found {
+					if !yield(p, nil) {
+						return
+					}
+				}
+			}
+		},
+	}
+}
+
+func TestGetEventsV1EventIdPaymentsReconciliation(t *testing.T) {
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+	eventId := uuid.New()
+
+	t.Run("reports mismatches", func(t *testing.T) {
+		mock := &mockDB{
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
+				return registration.GetAllRegistrationsResponse{Data: []registration.Registration{
+					&registration.IndividualRegistration{EventID: eventId, Email: "jane@test.com", Status: registration.STATUS_PENDING},
+				}}, nil
+			},
+			GetRegistrationIntentsForEventFunc: func(ctx context.Context, id uuid.UUID) ([]registration.RegistrationIntent, error) {
+				return nil, nil
+			},
+		}
+		querier := paymentsFor(payments.Payment{
+			ID:     "pi_1",
+			Amount: money.New(5000, "USD"),
+			Metadata: map[string]string{
+				"EMAIL":     "jane@test.com",
+				"EVENT_ID":  eventId.String(),
+				"ITEM_TYPE": "event_registration",
+			},
+		})
+		api := newTestAPI(mock, func(c *Config) { c.PaymentQuerier = querier })
+
+		resp, err := api.GetEventsV1EventIdPaymentsReconciliation(ctx, GetEventsV1EventIdPaymentsReconciliationRequestObject{EventId: eventId})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1EventIdPaymentsReconciliation200JSONResponse:
+			assert.Equal(t, 1, r.NumPayments)
+			assert.Equal(t, 1, r.NumRegistrations)
+			require.Len(t, r.Mismatches, 1)
+			assert.Equal(t, PaidNotMarkedPaid, r.Mismatches[0].Kind)
+			assert.True(t, r.Mismatches[0].Fixable)
+			assert.Equal(t, "pi_1", *r.Mismatches[0].PaymentId)
+			assert.Equal(t, &Money{Amount: 5000, Currency: "USD"}, r.Mismatches[0].Amount)
+			assert.Equal(t, RegistrationStatusPending, *r.Mismatches[0].RegistrationStatus)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("payment provider error", func(t *testing.T) {
+		mock := &mockDB{
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
+				return registration.GetAllRegistrationsResponse{}, nil
+			},
+			GetRegistrationIntentsForEventFunc: func(ctx context.Context, id uuid.UUID) ([]registration.RegistrationIntent, error) {
+				return nil, nil
+			},
+		}
+		querier := &mockPaymentQuerier{
+			ListChargesFunc: func(ctx context.Context, params payments.ChargeListParams) iter.Seq2[payments.Payment, error] {
+				return func(yield func(payments.Payment, error) bool) {
+					yield(payments.Payment{}, errors.New("stripe down"))
+				}
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.PaymentQuerier = querier })
+
+		resp, err := api.GetEventsV1EventIdPaymentsReconciliation(ctx, GetEventsV1EventIdPaymentsReconciliationRequestObject{EventId: eventId})
+		assert.NoError(t, err)
+		assert.IsType(t, GetEventsV1EventIdPaymentsReconciliation500JSONResponse{}, resp)
+	})
+}
+
+func TestPostEventsV1EventIdPaymentsReconciliationFix(t *testing.T) {
+	ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+	eventId := uuid.New()
+
+	t.Run("deletes an orphan intent and reconciles again", func(t *testing.T) {
+		intents := []registration.RegistrationIntent{{EventId: eventId, Email: "jane@test.com", Version: 1}}
+		mock := &mockDB{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (registration.RegistrationIntent, error) {
+				return intents[0], nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (registration.Registration, error) {
+				return nil, registration.NewRegistrationDoesNotExistsError("not found", nil)
+			},
+			DeleteRegistrationIntentFunc: func(ctx context.Context, intent registration.RegistrationIntent) error {
+				intents = nil
+				return nil
+			},
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
+				return registration.GetAllRegistrationsResponse{}, nil
+			},
+			GetRegistrationIntentsForEventFunc: func(ctx context.Context, id uuid.UUID) ([]registration.RegistrationIntent, error) {
+				return intents, nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdPaymentsReconciliationFix(ctx, PostEventsV1EventIdPaymentsReconciliationFixRequestObject{
+			EventId: eventId,
+			Body:    &PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody{Kind: OrphanIntent, Email: "Jane@test.com"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdPaymentsReconciliationFix200JSONResponse:
+			assert.Empty(t, r.Mismatches)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("not fixable", func(t *testing.T) {
+		api := newTestAPI(&mockDB{})
+
+		resp, err := api.PostEventsV1EventIdPaymentsReconciliationFix(ctx, PostEventsV1EventIdPaymentsReconciliationFixRequestObject{
+			EventId: eventId,
+			Body:    &PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody{Kind: MarkedPaidWithoutPayment, Email: "jane@test.com"},
+		})
+		assert.NoError(t, err)
+		assert.IsType(t, PostEventsV1EventIdPaymentsReconciliationFix400JSONResponse{}, resp)
+	})
+
+	t.Run("already reconciled", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.IndividualRegistration{EventID: id, Email: email, Status: registration.STATUS_PAID}, nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdPaymentsReconciliationFix(ctx, PostEventsV1EventIdPaymentsReconciliationFixRequestObject{
+			EventId: eventId,
+			Body:    &PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody{Kind: PaidNotMarkedPaid, Email: "jane@test.com"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdPaymentsReconciliationFix409JSONResponse:
+			assert.Equal(t, AlreadyReconciled, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("registration not found", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (registration.Registration, error) {
+				return nil, registration.NewRegistrationDoesNotExistsError("not found", nil)
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdPaymentsReconciliationFix(ctx, PostEventsV1EventIdPaymentsReconciliationFixRequestObject{
+			EventId: eventId,
+			Body:    &PostEventsV1EventIdPaymentsReconciliationFixJSONRequestBody{Kind: PaidNotMarkedPaid, Email: "jane@test.com"},
+		})
+		assert.NoError(t, err)
+		assert.IsType(t, PostEventsV1EventIdPaymentsReconciliationFix404JSONResponse{}, resp)
+	})
+}
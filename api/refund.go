@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"github.com/Rhymond/go-money"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailRefund(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailRefundRequestObject) (PostEventsV1EventIdRegistrationsEmailRefundResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailRefund")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	// Refunds talk to the payment provider, so give them some more time
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var refundedBy string
+	if jwt, ok := middleware.GetJWTFromCtx(ctx); ok {
+		refundedBy = jwt.UserEmail()
+	}
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	var amount *money.Money
+	if request.Body.Amount != nil {
+		amount = money.New(int64(request.Body.Amount.Amount), request.Body.Amount.Currency)
+	}
+
+	reg, refunds, err := registration.RefundRegistration(ctx, registration.RefundParams{
+		EventID:     request.EventId,
+		Email:       strings.ToLower(string(request.Email)),
+		Amount:      amount,
+		Reason:      request.Body.Reason,
+		RefundedBy:  refundedBy,
+		ReleaseSpot: request.Body.ReleaseSpot != nil && *request.Body.ReleaseSpot,
+	}, a.db, a.db, a.paymentQuerier, a.refunder)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to refund registration", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailRefund404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			case registration.REASON_PAYMENT_NOT_FOUND:
+				return PostEventsV1EventIdRegistrationsEmailRefund404JSONResponse{
+					Code:    NotFound,
+					Message: "No payment was found for the registration",
+				}, nil
+			case registration.REASON_REGISTRATION_NOT_PAID:
+				return PostEventsV1EventIdRegistrationsEmailRefund400JSONResponse{
+					Code:    NotPaid,
+					Message: "Registration has not been paid",
+				}, nil
+			case registration.REASON_INVALID_REFUND_AMOUNT:
+				return PostEventsV1EventIdRegistrationsEmailRefund400JSONResponse{
+					Code:    InvalidRefundAmount,
+					Message: registrationErr.Message,
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailRefund500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to refund registration",
+		}, nil
+	}
+
+	respReg, err := registrationToAdminApiRegistration(reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailRefund500JSONResponse{
+			Code:    InternalError,
+			Message: "Refund was made but failed to build the response",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsEmailRefund200JSONResponse{
+		Registration: respReg,
+		Refunds:      slices.Map(refunds, refundToApiRefund),
+	}, nil
+}
+
+func refundToApiRefund(refund registration.Refund) Refund {
+	return Refund{
+		Id: refund.ID,
+		Amount: Money{
+			Amount:   int(refund.Amount.Amount()),
+			Currency: refund.Amount.Currency().Code,
+		},
+		Reason:       refund.Reason,
+		RefundedBy:   refund.RefundedBy,
+		RefundedAt:   refund.RefundedAt,
+		ReleasedSpot: refund.ReleasedSpot,
+	}
+}
+
+func refundsToApiRefunds(refunds []registration.Refund) *[]Refund {
+	if len(refunds) == 0 {
+		return nil
+	}
+	apiRefunds := slices.Map(refunds, refundToApiRefund)
+	return &apiRefunds
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostEventsV1EventIdRegistrationsEmailRefund(t *testing.T) {
+	eventId := uuid.New()
+	paymentQuerier := &mockPaymentQuerier{
+		ListChargesFunc: func(ctx context.Context, params payments.ChargeListParams) iter.Seq2[payments.Payment, error] {
+			return func(yield func(payments.Payment, error) bool) {
+				yield(payments.Payment{ID: "ch_123", Amount: money.New(5000, "USD")}, nil)
+			}
+		},
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.IndividualRegistration{EventID: eventId, Version: 1, Status: registration.STATUS_PAID, Email: email}, nil
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.PaymentQuerier = paymentQuerier })
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailRefund(ctx, PostEventsV1EventIdRegistrationsEmailRefundRequestObject{
+			EventId: eventId,
+			Email:   "test@example.com",
+			Body: &PostEventsV1EventIdRegistrationsEmailRefundJSONRequestBody{
+				Amount: &Money{Amount: 1000, Currency: "USD"},
+				Reason: "Injured",
+			},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailRefund200JSONResponse:
+			assert.Len(t, r.Refunds, 1)
+			assert.Equal(t, 1000, r.Refunds[0].Amount.Amount)
+			assert.Equal(t, "admin@example.com", r.Refunds[0].RefundedBy)
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			assert.NoError(t, err)
+			assert.Len(t, *indivReg.Refunds, 1)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("too much", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.IndividualRegistration{EventID: eventId, Version: 1, Status: registration.STATUS_PAID, Email: email}, nil
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.PaymentQuerier = paymentQuerier })
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailRefund(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsEmailRefundRequestObject{
+			EventId: eventId,
+			Email:   "test@example.com",
+			Body: &PostEventsV1EventIdRegistrationsEmailRefundJSONRequestBody{
+				Amount: &Money{Amount: 10000, Currency: "USD"},
+				Reason: "Injured",
+			},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailRefund400JSONResponse:
+			assert.Equal(t, InvalidRefundAmount, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
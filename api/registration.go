@@ -10,6 +10,7 @@ import (
 
 	"github.com/International-Combat-Archery-Alliance/email"
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
 	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
 	"github.com/google/uuid"
@@ -57,12 +58,9 @@ func (a *API) PostEventsV1EventIdRegistrations(ctx context.Context, request Post
 		}, nil
 	}
 
-	returnURL := fmt.Sprintf("https://icaa.world/events/%s/success", request.EventId)
-	if a.env == LOCAL {
-		returnURL = fmt.Sprintf("http://localhost:5173/events/%s/success", request.EventId)
-	}
+	returnURL := fmt.Sprintf("%s/events/%s/success", a.frontendBaseURL(), request.EventId)
 
-	signedUpReg, regIntent, clientSecret, _, err := registration.RegisterWithPayment(ctx, reg, a.db, a.db, a.checkoutManager, returnURL)
+	signedUpReg, regIntent, clientSecret, event, err := registration.RegisterWithPayment(ctx, reg, a.db, a.db, a.checkoutManager, returnURL)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("Error trying to register", "error", err)
@@ -86,6 +84,16 @@ func (a *API) PostEventsV1EventIdRegistrations(ctx context.Context, request Post
 					Code:    AlreadyExists,
 					Message: "Registration already exists for this email",
 				}, nil
+			case registration.REASON_PLAYER_ALREADY_REGISTERED:
+				return PostEventsV1EventIdRegistrations409JSONResponse{
+					Code:    PlayerAlreadyRegistered,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_SPLIT_PAYMENT_NOT_ALLOWED:
+				return PostEventsV1EventIdRegistrations400JSONResponse{
+					Code:    SplitPaymentNotAllowed,
+					Message: registrationErr.Message,
+				}, nil
 			}
 		}
 
@@ -108,6 +116,25 @@ func (a *API) PostEventsV1EventIdRegistrations(ctx context.Context, request Post
 		}, nil
 	}
 
+	if clientSecret == "" {
+		// Free, so it was signed up without a checkout
+		if regIntent.IsEmailVerification() {
+			err = registration.SendEmailVerificationEmail(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, signedUpReg, regIntent, event, a.frontendBaseURL())
+			if err != nil {
+				span.RecordError(err)
+				logger.Error("failed to send verification email to signed up player", slog.String("error", err.Error()), slog.String("email", reg.GetEmail()))
+			}
+
+			return PostEventsV1EventIdRegistrations202JSONResponse{Registration: respReg, ExpiresAt: regIntent.ExpiresAt}, nil
+		}
+
+		a.sendConfirmationEmailNow(ctx, logger, signedUpReg)
+
+		return PostEventsV1EventIdRegistrations201JSONResponse{Registration: respReg}, nil
+	}
+
+	a.sendSharePaymentEmails(ctx, logger, signedUpReg, event)
+
 	return PostEventsV1EventIdRegistrations200JSONResponse{Info: RegistrationPaymentInfo{Registration: respReg, ClientSecret: clientSecret, ExpiresAt: regIntent.ExpiresAt}}, nil
 }
 
@@ -150,7 +177,7 @@ func (a *API) PostEventsV1EventIdRegister(ctx context.Context, request PostEvent
 			Message: "Invalid body",
 		}, nil
 	}
-	signedUpReg, event, err := registration.AttemptRegistration(ctx, reg, a.db, a.db)
+	signedUpReg, regIntent, event, err := registration.AttemptRegistration(ctx, reg, a.db, a.db)
 	if err != nil {
 		span.RecordError(err)
 		logger.Error("Error trying to register", "error", err)
@@ -174,6 +201,16 @@ func (a *API) PostEventsV1EventIdRegister(ctx context.Context, request PostEvent
 					Code:    AlreadyExists,
 					Message: "Registration already exists for this email",
 				}, nil
+			case registration.REASON_PLAYER_ALREADY_REGISTERED:
+				return PostEventsV1EventIdRegister409JSONResponse{
+					Code:    PlayerAlreadyRegistered,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_SPLIT_PAYMENT_NOT_ALLOWED:
+				return PostEventsV1EventIdRegister400JSONResponse{
+					Code:    SplitPaymentNotAllowed,
+					Message: registrationErr.Message,
+				}, nil
 			}
 		}
 
@@ -196,19 +233,18 @@ func (a *API) PostEventsV1EventIdRegister(ctx context.Context, request PostEvent
 		}, nil
 	}
 
-	err = registration.SendRegistrationConfirmationEmail(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, signedUpReg, event)
-	if err != nil {
-		span.RecordError(err)
-		logger.Error("failed to send email to signed up player", slog.String("error", err.Error()), slog.String("email", reg.GetEmail()))
+	if regIntent.IsEmailVerification() {
+		// Everything else waits until the email is verified
+		err = registration.SendEmailVerificationEmail(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, signedUpReg, regIntent, event, a.frontendBaseURL())
+		if err != nil {
+			span.RecordError(err)
+			logger.Error("failed to send verification email to signed up player", slog.String("error", err.Error()), slog.String("email", reg.GetEmail()))
+		}
 
-		// TODO: Is there other error handling we should do here?
-		// I don't want to send a failed status code to the user
-		// because they did actually sign up succesfully still...
+		return PostEventsV1EventIdRegister202JSONResponse{Registration: respReg, ExpiresAt: regIntent.ExpiresAt}, nil
 	}
 
-	if event.MailingListGroupID != nil {
-		registration.AddToMailingList(ctx, a.subscriberManager, signedUpReg, *event.MailingListGroupID, logger)
-	}
+	a.sendConfirmationEmailNow(ctx, logger, signedUpReg)
 
 	return PostEventsV1EventIdRegister200JSONResponse{Registration: respReg}, nil
 }
@@ -222,10 +258,18 @@ func (a *API) GetEventsV1EventIdRegistrations(ctx context.Context, request GetEv
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
-	// limit is guaranteed to be non-nil from openapi doc
-	limit := *request.Params.Limit
+	params, err := apiListParamsToListParams(request.Params)
+	if err != nil {
+		span.RecordError(err)
+		logger.Warn("Invalid registration list params", "error", err)
 
-	result, err := a.db.GetAllRegistrationsForEvent(ctx, request.EventId, int32(limit), request.Params.Cursor)
+		return GetEventsV1EventIdRegistrations400JSONResponse{
+			Code:    InputValidationError,
+			Message: err.Error(),
+		}, nil
+	}
+
+	result, err := registration.ListRegistrations(ctx, a.db, request.EventId, params)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -249,7 +293,7 @@ func (a *API) GetEventsV1EventIdRegistrations(ctx context.Context, request GetEv
 
 	respRegs := []Registration{}
 	for _, v := range result.Data {
-		convReg, err := registrationToApiRegistration(v)
+		convReg, err := registrationToAdminApiRegistration(v)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
@@ -265,11 +309,84 @@ func (a *API) GetEventsV1EventIdRegistrations(ctx context.Context, request GetEv
 
 	return GetEventsV1EventIdRegistrations200JSONResponse{
 		Data:        respRegs,
+		Counts:      listCountsToApiRegistrationCounts(result.Counts),
 		Cursor:      result.Cursor,
 		HasNextPage: result.HasNextPage,
 	}, nil
 }
 
+func apiListParamsToListParams(apiParams GetEventsV1EventIdRegistrationsParams) (registration.ListParams, error) {
+	params := registration.ListParams{
+		// limit is guaranteed to be non-nil from openapi doc
+		Limit:  *apiParams.Limit,
+		Cursor: apiParams.Cursor,
+		Filter: registration.ListFilter{
+			HomeCity:        apiParams.HomeCity,
+			RegisteredAfter: apiParams.RegisteredAfter,
+			Tag:             apiParams.Tag,
+		},
+	}
+
+	if apiParams.Type != nil {
+		regType, err := apiRegistrationTypeToRegistrationType(*apiParams.Type)
+		if err != nil {
+			return registration.ListParams{}, err
+		}
+		params.Filter.Type = &regType
+	}
+	if apiParams.Status != nil {
+		status, err := apiStatusToStatus(*apiParams.Status)
+		if err != nil {
+			return registration.ListParams{}, err
+		}
+		params.Filter.Status = &status
+	}
+	if apiParams.Experience != nil {
+		experience, err := apiExperienceToExperience(*apiParams.Experience)
+		if err != nil {
+			return registration.ListParams{}, err
+		}
+		params.Filter.Experience = &experience
+	}
+	if apiParams.Search != nil {
+		params.Filter.Search = *apiParams.Search
+	}
+	params.Filter.CheckedIn = apiParams.CheckedIn
+
+	if apiParams.SortBy != nil {
+		switch *apiParams.SortBy {
+		case Email:
+			params.SortBy = registration.SORT_BY_EMAIL
+		case RegisteredAt:
+			params.SortBy = registration.SORT_BY_REGISTERED_AT
+		case Name:
+			params.SortBy = registration.SORT_BY_NAME
+		default:
+			return registration.ListParams{}, fmt.Errorf("Unknown sortBy: %s", *apiParams.SortBy)
+		}
+	}
+	params.Descending = apiParams.SortOrder != nil && *apiParams.SortOrder == Desc
+
+	return params, nil
+}
+
+func listCountsToApiRegistrationCounts(counts registration.ListCounts) RegistrationCounts {
+	byStatus := map[string]int{}
+	for status, count := range counts.ByStatus {
+		if apiStatus := statusToApiStatus(status); apiStatus != nil {
+			byStatus[string(*apiStatus)] = count
+		}
+	}
+
+	return RegistrationCounts{
+		Total:       counts.Total,
+		Individuals: counts.Individuals,
+		Teams:       counts.Teams,
+		Players:     counts.Players,
+		ByStatus:    byStatus,
+	}
+}
+
 func apiRegistrationToRegistration(apiReg Registration, eventId uuid.UUID) (registration.Registration, error) {
 	discrim, err := apiReg.Discriminator()
 	if err != nil {
@@ -280,7 +397,6 @@ func apiRegistrationToRegistration(apiReg Registration, eventId uuid.UUID) (regi
 	id := uuid.New()
 	version := 1
 	registeredAt := time.Now()
-	paid := false
 
 	switch discrim {
 	case string(ByIndividual):
@@ -300,7 +416,7 @@ func apiRegistrationToRegistration(apiReg Registration, eventId uuid.UUID) (regi
 			Version:      version,
 			RegisteredAt: registeredAt,
 			HomeCity:     apiIndivReg.HomeCity,
-			Paid:         paid,
+			Status:       registration.STATUS_PENDING,
 			Email:        strings.ToLower(string(apiIndivReg.Email)),
 			PlayerInfo:   apiPlayerInfoToPlayerInfo(apiIndivReg.PlayerInfo),
 			Experience:   experience,
@@ -318,11 +434,12 @@ func apiRegistrationToRegistration(apiReg Registration, eventId uuid.UUID) (regi
 			RegisteredAt: registeredAt,
 			HomeCity:     apiTeamReg.HomeCity,
 			TeamName:     apiTeamReg.TeamName,
-			Paid:         paid,
+			Status:       registration.STATUS_PENDING,
 			CaptainEmail: strings.ToLower(string(apiTeamReg.CaptainEmail)),
 			Players: slices.Map(apiTeamReg.Players, func(v PlayerInfo) registration.PlayerInfo {
 				return apiPlayerInfoToPlayerInfo(v)
 			}),
+			SplitPayment: apiTeamReg.SplitPayment != nil && *apiTeamReg.SplitPayment,
 		}, nil
 	default:
 		return nil, fmt.Errorf("Unknown discriminator: %s", discrim)
@@ -330,6 +447,16 @@ func apiRegistrationToRegistration(apiReg Registration, eventId uuid.UUID) (regi
 }
 
 func registrationToApiRegistration(reg registration.Registration) (Registration, error) {
+	return buildApiRegistration(reg, false)
+}
+
+// registrationToAdminApiRegistration also includes the admin notes and tags, so it must only
+// be used in responses to admins.
+func registrationToAdminApiRegistration(reg registration.Registration) (Registration, error) {
+	return buildApiRegistration(reg, true)
+}
+
+func buildApiRegistration(reg registration.Registration, forAdmin bool) (Registration, error) {
 	switch reg.Type() {
 	case events.BY_INDIVIDUAL:
 		indivReg := reg.(*registration.IndividualRegistration)
@@ -340,15 +467,24 @@ func registrationToApiRegistration(reg registration.Registration) (Registration,
 		}
 
 		apiIndivReg := IndividualRegistration{
-			Id:           &indivReg.ID,
-			EventId:      &indivReg.EventID,
-			Version:      &indivReg.Version,
-			Email:        types.Email(indivReg.Email),
-			Paid:         &indivReg.Paid,
-			RegisteredAt: &indivReg.RegisteredAt,
-			HomeCity:     indivReg.HomeCity,
-			Experience:   experience,
-			PlayerInfo:   playerInfoToApiPlayerInfo(indivReg.PlayerInfo),
+			Id:             &indivReg.ID,
+			EventId:        &indivReg.EventID,
+			Version:        &indivReg.Version,
+			Email:          types.Email(indivReg.Email),
+			Paid:           ptr.Bool(indivReg.Status == registration.STATUS_PAID),
+			Status:         statusToApiStatus(indivReg.Status),
+			StatusHistory:  statusHistoryToApiStatusHistory(indivReg.StatusHistory),
+			Refunds:        refundsToApiRefunds(indivReg.Refunds),
+			Transfers:      transfersToApiTransfers(indivReg.Transfers),
+			OfflinePayment: offlinePaymentToApiOfflinePayment(indivReg.OfflinePayment),
+			RegisteredAt:   &indivReg.RegisteredAt,
+			HomeCity:       indivReg.HomeCity,
+			Experience:     experience,
+			PlayerInfo:     playerInfoToApiPlayerInfo(indivReg.PlayerInfo),
+		}
+		if forAdmin {
+			apiIndivReg.AdminNotes = adminNotesToApiAdminNotes(indivReg.AdminNotes)
+			apiIndivReg.Tags = tagsToApiTags(indivReg.Tags)
 		}
 
 		apiReg := &Registration{}
@@ -362,17 +498,28 @@ func registrationToApiRegistration(reg registration.Registration) (Registration,
 		teamReg := reg.(*registration.TeamRegistration)
 
 		apiTeamReg := TeamRegistration{
-			Id:           &teamReg.ID,
-			EventId:      &teamReg.EventID,
-			Version:      &teamReg.Version,
-			CaptainEmail: types.Email(teamReg.CaptainEmail),
-			HomeCity:     teamReg.HomeCity,
-			Paid:         &teamReg.Paid,
-			TeamName:     teamReg.TeamName,
-			RegisteredAt: &teamReg.RegisteredAt,
+			Id:             &teamReg.ID,
+			EventId:        &teamReg.EventID,
+			Version:        &teamReg.Version,
+			CaptainEmail:   types.Email(teamReg.CaptainEmail),
+			HomeCity:       teamReg.HomeCity,
+			Paid:           ptr.Bool(teamReg.Status == registration.STATUS_PAID),
+			Status:         statusToApiStatus(teamReg.Status),
+			StatusHistory:  statusHistoryToApiStatusHistory(teamReg.StatusHistory),
+			Refunds:        refundsToApiRefunds(teamReg.Refunds),
+			Transfers:      transfersToApiTransfers(teamReg.Transfers),
+			OfflinePayment: offlinePaymentToApiOfflinePayment(teamReg.OfflinePayment),
+			TeamName:       teamReg.TeamName,
+			RegisteredAt:   &teamReg.RegisteredAt,
 			Players: slices.Map(teamReg.Players, func(v registration.PlayerInfo) PlayerInfo {
 				return playerInfoToApiPlayerInfo(v)
 			}),
+			SplitPayment:    &teamReg.SplitPayment,
+			PaymentDeadline: teamReg.PaymentDeadline,
+		}
+		if forAdmin {
+			apiTeamReg.AdminNotes = adminNotesToApiAdminNotes(teamReg.AdminNotes)
+			apiTeamReg.Tags = tagsToApiTags(teamReg.Tags)
 		}
 
 		apiReg := &Registration{}
@@ -388,18 +535,46 @@ func registrationToApiRegistration(reg registration.Registration) (Registration,
 }
 
 func apiPlayerInfoToPlayerInfo(playerInfo PlayerInfo) registration.PlayerInfo {
-	return registration.PlayerInfo{
-		FirstName: playerInfo.FirstName,
-		LastName:  playerInfo.LastName,
-		Email:     (*string)(playerInfo.Email),
+	info := registration.PlayerInfo{
+		FirstName:        playerInfo.FirstName,
+		LastName:         playerInfo.LastName,
+		Email:            (*string)(playerInfo.Email),
+		EmergencyContact: apiEmergencyContactToEmergencyContact(playerInfo.EmergencyContact),
+	}
+	if playerInfo.MedicalNotes != nil {
+		info.MedicalNotes = *playerInfo.MedicalNotes
 	}
+	return info
 }
 
 func playerInfoToApiPlayerInfo(playerInfo registration.PlayerInfo) PlayerInfo {
-	return PlayerInfo{
-		FirstName: playerInfo.FirstName,
-		LastName:  playerInfo.LastName,
-		Email:     (*types.Email)(playerInfo.Email),
+	rosterStatus := rosterStatusToApiRosterStatus(playerInfo.RosterStatus)
+
+	apiPlayerInfo := PlayerInfo{
+		FirstName:    playerInfo.FirstName,
+		LastName:     playerInfo.LastName,
+		Email:        (*types.Email)(playerInfo.Email),
+		RosterStatus: &rosterStatus,
+		InvitedAt:    playerInfo.InvitedAt,
+		ConfirmedAt:  playerInfo.ConfirmedAt,
+		ShareStatus:  shareStatusToApiShareStatus(playerInfo.ShareStatus),
+		SharePaidAt:  playerInfo.SharePaidAt,
+		CheckedInAt:  playerInfo.CheckedInAt,
+	}
+	if playerInfo.CheckedInBy != "" {
+		apiPlayerInfo.CheckedInBy = &playerInfo.CheckedInBy
+	}
+	return apiPlayerInfo
+}
+
+func rosterStatusToApiRosterStatus(status registration.RosterStatus) RosterStatus {
+	switch status {
+	case registration.ROSTER_INVITED:
+		return Invited
+	case registration.ROSTER_CONFIRMED:
+		return Confirmed
+	default:
+		return NotInvited
 	}
 }
 
@@ -35,7 +35,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 				return nil, errors.New("invalid captcha")
 			},
 		}
-		api := NewAPI(&mockDB{}, noopLogger, LOCAL, newTestTokenService(), mockCaptcha, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(&mockDB{}, func(c *Config) { c.CaptchaValidator = mockCaptcha; c.CheckoutManager = &mockCheckoutManagerReg{} })
 		reg := Registration{}
 		indivReg := IndividualRegistration{
 			HomeCity:   "test city",
@@ -65,7 +65,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 	})
 
 	t.Run("invalid body", func(t *testing.T) {
-		api := NewAPI(&mockDB{}, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(&mockDB{}, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		reg := Registration{}
 		// Set a field that will cause the discriminator to fail
 		reg.FromIndividualRegistration(IndividualRegistration{})
@@ -93,7 +93,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 				return events.Event{}, &events.Error{Reason: events.REASON_EVENT_DOES_NOT_EXIST}
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		reg := &Registration{}
 		indivReg := IndividualRegistration{
 			HomeCity:   "test city",
@@ -124,11 +124,11 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
 				return events.Event{RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(10000, "USD")}}, RegistrationCloseTime: time.Now().Add(time.Hour * 1000)}, nil
 			},
-			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event) error {
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
 				return &registration.Error{Reason: registration.REASON_REGISTRATION_ALREADY_EXISTS}
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		reg := Registration{}
 		indivReg := IndividualRegistration{
 			HomeCity:   "test city",
@@ -154,16 +154,52 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 		}
 	})
 
+	t.Run("player already registered", func(t *testing.T) {
+		mock := &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(10000, "USD")}}, RegistrationCloseTime: time.Now().Add(time.Hour * 1000)}, nil
+			},
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
+				return registration.NewPlayerAlreadyRegisteredError("test@test.com", "captain@test.com", nil)
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
+		reg := Registration{}
+		indivReg := IndividualRegistration{
+			HomeCity:   "test city",
+			Email:      types.Email("test@test.com"),
+			PlayerInfo: PlayerInfo{FirstName: "first", LastName: "last"},
+			Experience: Novice,
+		}
+		reg.FromIndividualRegistration(indivReg)
+
+		req := PostEventsV1EventIdRegisterRequestObject{
+			EventId: uuid.New(),
+			Body:    &reg,
+		}
+
+		resp, err := api.PostEventsV1EventIdRegister(ctxWithLogger(context.Background(), noopLogger), req)
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegister409JSONResponse:
+			assert.Equal(t, PlayerAlreadyRegistered, r.Code)
+			assert.Contains(t, r.Message, "captain@test.com")
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
 	t.Run("registration is closed", func(t *testing.T) {
 		mock := &mockDB{
 			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
 				return events.Event{RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(5500, "USD")}}}, nil
 			},
-			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event) error {
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
 				return &registration.Error{Reason: registration.REASON_REGISTRATION_IS_CLOSED}
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		reg := Registration{}
 		indivReg := IndividualRegistration{
 			HomeCity:   "test city",
@@ -195,7 +231,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 				return events.Event{}, errors.New("some error")
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		reg := Registration{}
 		indivReg := IndividualRegistration{
 			HomeCity:   "test city",
@@ -231,7 +267,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
 				return events.Event{RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(10000, "USD")}}, RegistrationCloseTime: time.Now().Add(time.Hour * 1000)}, nil
 			},
-			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event) error {
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
 				// Verify that PlayerInfo email is preserved in the domain model
 				indivReg := reg.(*registration.IndividualRegistration)
 				assert.NotNil(t, indivReg.PlayerInfo.Email)
@@ -239,7 +275,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 				return nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), mockCaptcha, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CaptchaValidator = mockCaptcha; c.CheckoutManager = &mockCheckoutManagerReg{} })
 
 		// Create registration with player email using API types
 		playerEmail := types.Email("player@example.com")
@@ -287,14 +323,14 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
 				return events.Event{RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(10000, "USD")}}, RegistrationCloseTime: time.Now().Add(time.Hour * 1000)}, nil
 			},
-			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event) error {
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
 				// Verify that PlayerInfo email is nil in the domain model
 				indivReg := reg.(*registration.IndividualRegistration)
 				assert.Nil(t, indivReg.PlayerInfo.Email)
 				return nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), mockCaptcha, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CaptchaValidator = mockCaptcha; c.CheckoutManager = &mockCheckoutManagerReg{} })
 
 		// Create registration without player email
 		reg := Registration{}
@@ -344,7 +380,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 					RegistrationCloseTime: time.Now().Add(time.Hour * 1000),
 				}, nil
 			},
-			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event) error {
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
 				// Verify that PlayerInfo emails are preserved correctly in domain model
 				teamReg := reg.(*registration.TeamRegistration)
 				require.Len(t, teamReg.Players, 3)
@@ -354,7 +390,7 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 				return nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), mockCaptcha, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CaptchaValidator = mockCaptcha; c.CheckoutManager = &mockCheckoutManagerReg{} })
 
 		// Create team registration with mixed player emails using API types
 		player1Email := types.Email("player1@example.com")
@@ -411,6 +447,114 @@ func TestPostEventsEventIdRegister(t *testing.T) {
 	})
 }
 
+func TestPostEventsV1EventIdRegistrations(t *testing.T) {
+	mixedEvent := events.Event{
+		RegistrationOptions: []events.EventRegistrationOption{
+			{RegType: events.BY_INDIVIDUAL, Price: money.New(0, "USD")},
+			{RegType: events.BY_TEAM, Price: money.New(10000, "USD")},
+		},
+		AllowedTeamSizeRange:  events.Range{Min: 1, Max: 5},
+		RegistrationCloseTime: time.Now().Add(time.Hour * 1000),
+	}
+
+	t.Run("free sign up needs no payment", func(t *testing.T) {
+		var saved registration.Registration
+		var savedOutbox, deleted []registration.OutboxItem
+		mock := &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return mixedEvent, nil
+			},
+			CreateRegistrationFunc: func(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
+				saved = reg
+				savedOutbox = outbox
+				return nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return saved, nil
+			},
+			GetDueOutboxItemsFunc: func(ctx context.Context, now time.Time) ([]registration.OutboxItem, error) {
+				return savedOutbox, nil
+			},
+			DeleteOutboxItemFunc: func(ctx context.Context, item registration.OutboxItem) error {
+				deleted = append(deleted, item)
+				return nil
+			},
+		}
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				t.Fatal("should not create a checkout for a free sign up")
+				return payments.CheckoutInfo{}, nil
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = checkoutManager })
+		reg := Registration{}
+		require.NoError(t, reg.FromIndividualRegistration(IndividualRegistration{
+			HomeCity:   "test city",
+			Email:      types.Email("test@test.com"),
+			PlayerInfo: PlayerInfo{FirstName: "first", LastName: "last"},
+			Experience: Novice,
+		}))
+
+		resp, err := api.PostEventsV1EventIdRegistrations(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsRequestObject{
+			EventId: uuid.New(),
+			Body:    &reg,
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrations201JSONResponse:
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, types.Email("test@test.com"), indivReg.Email)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+		// The confirmation email went out with the response, the rest is left to the outbox
+		require.Len(t, deleted, 1)
+		assert.Equal(t, registration.OUTBOX_CONFIRMATION_EMAIL, deleted[0].Kind)
+	})
+
+	t.Run("paid type of a mixed event checks out", func(t *testing.T) {
+		mock := &mockDB{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return mixedEvent, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.RegistrationIntent, error) {
+				return registration.RegistrationIntent{}, registration.NewRegistrationDoesNotExistsError("no intent", nil)
+			},
+			CreateRegistrationWithPaymentFunc: func(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent, event events.Event) error {
+				return nil
+			},
+		}
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				return payments.CheckoutInfo{SessionId: "cs_123", ClientSecret: "secret"}, nil
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = checkoutManager })
+		reg := Registration{}
+		require.NoError(t, reg.FromTeamRegistration(TeamRegistration{
+			HomeCity:     "test city",
+			TeamName:     "team",
+			CaptainEmail: types.Email("captain@test.com"),
+			Players:      []PlayerInfo{{FirstName: "first", LastName: "last"}},
+		}))
+
+		resp, err := api.PostEventsV1EventIdRegistrations(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsRequestObject{
+			EventId: uuid.New(),
+			Body:    &reg,
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrations200JSONResponse:
+			assert.Equal(t, "secret", r.Info.ClientSecret)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
+
 func TestGetEventsEventIdRegistrations(t *testing.T) {
 	t.Run("internal server error", func(t *testing.T) {
 		mock := &mockDB{
@@ -418,7 +562,7 @@ func TestGetEventsEventIdRegistrations(t *testing.T) {
 				return registration.GetAllRegistrationsResponse{}, errors.New("some error")
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		req := GetEventsV1EventIdRegistrationsRequestObject{
 			EventId: uuid.New(),
 			Params: GetEventsV1EventIdRegistrationsParams{
@@ -443,7 +587,7 @@ func TestGetEventsEventIdRegistrations(t *testing.T) {
 				return registration.GetAllRegistrationsResponse{}, &registration.Error{Reason: registration.REASON_INVALID_CURSOR}
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		req := GetEventsV1EventIdRegistrationsRequestObject{
 			EventId: uuid.New(),
 			Params: GetEventsV1EventIdRegistrationsParams{
@@ -475,7 +619,7 @@ func TestGetEventsEventIdRegistrations(t *testing.T) {
 				}, nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		req := GetEventsV1EventIdRegistrationsRequestObject{
 			EventId: uuid.New(),
 			Params: GetEventsV1EventIdRegistrationsParams{
@@ -507,7 +651,7 @@ func TestGetEventsEventIdRegistrations(t *testing.T) {
 				}, nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		req := GetEventsV1EventIdRegistrationsRequestObject{
 			EventId: uuid.New(),
 			Params: GetEventsV1EventIdRegistrationsParams{
@@ -526,6 +670,48 @@ func TestGetEventsEventIdRegistrations(t *testing.T) {
 		}
 	})
 
+	t.Run("filters and counts", func(t *testing.T) {
+		mock := &mockDB{
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, eventID uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
+				return registration.GetAllRegistrationsResponse{
+					Data: []registration.Registration{
+						&registration.IndividualRegistration{Email: "a@test.com", Status: registration.STATUS_PAID},
+						&registration.IndividualRegistration{Email: "b@test.com", Status: registration.STATUS_PENDING},
+						&registration.TeamRegistration{CaptainEmail: "c@test.com", TeamName: "Team", Status: registration.STATUS_PAID},
+					},
+				}, nil
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
+		status := RegistrationStatusPaid
+		sortOrder := Desc
+		req := GetEventsV1EventIdRegistrationsRequestObject{
+			EventId: uuid.New(),
+			Params: GetEventsV1EventIdRegistrationsParams{
+				Limit:     ptr.Int(1),
+				Status:    &status,
+				SortOrder: &sortOrder,
+			},
+		}
+
+		resp, err := api.GetEventsV1EventIdRegistrations(ctxWithLogger(context.Background(), noopLogger), req)
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case GetEventsV1EventIdRegistrations200JSONResponse:
+			require.Len(t, r.Data, 1)
+			teamReg, err := r.Data[0].AsTeamRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, "c@test.com", string(teamReg.CaptainEmail))
+			assert.True(t, r.HasNextPage)
+			assert.Equal(t, 2, r.Counts.Total)
+			assert.Equal(t, 1, r.Counts.Teams)
+			assert.Equal(t, map[string]int{"Paid": 2}, r.Counts.ByStatus)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
 	t.Run("success with player emails in response", func(t *testing.T) {
 		mock := &mockDB{
 			GetAllRegistrationsForEventFunc: func(ctx context.Context, eventID uuid.UUID, limit int32, cursor *string) (registration.GetAllRegistrationsResponse, error) {
@@ -552,7 +738,7 @@ func TestGetEventsEventIdRegistrations(t *testing.T) {
 				}, nil
 			},
 		}
-		api := NewAPI(mock, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, &mockCheckoutManagerReg{}, func(context.Context) error { return nil })
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = &mockCheckoutManagerReg{} })
 		req := GetEventsV1EventIdRegistrationsRequestObject{
 			EventId: uuid.New(),
 			Params: GetEventsV1EventIdRegistrationsParams{
@@ -591,11 +777,11 @@ func TestGetEventsEventIdRegistrations(t *testing.T) {
 }
 
 type mockRegistration struct {
-	GetEventIDFunc  func() uuid.UUID
-	GetEmailFunc    func() string
-	TypeFunc        func() events.RegistrationType
-	SetToPaidFunc   func()
-	BumpVersionFunc func()
+	GetEventIDFunc   func() uuid.UUID
+	GetEmailFunc     func() string
+	TypeFunc         func() events.RegistrationType
+	TransitionToFunc func(status registration.Status, changedBy string, reason string) error
+	BumpVersionFunc  func()
 }
 
 func (m *mockRegistration) GetEventID() uuid.UUID {
@@ -610,10 +796,19 @@ func (m *mockRegistration) Type() events.RegistrationType {
 	return m.TypeFunc()
 }
 
-func (m *mockRegistration) SetToPaid() {
-	if m.SetToPaidFunc != nil {
-		m.SetToPaidFunc()
+func (m *mockRegistration) GetStatus() registration.Status {
+	return registration.STATUS_PENDING
+}
+
+func (m *mockRegistration) GetStatusHistory() []registration.StatusChange {
+	return nil
+}
+
+func (m *mockRegistration) TransitionTo(status registration.Status, changedBy string, reason string) error {
+	if m.TransitionToFunc != nil {
+		return m.TransitionToFunc(status, changedBy, reason)
 	}
+	return nil
 }
 
 func (m *mockRegistration) BumpVersion() {
@@ -621,3 +816,63 @@ func (m *mockRegistration) BumpVersion() {
 		m.BumpVersionFunc()
 	}
 }
+
+func (m *mockRegistration) GetVersion() int {
+	return 0
+}
+
+func (m *mockRegistration) GetRefunds() []registration.Refund {
+	return nil
+}
+
+func (m *mockRegistration) AddRefund(refund registration.Refund) {}
+
+func (m *mockRegistration) GetDuplicatePlayerEmails() []string {
+	return nil
+}
+
+func (m *mockRegistration) AllowDuplicatePlayer(email string) {}
+
+func (m *mockRegistration) GetTransfers() []registration.Transfer {
+	return nil
+}
+
+func (m *mockRegistration) AddTransfer(transfer registration.Transfer) {}
+
+func (m *mockRegistration) GetPendingTransfer() *registration.PendingTransfer {
+	return nil
+}
+
+func (m *mockRegistration) SetPendingTransfer(pending *registration.PendingTransfer) {}
+
+func (m *mockRegistration) GetOfflinePayment() *registration.OfflinePayment {
+	return nil
+}
+
+func (m *mockRegistration) SetOfflinePayment(payment registration.OfflinePayment) {}
+
+func (m *mockRegistration) GetPaymentIDs() []string {
+	return nil
+}
+
+func (m *mockRegistration) SetPaymentIDs(ids []string) {}
+
+func (m *mockRegistration) GetAdminNotes() []registration.AdminNote {
+	return nil
+}
+
+func (m *mockRegistration) AddAdminNote(note registration.AdminNote) {}
+
+func (m *mockRegistration) RemoveAdminNote(id uuid.UUID) bool {
+	return false
+}
+
+func (m *mockRegistration) GetTags() []string {
+	return nil
+}
+
+func (m *mockRegistration) AddTag(tag string) {}
+
+func (m *mockRegistration) RemoveTag(tag string) bool {
+	return false
+}
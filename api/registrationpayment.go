@@ -6,7 +6,6 @@ import (
 	"log/slog"
 	"net/http"
 
-	"github.com/International-Combat-Archery-Alliance/email"
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
 	"github.com/International-Combat-Archery-Alliance/middleware"
 	"github.com/International-Combat-Archery-Alliance/payments"
@@ -33,7 +32,7 @@ func (a *API) stripeRegistrationPaymentWebhookMiddleware(path string) middleware
 			return
 		}
 
-		reg, err := registration.ConfirmRegistrationPayment(ctx, payload, r.Header.Get("Stripe-Signature"), a.db, a.db, a.checkoutManager)
+		reg, err := registration.ConfirmRegistrationPayment(ctx, payload, r.Header.Get("Stripe-Signature"), a.db, a.db, a.db, a.checkoutManager)
 		if err != nil {
 			var regErr *registration.Error
 			if errors.As(err, &regErr) {
@@ -48,6 +47,22 @@ func (a *API) stripeRegistrationPaymentWebhookMiddleware(path string) middleware
 					}
 					logger.Info("Registration expired", logArgs...)
 
+					w.WriteHeader(http.StatusOK)
+					return
+				case registration.REASON_SHARE_CHECKOUT_EXPIRED:
+					logger.Info("Share checkout expired", slog.String("error", err.Error()))
+					w.WriteHeader(http.StatusOK)
+					return
+				case registration.REASON_TRANSFER_CHECKOUT_EXPIRED:
+					logger.Info("Transfer checkout expired, the registration was not moved", slog.String("error", err.Error()))
+					w.WriteHeader(http.StatusOK)
+					return
+				case registration.REASON_WEBHOOK_EVENT_ALREADY_HANDLED:
+					logger.Info("Got a webhook event that was already handled, ignoring", slog.String("error", err.Error()))
+					w.WriteHeader(http.StatusOK)
+					return
+				case registration.REASON_STALE_WEBHOOK_EVENT:
+					logger.Info("Got an expiry for a checkout the registration isn't waiting on, ignoring", slog.String("error", err.Error()))
 					w.WriteHeader(http.StatusOK)
 					return
 				case registration.REASON_WRONG_TRANSACTION_TYPE:
@@ -74,30 +89,23 @@ func (a *API) stripeRegistrationPaymentWebhookMiddleware(path string) middleware
 			return
 		}
 
-		event, err := a.db.GetEvent(ctx, reg.GetEventID())
-		if err != nil {
-			span.RecordError(err)
-			logger.Error("Failed to get event ID to send email with", slog.String("error", err.Error()))
-
-			// TODO: Probably want better error handling here
+		if isAwaitingShares(reg) {
+			// The team isn't signed up until every share is paid
+			logger.Info("Share paid", slog.String("eventId", reg.GetEventID().String()), slog.String("email", reg.GetEmail()))
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 
-		err = registration.SendRegistrationConfirmationEmail(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, reg, event)
-		if err != nil {
-			span.RecordError(err)
-			logger.Error("failed to send email to signed up player", slog.String("error", err.Error()), slog.String("email", reg.GetEmail()))
-
-			// TODO: Is there other error handling we should do here?
-			// I don't want to send a failed status code to the user
-			// because they did actually sign up succesfully still...
-		}
-
-		if event.MailingListGroupID != nil {
-			registration.AddToMailingList(ctx, a.subscriberManager, reg, *event.MailingListGroupID, logger)
+		if len(reg.GetTransfers()) > 0 {
+			// Transferred registrations can only have a checkout for the price difference, and
+			// the confirmation for where it moved to is delivered from the outbox
+			logger.Info("Transfer price difference paid, moved the registration", slog.String("eventId", reg.GetEventID().String()), slog.String("email", reg.GetEmail()))
+			w.WriteHeader(http.StatusOK)
+			return
 		}
 
+		// The confirmation email and everything else are delivered from the outbox
+		logger.Info("Registration paid", slog.String("eventId", reg.GetEventID().String()), slog.String("email", reg.GetEmail()))
 		w.WriteHeader(http.StatusOK)
 	})
 
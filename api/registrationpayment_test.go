@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
@@ -25,14 +26,14 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 			EventID: eventID,
 			Email:   email,
 			Version: 1,
-			Paid:    false,
+			Status:    registration.STATUS_PENDING,
 		}
 
 		mockDB := &mockDB{
 			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, regEmail string) (registration.Registration, error) {
 				return reg, nil
 			},
-			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration registration.Registration) error {
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration registration.Registration, outbox []registration.OutboxItem) error {
 				return nil
 			},
 			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
@@ -52,7 +53,7 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 			},
 		}
 
-		api := NewAPI(mockDB, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, mockCheckout, func(context.Context) error { return nil })
+		api := newTestAPI(mockDB, func(c *Config) { c.CheckoutManager = mockCheckout })
 
 		// Create a test server with the middleware
 		middleware := api.stripeRegistrationPaymentWebhookMiddleware("/test/webhook")
@@ -77,7 +78,7 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 			},
 		}
 
-		api := NewAPI(mockDB, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, mockCheckout, func(context.Context) error { return nil })
+		api := newTestAPI(mockDB, func(c *Config) { c.CheckoutManager = mockCheckout })
 
 		middleware := api.stripeRegistrationPaymentWebhookMiddleware("/test/webhook")
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -101,7 +102,7 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 			},
 		}
 
-		api := NewAPI(mockDB, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, mockCheckout, func(context.Context) error { return nil })
+		api := newTestAPI(mockDB, func(c *Config) { c.CheckoutManager = mockCheckout })
 
 		middleware := api.stripeRegistrationPaymentWebhookMiddleware("/test/webhook")
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -121,7 +122,7 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 		mockDB := &mockDB{}
 		mockCheckout := &mockCheckoutManager{}
 
-		api := NewAPI(mockDB, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, mockCheckout, func(context.Context) error { return nil })
+		api := newTestAPI(mockDB, func(c *Config) { c.CheckoutManager = mockCheckout })
 
 		middleware := api.stripeRegistrationPaymentWebhookMiddleware("/test/webhook")
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -140,7 +141,7 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 		mockDB := &mockDB{}
 		mockCheckout := &mockCheckoutManager{}
 
-		api := NewAPI(mockDB, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, mockCheckout, func(context.Context) error { return nil })
+		api := newTestAPI(mockDB, func(c *Config) { c.CheckoutManager = mockCheckout })
 
 		middleware := api.stripeRegistrationPaymentWebhookMiddleware("/test/webhook")
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -176,7 +177,7 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 			},
 		}
 
-		api := NewAPI(mockDB, noopLogger, LOCAL, newTestTokenService(), &mockCaptchaValidator{}, &mockEmailSender{}, &mockSubscriberManager{}, mockCheckout, func(context.Context) error { return nil })
+		api := newTestAPI(mockDB, func(c *Config) { c.CheckoutManager = mockCheckout })
 
 		middleware := api.stripeRegistrationPaymentWebhookMiddleware("/test/webhook")
 		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -191,4 +192,40 @@ func TestStripeRegistrationPaymentWebhookMiddleware(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code) // Should return OK for expired registrations
 	})
+
+	t.Run("duplicate webhook event is acknowledged without handling it again", func(t *testing.T) {
+		eventID := uuid.New()
+
+		// Reading or writing registrations, events or emails would panic on the unset funcs
+		mockDB := &mockDB{
+			ClaimWebhookEventFunc: func(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error {
+				assert.Equal(t, "evt_1", webhookEventId)
+				return registration.NewWebhookEventAlreadyHandledError("already handled")
+			},
+		}
+		mockCheckout := &mockCheckoutManager{
+			ConfirmCheckoutFunc: func(ctx context.Context, payload []byte, signature string) (map[string]string, error) {
+				return map[string]string{
+					"EMAIL":     "duplicate@example.com",
+					"EVENT_ID":  eventID.String(),
+					"ITEM_TYPE": "event_registration",
+				}, nil
+			},
+		}
+
+		api := newTestAPI(mockDB, func(c *Config) { c.CheckoutManager = mockCheckout })
+
+		middleware := api.stripeRegistrationPaymentWebhookMiddleware("/test/webhook")
+		handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+
+		req := httptest.NewRequest("POST", "/test/webhook", strings.NewReader(`{"id": "evt_1", "data": {"object": {"id": "cs_1"}}}`))
+		req.Header.Set("Stripe-Signature", "test_signature")
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
 }
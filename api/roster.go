@@ -0,0 +1,118 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"github.com/oapi-codegen/runtime/types"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailRosterConfirm(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailRosterConfirmRequestObject) (PostEventsV1EventIdRegistrationsEmailRosterConfirmResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailRosterConfirm")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	teamReg, player, err := registration.ConfirmRosterSpot(ctx, a.db, request.EventId, strings.ToLower(string(request.Email)), request.Body.Token)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to confirm roster spot", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST, registration.REASON_INVALID_ROSTER_INVITE:
+				return PostEventsV1EventIdRegistrationsEmailRosterConfirm404JSONResponse{
+					Code:    NotFound,
+					Message: "No invitation was found for this team",
+				}, nil
+			case registration.REASON_NOT_A_TEAM_REGISTRATION:
+				return PostEventsV1EventIdRegistrationsEmailRosterConfirm400JSONResponse{
+					Code:    InvalidBody,
+					Message: "Registration is not a team registration",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailRosterConfirm500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to confirm roster spot",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsEmailRosterConfirm200JSONResponse{
+		TeamName: teamReg.TeamName,
+		Player:   playerInfoToApiPlayerInfo(player),
+	}, nil
+}
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailRosterInvitations(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailRosterInvitationsRequestObject) (PostEventsV1EventIdRegistrationsEmailRosterInvitationsResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailRosterInvitations")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	captainEmail := strings.ToLower(string(request.Email))
+
+	jwt, ok := middleware.GetJWTFromCtx(ctx)
+	if !ok || (!jwt.IsAdmin() && !strings.EqualFold(jwt.UserEmail(), captainEmail)) {
+		logger.Warn("User tried to resend roster invitations for a team they are not the captain of")
+
+		return PostEventsV1EventIdRegistrationsEmailRosterInvitations403JSONResponse{
+			Code:    Forbidden,
+			Message: "Only the team captain can resend roster invitations",
+		}, nil
+	}
+
+	var playerEmails []string
+	if request.Body != nil && request.Body.PlayerEmails != nil {
+		playerEmails = slices.Map(*request.Body.PlayerEmails, func(e types.Email) string {
+			return string(e)
+		})
+	}
+
+	_, numInvited, err := registration.ResendRosterInvitations(ctx, a.db, a.db, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, request.EventId, captainEmail, playerEmails, a.frontendBaseURL())
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to resend roster invitations", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailRosterInvitations404JSONResponse{
+					Code:    NotFound,
+					Message: "Team registration was not found",
+				}, nil
+			case registration.REASON_NOT_A_TEAM_REGISTRATION:
+				return PostEventsV1EventIdRegistrationsEmailRosterInvitations400JSONResponse{
+					Code:    InvalidBody,
+					Message: "Registration is not a team registration",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailRosterInvitations500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to resend roster invitations",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsEmailRosterInvitations200JSONResponse{NumInvited: numInvited}, nil
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRosterTeamRegistration(eventId uuid.UUID) *registration.TeamRegistration {
+	return &registration.TeamRegistration{
+		EventID:      eventId,
+		Version:      1,
+		CaptainEmail: "captain@example.com",
+		TeamName:     "Team",
+		Players: []registration.PlayerInfo{
+			{FirstName: "Captain", Email: ptr.String("captain@example.com"), RosterStatus: registration.ROSTER_CONFIRMED, InviteToken: "a"},
+			{FirstName: "Player", Email: ptr.String("player@example.com"), RosterStatus: registration.ROSTER_INVITED, InviteToken: "b"},
+		},
+	}
+}
+
+func TestPostEventsV1EventIdRegistrationsEmailRosterConfirm(t *testing.T) {
+	eventId := uuid.New()
+
+	t.Run("success", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return newRosterTeamRegistration(eventId), nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailRosterConfirm(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsEmailRosterConfirmRequestObject{
+			EventId: eventId,
+			Email:   "Captain@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONRequestBody{Token: "b"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailRosterConfirm200JSONResponse:
+			assert.Equal(t, "Team", r.TeamName)
+			assert.Equal(t, "Player", r.Player.FirstName)
+			assert.Equal(t, Confirmed, *r.Player.RosterStatus)
+			assert.NotNil(t, r.Player.ConfirmedAt)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		mock := &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return newRosterTeamRegistration(eventId), nil
+			},
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailRosterConfirm(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsEmailRosterConfirmRequestObject{
+			EventId: eventId,
+			Email:   "captain@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailRosterConfirmJSONRequestBody{Token: "wrong"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailRosterConfirm404JSONResponse:
+			assert.Equal(t, NotFound, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
+
+func TestPostEventsV1EventIdRegistrationsEmailRosterInvitations(t *testing.T) {
+	eventId := uuid.New()
+	mock := &mockDB{
+		GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+			return newRosterTeamRegistration(eventId), nil
+		},
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Name: "Event"}, nil
+		},
+	}
+
+	t.Run("captain can resend", func(t *testing.T) {
+		api := newTestAPI(mock)
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "captain@example.com", false)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailRosterInvitations(ctx, PostEventsV1EventIdRegistrationsEmailRosterInvitationsRequestObject{
+			EventId: eventId,
+			Email:   "captain@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONRequestBody{},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailRosterInvitations200JSONResponse:
+			assert.Equal(t, 1, r.NumInvited)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("other users cannot resend", func(t *testing.T) {
+		api := newTestAPI(mock)
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "someone@example.com", false)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailRosterInvitations(ctx, PostEventsV1EventIdRegistrationsEmailRosterInvitationsRequestObject{
+			EventId: eventId,
+			Email:   "captain@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailRosterInvitationsJSONRequestBody{},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailRosterInvitations403JSONResponse:
+			assert.Equal(t, Forbidden, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailSharesCheckout(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject) (PostEventsV1EventIdRegistrationsEmailSharesCheckoutResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailSharesCheckout")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	returnURL := fmt.Sprintf("%s/events/%s/success", a.frontendBaseURL(), request.EventId)
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	checkout, err := registration.CreateShareCheckout(ctx, a.db, a.db, a.checkoutManager, request.EventId, strings.ToLower(string(request.Email)), request.Body.Token, returnURL)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to create share checkout", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST, registration.REASON_INVALID_ROSTER_INVITE:
+				return PostEventsV1EventIdRegistrationsEmailSharesCheckout404JSONResponse{
+					Code:    NotFound,
+					Message: "No share was found for this team",
+				}, nil
+			case registration.REASON_NOT_A_TEAM_REGISTRATION, registration.REASON_SPLIT_PAYMENT_NOT_ALLOWED:
+				return PostEventsV1EventIdRegistrationsEmailSharesCheckout400JSONResponse{
+					Code:    SplitPaymentNotAllowed,
+					Message: "Registration is not a team splitting the payment",
+				}, nil
+			case registration.REASON_SHARE_EXPIRED:
+				return PostEventsV1EventIdRegistrationsEmailSharesCheckout403JSONResponse{
+					Code:    ShareExpired,
+					Message: "Share expired, the team captain has to pay it now",
+				}, nil
+			case registration.REASON_SHARE_ALREADY_PAID:
+				return PostEventsV1EventIdRegistrationsEmailSharesCheckout409JSONResponse{
+					Code:    AlreadyPaid,
+					Message: "Share has already been paid",
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailSharesCheckout500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to create share checkout",
+		}, nil
+	}
+
+	return PostEventsV1EventIdRegistrationsEmailSharesCheckout200JSONResponse{
+		ClientSecret: checkout.ClientSecret,
+		ExpiresAt:    checkout.ExpiresAt,
+		Amount: Money{
+			Amount:   int(checkout.Amount.Amount()),
+			Currency: checkout.Amount.Currency().Code,
+		},
+	}, nil
+}
+
+// sendSharePaymentEmails emails the players on a newly signed up team a link to pay their share, if it is splitting the payment.
+func (a *API) sendSharePaymentEmails(ctx context.Context, logger *slog.Logger, reg registration.Registration, event events.Event) {
+	teamReg, ok := reg.(*registration.TeamRegistration)
+	if !ok || !teamReg.SplitPayment {
+		return
+	}
+
+	err := registration.SendSharePaymentEmails(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, teamReg, event, a.frontendBaseURL())
+	if err != nil {
+		logger.Error("failed to send share payment emails", slog.String("error", err.Error()), slog.String("email", reg.GetEmail()))
+	}
+}
+
+// isAwaitingShares is true for a team that is splitting the payment and still has shares to be paid.
+func isAwaitingShares(reg registration.Registration) bool {
+	teamReg, ok := reg.(*registration.TeamRegistration)
+	return ok && teamReg.SplitPayment && teamReg.Status != registration.STATUS_PAID
+}
+
+func shareStatusToApiShareStatus(status registration.ShareStatus) *ShareStatus {
+	var apiStatus ShareStatus
+	switch status {
+	case registration.SHARE_UNPAID:
+		apiStatus = ShareStatusUnpaid
+	case registration.SHARE_PAID:
+		apiStatus = ShareStatusPaid
+	case registration.SHARE_EXPIRED:
+		apiStatus = ShareStatusExpired
+	default:
+		return nil
+	}
+	return &apiStatus
+}
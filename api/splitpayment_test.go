@@ -0,0 +1,116 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSplitTeamRegistration(eventId uuid.UUID) *registration.TeamRegistration {
+	return &registration.TeamRegistration{
+		EventID:         eventId,
+		Version:         1,
+		CaptainEmail:    "captain@example.com",
+		TeamName:        "Team",
+		SplitPayment:    true,
+		PaymentDeadline: ptr.Time(time.Now().Add(time.Hour)),
+		Players: []registration.PlayerInfo{
+			{FirstName: "Captain", Email: ptr.String("captain@example.com"), InviteToken: "a", ShareStatus: registration.SHARE_PAID},
+			{FirstName: "Player", Email: ptr.String("player@example.com"), InviteToken: "b", ShareStatus: registration.SHARE_UNPAID},
+		},
+	}
+}
+
+func TestPostEventsV1EventIdRegistrationsEmailSharesCheckout(t *testing.T) {
+	eventId := uuid.New()
+
+	newMockDB := func() *mockDB {
+		return &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return newSplitTeamRegistration(eventId), nil
+			},
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{
+					ID:   id,
+					Name: "Event",
+					RegistrationOptions: []events.EventRegistrationOption{
+						{RegType: events.BY_TEAM, Price: money.New(5000, "USD")},
+					},
+				}, nil
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				return payments.CheckoutInfo{ClientSecret: "secret", SessionId: "cs_123"}, nil
+			},
+		}
+		api := newTestAPI(newMockDB(), func(c *Config) { c.CheckoutManager = checkoutManager })
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailSharesCheckout(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject{
+			EventId: eventId,
+			Email:   "Captain@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONRequestBody{Token: "b"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailSharesCheckout200JSONResponse:
+			assert.Equal(t, 2500, r.Amount.Amount)
+			assert.Equal(t, "USD", r.Amount.Currency)
+			assert.Equal(t, "secret", r.ClientSecret)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("already paid", func(t *testing.T) {
+		api := newTestAPI(newMockDB())
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailSharesCheckout(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject{
+			EventId: eventId,
+			Email:   "captain@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONRequestBody{Token: "a"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailSharesCheckout409JSONResponse:
+			assert.Equal(t, AlreadyPaid, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("not splitting the payment", func(t *testing.T) {
+		mock := newMockDB()
+		mock.GetRegistrationFunc = func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+			return newRosterTeamRegistration(eventId), nil
+		}
+		api := newTestAPI(mock)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailSharesCheckout(ctxWithLogger(context.Background(), noopLogger), PostEventsV1EventIdRegistrationsEmailSharesCheckoutRequestObject{
+			EventId: eventId,
+			Email:   "captain@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailSharesCheckoutJSONRequestBody{Token: "b"},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailSharesCheckout400JSONResponse:
+			assert.Equal(t, SplitPaymentNotAllowed, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
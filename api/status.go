@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+)
+
+func statusToApiStatus(status registration.Status) *RegistrationStatus {
+	var apiStatus RegistrationStatus
+	switch status {
+	case registration.STATUS_PENDING:
+		apiStatus = RegistrationStatusPending
+	case registration.STATUS_PAID:
+		apiStatus = RegistrationStatusPaid
+	case registration.STATUS_COMPED:
+		apiStatus = RegistrationStatusComped
+	case registration.STATUS_CANCELLED:
+		apiStatus = RegistrationStatusCancelled
+	case registration.STATUS_REFUNDED:
+		apiStatus = RegistrationStatusRefunded
+	case registration.STATUS_EXPIRED:
+		apiStatus = RegistrationStatusExpired
+	case registration.STATUS_CONFIRMED:
+		apiStatus = RegistrationStatusConfirmed
+	default:
+		return nil
+	}
+	return &apiStatus
+}
+
+func apiStatusToStatus(status RegistrationStatus) (registration.Status, error) {
+	switch status {
+	case RegistrationStatusPending:
+		return registration.STATUS_PENDING, nil
+	case RegistrationStatusPaid:
+		return registration.STATUS_PAID, nil
+	case RegistrationStatusComped:
+		return registration.STATUS_COMPED, nil
+	case RegistrationStatusCancelled:
+		return registration.STATUS_CANCELLED, nil
+	case RegistrationStatusRefunded:
+		return registration.STATUS_REFUNDED, nil
+	case RegistrationStatusExpired:
+		return registration.STATUS_EXPIRED, nil
+	case RegistrationStatusConfirmed:
+		return registration.STATUS_CONFIRMED, nil
+	default:
+		return registration.Status(0), fmt.Errorf("Unknown registration status: %s", status)
+	}
+}
+
+func statusChangeToApiStatusChange(change registration.StatusChange) StatusChange {
+	apiChange := StatusChange{
+		From:      statusToApiStatus(change.From),
+		To:        statusToApiStatus(change.To),
+		ChangedBy: change.ChangedBy,
+		ChangedAt: change.ChangedAt,
+	}
+	if change.Reason != "" {
+		apiChange.Reason = &change.Reason
+	}
+	return apiChange
+}
+
+func statusHistoryToApiStatusHistory(history []registration.StatusChange) *[]StatusChange {
+	if len(history) == 0 {
+		return nil
+	}
+	apiHistory := slices.Map(history, statusChangeToApiStatusChange)
+	return &apiHistory
+}
@@ -30,7 +30,7 @@ func (a *API) PostEventsV1AdminTestEmail(ctx context.Context, request PostEvents
 	event := newFakeEvent()
 	reg := newFakeRegistration(event.ID, targetEmail)
 
-	err := registration.SendRegistrationConfirmationEmail(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, reg, event)
+	err := registration.SendRegistrationConfirmationEmail(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, reg, event, a.checkInSigner)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -94,6 +94,6 @@ func newFakeRegistration(eventID uuid.UUID, email string) *registration.Individu
 			Email:     ptr.String(email),
 		},
 		Experience: registration.INTERMEDIATE,
-		Paid:       false,
+		Status:     registration.STATUS_PENDING,
 	}
 }
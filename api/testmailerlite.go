@@ -57,9 +57,12 @@ func (a *API) PostEventsV1AdminTestMailerlite(ctx context.Context, request PostE
 					Email:     ptr.String(strings.ToLower(string(email))),
 				},
 				Experience: registration.INTERMEDIATE,
-				Paid:       false,
+				Status:     registration.STATUS_PENDING,
+			}
+			_, err := registration.AddToMailingList(ctx, a.subscriberManager, reg, groupID, nil)
+			if err != nil {
+				logger.Warn("failed to add individual subscriber to mailerlite group", "email", reg.Email, "error", err)
 			}
-			registration.AddToMailingList(ctx, a.subscriberManager, reg, groupID, logger)
 		}
 	case ByTeam:
 		if request.Body.TeamName == nil {
@@ -95,9 +98,12 @@ func (a *API) PostEventsV1AdminTestMailerlite(ctx context.Context, request PostE
 			TeamName:     *request.Body.TeamName,
 			CaptainEmail: captainEmail,
 			Players:      players,
-			Paid:         false,
+			Status:       registration.STATUS_PENDING,
+		}
+		_, err := registration.AddToMailingList(ctx, a.subscriberManager, reg, groupID, nil)
+		if err != nil {
+			logger.Warn("failed to add team to mailerlite group", "email", reg.CaptainEmail, "error", err)
 		}
-		registration.AddToMailingList(ctx, a.subscriberManager, reg, groupID, logger)
 	default:
 		return PostEventsV1AdminTestMailerlite400JSONResponse{
 			Code:    InvalidBody,
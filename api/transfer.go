@@ -0,0 +1,196 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func (a *API) PostEventsV1EventIdRegistrationsEmailTransfer(ctx context.Context, request PostEventsV1EventIdRegistrationsEmailTransferRequestObject) (PostEventsV1EventIdRegistrationsEmailTransferResponseObject, error) {
+	ctx, span := a.tracer.Start(ctx, "PostEventsV1EventIdRegistrationsEmailTransfer")
+	defer span.End()
+
+	logger := a.getLoggerOrBaseLogger(ctx)
+
+	regEmail := strings.ToLower(string(request.Email))
+
+	jwt, ok := middleware.GetJWTFromCtx(ctx)
+	if !ok || jwt.UserEmail() == "" {
+		logger.Warn("Tried to transfer a registration without a user email")
+
+		return PostEventsV1EventIdRegistrationsEmailTransfer401JSONResponse{
+			Code:    AuthError,
+			Message: "Must be signed in to transfer a registration",
+		}, nil
+	}
+	if !jwt.IsAdmin() && !strings.EqualFold(jwt.UserEmail(), regEmail) {
+		logger.Warn("User tried to transfer a registration that isn't theirs")
+
+		return PostEventsV1EventIdRegistrationsEmailTransfer403JSONResponse{
+			Code:    Forbidden,
+			Message: "Only the registrant can transfer the registration",
+		}, nil
+	}
+
+	// Transfers can talk to the payment provider, so give them some more time
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// request.Body is guaranteed to be non-nil from openapi doc
+	params := registration.TransferParams{
+		EventID:          request.EventId,
+		Email:            regEmail,
+		NewEventID:       request.Body.ToEventId,
+		TransferredBy:    jwt.UserEmail(),
+		PaymentReturnURL: fmt.Sprintf("%s/events/%s/success", a.frontendBaseURL(), request.EventId),
+	}
+	if request.Body.ToEventId != nil {
+		params.PaymentReturnURL = fmt.Sprintf("%s/events/%s/success", a.frontendBaseURL(), *request.Body.ToEventId)
+	}
+	if request.Body.ToPlayer != nil {
+		player := apiPlayerInfoToPlayerInfo(*request.Body.ToPlayer)
+		params.NewPlayer = &player
+	}
+	if request.Body.Reason != nil {
+		params.Reason = *request.Body.Reason
+	}
+
+	result, err := registration.TransferRegistration(ctx, params, a.db, a.db, a.checkoutManager, a.paymentQuerier, a.refunder)
+	if err != nil {
+		span.RecordError(err)
+		logger.Error("Failed to transfer registration", "error", err, "eventId", request.EventId)
+
+		var registrationErr *registration.Error
+		if errors.As(err, &registrationErr) {
+			switch registrationErr.Reason {
+			case registration.REASON_REGISTRATION_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailTransfer404JSONResponse{
+					Code:    NotFound,
+					Message: "Registration was not found",
+				}, nil
+			case registration.REASON_ASSOCIATED_EVENT_DOES_NOT_EXIST:
+				return PostEventsV1EventIdRegistrationsEmailTransfer404JSONResponse{
+					Code:    NotFound,
+					Message: "Event was not found",
+				}, nil
+			case registration.REASON_PAYMENT_NOT_FOUND:
+				return PostEventsV1EventIdRegistrationsEmailTransfer404JSONResponse{
+					Code:    NotFound,
+					Message: "No payment was found for the registration",
+				}, nil
+			case registration.REASON_NOT_TRANSFERABLE:
+				return PostEventsV1EventIdRegistrationsEmailTransfer400JSONResponse{
+					Code:    NotTransferable,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_IS_CLOSED:
+				return PostEventsV1EventIdRegistrationsEmailTransfer400JSONResponse{
+					Code:    RegistrationClosed,
+					Message: "Registration is closed for the new event",
+				}, nil
+			case registration.REASON_NOT_ALLOWED_TO_SIGN_UP_AS_TYPE, registration.REASON_TEAM_SIZE_NOT_ALLOWED:
+				return PostEventsV1EventIdRegistrationsEmailTransfer400JSONResponse{
+					Code:    InputValidationError,
+					Message: registrationErr.Message,
+				}, nil
+			case registration.REASON_REGISTRATION_ALREADY_EXISTS:
+				return PostEventsV1EventIdRegistrationsEmailTransfer409JSONResponse{
+					Code:    AlreadyExists,
+					Message: "The new registrant is already registered for the event",
+				}, nil
+			case registration.REASON_PLAYER_ALREADY_REGISTERED:
+				return PostEventsV1EventIdRegistrationsEmailTransfer409JSONResponse{
+					Code:    PlayerAlreadyRegistered,
+					Message: registrationErr.Message,
+				}, nil
+			}
+		}
+
+		span.SetStatus(codes.Error, err.Error())
+		return PostEventsV1EventIdRegistrationsEmailTransfer500JSONResponse{
+			Code:    InternalError,
+			Message: "Failed to transfer registration",
+		}, nil
+	}
+
+	if result.ClientSecret != "" {
+		// The confirmation goes out from the outbox once the difference is paid and the registration moves
+		logger.Info("Transfer is waiting on the price difference",
+			slog.String("transferId", result.Transfer.ID.String()),
+			slog.String("fromEventId", result.Transfer.FromEventID.String()),
+			slog.String("toEventId", result.Transfer.ToEventID.String()),
+			slog.String("transferredBy", result.Transfer.TransferredBy))
+	} else {
+		logger.Info("Transferred registration",
+			slog.String("transferId", result.Transfer.ID.String()),
+			slog.String("fromEventId", result.Transfer.FromEventID.String()),
+			slog.String("toEventId", result.Transfer.ToEventID.String()),
+			slog.String("transferredBy", result.Transfer.TransferredBy))
+
+		err = registration.SendRegistrationConfirmationEmail(ctx, a.emailSender, email.Address{Name: "ICAA", Address: "info@icaa.world"}, result.Registration, result.Event, a.checkInSigner)
+		if err != nil {
+			span.RecordError(err)
+			logger.Error("failed to send email to transferred registrant", slog.String("error", err.Error()), slog.String("email", result.Registration.GetEmail()))
+		}
+	}
+
+	respReg, err := registrationToApiRegistration(result.Registration)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		logger.Error("Failed to convert registration to api registration", "error", err)
+
+		return PostEventsV1EventIdRegistrationsEmailTransfer500JSONResponse{
+			Code:    InternalError,
+			Message: "Transfer was made but failed to build the response",
+		}, nil
+	}
+
+	resp := PostEventsV1EventIdRegistrationsEmailTransfer200JSONResponse{
+		Registration: respReg,
+		Transfer:     transferToApiTransfer(result.Transfer),
+	}
+	if result.ClientSecret != "" {
+		resp.ClientSecret = &result.ClientSecret
+	}
+	return resp, nil
+}
+
+func transferToApiTransfer(transfer registration.Transfer) Transfer {
+	apiTransfer := Transfer{
+		Id:            transfer.ID,
+		FromEventId:   transfer.FromEventID,
+		FromEmail:     transfer.FromEmail,
+		ToEventId:     transfer.ToEventID,
+		ToEmail:       transfer.ToEmail,
+		ChargePaid:    transfer.ChargePaid,
+		RefundId:      transfer.RefundID,
+		Reason:        transfer.Reason,
+		TransferredBy: transfer.TransferredBy,
+		TransferredAt: transfer.TransferredAt,
+	}
+	if transfer.PriceDifference != nil {
+		apiTransfer.PriceDifference = &Money{
+			Amount:   int(transfer.PriceDifference.Amount()),
+			Currency: transfer.PriceDifference.Currency().Code,
+		}
+	}
+	return apiTransfer
+}
+
+func transfersToApiTransfers(transfers []registration.Transfer) *[]Transfer {
+	if len(transfers) == 0 {
+		return nil
+	}
+	apiTransfers := slices.Map(transfers, transferToApiTransfer)
+	return &apiTransfers
+}
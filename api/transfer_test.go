@@ -0,0 +1,167 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/oapi-codegen/runtime/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostEventsV1EventIdRegistrationsEmailTransfer(t *testing.T) {
+	eventId := uuid.New()
+	newMock := func() *mockDB {
+		return &mockDB{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
+				return &registration.IndividualRegistration{
+					EventID:    eventId,
+					Version:    1,
+					Status:     registration.STATUS_PAID,
+					Email:      email,
+					PlayerInfo: registration.PlayerInfo{Email: ptr.String(email)},
+				}, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (registration.RegistrationIntent, error) {
+				return registration.RegistrationIntent{}, registration.NewRegistrationDoesNotExistsError("no intent", nil)
+			},
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{ID: id, Name: "Event", TimeZone: time.UTC}, nil
+			},
+		}
+	}
+	newEmail := types.Email("new@example.com")
+	body := &PostEventsV1EventIdRegistrationsEmailTransferJSONRequestBody{
+		ToPlayer: &PlayerInfo{FirstName: "New", LastName: "Player", Email: &newEmail},
+		Reason:   ptr.String("Can't make it"),
+	}
+
+	t.Run("registrant hands their spot to someone else", func(t *testing.T) {
+		emailSender := &mockEmailSender{}
+		api := newTestAPI(newMock(), func(c *Config) { c.EmailSender = emailSender })
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "old@example.com", false)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailTransfer(ctx, PostEventsV1EventIdRegistrationsEmailTransferRequestObject{
+			EventId: eventId,
+			Email:   "old@example.com",
+			Body:    body,
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailTransfer200JSONResponse:
+			assert.Equal(t, "old@example.com", r.Transfer.FromEmail)
+			assert.Equal(t, "new@example.com", r.Transfer.ToEmail)
+			assert.Equal(t, "old@example.com", r.Transfer.TransferredBy)
+			assert.Nil(t, r.ClientSecret)
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, "new@example.com", string(indivReg.Email))
+			assert.Len(t, *indivReg.Transfers, 1)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("move to a pricier event waits on its checkout", func(t *testing.T) {
+		newEventId := uuid.New()
+		mock := newMock()
+		mock.GetEventFunc = func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			price := int64(5000)
+			if id == newEventId {
+				price = 7000
+			}
+			return events.Event{
+				ID:                    id,
+				Name:                  "Event",
+				TimeZone:              time.UTC,
+				RegistrationCloseTime: time.Now().Add(time.Hour),
+				RegistrationOptions:   []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(price, "USD")}},
+			}, nil
+		}
+		mock.TransferRegistrationFunc = func(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event) error {
+			t.Fatal("registration was moved before the difference was paid")
+			return nil
+		}
+		var pending registration.Registration
+		mock.CreateTransferWithPaymentFunc = func(ctx context.Context, reg registration.Registration, intent registration.RegistrationIntent) error {
+			pending = reg
+			return nil
+		}
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				return payments.CheckoutInfo{ClientSecret: "transfer_secret", SessionId: "cs_transfer"}, nil
+			},
+		}
+		api := newTestAPI(mock, func(c *Config) { c.CheckoutManager = checkoutManager })
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "old@example.com", false)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailTransfer(ctx, PostEventsV1EventIdRegistrationsEmailTransferRequestObject{
+			EventId: eventId,
+			Email:   "old@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailTransferJSONRequestBody{ToEventId: &newEventId},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailTransfer200JSONResponse:
+			require.NotNil(t, r.ClientSecret)
+			assert.Equal(t, "transfer_secret", *r.ClientSecret)
+			assert.Equal(t, newEventId, r.Transfer.ToEventId)
+			assert.False(t, r.Transfer.ChargePaid)
+			indivReg, err := r.Registration.AsIndividualRegistration()
+			require.NoError(t, err)
+			assert.Equal(t, eventId, *indivReg.EventId)
+			assert.Nil(t, indivReg.Transfers)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+		require.NotNil(t, pending)
+		assert.Equal(t, newEventId, pending.GetPendingTransfer().Transfer.ToEventID)
+	})
+
+	t.Run("someone else's registration", func(t *testing.T) {
+		api := newTestAPI(newMock())
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "someone@example.com", false)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailTransfer(ctx, PostEventsV1EventIdRegistrationsEmailTransferRequestObject{
+			EventId: eventId,
+			Email:   "old@example.com",
+			Body:    body,
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailTransfer403JSONResponse:
+			assert.Equal(t, Forbidden, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+
+	t.Run("nothing to transfer", func(t *testing.T) {
+		api := newTestAPI(newMock())
+		ctx := ctxWithJWT(ctxWithLogger(context.Background(), noopLogger), "admin@example.com", true)
+
+		resp, err := api.PostEventsV1EventIdRegistrationsEmailTransfer(ctx, PostEventsV1EventIdRegistrationsEmailTransferRequestObject{
+			EventId: eventId,
+			Email:   "old@example.com",
+			Body:    &PostEventsV1EventIdRegistrationsEmailTransferJSONRequestBody{},
+		})
+		assert.NoError(t, err)
+
+		switch r := resp.(type) {
+		case PostEventsV1EventIdRegistrationsEmailTransfer400JSONResponse:
+			assert.Equal(t, NotTransferable, r.Code)
+		default:
+			t.Fatalf("unexpected response type: %T", resp)
+		}
+	})
+}
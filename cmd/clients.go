@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -12,11 +14,14 @@ import (
 	"github.com/International-Combat-Archery-Alliance/email/mailersend"
 	"github.com/International-Combat-Archery-Alliance/event-registration/api"
 	"github.com/International-Combat-Archery-Alliance/event-registration/dynamo"
+	"github.com/International-Combat-Archery-Alliance/event-registration/encryption"
 	"github.com/International-Combat-Archery-Alliance/payments/stripe"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	mailerlitego "github.com/mailerlite/mailerlite-go"
 )
 
 func makeDB(ctx context.Context) (api.DB, error) {
@@ -44,7 +49,12 @@ func makeLocalDB(ctx context.Context) (api.DB, error) {
 		o.BaseEndpoint = aws.String("http://dynamodb:8000")
 	})
 
-	return dynamo.NewDB(dynamoClient, os.Getenv("DYNAMO_TABLE_NAME")), nil
+	encrypter, err := makeLocalEncrypter()
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamo.NewDB(dynamoClient, os.Getenv("DYNAMO_TABLE_NAME"), encrypter), nil
 }
 
 func makeProdDB(ctx context.Context) (api.DB, error) {
@@ -54,7 +64,26 @@ func makeProdDB(ctx context.Context) (api.DB, error) {
 	}
 
 	dynamoClient := dynamodb.NewFromConfig(cfg)
-	return dynamo.NewDB(dynamoClient, os.Getenv("DYNAMO_TABLE_NAME")), nil
+	encrypter := encryption.NewEncrypter(encryption.NewKMSKeyProvider(kms.NewFromConfig(cfg), os.Getenv("FIELD_ENCRYPTION_KMS_KEY_ID")))
+	return dynamo.NewDB(dynamoClient, os.Getenv("DYNAMO_TABLE_NAME"), encrypter), nil
+}
+
+// makeLocalEncrypter uses a static key, FIELD_ENCRYPTION_KEY (base64) if it's set.
+func makeLocalEncrypter() (*encryption.Encrypter, error) {
+	key := []byte("local-dev-field-encryption-key!!")
+	if v := os.Getenv("FIELD_ENCRYPTION_KEY"); v != "" {
+		decodedKey, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 field encryption key: %w", err)
+		}
+		key = decodedKey
+	}
+
+	keyProvider, err := encryption.NewStaticKeyProvider(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local field encryption key provider: %w", err)
+	}
+	return encryption.NewEncrypter(keyProvider), nil
 }
 
 func makeStripeClient(secretKey, endpointSecret string, httpClient *http.Client) *stripe.Client {
@@ -83,7 +112,7 @@ func createProdMailerSendSender(apiKey string) (email.Sender, error) {
 	return mailersend.NewMailerSendSender(apiKey), nil
 }
 
-var _ email.SubscriberManager = &subscriberLogger{}
+var _ api.SubscriberManager = &subscriberLogger{}
 
 type subscriberLogger struct {
 	logger *slog.Logger
@@ -104,11 +133,51 @@ func (s *subscriberLogger) AddSubscriberToGroup(ctx context.Context, email, name
 	return nil
 }
 
-func createSubscriberManager(logger *slog.Logger, env api.Environment, mailerLiteAPIKey string) (email.SubscriberManager, error) {
+func (s *subscriberLogger) ForgetSubscriber(ctx context.Context, email string) error {
+	s.logger.Info("mailerlite subscriber that would be forgotten", slog.String("email", email))
+	return nil
+}
+
+func createSubscriberManager(logger *slog.Logger, env api.Environment, mailerLiteAPIKey string) (api.SubscriberManager, error) {
 	if env == api.LOCAL {
 		return &subscriberLogger{logger: logger}, nil
 	}
-	return mailerlite.NewMailerLiteManager(mailerLiteAPIKey), nil
+	return newMailerLiteSubscriberManager(mailerLiteAPIKey), nil
+}
+
+var _ api.SubscriberManager = &mailerLiteSubscriberManager{}
+
+// mailerLiteSubscriberManager adds erasing subscribers, which the email package doesn't do, to its MailerLite manager.
+type mailerLiteSubscriberManager struct {
+	*mailerlite.MailerLiteManager
+
+	client *mailerlitego.Client
+}
+
+func newMailerLiteSubscriberManager(apiKey string) *mailerLiteSubscriberManager {
+	return &mailerLiteSubscriberManager{
+		MailerLiteManager: mailerlite.NewMailerLiteManager(apiKey),
+		client:            mailerlitego.NewClient(apiKey),
+	}
+}
+
+// ForgetSubscriber uses MailerLite's forget, which deletes the subscriber and all of their data.
+func (m *mailerLiteSubscriberManager) ForgetSubscriber(ctx context.Context, subscriberEmail string) error {
+	subscriber, _, err := m.client.Subscriber.Get(ctx, &mailerlitego.GetSubscriberOptions{Email: subscriberEmail})
+	if err != nil {
+		var errResp *mailerlitego.ErrorResponse
+		if errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound {
+			// Never subscribed, or already forgotten
+			return nil
+		}
+		return fmt.Errorf("failed to find mailerlite subscriber: %w", err)
+	}
+
+	_, _, err = m.client.Subscriber.Forget(ctx, subscriber.Data.ID)
+	if err != nil {
+		return fmt.Errorf("failed to forget mailerlite subscriber: %w", err)
+	}
+	return nil
 }
 
 
@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"sync"
 
 	"github.com/International-Combat-Archery-Alliance/auth/token"
 	"github.com/International-Combat-Archery-Alliance/event-registration/api"
+	"github.com/International-Combat-Archery-Alliance/event-registration/redact"
 	"github.com/International-Combat-Archery-Alliance/telemetry"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -109,11 +111,13 @@ type AppConfig struct {
 	StripeEndpointSecret string
 	MailerSendAPIKey     string
 	MailerLiteAPIKey     string
+	CheckInSigningKey    []byte
+	PIIHashKey           []byte
 }
 
 // fetchAppConfig retrieves all application configuration.
 // In local mode, returns values from environment variables / defaults.
-// In production, fetches all 5 parameters in a single batched SSM GetParameters call.
+// In production, fetches all of the parameters in a single batched SSM GetParameters call.
 func fetchAppConfig(ctx context.Context, env api.Environment) (*AppConfig, error) {
 	if env == api.LOCAL {
 		return localAppConfig()
@@ -127,6 +131,13 @@ func localAppConfig() (*AppConfig, error) {
 		key = "local-development-signing-key-minimum-32-characters-long"
 	}
 
+	checkInKey := os.Getenv("CHECK_IN_SIGNING_KEY")
+	if checkInKey == "" {
+		checkInKey = "local-development-check-in-key-minimum-32-characters-long"
+	}
+
+	piiHashKey := getEnvOrDefault("PII_HASH_KEY", "local-development-pii-hash-key")
+
 	return &AppConfig{
 		JWTSigningKeys: map[string]token.SigningKey{
 			"local": {ID: "local", Key: []byte(key)},
@@ -136,6 +147,8 @@ func localAppConfig() (*AppConfig, error) {
 		StripeSecretKey:      os.Getenv("STRIPE_SECRET_KEY"),
 		StripeEndpointSecret: os.Getenv("STRIPE_ENDPOINT_SECRET"),
 		MailerLiteAPIKey:     os.Getenv("MAILERLITE_API_KEY"),
+		CheckInSigningKey:    []byte(checkInKey),
+		PIIHashKey:           []byte(piiHashKey),
 	}, nil
 }
 
@@ -147,6 +160,8 @@ func fetchProdAppConfig(ctx context.Context) (*AppConfig, error) {
 		"/mailerLiteApiKey",
 		"/stripeSecretKey",
 		"/stripeEndpointSecret",
+		"/checkInSigningKey",
+		"/piiHashKey",
 	}
 
 	params, err := getSSMParameters(ctx, ssmNames)
@@ -197,6 +212,26 @@ func fetchProdAppConfig(ctx context.Context) (*AppConfig, error) {
 		return nil, fmt.Errorf("missing SSM parameter: /stripeEndpointSecret")
 	}
 
+	if v, ok := params["/checkInSigningKey"]; ok {
+		decodedKey, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 check-in signing key: %w", err)
+		}
+		cfg.CheckInSigningKey = decodedKey
+	} else {
+		return nil, fmt.Errorf("missing SSM parameter: /checkInSigningKey")
+	}
+
+	if v, ok := params["/piiHashKey"]; ok {
+		decodedKey, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode base64 PII hash key: %w", err)
+		}
+		cfg.PIIHashKey = decodedKey
+	} else {
+		return nil, fmt.Errorf("missing SSM parameter: /piiHashKey")
+	}
+
 	return cfg, nil
 }
 
@@ -239,6 +274,42 @@ func getNewRelicLicenseKey(ctx context.Context, env api.Environment) (string, er
 	return getSSMParameter(ctx, newRelicLicenseSSMPath)
 }
 
+// getRedactionMode is how emails and names are redacted in logs and traces. PII_REDACTION
+// overrides the default, which is to leave them alone locally and hash them in production.
+func getRedactionMode(env api.Environment) (redact.Mode, error) {
+	if v, ok := os.LookupEnv("PII_REDACTION"); ok {
+		return redact.ParseMode(v)
+	}
+	if env == api.LOCAL {
+		return redact.MODE_OFF, nil
+	}
+	return redact.MODE_HASH, nil
+}
+
+const (
+	defaultPersonalDataRetentionMonths = 24
+	// Payments keep getting reconciled for 30 days after an event ends, so the data has to outlive that
+	minPersonalDataRetentionMonths = 2
+	maxPersonalDataRetentionMonths = 120
+)
+
+// getPersonalDataRetentionMonths is how many months after an event ends players' personal data is kept
+// on its registrations. PERSONAL_DATA_RETENTION_MONTHS overrides the default.
+func getPersonalDataRetentionMonths() (int, error) {
+	v, ok := os.LookupEnv("PERSONAL_DATA_RETENTION_MONTHS")
+	if !ok || v == "" {
+		return defaultPersonalDataRetentionMonths, nil
+	}
+	months, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("PERSONAL_DATA_RETENTION_MONTHS must be a whole number of months, got %q", v)
+	}
+	if months < minPersonalDataRetentionMonths || months > maxPersonalDataRetentionMonths {
+		return 0, fmt.Errorf("PERSONAL_DATA_RETENTION_MONTHS must be between %d and %d, got %d", minPersonalDataRetentionMonths, maxPersonalDataRetentionMonths, months)
+	}
+	return months, nil
+}
+
 func getApiEnvironment() api.Environment {
 	if isLocal() {
 		return api.LOCAL
@@ -13,15 +13,17 @@ import (
 	"github.com/International-Combat-Archery-Alliance/auth/token"
 	"github.com/International-Combat-Archery-Alliance/captcha/cfturnstile"
 	"github.com/International-Combat-Archery-Alliance/event-registration/api"
+	"github.com/International-Combat-Archery-Alliance/event-registration/redact"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
 	"github.com/International-Combat-Archery-Alliance/telemetry"
 	"go.opentelemetry.io/otel"
 	"golang.org/x/sync/errgroup"
 )
 
-var tracer = otel.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/cmd")
+var tracer = redact.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/cmd")
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	logger := slog.New(redact.NewHandler(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})))
 
 	logger.Info("starting up")
 	if err := run(logger); err != nil {
@@ -50,6 +52,10 @@ func run(logger *slog.Logger) error {
 
 	serverErrCh := make(chan error, 1)
 	go func() {
+		if serverSettings.RunMode == runModeJobs {
+			serverErrCh <- eventAPI.ListenAndServeJobs(serverSettings.Host, serverSettings.Port)
+			return
+		}
 		serverErrCh <- eventAPI.ListenAndServe(serverSettings.Host, serverSettings.Port)
 	}()
 
@@ -96,6 +102,8 @@ func setupApi(logger *slog.Logger) (*api.API, func(context.Context) error, error
 	if err != nil {
 		return nil, traceShutdown, fmt.Errorf("telemetry init: %w", err)
 	}
+	// Instrumentation like otelhttp names spans after request paths, which can have emails in them
+	otel.SetTracerProvider(redact.TracerProvider(otel.GetTracerProvider()))
 
 	ctx, startupSpan := tracer.Start(ctx, "startup")
 	defer startupSpan.End()
@@ -146,6 +154,21 @@ func setupApi(logger *slog.Logger) (*api.API, func(context.Context) error, error
 	// Phase 3: Wire up services (all instant after config is loaded)
 	// -----------------------------------------------------------------------
 
+	redactionMode, err := getRedactionMode(env)
+	if err != nil {
+		startupSpan.RecordError(err)
+		startupSpan.End()
+		return nil, traceShutdown, fmt.Errorf("pii redaction: %w", err)
+	}
+	redact.SetDefault(redact.NewRedactor(redactionMode, cfg.PIIHashKey))
+
+	personalDataRetentionMonths, err := getPersonalDataRetentionMonths()
+	if err != nil {
+		startupSpan.RecordError(err)
+		startupSpan.End()
+		return nil, traceShutdown, fmt.Errorf("personal data retention: %w", err)
+	}
+
 	tokenService := token.NewTokenService(
 		cfg.JWTSigningKeys[cfg.JWTCurrentKeyID],
 		token.WithSigningKeys(cfg.JWTSigningKeys, cfg.JWTCurrentKeyID),
@@ -169,19 +192,45 @@ func setupApi(logger *slog.Logger) (*api.API, func(context.Context) error, error
 
 	stripeClient := makeStripeClient(cfg.StripeSecretKey, cfg.StripeEndpointSecret, httpClient)
 
-	eventAPI := api.NewAPI(db, logger, env, tokenService, cfTurnstileValidator, emailSender, subscriberManager, stripeClient, flushTraces)
+	stripeRefunder := makeStripeRefunder(cfg.StripeSecretKey, httpClient)
+
+	checkInSigner := registration.NewCheckInSigner(cfg.CheckInSigningKey)
+
+	eventAPI := api.NewAPI(api.Config{
+		DB:                          db,
+		Logger:                      logger,
+		Env:                         env,
+		TokenService:                tokenService,
+		CaptchaValidator:            cfTurnstileValidator,
+		EmailSender:                 emailSender,
+		SubscriberManager:           subscriberManager,
+		CheckoutManager:             stripeClient,
+		PaymentQuerier:              stripeClient,
+		Refunder:                    stripeRefunder,
+		CheckInSigner:               checkInSigner,
+		PersonalDataRetentionMonths: personalDataRetentionMonths,
+		FlushTraces:                 flushTraces,
+	})
 
 	return eventAPI, traceShutdown, nil
 }
 
+const (
+	runModeAPI  = "api"
+	runModeJobs = "jobs"
+)
+
 type ServerSettings struct {
 	Host string
 	Port string
+	// Either serve the API or the scheduled jobs
+	RunMode string
 }
 
 func getServerSettingsFromEnv() ServerSettings {
 	return ServerSettings{
-		Host: getEnvOrDefault("HOST", "0.0.0.0"),
-		Port: getEnvOrDefault("PORT", "8080"),
+		Host:    getEnvOrDefault("HOST", "0.0.0.0"),
+		Port:    getEnvOrDefault("PORT", "8080"),
+		RunMode: getEnvOrDefault("RUN_MODE", runModeAPI),
 	}
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/Rhymond/go-money"
+	stripeapi "github.com/stripe/stripe-go/v85"
+)
+
+var _ registration.Refunder = &stripeRefunder{}
+
+// stripeRefunder talks to stripe directly for the things the payments library doesn't support.
+type stripeRefunder struct {
+	client *stripeapi.Client
+}
+
+func makeStripeRefunder(secretKey string, httpClient *http.Client) *stripeRefunder {
+	backends := stripeapi.NewBackendsWithConfig(&stripeapi.BackendConfig{HTTPClient: httpClient})
+	return &stripeRefunder{
+		client: stripeapi.NewClient(secretKey, stripeapi.WithBackends(backends)),
+	}
+}
+
+func (s *stripeRefunder) RefundPayment(ctx context.Context, paymentId string, amount *money.Money, reason string, idempotencyKey string) (string, error) {
+	params := &stripeapi.RefundCreateParams{
+		Charge: stripeapi.String(paymentId),
+		Amount: stripeapi.Int64(amount.Amount()),
+		Reason: stripeapi.String(string(stripeapi.RefundReasonRequestedByCustomer)),
+		Metadata: map[string]string{
+			"reason": reason,
+		},
+	}
+	params.SetIdempotencyKey(idempotencyKey)
+
+	refund, err := s.client.V1Refunds.Create(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("failed to create stripe refund: %w", err)
+	}
+	return refund.ID, nil
+}
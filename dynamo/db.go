@@ -1,23 +1,28 @@
 package dynamo
 
 import (
+	"github.com/International-Combat-Archery-Alliance/event-registration/encryption"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 )
 
 const (
 	gsi1 = "GSI1"
+	gsi2 = "GSI2"
 )
 
 type DB struct {
 	dynamoClient *dynamodb.Client
 	tableName    string
+	// Encrypts players' emergency contacts and medical notes before they're stored
+	encrypter *encryption.Encrypter
 }
 
-func NewDB(dynamoClient *dynamodb.Client, tableName string) *DB {
+func NewDB(dynamoClient *dynamodb.Client, tableName string, encrypter *encryption.Encrypter) *DB {
 	return &DB{
 		dynamoClient: dynamoClient,
 		tableName:    tableName,
+		encrypter:    encrypter,
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/International-Combat-Archery-Alliance/event-registration/encryption"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -72,7 +73,10 @@ func setupDynamoTestContainers(ctx context.Context) error {
 		return err
 	}
 
-	db = NewDB(dynamoClient, tableName)
+	db, err = newTestDB()
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -100,11 +104,22 @@ func setupDynamoInCI(ctx context.Context) error {
 		return err
 	}
 
-	db = NewDB(dynamoClient, tableName)
+	db, err = newTestDB()
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
 
+func newTestDB() (*DB, error) {
+	keyProvider, err := encryption.NewStaticKeyProvider([]byte("test-field-encryption-key-32byte"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key provider: %w", err)
+	}
+	return NewDB(dynamoClient, tableName, encryption.NewEncrypter(keyProvider)), nil
+}
+
 func makeTable(ctx context.Context) error {
 	_, err := dynamoClient.CreateTable(ctx, &dynamodb.CreateTableInput{
 		TableName:   aws.String(tableName),
@@ -126,6 +141,14 @@ func makeTable(ctx context.Context) error {
 				AttributeName: aws.String("GSI1SK"),
 				AttributeType: types.ScalarAttributeTypeS,
 			},
+			{
+				AttributeName: aws.String("GSI2PK"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("GSI2SK"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
 		},
 		KeySchema: []types.KeySchemaElement{
 			{
@@ -154,6 +177,22 @@ func makeTable(ctx context.Context) error {
 					ProjectionType: types.ProjectionTypeAll,
 				},
 			},
+			{
+				IndexName: aws.String(gsi2),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("GSI2PK"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("GSI2SK"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeKeysOnly,
+				},
+			},
 		},
 	})
 	if err != nil {
@@ -23,26 +23,34 @@ var locationCache sync.Map
 var _ events.Repository = &DB{}
 
 type eventDynamo struct {
-	PK                    string
-	SK                    string
-	GSI1PK                string
-	GSI1SK                string
-	ID                    string
-	Version               int
-	Name                  string
-	EventLocation         events.Location
-	TimeZone              *string
-	StartTime             time.Time
-	EndTime               time.Time
-	RegistrationCloseTime time.Time
-	RegistrationOptions   []eventRegistrationOptionDynamo
-	AllowedTeamSizeRange  events.Range
-	NumTeams              int
-	NumRosteredPlayers    int
-	NumTotalPlayers       int
-	RulesDocLink          *string
-	ImageName             *string
-	MailingListGroupID     *string
+	PK                       string
+	SK                       string
+	GSI1PK                   string
+	GSI1SK                   string
+	ID                       string
+	Version                  int
+	Name                     string
+	EventLocation            events.Location
+	TimeZone                 *string
+	StartTime                time.Time
+	EndTime                  time.Time
+	RegistrationCloseTime    time.Time
+	RegistrationOptions      []eventRegistrationOptionDynamo
+	AllowedTeamSizeRange     events.Range
+	NumTeams                 int
+	NumRosteredPlayers       int
+	NumTotalPlayers          int
+	NumCheckedInPlayers      int
+	NumCheckedInTeams        int
+	RulesDocLink             *string
+	ImageName                *string
+	MailingListGroupID       *string
+	SplitPaymentWindow       *time.Duration
+	RequireEmailVerification bool
+	CheckoutHoldDuration     *time.Duration
+	CheckoutItemDescription  *string
+	DisableAdaptivePricing   bool
+	PersonalDataPurgedAt     *time.Time
 }
 
 type eventRegistrationOptionDynamo struct {
@@ -87,13 +95,21 @@ func newEventDynamo(event events.Event) eventDynamo {
 		RegistrationOptions: slices.Map(event.RegistrationOptions, func(o events.EventRegistrationOption) eventRegistrationOptionDynamo {
 			return eventRegOptionToDynamo(o)
 		}),
-		AllowedTeamSizeRange: event.AllowedTeamSizeRange,
-		NumTeams:             event.NumTeams,
-		NumRosteredPlayers:   event.NumRosteredPlayers,
-		NumTotalPlayers:      event.NumTotalPlayers,
-		RulesDocLink:         event.RulesDocLink,
-		ImageName:            event.ImageName,
-		MailingListGroupID:    event.MailingListGroupID,
+		AllowedTeamSizeRange:     event.AllowedTeamSizeRange,
+		NumTeams:                 event.NumTeams,
+		NumRosteredPlayers:       event.NumRosteredPlayers,
+		NumTotalPlayers:          event.NumTotalPlayers,
+		NumCheckedInPlayers:      event.NumCheckedInPlayers,
+		NumCheckedInTeams:        event.NumCheckedInTeams,
+		RulesDocLink:             event.RulesDocLink,
+		ImageName:                event.ImageName,
+		MailingListGroupID:       event.MailingListGroupID,
+		SplitPaymentWindow:       event.SplitPaymentWindow,
+		RequireEmailVerification: event.RequireEmailVerification,
+		CheckoutHoldDuration:     event.CheckoutHoldDuration,
+		CheckoutItemDescription:  event.CheckoutItemDescription,
+		DisableAdaptivePricing:   event.DisableAdaptivePricing,
+		PersonalDataPurgedAt:     event.PersonalDataPurgedAt,
 	}
 }
 
@@ -130,13 +146,21 @@ func eventFromEventDynamo(event eventDynamo) events.Event {
 		RegistrationOptions: slices.Map(event.RegistrationOptions, func(o eventRegistrationOptionDynamo) events.EventRegistrationOption {
 			return dynamoEventRegOptionToEventRegOption(o)
 		}),
-		AllowedTeamSizeRange: event.AllowedTeamSizeRange,
-		NumTeams:             event.NumTeams,
-		NumRosteredPlayers:   event.NumRosteredPlayers,
-		NumTotalPlayers:      event.NumTotalPlayers,
-		RulesDocLink:         event.RulesDocLink,
-		ImageName:            event.ImageName,
-		MailingListGroupID:    event.MailingListGroupID,
+		AllowedTeamSizeRange:     event.AllowedTeamSizeRange,
+		NumTeams:                 event.NumTeams,
+		NumRosteredPlayers:       event.NumRosteredPlayers,
+		NumTotalPlayers:          event.NumTotalPlayers,
+		NumCheckedInPlayers:      event.NumCheckedInPlayers,
+		NumCheckedInTeams:        event.NumCheckedInTeams,
+		RulesDocLink:             event.RulesDocLink,
+		ImageName:                event.ImageName,
+		MailingListGroupID:       event.MailingListGroupID,
+		SplitPaymentWindow:       event.SplitPaymentWindow,
+		RequireEmailVerification: event.RequireEmailVerification,
+		CheckoutHoldDuration:     event.CheckoutHoldDuration,
+		CheckoutItemDescription:  event.CheckoutItemDescription,
+		DisableAdaptivePricing:   event.DisableAdaptivePricing,
+		PersonalDataPurgedAt:     event.PersonalDataPurgedAt,
 	}
 }
 
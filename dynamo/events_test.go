@@ -83,6 +83,8 @@ func TestCreateEvent(t *testing.T) {
 			NumTeams:              10,
 			NumRosteredPlayers:    50,
 			NumTotalPlayers:       60,
+			NumCheckedInPlayers:   12,
+			NumCheckedInTeams:     2,
 			RulesDocLink:          ptr.String("https://example.com/rules"),
 			Version:               1,
 		}
@@ -121,6 +123,8 @@ func TestCreateEvent(t *testing.T) {
 		assert.Equal(t, event.NumTeams, savedEvent.NumTeams)
 		assert.Equal(t, event.NumRosteredPlayers, savedEvent.NumRosteredPlayers)
 		assert.Equal(t, event.NumTotalPlayers, savedEvent.NumTotalPlayers)
+		assert.Equal(t, event.NumCheckedInPlayers, savedEvent.NumCheckedInPlayers)
+		assert.Equal(t, event.NumCheckedInTeams, savedEvent.NumCheckedInTeams)
 		assert.Equal(t, event.RulesDocLink, savedEvent.RulesDocLink)
 		assert.Equal(t, event.Version, savedEvent.Version)
 	})
@@ -392,6 +396,9 @@ func TestUpdateEvent(t *testing.T) {
 		event.StartTime = time.Now().Add(time.Hour).UTC().Truncate(time.Second)
 		event.EndTime = time.Now().Add(2 * time.Hour).UTC().Truncate(time.Second)
 		event.RulesDocLink = ptr.String("https://example.com/new-rules")
+		event.CheckoutHoldDuration = ptr.Duration(2 * time.Hour)
+		event.CheckoutItemDescription = ptr.String("Test Event Entry")
+		event.DisableAdaptivePricing = true
 		event.Version++
 		require.NoError(t, db.UpdateEvent(ctx, event))
 
@@ -428,6 +435,9 @@ func TestUpdateEvent(t *testing.T) {
 		assert.Equal(t, event.NumRosteredPlayers, savedEvent.NumRosteredPlayers)
 		assert.Equal(t, event.NumTotalPlayers, savedEvent.NumTotalPlayers)
 		assert.Equal(t, event.RulesDocLink, savedEvent.RulesDocLink)
+		assert.Equal(t, event.CheckoutHoldDuration, savedEvent.CheckoutHoldDuration)
+		assert.Equal(t, event.CheckoutItemDescription, savedEvent.CheckoutItemDescription)
+		assert.True(t, savedEvent.DisableAdaptivePricing)
 		assert.Equal(t, event.Version, savedEvent.Version)
 	})
 }
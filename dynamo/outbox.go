@@ -0,0 +1,260 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+)
+
+type outboxItemDynamo struct {
+	PK     string
+	SK     string
+	GSI1PK string
+	GSI1SK string
+
+	ID            uuid.UUID
+	Version       int
+	Kind          registration.OutboxKind
+	EventID       uuid.UUID
+	Email         string
+	Status        registration.OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	DeliveredTo   []string
+	CreatedAt     time.Time
+}
+
+const (
+	outboxEntityName = "OUTBOX"
+
+	// Fixed width so the times sort as strings, unlike the default format which drops trailing zeros
+	outboxSortableTimeFormat = "2006-01-02T15:04:05.000000000Z"
+)
+
+func outboxKey(id uuid.UUID) string {
+	return fmt.Sprintf("%s#%s", outboxEntityName, id)
+}
+
+func outboxGSI1PK(status registration.OutboxStatus) string {
+	return fmt.Sprintf("%s#%s", outboxEntityName, strings.TrimPrefix(status.String(), "OUTBOX_"))
+}
+
+func outboxGSI1SKPrefix(nextAttemptAt time.Time) string {
+	return fmt.Sprintf("%s#%s", outboxEntityName, nextAttemptAt.UTC().Format(outboxSortableTimeFormat))
+}
+
+func outboxItemToDynamo(item registration.OutboxItem) outboxItemDynamo {
+	return outboxItemDynamo{
+		PK:            outboxKey(item.ID),
+		SK:            outboxKey(item.ID),
+		GSI1PK:        outboxGSI1PK(item.Status),
+		GSI1SK:        fmt.Sprintf("%s#%s", outboxGSI1SKPrefix(item.NextAttemptAt), item.ID),
+		ID:            item.ID,
+		Version:       item.Version,
+		Kind:          item.Kind,
+		EventID:       item.EventID,
+		Email:         item.Email,
+		Status:        item.Status,
+		Attempts:      item.Attempts,
+		NextAttemptAt: item.NextAttemptAt,
+		LastError:     item.LastError,
+		DeliveredTo:   item.DeliveredTo,
+		CreatedAt:     item.CreatedAt,
+	}
+}
+
+func dynamoOutboxItemToOutboxItem(item outboxItemDynamo) registration.OutboxItem {
+	return registration.OutboxItem{
+		ID:            item.ID,
+		Version:       item.Version,
+		Kind:          item.Kind,
+		EventID:       item.EventID,
+		Email:         item.Email,
+		Status:        item.Status,
+		Attempts:      item.Attempts,
+		NextAttemptAt: item.NextAttemptAt,
+		LastError:     item.LastError,
+		DeliveredTo:   item.DeliveredTo,
+		CreatedAt:     item.CreatedAt,
+	}
+}
+
+// outboxPuts are the transaction items that add new outbox items alongside the write they came from.
+func (d *DB) outboxPuts(outbox []registration.OutboxItem) ([]types.TransactWriteItem, error) {
+	var items []types.TransactWriteItem
+	for _, outboxItem := range outbox {
+		item, err := attributevalue.MarshalMap(outboxItemToDynamo(outboxItem))
+		if err != nil {
+			return nil, registration.NewFailedToTranslateToDBModelError("Failed to translate outbox item to dynamo model", err)
+		}
+		expr := exprMustBuild(expression.NewBuilder().
+			WithCondition(newEntityVersionConditional(outboxItem.Version)))
+		items = append(items, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      item,
+				ConditionExpression:       expr.Condition(),
+				ExpressionAttributeNames:  expr.Names(),
+				ExpressionAttributeValues: expr.Values(),
+			},
+		})
+	}
+	return items, nil
+}
+
+func (d *DB) GetDueOutboxItems(ctx context.Context, now time.Time) ([]registration.OutboxItem, error) {
+	// '$' sorts right after '#', so this takes every item due at or before now whatever its ID
+	keyCond := expression.Key("GSI1PK").Equal(expression.Value(outboxGSI1PK(registration.OUTBOX_PENDING))).
+		And(expression.Key("GSI1SK").Between(expression.Value(outboxEntityName+"#"), expression.Value(outboxGSI1SKPrefix(now)+"$")))
+
+	return d.queryOutboxItems(ctx, keyCond)
+}
+
+func (d *DB) GetOutboxItemsWithStatus(ctx context.Context, status registration.OutboxStatus) ([]registration.OutboxItem, error) {
+	keyCond := expression.Key("GSI1PK").Equal(expression.Value(outboxGSI1PK(status))).
+		And(expression.Key("GSI1SK").BeginsWith(outboxEntityName))
+
+	return d.queryOutboxItems(ctx, keyCond)
+}
+
+func (d *DB) queryOutboxItems(ctx context.Context, keyCond expression.KeyConditionBuilder) ([]registration.OutboxItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	expr := exprMustBuild(expression.NewBuilder().WithKeyCondition(keyCond))
+
+	paginator := dynamodb.NewQueryPaginator(d.dynamoClient, &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		IndexName:                 aws.String(gsi1),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	var items []registration.OutboxItem
+	for paginator.HasMorePages() {
+		resp, err := paginator.NextPage(ctx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, registration.NewTimeoutError("Querying outbox items timed out")
+			}
+			return nil, registration.NewFailedToFetchError("Failed to query outbox items", err)
+		}
+
+		var dynamoItems []outboxItemDynamo
+		err = attributevalue.UnmarshalListOfMaps(resp.Items, &dynamoItems)
+		if err != nil {
+			panic(fmt.Sprintf("failed to unmarshal outbox items from DB: %s", err))
+		}
+		for _, item := range dynamoItems {
+			items = append(items, dynamoOutboxItemToOutboxItem(item))
+		}
+	}
+
+	return items, nil
+}
+
+func (d *DB) GetOutboxItem(ctx context.Context, id uuid.UUID) (registration.OutboxItem, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	resp, err := d.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: outboxKey(id)},
+			"SK": &types.AttributeValueMemberS{Value: outboxKey(id)},
+		},
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return registration.OutboxItem{}, registration.NewTimeoutError("GetOutboxItem timed out")
+		}
+		return registration.OutboxItem{}, registration.NewFailedToFetchError(fmt.Sprintf("Failed to fetch outbox item with ID %q", id), err)
+	}
+
+	if len(resp.Item) == 0 {
+		return registration.OutboxItem{}, registration.NewOutboxItemDoesNotExistError(fmt.Sprintf("Outbox item with ID %q not found", id))
+	}
+
+	var item outboxItemDynamo
+	err = attributevalue.UnmarshalMap(resp.Item, &item)
+	if err != nil {
+		panic(fmt.Sprintf("failed to unmarshal outbox item from DB: %s", err))
+	}
+	return dynamoOutboxItemToOutboxItem(item), nil
+}
+
+func (d *DB) UpdateOutboxItem(ctx context.Context, outboxItem registration.OutboxItem) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	item, err := attributevalue.MarshalMap(outboxItemToDynamo(outboxItem))
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate outbox item to dynamo model", err)
+	}
+
+	expr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(outboxItem.Version)))
+
+	_, err = d.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(d.tableName),
+		Item:                      item,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var condCheckFailedErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condCheckFailedErr) {
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("UpdateOutboxItem timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed PutItem call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) DeleteOutboxItem(ctx context.Context, outboxItem registration.OutboxItem) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	expr := exprMustBuild(expression.NewBuilder().
+		WithCondition(deleteEntityVersionConditional(outboxItem.Version)))
+
+	_, err := d.dynamoClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: outboxKey(outboxItem.ID)},
+			"SK": &types.AttributeValueMemberS{Value: outboxKey(outboxItem.ID)},
+		},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var condCheckFailedErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condCheckFailedErr) {
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("DeleteOutboxItem timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed DeleteItem call", err)
+		}
+	}
+
+	return nil
+}
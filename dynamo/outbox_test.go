@@ -0,0 +1,127 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestOutboxItem(eventId uuid.UUID, nextAttemptAt time.Time) registration.OutboxItem {
+	return registration.OutboxItem{
+		ID:            uuid.New(),
+		Version:       1,
+		Kind:          registration.OUTBOX_CONFIRMATION_EMAIL,
+		EventID:       eventId,
+		Email:         "test@example.com",
+		Status:        registration.OUTBOX_PENDING,
+		NextAttemptAt: nextAttemptAt,
+		CreatedAt:     nextAttemptAt,
+	}
+}
+
+func putOutboxItem(t *testing.T, ctx context.Context, item registration.OutboxItem) {
+	transactItems, err := db.outboxPuts([]registration.OutboxItem{item})
+	require.NoError(t, err)
+	_, err = db.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: transactItems})
+	require.NoError(t, err)
+}
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+
+	t.Run("written with the registration", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.IndividualRegistration{
+			ID:      uuid.New(),
+			EventID: eventID,
+			Version: 1,
+			Status:  registration.STATUS_PAID,
+			Email:   "test@example.com",
+		}
+		item := newTestOutboxItem(eventID, now)
+
+		event.Version++
+		require.NoError(t, db.CreateRegistration(ctx, &reg, event, []registration.OutboxItem{item}))
+
+		saved, err := db.GetOutboxItem(ctx, item.ID)
+		require.NoError(t, err)
+		assert.Equal(t, item.Kind, saved.Kind)
+		assert.Equal(t, item.EventID, saved.EventID)
+		assert.True(t, item.NextAttemptAt.Equal(saved.NextAttemptAt))
+	})
+
+	t.Run("not written when the registration fails", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "test@example.com"}
+		item := newTestOutboxItem(eventID, now)
+
+		// Stale event version
+		require.Error(t, db.CreateRegistration(ctx, &reg, event, []registration.OutboxItem{item}))
+
+		_, err := db.GetOutboxItem(ctx, item.ID)
+		var registrationErr *registration.Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, registration.REASON_OUTBOX_ITEM_DOES_NOT_EXIST, registrationErr.Reason)
+	})
+
+	t.Run("due items", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		overdue := newTestOutboxItem(eventID, now.Add(-time.Hour))
+		due := newTestOutboxItem(eventID, now)
+		later := newTestOutboxItem(eventID, now.Add(time.Minute))
+		dead := newTestOutboxItem(eventID, now.Add(-time.Hour))
+		for _, item := range []registration.OutboxItem{overdue, due, later, dead} {
+			putOutboxItem(t, ctx, item)
+		}
+		dead.Status = registration.OUTBOX_DEAD
+		dead.Version++
+		require.NoError(t, db.UpdateOutboxItem(ctx, dead))
+
+		items, err := db.GetDueOutboxItems(ctx, now)
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, overdue.ID, items[0].ID)
+		assert.Equal(t, due.ID, items[1].ID)
+
+		deadItems, err := db.GetOutboxItemsWithStatus(ctx, registration.OUTBOX_DEAD)
+		require.NoError(t, err)
+		require.Len(t, deadItems, 1)
+		assert.Equal(t, dead.ID, deadItems[0].ID)
+	})
+
+	t.Run("stale update fails", func(t *testing.T) {
+		resetTable(ctx)
+
+		item := newTestOutboxItem(uuid.New(), now)
+		putOutboxItem(t, ctx, item)
+
+		item.Version++
+		require.NoError(t, db.UpdateOutboxItem(ctx, item))
+		assert.Error(t, db.UpdateOutboxItem(ctx, item))
+
+		require.NoError(t, db.DeleteOutboxItem(ctx, item))
+		_, err := db.GetOutboxItem(ctx, item.ID)
+		assert.Error(t, err)
+	})
+}
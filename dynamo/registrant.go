@@ -0,0 +1,267 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// registrantDynamo points from one email on a registration back to the registration, so
+// everything someone is on can be found through GSI2. Team players get one too, not just the captain.
+//
+// Its key is also what stops a player from being on two registrations for the same event: the
+// first registration to claim an email gets REGISTRANT#<email>. Players an admin let through anyway
+// get one keyed by both emails instead, so they can still be found.
+type registrantDynamo struct {
+	PK     string
+	SK     string
+	GSI2PK string
+	GSI2SK string
+
+	EventID string
+	Email   string
+	// Email the registration itself is stored under
+	RegistrationEmail string
+}
+
+const (
+	registrantEntityName = "REGISTRANT"
+
+	// Most keys BatchGetItem allows in one call
+	maxBatchGetKeys = 100
+)
+
+func registrantSK(email string) string {
+	return fmt.Sprintf("%s#%s", registrantEntityName, email)
+}
+
+func duplicateRegistrantSK(email string, registrationEmail string) string {
+	return fmt.Sprintf("%s#%s#%s", registrantEntityName, email, registrationEmail)
+}
+
+func registrantGSI2PK(email string) string {
+	return fmt.Sprintf("%s#%s", registrantEntityName, email)
+}
+
+func registrantsToDynamo(reg registration.Registration) []registrantDynamo {
+	emails := registration.Emails(reg)
+	registrants := make([]registrantDynamo, 0, len(emails))
+	for _, email := range emails {
+		sk := registrantSK(email)
+		if slices.Contains(reg.GetDuplicatePlayerEmails(), email) {
+			sk = duplicateRegistrantSK(email, reg.GetEmail())
+		}
+		registrants = append(registrants, registrantDynamo{
+			PK:                registrationPK(reg.GetEventID()),
+			SK:                sk,
+			GSI2PK:            registrantGSI2PK(email),
+			GSI2SK:            eventPK(reg.GetEventID()),
+			EventID:           reg.GetEventID().String(),
+			Email:             email,
+			RegistrationEmail: reg.GetEmail(),
+		})
+	}
+	return registrants
+}
+
+// registrantPuts are the transaction items that add every email on the registration to the index.
+func (d *DB) registrantPuts(reg registration.Registration) ([]types.TransactWriteItem, error) {
+	var items []types.TransactWriteItem
+	for _, registrant := range registrantsToDynamo(reg) {
+		item, err := attributevalue.MarshalMap(registrant)
+		if err != nil {
+			return nil, registration.NewFailedToTranslateToDBModelError("Failed to translate registrant to dynamo model", err)
+		}
+		items = append(items, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName: aws.String(d.tableName),
+				Item:      item,
+			},
+		})
+	}
+	return items, nil
+}
+
+// newRegistrantPuts are registrantPuts for a new registration, failing if any of its emails
+// are already on another registration for the event. See registrantConflictError.
+func (d *DB) newRegistrantPuts(reg registration.Registration) ([]types.TransactWriteItem, error) {
+	items, err := d.registrantPuts(reg)
+	if err != nil {
+		return nil, err
+	}
+
+	expr := exprMustBuild(expression.NewBuilder().
+		WithCondition(expression.Name("PK").AttributeNotExists()))
+	for _, item := range items {
+		item.Put.ConditionExpression = expr.Condition()
+		item.Put.ExpressionAttributeNames = expr.Names()
+		item.Put.ExpressionAttributeValues = expr.Values()
+		item.Put.ReturnValuesOnConditionCheckFailure = types.ReturnValuesOnConditionCheckFailureAllOld
+	}
+	return items, nil
+}
+
+// registrantConflictError finds a registrant put from newRegistrantPuts that failed in a
+// cancelled transaction, returning nil if there isn't one.
+func registrantConflictError(transactionFailedErr *types.TransactionCanceledException) error {
+	for _, reason := range transactionFailedErr.CancellationReasons {
+		if reason.Code == nil || *reason.Code != "ConditionalCheckFailed" || len(reason.Item) == 0 {
+			continue
+		}
+
+		var existing registrantDynamo
+		err := attributevalue.UnmarshalMap(reason.Item, &existing)
+		if err != nil || !strings.HasPrefix(existing.SK, registrantEntityName) {
+			continue
+		}
+		return registration.NewPlayerAlreadyRegisteredError(existing.Email, existing.RegistrationEmail, transactionFailedErr)
+	}
+	return nil
+}
+
+// registrantDeletes are the transaction items that remove every email on the registration from the index.
+func (d *DB) registrantDeletes(reg registration.Registration) []types.TransactWriteItem {
+	var items []types.TransactWriteItem
+	for _, registrant := range registrantsToDynamo(reg) {
+		items = append(items, types.TransactWriteItem{
+			Delete: &types.Delete{
+				TableName: aws.String(d.tableName),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: registrant.PK},
+					"SK": &types.AttributeValueMemberS{Value: registrant.SK},
+				},
+			},
+		})
+	}
+	return items
+}
+
+// registrantTransferItems are the transaction items that move the index from one version of a
+// registration to another whose key changed. Emails only on from are removed, emails only on to
+// have to be free to claim, and the ones on both are rewritten to point at the new registration.
+func (d *DB) registrantTransferItems(from registration.Registration, to registration.Registration) ([]types.TransactWriteItem, error) {
+	key := func(r registrantDynamo) string { return r.PK + "|" + r.SK }
+	fromRegistrants := registrantsToDynamo(from)
+	toRegistrants := registrantsToDynamo(to)
+
+	toKeys := map[string]bool{}
+	for _, registrant := range toRegistrants {
+		toKeys[key(registrant)] = true
+	}
+	fromKeys := map[string]bool{}
+	deletes := d.registrantDeletes(from)
+	var items []types.TransactWriteItem
+	for i, registrant := range fromRegistrants {
+		fromKeys[key(registrant)] = true
+		if !toKeys[key(registrant)] {
+			items = append(items, deletes[i])
+		}
+	}
+
+	puts, err := d.newRegistrantPuts(to)
+	if err != nil {
+		return nil, err
+	}
+	for i, registrant := range toRegistrants {
+		put := puts[i]
+		if fromKeys[key(registrant)] {
+			// Already claimed by the registration being replaced
+			put.Put.ConditionExpression = nil
+			put.Put.ExpressionAttributeNames = nil
+			put.Put.ExpressionAttributeValues = nil
+			put.Put.ReturnValuesOnConditionCheckFailure = ""
+		}
+		items = append(items, put)
+	}
+	return items, nil
+}
+
+func (d *DB) GetRegistrationsByEmail(ctx context.Context, email string) ([]registration.Registration, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	keyCond := expression.Key("GSI2PK").Equal(expression.Value(registrantGSI2PK(strings.ToLower(email))))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		panic(fmt.Sprintf("failed to build dynamo key expression: %s", err))
+	}
+
+	paginator := dynamodb.NewQueryPaginator(d.dynamoClient, &dynamodb.QueryInput{
+		IndexName:                 aws.String(gsi2),
+		TableName:                 aws.String(d.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	var keys []map[string]types.AttributeValue
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, registration.NewTimeoutError("GetRegistrationsByEmail timed out")
+			}
+			return nil, registration.NewFailedToFetchError(fmt.Sprintf("Failed to fetch registrants for %s", email), err)
+		}
+
+		var registrants []registrantDynamo
+		err = attributevalue.UnmarshalListOfMaps(result.Items, &registrants)
+		if err != nil {
+			panic(fmt.Sprintf("failed to unmarshal dynamo registrants: %s", err))
+		}
+
+		for _, registrant := range registrants {
+			keys = append(keys, map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: registrant.PK},
+				"SK": &types.AttributeValueMemberS{Value: registrationSK(registrant.RegistrationEmail)},
+			})
+		}
+	}
+
+	var regs []registration.Registration
+	for batch := range slices.Chunk(keys, maxBatchGetKeys) {
+		requestItems := map[string]types.KeysAndAttributes{
+			d.tableName: {Keys: batch},
+		}
+		// DynamoDB can hand back some of the keys to be asked for again
+		for len(requestItems) > 0 {
+			result, err := d.dynamoClient.BatchGetItem(ctx, &dynamodb.BatchGetItemInput{
+				RequestItems: requestItems,
+			})
+			if err != nil {
+				if errors.Is(err, context.DeadlineExceeded) {
+					return nil, registration.NewTimeoutError("GetRegistrationsByEmail timed out")
+				}
+				return nil, registration.NewFailedToFetchError(fmt.Sprintf("Failed to fetch registrations for %s", email), err)
+			}
+
+			var dynamoRegs []registrationDynamo
+			err = attributevalue.UnmarshalListOfMaps(result.Responses[d.tableName], &dynamoRegs)
+			if err != nil {
+				panic(fmt.Sprintf("failed to unmarshal dynamo registrations: %s", err))
+			}
+			for _, dynamoReg := range dynamoRegs {
+				reg, err := d.dynamoToRegistration(ctx, dynamoReg)
+				if err != nil {
+					return nil, err
+				}
+				regs = append(regs, reg)
+			}
+
+			requestItems = result.UnprocessedKeys
+		}
+	}
+
+	return regs, nil
+}
@@ -0,0 +1,160 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRegistrationsByEmail(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("finds registrations across events, including as a team player", func(t *testing.T) {
+		resetTable(ctx)
+
+		firstEvent := events.Event{ID: uuid.New(), Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, firstEvent))
+		secondEvent := events.Event{ID: uuid.New(), Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, secondEvent))
+
+		individual := &registration.IndividualRegistration{
+			ID:         uuid.New(),
+			EventID:    firstEvent.ID,
+			Version:    1,
+			Email:      "player@example.com",
+			PlayerInfo: registration.PlayerInfo{Email: ptr.String("player@example.com")},
+		}
+		firstEvent.Version++
+		require.NoError(t, db.CreateRegistration(ctx, individual, firstEvent, nil))
+
+		team := &registration.TeamRegistration{
+			ID:           uuid.New(),
+			EventID:      secondEvent.ID,
+			Version:      1,
+			CaptainEmail: "captain@example.com",
+			TeamName:     "Team",
+			Players: []registration.PlayerInfo{
+				{Email: ptr.String("captain@example.com")},
+				{Email: ptr.String("Player@example.com")},
+			},
+		}
+		secondEvent.Version++
+		require.NoError(t, db.CreateRegistration(ctx, team, secondEvent, nil))
+
+		regs, err := db.GetRegistrationsByEmail(ctx, "PLAYER@example.com")
+		require.NoError(t, err)
+		require.Len(t, regs, 2)
+		emails := []string{regs[0].GetEmail(), regs[1].GetEmail()}
+		assert.ElementsMatch(t, []string{"player@example.com", "captain@example.com"}, emails)
+
+		// Registrants don't show up as registrations for the event
+		resp, err := db.GetAllRegistrationsForEvent(ctx, secondEvent.ID, 10, nil)
+		require.NoError(t, err)
+		assert.Len(t, resp.Data, 1)
+	})
+
+	t.Run("registrants are removed with an expired registration", func(t *testing.T) {
+		resetTable(ctx)
+
+		event := events.Event{ID: uuid.New(), Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := &registration.IndividualRegistration{ID: uuid.New(), EventID: event.ID, Version: 1, Email: "player@example.com"}
+		regIntent := registration.RegistrationIntent{Version: 1, EventId: event.ID, Email: "player@example.com"}
+		event.Version++
+		require.NoError(t, db.CreateRegistrationWithPayment(ctx, reg, regIntent, event))
+
+		event.Version++
+		require.NoError(t, db.DeleteExpiredRegistration(ctx, reg, regIntent, event))
+
+		regs, err := db.GetRegistrationsByEmail(ctx, "player@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, regs)
+	})
+
+	t.Run("no registrations", func(t *testing.T) {
+		resetTable(ctx)
+
+		regs, err := db.GetRegistrationsByEmail(ctx, "nobody@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, regs)
+	})
+}
+
+func TestDuplicatePlayers(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) events.Event {
+		resetTable(ctx)
+
+		event := events.Event{ID: uuid.New(), Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		freeAgent := &registration.IndividualRegistration{ID: uuid.New(), EventID: event.ID, Version: 1, Email: "player@example.com"}
+		event.Version++
+		require.NoError(t, db.CreateRegistration(ctx, freeAgent, event, nil))
+		return event
+	}
+	newTeam := func(eventId uuid.UUID) *registration.TeamRegistration {
+		return &registration.TeamRegistration{
+			ID:           uuid.New(),
+			EventID:      eventId,
+			Version:      1,
+			CaptainEmail: "captain@example.com",
+			TeamName:     "Team",
+			Players: []registration.PlayerInfo{
+				{Email: ptr.String("captain@example.com")},
+				{Email: ptr.String("player@example.com")},
+			},
+		}
+	}
+
+	t.Run("player on another registration is rejected", func(t *testing.T) {
+		event := setup(t)
+
+		event.Version++
+		err := db.CreateRegistration(ctx, newTeam(event.ID), event, nil)
+		var regErr *registration.Error
+		require.ErrorAs(t, err, &regErr)
+		assert.Equal(t, registration.REASON_PLAYER_ALREADY_REGISTERED, regErr.Reason)
+		assert.Contains(t, regErr.Message, "player@example.com")
+
+		_, err = db.GetRegistration(ctx, event.ID, "captain@example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("player on another registration is rejected when starting a checkout", func(t *testing.T) {
+		event := setup(t)
+
+		team := newTeam(event.ID)
+		regIntent := registration.RegistrationIntent{Version: 1, EventId: event.ID, Email: team.CaptainEmail, PaymentSessionId: "cs_123"}
+		event.Version++
+		err := db.CreateRegistrationWithPayment(ctx, team, regIntent, event)
+		var regErr *registration.Error
+		require.ErrorAs(t, err, &regErr)
+		assert.Equal(t, registration.REASON_PLAYER_ALREADY_REGISTERED, regErr.Reason)
+		assert.Contains(t, regErr.Message, "player@example.com")
+
+		_, err = db.GetRegistrationIntent(ctx, event.ID, "captain@example.com")
+		assert.Error(t, err)
+	})
+
+	t.Run("allowed duplicates are saved and found by email", func(t *testing.T) {
+		event := setup(t)
+
+		team := newTeam(event.ID)
+		team.AllowDuplicatePlayer("player@example.com")
+		event.Version++
+		require.NoError(t, db.CreateRegistrations(ctx, []registration.Registration{team}, event))
+
+		regs, err := db.GetRegistrationsByEmail(ctx, "player@example.com")
+		require.NoError(t, err)
+		assert.Len(t, regs, 2)
+	})
+}
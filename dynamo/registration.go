@@ -2,6 +2,7 @@ package dynamo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
 	"github.com/International-Combat-Archery-Alliance/event-registration/slices"
+	"github.com/Rhymond/go-money"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
@@ -31,7 +33,24 @@ type registrationDynamo struct {
 	EventID      string
 	RegisteredAt time.Time
 	HomeCity     string
-	Paid         bool
+	// Only kept up to date so items can still be read by older code. Use Status instead.
+	Paid bool
+	// Items written before statuses existed don't have one, see dynamoStatus
+	Status        *registration.Status
+	StatusHistory []registration.StatusChange
+	Refunds       []refundDynamo
+	Transfers     []transferDynamo
+	// Only set while a transfer is waiting on its price difference to be paid
+	PendingTransfer *pendingTransferDynamo
+	// Only set if an admin recorded a payment made outside of the payment provider
+	OfflinePayment *offlinePaymentDynamo
+	// Only set once personal data is erased, since the payments can't be found by email after that
+	PaymentIDs []string
+	// Emails an admin allowed to also be on another registration for the event
+	DuplicatePlayerEmails []string
+	// Organizer only, never shown to the registrant
+	AdminNotes []adminNoteDynamo
+	Tags       []string
 
 	// Individual attributes
 	Email      string
@@ -39,9 +58,72 @@ type registrationDynamo struct {
 	Experience registration.ExperienceLevel
 
 	// Team attributes
-	TeamName     string
-	CaptainEmail string
-	Players      []registration.PlayerInfo
+	TeamName        string
+	CaptainEmail    string
+	Players         []registration.PlayerInfo
+	SplitPayment    bool
+	PaymentDeadline *time.Time
+
+	// Encrypted JSON list of medicalInfoDynamo, one for each player in the same order as
+	// Players (or just PlayerInfo for individuals). Not set if no player gave any.
+	MedicalInfo []byte
+}
+
+type refundDynamo struct {
+	ID               string
+	PaymentID        string
+	ProviderRefundID string
+	AmountValue      int64
+	AmountCurrency   string
+	Reason           string
+	RefundedBy       string
+	RefundedAt       time.Time
+	ReleasedSpot     bool
+}
+
+type transferDynamo struct {
+	ID          string
+	FromEventID string
+	FromEmail   string
+	ToEventID   string
+	ToEmail     string
+	// Only set along with PriceDifferenceCurrency
+	PriceDifferenceValue    *int64
+	PriceDifferenceCurrency string
+	ChargePaid              bool
+	RefundID                *string
+	Reason                  string
+	TransferredBy           string
+	TransferredAt           time.Time
+}
+
+type pendingTransferDynamo struct {
+	Transfer transferDynamo
+	// Only the name and email, never any medical info
+	NewPlayer *registration.PlayerInfo
+}
+
+type offlinePaymentDynamo struct {
+	Method registration.PaymentMethod
+	// Only set along with AmountCurrency, comps don't have an amount
+	AmountValue    *int64
+	AmountCurrency string
+	Note           string
+	RecordedBy     string
+	RecordedAt     time.Time
+}
+
+// medicalInfoDynamo is the part of a player that is encrypted. It's kept out of PlayerInfo/Players.
+type medicalInfoDynamo struct {
+	EmergencyContact *registration.EmergencyContact
+	MedicalNotes     string
+}
+
+type adminNoteDynamo struct {
+	ID        string
+	Text      string
+	Author    string
+	CreatedAt time.Time
 }
 
 const (
@@ -56,76 +138,300 @@ func registrationSK(email string) string {
 	return fmt.Sprintf("%s#%s", registrationEntityName, email)
 }
 
-func registrationToDynamo(reg registration.Registration) registrationDynamo {
+// registrationToDynamo encrypts the players' medical info, so it has to be able to reach the key provider.
+func (d *DB) registrationToDynamo(ctx context.Context, reg registration.Registration) (registrationDynamo, error) {
 	switch reg.Type() {
 	case events.BY_INDIVIDUAL:
 		indivReg := reg.(*registration.IndividualRegistration)
-		return registrationDynamo{
-			PK:           registrationPK(indivReg.EventID),
-			SK:           registrationSK(indivReg.Email),
-			Type:         indivReg.Type(),
-			ID:           indivReg.ID.String(),
-			Version:      indivReg.Version,
-			EventID:      indivReg.EventID.String(),
-			RegisteredAt: indivReg.RegisteredAt,
-			HomeCity:     indivReg.HomeCity,
-			Paid:         indivReg.Paid,
-			Email:        indivReg.Email,
-			PlayerInfo:   indivReg.PlayerInfo,
-			Experience:   indivReg.Experience,
+		medicalInfo, err := d.encryptMedicalInfo(ctx, []registration.PlayerInfo{indivReg.PlayerInfo})
+		if err != nil {
+			return registrationDynamo{}, err
 		}
+		return registrationDynamo{
+			PK:              registrationPK(indivReg.EventID),
+			SK:              registrationSK(indivReg.Email),
+			Type:            indivReg.Type(),
+			ID:              indivReg.ID.String(),
+			Version:         indivReg.Version,
+			EventID:         indivReg.EventID.String(),
+			RegisteredAt:    indivReg.RegisteredAt,
+			HomeCity:        indivReg.HomeCity,
+			Paid:            indivReg.Status == registration.STATUS_PAID,
+			Status:          &indivReg.Status,
+			StatusHistory:   indivReg.StatusHistory,
+			Refunds:         slices.Map(indivReg.Refunds, refundToDynamo),
+			Transfers:       slices.Map(indivReg.Transfers, transferToDynamo),
+			PendingTransfer: pendingTransferToDynamo(indivReg.PendingTransfer),
+			OfflinePayment:  offlinePaymentToDynamo(indivReg.OfflinePayment),
+			PaymentIDs:      indivReg.PaymentIDs,
+			Email:           indivReg.Email,
+			PlayerInfo:      withoutMedicalInfo(indivReg.PlayerInfo),
+			Experience:      indivReg.Experience,
+
+			DuplicatePlayerEmails: indivReg.DuplicatePlayerEmails,
+			AdminNotes:            slices.Map(indivReg.AdminNotes, adminNoteToDynamo),
+			Tags:                  indivReg.Tags,
+
+			MedicalInfo: medicalInfo,
+		}, nil
 	case events.BY_TEAM:
 		teamReg := reg.(*registration.TeamRegistration)
-		return registrationDynamo{
-			PK:           registrationPK(teamReg.EventID),
-			SK:           registrationSK(teamReg.CaptainEmail),
-			Type:         teamReg.Type(),
-			ID:           teamReg.ID.String(),
-			Version:      teamReg.Version,
-			EventID:      teamReg.EventID.String(),
-			RegisteredAt: teamReg.RegisteredAt,
-			HomeCity:     teamReg.HomeCity,
-			Paid:         teamReg.Paid,
-			TeamName:     teamReg.TeamName,
-			CaptainEmail: teamReg.CaptainEmail,
-			Players:      teamReg.Players,
+		medicalInfo, err := d.encryptMedicalInfo(ctx, teamReg.Players)
+		if err != nil {
+			return registrationDynamo{}, err
 		}
+		return registrationDynamo{
+			PK:              registrationPK(teamReg.EventID),
+			SK:              registrationSK(teamReg.CaptainEmail),
+			Type:            teamReg.Type(),
+			ID:              teamReg.ID.String(),
+			Version:         teamReg.Version,
+			EventID:         teamReg.EventID.String(),
+			RegisteredAt:    teamReg.RegisteredAt,
+			HomeCity:        teamReg.HomeCity,
+			Paid:            teamReg.Status == registration.STATUS_PAID,
+			Status:          &teamReg.Status,
+			StatusHistory:   teamReg.StatusHistory,
+			Refunds:         slices.Map(teamReg.Refunds, refundToDynamo),
+			Transfers:       slices.Map(teamReg.Transfers, transferToDynamo),
+			PendingTransfer: pendingTransferToDynamo(teamReg.PendingTransfer),
+			OfflinePayment:  offlinePaymentToDynamo(teamReg.OfflinePayment),
+			PaymentIDs:      teamReg.PaymentIDs,
+			TeamName:        teamReg.TeamName,
+			CaptainEmail:    teamReg.CaptainEmail,
+			Players:         slices.Map(teamReg.Players, withoutMedicalInfo),
+			SplitPayment:    teamReg.SplitPayment,
+			PaymentDeadline: teamReg.PaymentDeadline,
+
+			DuplicatePlayerEmails: teamReg.DuplicatePlayerEmails,
+			AdminNotes:            slices.Map(teamReg.AdminNotes, adminNoteToDynamo),
+			Tags:                  teamReg.Tags,
+
+			MedicalInfo: medicalInfo,
+		}, nil
 	default:
 		panic("unknown registration type")
 	}
 }
 
-func dynamoToRegistration(dynReg registrationDynamo) registration.Registration {
+func (d *DB) dynamoToRegistration(ctx context.Context, dynReg registrationDynamo) (registration.Registration, error) {
+	medicalInfo, err := d.decryptMedicalInfo(ctx, dynReg.MedicalInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	switch dynReg.Type {
 	case events.BY_INDIVIDUAL:
-		return &registration.IndividualRegistration{
-			ID:           uuid.MustParse(dynReg.ID),
-			Version:      dynReg.Version,
-			EventID:      uuid.MustParse(dynReg.EventID),
-			RegisteredAt: dynReg.RegisteredAt,
-			HomeCity:     dynReg.HomeCity,
-			Paid:         dynReg.Paid,
-			Email:        dynReg.Email,
-			PlayerInfo:   dynReg.PlayerInfo,
-			Experience:   dynReg.Experience,
+		reg := &registration.IndividualRegistration{
+			ID:              uuid.MustParse(dynReg.ID),
+			Version:         dynReg.Version,
+			EventID:         uuid.MustParse(dynReg.EventID),
+			RegisteredAt:    dynReg.RegisteredAt,
+			HomeCity:        dynReg.HomeCity,
+			Status:          dynamoStatus(dynReg),
+			StatusHistory:   dynReg.StatusHistory,
+			Refunds:         slices.Map(dynReg.Refunds, dynamoToRefund),
+			Transfers:       slices.Map(dynReg.Transfers, dynamoToTransfer),
+			PendingTransfer: dynamoToPendingTransfer(dynReg.PendingTransfer),
+			OfflinePayment:  dynamoToOfflinePayment(dynReg.OfflinePayment),
+			PaymentIDs:      dynReg.PaymentIDs,
+			Email:           dynReg.Email,
+			PlayerInfo:      dynReg.PlayerInfo,
+			Experience:      dynReg.Experience,
+
+			DuplicatePlayerEmails: dynReg.DuplicatePlayerEmails,
+			AdminNotes:            slices.Map(dynReg.AdminNotes, dynamoToAdminNote),
+			Tags:                  dynReg.Tags,
 		}
+		if len(medicalInfo) > 0 {
+			withMedicalInfo(&reg.PlayerInfo, medicalInfo[0])
+		}
+		return reg, nil
 	case events.BY_TEAM:
-		return &registration.TeamRegistration{
-			ID:           uuid.MustParse(dynReg.ID),
-			Version:      dynReg.Version,
-			EventID:      uuid.MustParse(dynReg.EventID),
-			RegisteredAt: dynReg.RegisteredAt,
-			HomeCity:     dynReg.HomeCity,
-			Paid:         dynReg.Paid,
-			TeamName:     dynReg.TeamName,
-			CaptainEmail: dynReg.CaptainEmail,
-			Players:      dynReg.Players,
+		reg := &registration.TeamRegistration{
+			ID:              uuid.MustParse(dynReg.ID),
+			Version:         dynReg.Version,
+			EventID:         uuid.MustParse(dynReg.EventID),
+			RegisteredAt:    dynReg.RegisteredAt,
+			HomeCity:        dynReg.HomeCity,
+			Status:          dynamoStatus(dynReg),
+			StatusHistory:   dynReg.StatusHistory,
+			Refunds:         slices.Map(dynReg.Refunds, dynamoToRefund),
+			Transfers:       slices.Map(dynReg.Transfers, dynamoToTransfer),
+			PendingTransfer: dynamoToPendingTransfer(dynReg.PendingTransfer),
+			OfflinePayment:  dynamoToOfflinePayment(dynReg.OfflinePayment),
+			PaymentIDs:      dynReg.PaymentIDs,
+			TeamName:        dynReg.TeamName,
+			CaptainEmail:    dynReg.CaptainEmail,
+			Players:         dynReg.Players,
+			SplitPayment:    dynReg.SplitPayment,
+			PaymentDeadline: dynReg.PaymentDeadline,
+
+			DuplicatePlayerEmails: dynReg.DuplicatePlayerEmails,
+			AdminNotes:            slices.Map(dynReg.AdminNotes, dynamoToAdminNote),
+			Tags:                  dynReg.Tags,
 		}
+		for i := range min(len(reg.Players), len(medicalInfo)) {
+			withMedicalInfo(&reg.Players[i], medicalInfo[i])
+		}
+		return reg, nil
 	default:
 		panic("unknown registration type")
 	}
 }
 
+// dynamoStatus gets the status of a registration, working out what it should be for items
+// that were saved when only whether it was paid was tracked. Free sign ups from back then
+// look unpaid until registration.RewriteRegistrations confirms them.
+func dynamoStatus(dynReg registrationDynamo) registration.Status {
+	if dynReg.Status != nil {
+		return *dynReg.Status
+	}
+	return registration.StatusFromPaid(dynReg.Paid, false)
+}
+
+func refundToDynamo(refund registration.Refund) refundDynamo {
+	return refundDynamo{
+		ID:               refund.ID.String(),
+		PaymentID:        refund.PaymentID,
+		ProviderRefundID: refund.ProviderRefundID,
+		AmountValue:      refund.Amount.Amount(),
+		AmountCurrency:   refund.Amount.Currency().Code,
+		Reason:           refund.Reason,
+		RefundedBy:       refund.RefundedBy,
+		RefundedAt:       refund.RefundedAt.UTC(),
+		ReleasedSpot:     refund.ReleasedSpot,
+	}
+}
+
+func dynamoToRefund(refund refundDynamo) registration.Refund {
+	return registration.Refund{
+		ID:               uuid.MustParse(refund.ID),
+		PaymentID:        refund.PaymentID,
+		ProviderRefundID: refund.ProviderRefundID,
+		Amount:           money.New(refund.AmountValue, refund.AmountCurrency),
+		Reason:           refund.Reason,
+		RefundedBy:       refund.RefundedBy,
+		RefundedAt:       refund.RefundedAt,
+		ReleasedSpot:     refund.ReleasedSpot,
+	}
+}
+
+func transferToDynamo(transfer registration.Transfer) transferDynamo {
+	dynTransfer := transferDynamo{
+		ID:            transfer.ID.String(),
+		FromEventID:   transfer.FromEventID.String(),
+		FromEmail:     transfer.FromEmail,
+		ToEventID:     transfer.ToEventID.String(),
+		ToEmail:       transfer.ToEmail,
+		ChargePaid:    transfer.ChargePaid,
+		Reason:        transfer.Reason,
+		TransferredBy: transfer.TransferredBy,
+		TransferredAt: transfer.TransferredAt.UTC(),
+	}
+	if transfer.PriceDifference != nil {
+		dynTransfer.PriceDifferenceValue = aws.Int64(transfer.PriceDifference.Amount())
+		dynTransfer.PriceDifferenceCurrency = transfer.PriceDifference.Currency().Code
+	}
+	if transfer.RefundID != nil {
+		dynTransfer.RefundID = aws.String(transfer.RefundID.String())
+	}
+	return dynTransfer
+}
+
+func pendingTransferToDynamo(pending *registration.PendingTransfer) *pendingTransferDynamo {
+	if pending == nil {
+		return nil
+	}
+	return &pendingTransferDynamo{
+		Transfer:  transferToDynamo(pending.Transfer),
+		NewPlayer: pending.NewPlayer,
+	}
+}
+
+func dynamoToPendingTransfer(pending *pendingTransferDynamo) *registration.PendingTransfer {
+	if pending == nil {
+		return nil
+	}
+	return &registration.PendingTransfer{
+		Transfer:  dynamoToTransfer(pending.Transfer),
+		NewPlayer: pending.NewPlayer,
+	}
+}
+
+func offlinePaymentToDynamo(payment *registration.OfflinePayment) *offlinePaymentDynamo {
+	if payment == nil {
+		return nil
+	}
+	dynPayment := &offlinePaymentDynamo{
+		Method:     payment.Method,
+		Note:       payment.Note,
+		RecordedBy: payment.RecordedBy,
+		RecordedAt: payment.RecordedAt.UTC(),
+	}
+	if payment.Amount != nil {
+		dynPayment.AmountValue = aws.Int64(payment.Amount.Amount())
+		dynPayment.AmountCurrency = payment.Amount.Currency().Code
+	}
+	return dynPayment
+}
+
+func dynamoToOfflinePayment(payment *offlinePaymentDynamo) *registration.OfflinePayment {
+	if payment == nil {
+		return nil
+	}
+	result := &registration.OfflinePayment{
+		Method:     payment.Method,
+		Note:       payment.Note,
+		RecordedBy: payment.RecordedBy,
+		RecordedAt: payment.RecordedAt,
+	}
+	if payment.AmountValue != nil {
+		result.Amount = money.New(*payment.AmountValue, payment.AmountCurrency)
+	}
+	return result
+}
+
+func adminNoteToDynamo(note registration.AdminNote) adminNoteDynamo {
+	return adminNoteDynamo{
+		ID:        note.ID.String(),
+		Text:      note.Text,
+		Author:    note.Author,
+		CreatedAt: note.CreatedAt.UTC(),
+	}
+}
+
+func dynamoToAdminNote(note adminNoteDynamo) registration.AdminNote {
+	return registration.AdminNote{
+		ID:        uuid.MustParse(note.ID),
+		Text:      note.Text,
+		Author:    note.Author,
+		CreatedAt: note.CreatedAt,
+	}
+}
+
+func dynamoToTransfer(transfer transferDynamo) registration.Transfer {
+	result := registration.Transfer{
+		ID:            uuid.MustParse(transfer.ID),
+		FromEventID:   uuid.MustParse(transfer.FromEventID),
+		FromEmail:     transfer.FromEmail,
+		ToEventID:     uuid.MustParse(transfer.ToEventID),
+		ToEmail:       transfer.ToEmail,
+		ChargePaid:    transfer.ChargePaid,
+		Reason:        transfer.Reason,
+		TransferredBy: transfer.TransferredBy,
+		TransferredAt: transfer.TransferredAt,
+	}
+	if transfer.PriceDifferenceValue != nil {
+		result.PriceDifference = money.New(*transfer.PriceDifferenceValue, transfer.PriceDifferenceCurrency)
+	}
+	if transfer.RefundID != nil {
+		refundId := uuid.MustParse(*transfer.RefundID)
+		result.RefundID = &refundId
+	}
+	return result
+}
+
 func (d *DB) GetRegistration(ctx context.Context, eventId uuid.UUID, email string) (registration.Registration, error) {
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
@@ -138,39 +444,549 @@ func (d *DB) GetRegistration(ctx context.Context, eventId uuid.UUID, email strin
 		},
 	})
 	if err != nil {
-		if errors.Is(err, context.DeadlineExceeded) {
-			return nil, registration.NewTimeoutError("GetRegistration timed out")
-		}
-		return nil, registration.NewFailedToFetchError(fmt.Sprintf("Failed to fetch registration for event ID %q and email %s", eventId, email), err)
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, registration.NewTimeoutError("GetRegistration timed out")
+		}
+		return nil, registration.NewFailedToFetchError(fmt.Sprintf("Failed to fetch registration for event ID %q and email %s", eventId, email), err)
+	}
+
+	if len(resp.Item) == 0 {
+		return nil, registration.NewRegistrationDoesNotExistsError(fmt.Sprintf("Registration for event ID %q and email %s not found", eventId, email), nil)
+	}
+
+	var reg registrationDynamo
+	err = attributevalue.UnmarshalMap(resp.Item, &reg)
+	if err != nil {
+		panic(fmt.Sprintf("failed to unmarshal registration from DB: %s", err))
+	}
+	return d.dynamoToRegistration(ctx, reg)
+}
+
+func (d *DB) CreateRegistration(ctx context.Context, reg registration.Registration, event events.Event, outbox []registration.OutboxItem) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
+
+	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
+	regExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(newEntityVersionConditional(dynamoReg.Version)))
+
+	dynamoEvent := newEventDynamo(event)
+
+	eventItem, err := attributevalue.MarshalMap(dynamoEvent)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
+	}
+	eventExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(event.Version)))
+
+	registrantItems, err := d.newRegistrantPuts(reg)
+	if err != nil {
+		return err
+	}
+
+	outboxItems, err := d.outboxPuts(outbox)
+	if err != nil {
+		return err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regItem,
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      eventItem,
+				ConditionExpression:       eventExpr.Condition(),
+				ExpressionAttributeNames:  eventExpr.Names(),
+				ExpressionAttributeValues: eventExpr.Values(),
+			},
+		},
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(append(transactItems, registrantItems...), outboxItems...),
+	})
+	if err != nil {
+		var transactionFailedErr *types.TransactionCanceledException
+		if errors.As(err, &transactionFailedErr) {
+			if transactionFailedErr.CancellationReasons[0].Code != nil && *transactionFailedErr.CancellationReasons[0].Code == "ConditionalCheckFailed" {
+				return registration.NewRegistrationAlreadyExistsError(fmt.Sprintf("Registration with ID %q already exists", dynamoReg.ID), err)
+			}
+			if conflictErr := registrantConflictError(transactionFailedErr); conflictErr != nil {
+				return conflictErr
+			}
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("CreateRegistration timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed PutItem call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) CreateRegistrations(ctx context.Context, regs []registration.Registration, event events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	transactItems := make([]types.TransactWriteItem, 0, len(regs)+1)
+	for _, reg := range regs {
+		dynamoReg, err := d.registrationToDynamo(ctx, reg)
+		if err != nil {
+			return err
+		}
+
+		regItem, err := attributevalue.MarshalMap(dynamoReg)
+		if err != nil {
+			return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+		}
+		regExpr := exprMustBuild(expression.NewBuilder().
+			WithCondition(newEntityVersionConditional(dynamoReg.Version)))
+
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regItem,
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
+			},
+		})
+	}
+
+	dynamoEvent := newEventDynamo(event)
+	eventItem, err := attributevalue.MarshalMap(dynamoEvent)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
+	}
+	eventExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(event.Version)))
+
+	transactItems = append(transactItems, types.TransactWriteItem{
+		Put: &types.Put{
+			TableName:                 aws.String(d.tableName),
+			Item:                      eventItem,
+			ConditionExpression:       eventExpr.Condition(),
+			ExpressionAttributeNames:  eventExpr.Names(),
+			ExpressionAttributeValues: eventExpr.Values(),
+		},
+	})
+
+	// The registrants go last so the registrations line up with the cancellation reasons
+	for _, reg := range regs {
+		registrantItems, err := d.newRegistrantPuts(reg)
+		if err != nil {
+			return err
+		}
+		transactItems = append(transactItems, registrantItems...)
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		var transactionFailedErr *types.TransactionCanceledException
+		if errors.As(err, &transactionFailedErr) {
+			// The first items are the registrations
+			for i, reason := range transactionFailedErr.CancellationReasons[:len(regs)] {
+				if reason.Code != nil && *reason.Code == "ConditionalCheckFailed" {
+					return registration.NewRegistrationAlreadyExistsError(fmt.Sprintf("Registration for %s already exists", regs[i].GetEmail()), err)
+				}
+			}
+			if conflictErr := registrantConflictError(transactionFailedErr); conflictErr != nil {
+				return conflictErr
+			}
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("CreateRegistrations timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) CreateRegistrationWithPayment(ctx context.Context, reg registration.Registration, regIntent registration.RegistrationIntent, event events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
+
+	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
+	regExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(newEntityVersionConditional(dynamoReg.Version)))
+
+	dynamoRegIntent := regIntentToDynamo(regIntent)
+
+	regIntentItem, err := attributevalue.MarshalMap(dynamoRegIntent)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate regIntent to dynamo model", err)
+	}
+	regIntentExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(newEntityVersionConditional(dynamoRegIntent.Version)))
+
+	dynamoEvent := newEventDynamo(event)
+
+	eventItem, err := attributevalue.MarshalMap(dynamoEvent)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
+	}
+	eventExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(event.Version)))
+
+	registrantItems, err := d.newRegistrantPuts(reg)
+	if err != nil {
+		return err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regItem,
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regIntentItem,
+				ConditionExpression:       regIntentExpr.Condition(),
+				ExpressionAttributeNames:  regIntentExpr.Names(),
+				ExpressionAttributeValues: regIntentExpr.Values(),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      eventItem,
+				ConditionExpression:       eventExpr.Condition(),
+				ExpressionAttributeNames:  eventExpr.Names(),
+				ExpressionAttributeValues: eventExpr.Values(),
+			},
+		},
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(transactItems, registrantItems...),
+	})
+	if err != nil {
+		var transactionFailedErr *types.TransactionCanceledException
+		if errors.As(err, &transactionFailedErr) {
+			if transactionFailedErr.CancellationReasons[0].Code != nil && *transactionFailedErr.CancellationReasons[0].Code == "ConditionalCheckFailed" {
+				return registration.NewRegistrationAlreadyExistsError(fmt.Sprintf("Registration with ID %q already exists", dynamoReg.ID), err)
+			}
+			if conflictErr := registrantConflictError(transactionFailedErr); conflictErr != nil {
+				return conflictErr
+			}
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("CreateRegistration timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed PutItem call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) UpdateRegistrationToPaid(ctx context.Context, reg registration.Registration, outbox []registration.OutboxItem) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
+
+	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
+	regExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(dynamoReg.Version)))
+
+	outboxItems, err := d.outboxPuts(outbox)
+	if err != nil {
+		return err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regItem,
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
+			},
+		},
+		{
+			Delete: &types.Delete{
+				TableName: aws.String(d.tableName),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: registrationIntentPK(reg.GetEventID())},
+					"SK": &types.AttributeValueMemberS{Value: registrationIntentSK(reg.GetEmail())},
+				},
+			},
+		},
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(transactItems, outboxItems...),
+	})
+	if err != nil {
+		var transactionFailedErr *types.TransactionCanceledException
+		if errors.As(err, &transactionFailedErr) {
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("UpdateRegistrationToPaid timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed PutItem call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) UpdateRegistration(ctx context.Context, reg registration.Registration) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
+
+	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
+	regExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(dynamoReg.Version)))
+
+	// Rewriting the registrants keeps the email index filled in for registrations made before it existed
+	registrantItems, err := d.registrantPuts(reg)
+	if err != nil {
+		return err
+	}
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regItem,
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
+			},
+		},
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(transactItems, registrantItems...),
+	})
+	if err != nil {
+		var transactionFailedErr *types.TransactionCanceledException
+		if errors.As(err, &transactionFailedErr) {
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("UpdateRegistration timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) UpdateRegistrationWithEvent(ctx context.Context, reg registration.Registration, event events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
+
+	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
+	regExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(dynamoReg.Version)))
+
+	dynamoEvent := newEventDynamo(event)
+
+	eventItem, err := attributevalue.MarshalMap(dynamoEvent)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
+	}
+	eventExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(event.Version)))
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{
+				Put: &types.Put{
+					TableName:                 aws.String(d.tableName),
+					Item:                      regItem,
+					ConditionExpression:       regExpr.Condition(),
+					ExpressionAttributeNames:  regExpr.Names(),
+					ExpressionAttributeValues: regExpr.Values(),
+				},
+			},
+			{
+				Put: &types.Put{
+					TableName:                 aws.String(d.tableName),
+					Item:                      eventItem,
+					ConditionExpression:       eventExpr.Condition(),
+					ExpressionAttributeNames:  eventExpr.Names(),
+					ExpressionAttributeValues: eventExpr.Values(),
+				},
+			},
+		},
+	})
+	if err != nil {
+		var transactionFailedErr *types.TransactionCanceledException
+		if errors.As(err, &transactionFailedErr) {
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("UpdateRegistrationWithEvent timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) ReplaceExpiredRegistrationWithPayment(ctx context.Context, expired registration.Registration, expiredIntent registration.RegistrationIntent, reg registration.Registration, regIntent registration.RegistrationIntent, event events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
+	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
+	// Same key as the expired registration, so this only has to check it's still the expired one
+	regExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(deleteEntityVersionConditional(expired.GetVersion())))
+
+	regIntentItem, err := attributevalue.MarshalMap(regIntentToDynamo(regIntent))
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate regIntent to dynamo model", err)
+	}
+	regIntentExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(deleteEntityVersionConditional(expiredIntent.Version)))
+
+	eventItem, err := attributevalue.MarshalMap(newEventDynamo(event))
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
+	}
+	eventExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(existingEntityVersionConditional(event.Version)))
+
+	registrantItems, err := d.registrantTransferItems(expired, reg)
+	if err != nil {
+		return err
 	}
 
-	if len(resp.Item) == 0 {
-		return nil, registration.NewRegistrationDoesNotExistsError(fmt.Sprintf("Registration for event ID %q and email %s not found", eventId, email), nil)
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regItem,
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regIntentItem,
+				ConditionExpression:       regIntentExpr.Condition(),
+				ExpressionAttributeNames:  regIntentExpr.Names(),
+				ExpressionAttributeValues: regIntentExpr.Values(),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      eventItem,
+				ConditionExpression:       eventExpr.Condition(),
+				ExpressionAttributeNames:  eventExpr.Names(),
+				ExpressionAttributeValues: eventExpr.Values(),
+			},
+		},
 	}
 
-	var reg registrationDynamo
-	err = attributevalue.UnmarshalMap(resp.Item, &reg)
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(transactItems, registrantItems...),
+	})
 	if err != nil {
-		panic(fmt.Sprintf("failed to unmarshal registration from DB: %s", err))
+		var transactionFailedErr *types.TransactionCanceledException
+		if errors.As(err, &transactionFailedErr) {
+			if conflictErr := registrantConflictError(transactionFailedErr); conflictErr != nil {
+				return conflictErr
+			}
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("ReplaceExpiredRegistrationWithPayment timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
+		}
 	}
-	return dynamoToRegistration(reg), nil
+
+	return nil
 }
 
-func (d *DB) CreateRegistration(ctx context.Context, reg registration.Registration, event events.Event) error {
+func (d *DB) DeleteExpiredRegistration(ctx context.Context, reg registration.Registration, regIntent registration.RegistrationIntent, event events.Event) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
-	dynamoReg := registrationToDynamo(reg)
-
-	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
 	if err != nil {
-		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+		return err
 	}
 	regExpr := exprMustBuild(expression.NewBuilder().
-		WithCondition(newEntityVersionConditional(dynamoReg.Version)))
+		WithCondition(deleteEntityVersionConditional(dynamoReg.Version)))
 
-	dynamoEvent := newEventDynamo(event)
+	dynamoRegIntent := regIntentToDynamo(regIntent)
+	regIntentExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(deleteEntityVersionConditional(dynamoRegIntent.Version)))
 
+	dynamoEvent := newEventDynamo(event)
 	eventItem, err := attributevalue.MarshalMap(dynamoEvent)
 	if err != nil {
 		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
@@ -178,37 +994,52 @@ func (d *DB) CreateRegistration(ctx context.Context, reg registration.Registrati
 	eventExpr := exprMustBuild(expression.NewBuilder().
 		WithCondition(existingEntityVersionConditional(event.Version)))
 
-	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
-		TransactItems: []types.TransactWriteItem{
-			{
-				Put: &types.Put{
-					TableName:                 aws.String(d.tableName),
-					Item:                      regItem,
-					ConditionExpression:       regExpr.Condition(),
-					ExpressionAttributeNames:  regExpr.Names(),
-					ExpressionAttributeValues: regExpr.Values(),
+	transactItems := []types.TransactWriteItem{
+		// Delete the reg, reg intent and registrants, update the event to have the updated stats
+		{
+			Delete: &types.Delete{
+				TableName: aws.String(d.tableName),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: dynamoReg.PK},
+					"SK": &types.AttributeValueMemberS{Value: dynamoReg.SK},
 				},
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
 			},
-			{
-				Put: &types.Put{
-					TableName:                 aws.String(d.tableName),
-					Item:                      eventItem,
-					ConditionExpression:       eventExpr.Condition(),
-					ExpressionAttributeNames:  eventExpr.Names(),
-					ExpressionAttributeValues: eventExpr.Values(),
+		},
+		{
+			Delete: &types.Delete{
+				TableName: aws.String(d.tableName),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: dynamoRegIntent.PK},
+					"SK": &types.AttributeValueMemberS{Value: dynamoRegIntent.SK},
 				},
+				ConditionExpression:       regIntentExpr.Condition(),
+				ExpressionAttributeNames:  regIntentExpr.Names(),
+				ExpressionAttributeValues: regIntentExpr.Values(),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      eventItem,
+				ConditionExpression:       eventExpr.Condition(),
+				ExpressionAttributeNames:  eventExpr.Names(),
+				ExpressionAttributeValues: eventExpr.Values(),
 			},
 		},
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(transactItems, d.registrantDeletes(reg)...),
 	})
 	if err != nil {
 		var transactionFailedErr *types.TransactionCanceledException
 		if errors.As(err, &transactionFailedErr) {
-			if transactionFailedErr.CancellationReasons[0].Code != nil {
-				return registration.NewRegistrationAlreadyExistsError(fmt.Sprintf("Registration with ID %q already exists", dynamoReg.ID), err)
-			}
 			return registration.NewFailedToWriteError("Version conflict error", err)
 		} else if errors.Is(err, context.DeadlineExceeded) {
-			return registration.NewTimeoutError("CreateRegistration timed out")
+			return registration.NewTimeoutError("DeleteExpiredRegistration timed out")
 		} else {
 			return registration.NewFailedToWriteError("Failed PutItem call", err)
 		}
@@ -217,37 +1048,172 @@ func (d *DB) CreateRegistration(ctx context.Context, reg registration.Registrati
 	return nil
 }
 
-func (d *DB) CreateRegistrationWithPayment(ctx context.Context, reg registration.Registration, regIntent registration.RegistrationIntent, event events.Event) error {
+func (d *DB) TransferRegistration(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	transactItems, err := d.transferItems(ctx, from, to, eventUpdates)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: transactItems,
+	})
+	if err != nil {
+		return transferError(err, to, "TransferRegistration")
+	}
+
+	return nil
+}
+
+func (d *DB) TransferRegistrationToPaid(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event, outbox []registration.OutboxItem) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
-	dynamoReg := registrationToDynamo(reg)
+	transactItems, err := d.transferItems(ctx, from, to, eventUpdates)
+	if err != nil {
+		return err
+	}
+
+	outboxItems, err := d.outboxPuts(outbox)
+	if err != nil {
+		return err
+	}
+
+	// The intent was for the checkout of the price difference, which is under the old key
+	transactItems = append(transactItems, types.TransactWriteItem{
+		Delete: &types.Delete{
+			TableName: aws.String(d.tableName),
+			Key: map[string]types.AttributeValue{
+				"PK": &types.AttributeValueMemberS{Value: registrationIntentPK(from.GetEventID())},
+				"SK": &types.AttributeValueMemberS{Value: registrationIntentSK(from.GetEmail())},
+			},
+		},
+	})
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(transactItems, outboxItems...),
+	})
+	if err != nil {
+		return transferError(err, to, "TransferRegistrationToPaid")
+	}
+
+	return nil
+}
+
+// transferItems are the transaction items that replace from with to, starting with from's delete
+// and to's put so transferError can tell when to's key was already taken.
+func (d *DB) transferItems(ctx context.Context, from registration.Registration, to registration.Registration, eventUpdates []events.Event) ([]types.TransactWriteItem, error) {
+	dynamoFrom, err := d.registrationToDynamo(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	fromExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(deleteEntityVersionConditional(dynamoFrom.Version)))
+
+	dynamoTo, err := d.registrationToDynamo(ctx, to)
+	if err != nil {
+		return nil, err
+	}
+	toItem, err := attributevalue.MarshalMap(dynamoTo)
+	if err != nil {
+		return nil, registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
+	// The new key has to be free, but the registration keeps counting versions from where it was
+	toExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(expression.Name("PK").AttributeNotExists()))
+
+	transactItems := []types.TransactWriteItem{
+		{
+			Delete: &types.Delete{
+				TableName: aws.String(d.tableName),
+				Key: map[string]types.AttributeValue{
+					"PK": &types.AttributeValueMemberS{Value: dynamoFrom.PK},
+					"SK": &types.AttributeValueMemberS{Value: dynamoFrom.SK},
+				},
+				ConditionExpression:       fromExpr.Condition(),
+				ExpressionAttributeNames:  fromExpr.Names(),
+				ExpressionAttributeValues: fromExpr.Values(),
+			},
+		},
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      toItem,
+				ConditionExpression:       toExpr.Condition(),
+				ExpressionAttributeNames:  toExpr.Names(),
+				ExpressionAttributeValues: toExpr.Values(),
+			},
+		},
+	}
+
+	for _, event := range eventUpdates {
+		eventItem, err := attributevalue.MarshalMap(newEventDynamo(event))
+		if err != nil {
+			return nil, registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
+		}
+		eventExpr := exprMustBuild(expression.NewBuilder().
+			WithCondition(existingEntityVersionConditional(event.Version)))
+
+		transactItems = append(transactItems, types.TransactWriteItem{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      eventItem,
+				ConditionExpression:       eventExpr.Condition(),
+				ExpressionAttributeNames:  eventExpr.Names(),
+				ExpressionAttributeValues: eventExpr.Values(),
+			},
+		})
+	}
+
+	registrantItems, err := d.registrantTransferItems(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return append(transactItems, registrantItems...), nil
+}
+
+func transferError(err error, to registration.Registration, name string) error {
+	var transactionFailedErr *types.TransactionCanceledException
+	if errors.As(err, &transactionFailedErr) {
+		if transactionFailedErr.CancellationReasons[1].Code != nil && *transactionFailedErr.CancellationReasons[1].Code == "ConditionalCheckFailed" {
+			return registration.NewRegistrationAlreadyExistsError(fmt.Sprintf("Registration for %s already exists at event ID %q", to.GetEmail(), to.GetEventID()), err)
+		}
+		if conflictErr := registrantConflictError(transactionFailedErr); conflictErr != nil {
+			return conflictErr
+		}
+		return registration.NewFailedToWriteError("Version conflict error", err)
+	} else if errors.Is(err, context.DeadlineExceeded) {
+		return registration.NewTimeoutError(fmt.Sprintf("%s timed out", name))
+	} else {
+		return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
+	}
+}
+
+func (d *DB) CreateTransferWithPayment(ctx context.Context, reg registration.Registration, regIntent registration.RegistrationIntent) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
 
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
 	regItem, err := attributevalue.MarshalMap(dynamoReg)
 	if err != nil {
 		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
 	}
 	regExpr := exprMustBuild(expression.NewBuilder().
-		WithCondition(newEntityVersionConditional(dynamoReg.Version)))
+		WithCondition(existingEntityVersionConditional(dynamoReg.Version)))
 
 	dynamoRegIntent := regIntentToDynamo(regIntent)
-
 	regIntentItem, err := attributevalue.MarshalMap(dynamoRegIntent)
 	if err != nil {
-		return registration.NewFailedToTranslateToDBModelError("Failed to translate regIntent to dynamo model", err)
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration intent to dynamo model", err)
 	}
 	regIntentExpr := exprMustBuild(expression.NewBuilder().
 		WithCondition(newEntityVersionConditional(dynamoRegIntent.Version)))
 
-	dynamoEvent := newEventDynamo(event)
-
-	eventItem, err := attributevalue.MarshalMap(dynamoEvent)
-	if err != nil {
-		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
-	}
-	eventExpr := exprMustBuild(expression.NewBuilder().
-		WithCondition(existingEntityVersionConditional(event.Version)))
-
 	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
 		TransactItems: []types.TransactWriteItem{
 			{
@@ -268,40 +1234,30 @@ func (d *DB) CreateRegistrationWithPayment(ctx context.Context, reg registration
 					ExpressionAttributeValues: regIntentExpr.Values(),
 				},
 			},
-			{
-				Put: &types.Put{
-					TableName:                 aws.String(d.tableName),
-					Item:                      eventItem,
-					ConditionExpression:       eventExpr.Condition(),
-					ExpressionAttributeNames:  eventExpr.Names(),
-					ExpressionAttributeValues: eventExpr.Values(),
-				},
-			},
 		},
 	})
 	if err != nil {
 		var transactionFailedErr *types.TransactionCanceledException
 		if errors.As(err, &transactionFailedErr) {
-			if transactionFailedErr.CancellationReasons[0].Code != nil {
-				return registration.NewRegistrationAlreadyExistsError(fmt.Sprintf("Registration with ID %q already exists", dynamoReg.ID), err)
-			}
 			return registration.NewFailedToWriteError("Version conflict error", err)
 		} else if errors.Is(err, context.DeadlineExceeded) {
-			return registration.NewTimeoutError("CreateRegistration timed out")
+			return registration.NewTimeoutError("CreateTransferWithPayment timed out")
 		} else {
-			return registration.NewFailedToWriteError("Failed PutItem call", err)
+			return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
 		}
 	}
 
 	return nil
 }
 
-func (d *DB) UpdateRegistrationToPaid(ctx context.Context, reg registration.Registration) error {
+func (d *DB) DeleteExpiredTransfer(ctx context.Context, reg registration.Registration, regIntent registration.RegistrationIntent) error {
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
-	dynamoReg := registrationToDynamo(reg)
-
+	dynamoReg, err := d.registrationToDynamo(ctx, reg)
+	if err != nil {
+		return err
+	}
 	regItem, err := attributevalue.MarshalMap(dynamoReg)
 	if err != nil {
 		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
@@ -309,6 +1265,10 @@ func (d *DB) UpdateRegistrationToPaid(ctx context.Context, reg registration.Regi
 	regExpr := exprMustBuild(expression.NewBuilder().
 		WithCondition(existingEntityVersionConditional(dynamoReg.Version)))
 
+	dynamoRegIntent := regIntentToDynamo(regIntent)
+	regIntentExpr := exprMustBuild(expression.NewBuilder().
+		WithCondition(deleteEntityVersionConditional(dynamoRegIntent.Version)))
+
 	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
 		TransactItems: []types.TransactWriteItem{
 			{
@@ -324,9 +1284,12 @@ func (d *DB) UpdateRegistrationToPaid(ctx context.Context, reg registration.Regi
 				Delete: &types.Delete{
 					TableName: aws.String(d.tableName),
 					Key: map[string]types.AttributeValue{
-						"PK": &types.AttributeValueMemberS{Value: registrationIntentPK(reg.GetEventID())},
-						"SK": &types.AttributeValueMemberS{Value: registrationIntentSK(reg.GetEmail())},
+						"PK": &types.AttributeValueMemberS{Value: dynamoRegIntent.PK},
+						"SK": &types.AttributeValueMemberS{Value: dynamoRegIntent.SK},
 					},
+					ConditionExpression:       regIntentExpr.Condition(),
+					ExpressionAttributeNames:  regIntentExpr.Names(),
+					ExpressionAttributeValues: regIntentExpr.Values(),
 				},
 			},
 		},
@@ -336,81 +1299,64 @@ func (d *DB) UpdateRegistrationToPaid(ctx context.Context, reg registration.Regi
 		if errors.As(err, &transactionFailedErr) {
 			return registration.NewFailedToWriteError("Version conflict error", err)
 		} else if errors.Is(err, context.DeadlineExceeded) {
-			return registration.NewTimeoutError("UpdateRegistrationToPaid timed out")
+			return registration.NewTimeoutError("DeleteExpiredTransfer timed out")
 		} else {
-			return registration.NewFailedToWriteError("Failed PutItem call", err)
+			return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
 		}
 	}
 
 	return nil
 }
 
-func (d *DB) DeleteExpiredRegistration(ctx context.Context, reg registration.Registration, regIntent registration.RegistrationIntent, event events.Event) error {
+func (d *DB) AnonymizeRegistration(ctx context.Context, from registration.Registration, to registration.Registration) error {
+	if from.GetEmail() != to.GetEmail() {
+		// The key changes, which is the same as handing the registration to someone else
+		return d.TransferRegistration(ctx, from, to, nil)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, time.Second)
 	defer cancel()
 
-	dynamoReg := registrationToDynamo(reg)
+	dynamoReg, err := d.registrationToDynamo(ctx, to)
+	if err != nil {
+		return err
+	}
+	regItem, err := attributevalue.MarshalMap(dynamoReg)
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate registration to dynamo model", err)
+	}
 	regExpr := exprMustBuild(expression.NewBuilder().
-		WithCondition(deleteEntityVersionConditional(dynamoReg.Version)))
-
-	dynamoRegIntent := regIntentToDynamo(regIntent)
-	regIntentExpr := exprMustBuild(expression.NewBuilder().
-		WithCondition(deleteEntityVersionConditional(dynamoRegIntent.Version)))
+		WithCondition(existingEntityVersionConditional(dynamoReg.Version)))
 
-	dynamoEvent := newEventDynamo(event)
-	eventItem, err := attributevalue.MarshalMap(dynamoEvent)
+	// Removes the erased emails from the index and rewrites the rest
+	registrantItems, err := d.registrantTransferItems(from, to)
 	if err != nil {
-		return registration.NewFailedToTranslateToDBModelError("Failed to translate event to dynamo model", err)
+		return err
 	}
-	eventExpr := exprMustBuild(expression.NewBuilder().
-		WithCondition(existingEntityVersionConditional(event.Version)))
 
-	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
-		TransactItems: []types.TransactWriteItem{
-			// Delete the reg and reg intent, update the event to have the updated stats
-			{
-				Delete: &types.Delete{
-					TableName: aws.String(d.tableName),
-					Key: map[string]types.AttributeValue{
-						"PK": &types.AttributeValueMemberS{Value: dynamoReg.PK},
-						"SK": &types.AttributeValueMemberS{Value: dynamoReg.SK},
-					},
-					ConditionExpression:       regExpr.Condition(),
-					ExpressionAttributeNames:  regExpr.Names(),
-					ExpressionAttributeValues: regExpr.Values(),
-				},
-			},
-			{
-				Delete: &types.Delete{
-					TableName: aws.String(d.tableName),
-					Key: map[string]types.AttributeValue{
-						"PK": &types.AttributeValueMemberS{Value: dynamoRegIntent.PK},
-						"SK": &types.AttributeValueMemberS{Value: dynamoRegIntent.SK},
-					},
-					ConditionExpression:       regIntentExpr.Condition(),
-					ExpressionAttributeNames:  regIntentExpr.Names(),
-					ExpressionAttributeValues: regIntentExpr.Values(),
-				},
-			},
-			{
-				Put: &types.Put{
-					TableName:                 aws.String(d.tableName),
-					Item:                      eventItem,
-					ConditionExpression:       eventExpr.Condition(),
-					ExpressionAttributeNames:  eventExpr.Names(),
-					ExpressionAttributeValues: eventExpr.Values(),
-				},
+	transactItems := []types.TransactWriteItem{
+		{
+			Put: &types.Put{
+				TableName:                 aws.String(d.tableName),
+				Item:                      regItem,
+				ConditionExpression:       regExpr.Condition(),
+				ExpressionAttributeNames:  regExpr.Names(),
+				ExpressionAttributeValues: regExpr.Values(),
 			},
 		},
+	}
+
+	_, err = d.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: append(transactItems, registrantItems...),
 	})
 	if err != nil {
 		var transactionFailedErr *types.TransactionCanceledException
 		if errors.As(err, &transactionFailedErr) {
 			return registration.NewFailedToWriteError("Version conflict error", err)
 		} else if errors.Is(err, context.DeadlineExceeded) {
-			return registration.NewTimeoutError("DeleteExpiredRegistration timed out")
+			return registration.NewTimeoutError("AnonymizeRegistration timed out")
 		} else {
-			return registration.NewFailedToWriteError("Failed PutItem call", err)
+			return registration.NewFailedToWriteError("Failed TransactWriteItems call", err)
 		}
 	}
 
@@ -473,11 +1419,72 @@ func (d *DB) GetAllRegistrationsForEvent(ctx context.Context, eventId uuid.UUID,
 		newCursor = &c
 	}
 
+	regs := make([]registration.Registration, 0, len(dynamoItems))
+	for _, v := range dynamoItems[:min(int(limit), len(dynamoItems))] {
+		reg, err := d.dynamoToRegistration(ctx, v)
+		if err != nil {
+			return registration.GetAllRegistrationsResponse{}, err
+		}
+		regs = append(regs, reg)
+	}
+
 	return registration.GetAllRegistrationsResponse{
-		Data: slices.Map(dynamoItems, func(v registrationDynamo) registration.Registration {
-			return dynamoToRegistration(v)
-		})[:min(int(limit), len(dynamoItems))],
+		Data:        regs,
 		Cursor:      newCursor,
 		HasNextPage: hasNextPage,
 	}, nil
 }
+
+func withoutMedicalInfo(player registration.PlayerInfo) registration.PlayerInfo {
+	player.EmergencyContact = nil
+	player.MedicalNotes = ""
+	return player
+}
+
+func withMedicalInfo(player *registration.PlayerInfo, info medicalInfoDynamo) {
+	player.EmergencyContact = info.EmergencyContact
+	player.MedicalNotes = info.MedicalNotes
+}
+
+func (d *DB) encryptMedicalInfo(ctx context.Context, players []registration.PlayerInfo) ([]byte, error) {
+	var hasMedicalInfo bool
+	info := make([]medicalInfoDynamo, 0, len(players))
+	for _, player := range players {
+		hasMedicalInfo = hasMedicalInfo || player.HasMedicalInfo()
+		info = append(info, medicalInfoDynamo{
+			EmergencyContact: player.EmergencyContact,
+			MedicalNotes:     player.MedicalNotes,
+		})
+	}
+	if !hasMedicalInfo {
+		return nil, nil
+	}
+	plaintext, err := json.Marshal(info)
+	if err != nil {
+		return nil, registration.NewFailedToTranslateToDBModelError("Failed to marshal medical info", err)
+	}
+
+	encrypted, err := d.encrypter.Encrypt(ctx, plaintext)
+	if err != nil {
+		return nil, registration.NewFailedToTranslateToDBModelError("Failed to encrypt medical info", err)
+	}
+	return encrypted, nil
+}
+
+func (d *DB) decryptMedicalInfo(ctx context.Context, encrypted []byte) ([]medicalInfoDynamo, error) {
+	if len(encrypted) == 0 {
+		return nil, nil
+	}
+
+	plaintext, err := d.encrypter.Decrypt(ctx, encrypted)
+	if err != nil {
+		return nil, registration.NewFailedToFetchError("Failed to decrypt medical info", err)
+	}
+
+	var info []medicalInfoDynamo
+	err = json.Unmarshal(plaintext, &info)
+	if err != nil {
+		return nil, registration.NewFailedToFetchError("Failed to unmarshal medical info", err)
+	}
+	return info, nil
+}
@@ -3,10 +3,15 @@ package dynamo
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
 	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/Rhymond/go-money"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -27,14 +32,14 @@ func TestCreateRegistration(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Test City",
-			Paid:       true,
+			Status:     registration.STATUS_PAID,
 			Email:      "test@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "John", LastName: "Doe"},
 			Experience: registration.NOVICE,
 		}
 
 		event.Version++
-		require.NoError(t, db.CreateRegistration(ctx, &reg, *event))
+		require.NoError(t, db.CreateRegistration(ctx, &reg, *event, nil))
 
 	})
 
@@ -51,14 +56,14 @@ func TestCreateRegistration(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Team City",
-			Paid:         false,
+			Status:       registration.STATUS_PENDING,
 			TeamName:     "Test Team",
 			CaptainEmail: "captain@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Jane", LastName: "Doe"}},
 		}
 
 		event.Version++
-		require.NoError(t, db.CreateRegistration(ctx, &reg, *event))
+		require.NoError(t, db.CreateRegistration(ctx, &reg, *event, nil))
 
 	})
 
@@ -74,18 +79,18 @@ func TestCreateRegistration(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Test City",
-			Paid:       true,
+			Status:     registration.STATUS_PAID,
 			Email:      "test@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "John", LastName: "Doe"},
 			Experience: registration.NOVICE,
 		}
 
 		event.Version++
-		require.NoError(t, db.CreateRegistration(ctx, reg, *event))
+		require.NoError(t, db.CreateRegistration(ctx, reg, *event, nil))
 
 		event.Version++
 		reg.Version++
-		err := db.CreateRegistration(ctx, reg, *event)
+		err := db.CreateRegistration(ctx, reg, *event, nil)
 		require.Error(t, err)
 		var regError *registration.Error
 		require.ErrorAs(t, err, &regError)
@@ -105,6 +110,58 @@ func getRegistrationID(reg registration.Registration) uuid.UUID {
 	}
 }
 
+func TestCreateRegistrations(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates every registration with the event", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		regs := []registration.Registration{
+			&registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "one@example.com"},
+			&registration.TeamRegistration{ID: uuid.New(), EventID: eventID, Version: 1, CaptainEmail: "two@example.com", TeamName: "Team"},
+		}
+
+		event.Version++
+		event.NumTotalPlayers = 2
+		require.NoError(t, db.CreateRegistrations(ctx, regs, event))
+
+		saved, err := db.GetEvent(ctx, eventID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, saved.NumTotalPlayers)
+
+		_, err = db.GetRegistration(ctx, eventID, "two@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("nothing is created if one already exists", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+		existing := &registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "one@example.com"}
+		event.Version++
+		require.NoError(t, db.CreateRegistration(ctx, existing, event, nil))
+
+		regs := []registration.Registration{
+			&registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "new@example.com"},
+			&registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "one@example.com"},
+		}
+		event.Version++
+		err := db.CreateRegistrations(ctx, regs, event)
+		var regErr *registration.Error
+		require.ErrorAs(t, err, &regErr)
+		assert.Equal(t, registration.REASON_REGISTRATION_ALREADY_EXISTS, regErr.Reason)
+
+		_, err = db.GetRegistration(ctx, eventID, "new@example.com")
+		assert.Error(t, err)
+	})
+}
+
 func TestGetAllRegistrationsForEvent(t *testing.T) {
 	ctx := context.Background()
 	a := assert.New(t)
@@ -122,7 +179,7 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "City A",
-			Paid:       true,
+			Status:     registration.STATUS_PAID,
 			Email:      "a@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Alice", LastName: "Smith"},
 			Experience: registration.NOVICE,
@@ -132,16 +189,16 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "City B",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "b@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Bob", LastName: "Johnson"},
 			Experience: registration.INTERMEDIATE,
 		}
 
 		event1 := events.Event{ID: reg1.EventID, Version: 2}
-		require.NoError(t, db.CreateRegistration(ctx, &reg1, event1))
+		require.NoError(t, db.CreateRegistration(ctx, &reg1, event1, nil))
 		event2 := events.Event{ID: reg2.EventID, Version: 3}
-		require.NoError(t, db.CreateRegistration(ctx, &reg2, event2))
+		require.NoError(t, db.CreateRegistration(ctx, &reg2, event2, nil))
 
 		resp, err := db.GetAllRegistrationsForEvent(ctx, eventID, 100, nil)
 		a.NoError(err)
@@ -178,7 +235,7 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Team City 1",
-			Paid:         true,
+			Status:       registration.STATUS_PAID,
 			TeamName:     "Team Alpha",
 			CaptainEmail: "alpha@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Charlie", LastName: "Brown"}},
@@ -188,16 +245,16 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Team City 2",
-			Paid:         false,
+			Status:       registration.STATUS_PENDING,
 			TeamName:     "Team Beta",
 			CaptainEmail: "beta@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Diana", LastName: "Prince"}},
 		}
 
 		eventTeam1 := events.Event{ID: teamReg1.EventID, Version: 2}
-		require.NoError(t, db.CreateRegistration(ctx, &teamReg1, eventTeam1))
+		require.NoError(t, db.CreateRegistration(ctx, &teamReg1, eventTeam1, nil))
 		eventTeam2 := events.Event{ID: teamReg2.EventID, Version: 3}
-		require.NoError(t, db.CreateRegistration(ctx, &teamReg2, eventTeam2))
+		require.NoError(t, db.CreateRegistration(ctx, &teamReg2, eventTeam2, nil))
 
 		resp, err := db.GetAllRegistrationsForEvent(ctx, eventID, 100, nil)
 		a.NoError(err)
@@ -245,7 +302,7 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Mixed City",
-			Paid:       true,
+			Status:     registration.STATUS_PAID,
 			Email:      "mixed@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Mixed", LastName: "User"},
 			Experience: registration.NOVICE,
@@ -255,16 +312,16 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Mixed Team City",
-			Paid:         false,
+			Status:       registration.STATUS_PENDING,
 			TeamName:     "Mixed Team",
 			CaptainEmail: "mixedteam@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Mixed", LastName: "Team Player"}},
 		}
 
 		eventIndiv := events.Event{ID: regIndiv.EventID, Version: 2}
-		require.NoError(t, db.CreateRegistration(ctx, &regIndiv, eventIndiv))
+		require.NoError(t, db.CreateRegistration(ctx, &regIndiv, eventIndiv, nil))
 		eventTeam := events.Event{ID: regTeam.EventID, Version: 3}
-		require.NoError(t, db.CreateRegistration(ctx, &regTeam, eventTeam))
+		require.NoError(t, db.CreateRegistration(ctx, &regTeam, eventTeam, nil))
 
 		resp, err := db.GetAllRegistrationsForEvent(ctx, eventID, 100, nil)
 		a.NoError(err)
@@ -301,11 +358,11 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 		reg3 := registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "email3@email.com", PlayerInfo: registration.PlayerInfo{FirstName: "P3"}}
 
 		event1 := events.Event{ID: reg1.EventID, Version: 2}
-		require.NoError(t, db.CreateRegistration(ctx, &reg1, event1))
+		require.NoError(t, db.CreateRegistration(ctx, &reg1, event1, nil))
 		event2 := events.Event{ID: reg2.EventID, Version: 3}
-		require.NoError(t, db.CreateRegistration(ctx, &reg2, event2))
+		require.NoError(t, db.CreateRegistration(ctx, &reg2, event2, nil))
 		event3 := events.Event{ID: reg3.EventID, Version: 4}
-		require.NoError(t, db.CreateRegistration(ctx, &reg3, event3))
+		require.NoError(t, db.CreateRegistration(ctx, &reg3, event3, nil))
 
 		// Fetch with limit 2
 		resp, err := db.GetAllRegistrationsForEvent(ctx, eventID, 2, nil)
@@ -336,11 +393,11 @@ func TestGetAllRegistrationsForEvent(t *testing.T) {
 		reg3 := registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "email3@email.com", PlayerInfo: registration.PlayerInfo{FirstName: "P3"}}
 
 		event1 := events.Event{ID: reg1.EventID, Version: 2}
-		require.NoError(t, db.CreateRegistration(ctx, &reg1, event1))
+		require.NoError(t, db.CreateRegistration(ctx, &reg1, event1, nil))
 		event2 := events.Event{ID: reg2.EventID, Version: 3}
-		require.NoError(t, db.CreateRegistration(ctx, &reg2, event2))
+		require.NoError(t, db.CreateRegistration(ctx, &reg2, event2, nil))
 		event3 := events.Event{ID: reg3.EventID, Version: 4}
-		require.NoError(t, db.CreateRegistration(ctx, &reg3, event3))
+		require.NoError(t, db.CreateRegistration(ctx, &reg3, event3, nil))
 
 		// Fetch first page to get cursor
 		resp1, err := db.GetAllRegistrationsForEvent(ctx, eventID, 2, nil)
@@ -383,14 +440,14 @@ func TestGetRegistration(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Test City",
-			Paid:       true,
+			Status:     registration.STATUS_PAID,
 			Email:      "test@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "John", LastName: "Doe"},
 			Experience: registration.ADVANCED,
 		}
 
 		event2 := events.Event{ID: eventID, Version: 2}
-		require.NoError(t, db.CreateRegistration(ctx, &reg, event2))
+		require.NoError(t, db.CreateRegistration(ctx, &reg, event2, nil))
 
 		retrieved, err := db.GetRegistration(ctx, eventID, "test@example.com")
 		a.NoError(err)
@@ -409,14 +466,14 @@ func TestGetRegistration(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Team City",
-			Paid:         false,
+			Status:       registration.STATUS_PENDING,
 			TeamName:     "Test Team",
 			CaptainEmail: "captain@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Jane", LastName: "Smith"}},
 		}
 
 		event2 := events.Event{ID: eventID, Version: 2}
-		require.NoError(t, db.CreateRegistration(ctx, &reg, event2))
+		require.NoError(t, db.CreateRegistration(ctx, &reg, event2, nil))
 
 		retrieved, err := db.GetRegistration(ctx, eventID, "captain@example.com")
 		a.NoError(err)
@@ -451,7 +508,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Payment City",
-			Paid:       false, // Should be false initially
+			Status:     registration.STATUS_PENDING, // Should be false initially
 			Email:      "payment@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Payment", LastName: "User"},
 			Experience: registration.NOVICE,
@@ -472,7 +529,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 		retrieved, err := db.GetRegistration(ctx, eventID, "payment@example.com")
 		a.NoError(err)
 		a.Equal(reg, *retrieved.(*registration.IndividualRegistration))
-		a.False(retrieved.(*registration.IndividualRegistration).Paid) // Should still be unpaid
+		a.Equal(registration.STATUS_PENDING, retrieved.(*registration.IndividualRegistration).Status) // Should still be unpaid
 	})
 
 	t.Run("successfully create team registration with payment intent", func(t *testing.T) {
@@ -487,7 +544,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Payment Team City",
-			Paid:         false, // Should be false initially
+			Status:       registration.STATUS_PENDING, // Should be false initially
 			TeamName:     "Payment Team",
 			CaptainEmail: "team-payment@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Team", LastName: "Player"}},
@@ -508,7 +565,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 		retrieved, err := db.GetRegistration(ctx, eventID, "team-payment@example.com")
 		a.NoError(err)
 		a.Equal(reg, *retrieved.(*registration.TeamRegistration))
-		a.False(retrieved.(*registration.TeamRegistration).Paid) // Should still be unpaid
+		a.Equal(registration.STATUS_PENDING, retrieved.(*registration.TeamRegistration).Status) // Should still be unpaid
 	})
 
 	t.Run("successfully create individual registration with payment intent and player email", func(t *testing.T) {
@@ -523,7 +580,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Payment City",
-			Paid:       false, // Should be false initially
+			Status:     registration.STATUS_PENDING, // Should be false initially
 			Email:      "payment-with-player-email@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Payment", LastName: "User", Email: ptr.String("player.payment@example.com")},
 			Experience: registration.NOVICE,
@@ -545,7 +602,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 		a.NoError(err)
 		indivReg := retrieved.(*registration.IndividualRegistration)
 		a.Equal(reg, *indivReg)
-		a.False(indivReg.Paid) // Should still be unpaid
+		a.Equal(registration.STATUS_PENDING, indivReg.Status) // Should still be unpaid
 		a.Equal("player.payment@example.com", *indivReg.PlayerInfo.Email)
 	})
 
@@ -561,7 +618,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Payment City No Email",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "payment-no-player-email@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Payment", LastName: "NoEmail", Email: nil},
 			Experience: registration.INTERMEDIATE,
@@ -583,7 +640,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 		a.NoError(err)
 		indivReg := retrieved.(*registration.IndividualRegistration)
 		a.Equal(reg, *indivReg)
-		a.False(indivReg.Paid)
+		a.Equal(registration.STATUS_PENDING, indivReg.Status)
 		a.Nil(indivReg.PlayerInfo.Email)
 	})
 
@@ -599,7 +656,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Payment Team City Mixed",
-			Paid:         false,
+			Status:       registration.STATUS_PENDING,
 			TeamName:     "Payment Team Mixed Emails",
 			CaptainEmail: "team-payment-mixed@example.com",
 			Players: []registration.PlayerInfo{
@@ -626,7 +683,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 		a.NoError(err)
 		teamReg := retrieved.(*registration.TeamRegistration)
 		a.Equal(reg, *teamReg)
-		a.False(teamReg.Paid)
+		a.Equal(registration.STATUS_PENDING, teamReg.Status)
 
 		require.Len(t, teamReg.Players, 4)
 		// Player 1 - has email
@@ -661,7 +718,7 @@ func TestCreateRegistrationWithPayment(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Duplicate City",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "duplicate@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Duplicate", LastName: "User"},
 			Experience: registration.NOVICE,
@@ -707,7 +764,7 @@ func TestUpdateRegistrationToPaid(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Update City",
-			Paid:       false, // Start unpaid
+			Status:     registration.STATUS_PENDING, // Start unpaid
 			Email:      "update@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Update", LastName: "User"},
 			Experience: registration.INTERMEDIATE,
@@ -726,18 +783,57 @@ func TestUpdateRegistrationToPaid(t *testing.T) {
 		a.NoError(err)
 
 		// Update to paid
-		reg.Paid = true
+		reg.Status = registration.STATUS_PAID
 		reg.Version = 2
-		err = db.UpdateRegistrationToPaid(ctx, &reg)
+		err = db.UpdateRegistrationToPaid(ctx, &reg, nil)
 		a.NoError(err)
 
 		// Verify registration is now paid
 		retrieved, err := db.GetRegistration(ctx, eventID, "update@example.com")
 		a.NoError(err)
-		a.True(retrieved.(*registration.IndividualRegistration).Paid)
+		a.Equal(registration.STATUS_PAID, retrieved.(*registration.IndividualRegistration).Status)
 		a.Equal(2, retrieved.(*registration.IndividualRegistration).Version)
 	})
 
+	t.Run("keeps the offline payment", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.IndividualRegistration{
+			ID:      uuid.New(),
+			EventID: eventID,
+			Version: 1,
+			Status:  registration.STATUS_PENDING,
+			Email:   "cash@example.com",
+		}
+		event.Version++
+		require.NoError(t, db.CreateRegistration(ctx, &reg, event, nil))
+
+		payment := registration.OfflinePayment{
+			Method:     registration.PAYMENT_METHOD_CASH,
+			Amount:     money.New(2000, "USD"),
+			Note:       "Paid at the door",
+			RecordedBy: "admin@example.com",
+			RecordedAt: time.Now().UTC().Truncate(time.Second),
+		}
+		reg.Status = registration.STATUS_PAID
+		reg.Version = 2
+		reg.SetOfflinePayment(payment)
+		require.NoError(t, db.UpdateRegistrationToPaid(ctx, &reg, nil))
+
+		retrieved, err := db.GetRegistration(ctx, eventID, "cash@example.com")
+		require.NoError(t, err)
+		saved := retrieved.GetOfflinePayment()
+		require.NotNil(t, saved)
+		a.Equal(registration.PAYMENT_METHOD_CASH, saved.Method)
+		a.Equal(int64(2000), saved.Amount.Amount())
+		a.Equal("Paid at the door", saved.Note)
+		a.Equal(payment.RecordedAt, saved.RecordedAt)
+	})
+
 	t.Run("successfully update team registration to paid", func(t *testing.T) {
 		resetTable(ctx)
 		eventID := uuid.New()
@@ -750,7 +846,7 @@ func TestUpdateRegistrationToPaid(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Update Team City",
-			Paid:         false, // Start unpaid
+			Status:       registration.STATUS_PENDING, // Start unpaid
 			TeamName:     "Update Team",
 			CaptainEmail: "team-update@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Team", LastName: "Update"}},
@@ -769,15 +865,15 @@ func TestUpdateRegistrationToPaid(t *testing.T) {
 		a.NoError(err)
 
 		// Update to paid
-		reg.Paid = true
+		reg.Status = registration.STATUS_PAID
 		reg.Version = 2
-		err = db.UpdateRegistrationToPaid(ctx, &reg)
+		err = db.UpdateRegistrationToPaid(ctx, &reg, nil)
 		a.NoError(err)
 
 		// Verify registration is now paid
 		retrieved, err := db.GetRegistration(ctx, eventID, "team-update@example.com")
 		a.NoError(err)
-		a.True(retrieved.(*registration.TeamRegistration).Paid)
+		a.Equal(registration.STATUS_PAID, retrieved.(*registration.TeamRegistration).Status)
 		a.Equal(2, retrieved.(*registration.TeamRegistration).Version)
 	})
 
@@ -790,13 +886,13 @@ func TestUpdateRegistrationToPaid(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Nonexistent City",
-			Paid:       true,
+			Status:     registration.STATUS_PAID,
 			Email:      "nonexistent@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Nonexistent", LastName: "User"},
 			Experience: registration.NOVICE,
 		}
 
-		err := db.UpdateRegistrationToPaid(ctx, &reg)
+		err := db.UpdateRegistrationToPaid(ctx, &reg, nil)
 		a.Error(err)
 		var regError *registration.Error
 		require.ErrorAs(t, err, &regError)
@@ -815,7 +911,7 @@ func TestUpdateRegistrationToPaid(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Version City",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "version@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Version", LastName: "User"},
 			Experience: registration.ADVANCED,
@@ -834,9 +930,9 @@ func TestUpdateRegistrationToPaid(t *testing.T) {
 		a.NoError(err)
 
 		// Try to update with wrong version (should be 2, but we're using 3 to simulate stale data)
-		reg.Paid = true
+		reg.Status = registration.STATUS_PAID
 		reg.Version = 3 // Wrong version - too high
-		err = db.UpdateRegistrationToPaid(ctx, &reg)
+		err = db.UpdateRegistrationToPaid(ctx, &reg, nil)
 		a.Error(err)
 		var regError *registration.Error
 		require.ErrorAs(t, err, &regError)
@@ -867,7 +963,7 @@ func TestDeleteExpiredRegistration(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Expired City",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "expired@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Expired", LastName: "User"},
 			Experience: registration.INTERMEDIATE,
@@ -939,7 +1035,7 @@ func TestDeleteExpiredRegistration(t *testing.T) {
 			EventID:      eventID,
 			Version:      1,
 			HomeCity:     "Expired Team City",
-			Paid:         false,
+			Status:       registration.STATUS_PENDING,
 			TeamName:     "Expired Team",
 			CaptainEmail: "expired-team@example.com",
 			Players:      []registration.PlayerInfo{{FirstName: "Player1", LastName: "Team"}, {FirstName: "Player2", LastName: "Team"}}, // 2 players
@@ -1047,14 +1143,14 @@ func TestDeleteExpiredRegistration(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "No Intent City",
-			Paid:       true, // Already paid, so no intent should exist
+			Status:     registration.STATUS_PAID, // Already paid, so no intent should exist
 			Email:      "nointent@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "NoIntent", LastName: "User"},
 			Experience: registration.NOVICE,
 		}
 
 		event2 := events.Event{ID: eventID, Version: 2}
-		err := db.CreateRegistration(ctx, &reg, event2)
+		err := db.CreateRegistration(ctx, &reg, event2, nil)
 		a.NoError(err)
 
 		// Try to delete with a non-existent intent
@@ -1091,7 +1187,7 @@ func TestDeleteExpiredRegistration(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Version Conflict City",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "version@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Version", LastName: "User"},
 			Experience: registration.INTERMEDIATE,
@@ -1145,7 +1241,7 @@ func TestDeleteExpiredRegistration(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Reg Version Conflict City",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "regversion@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "RegVersion", LastName: "User"},
 			Experience: registration.ADVANCED,
@@ -1188,3 +1284,350 @@ func TestDeleteExpiredRegistration(t *testing.T) {
 		a.NotNil(retrievedReg)
 	})
 }
+
+func TestReplaceExpiredRegistrationWithPayment(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (registration.IndividualRegistration, registration.RegistrationIntent, events.Event) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		expired := registration.IndividualRegistration{
+			ID:         uuid.New(),
+			EventID:    eventID,
+			Version:    1,
+			HomeCity:   "Old City",
+			Status:     registration.STATUS_PENDING,
+			Email:      "replace@example.com",
+			PlayerInfo: registration.PlayerInfo{FirstName: "Old", LastName: "User"},
+			Experience: registration.NOVICE,
+		}
+		expiredIntent := registration.RegistrationIntent{
+			Version:          1,
+			EventId:          eventID,
+			PaymentSessionId: "stripe_session_expired",
+			ClientSecret:     "stripe_secret_expired",
+			Email:            "replace@example.com",
+			ExpiresAt:        time.Now().Add(-time.Minute).UTC().Truncate(time.Second),
+		}
+		event.Version++
+		require.NoError(t, db.CreateRegistrationWithPayment(ctx, &expired, expiredIntent, event))
+		return expired, expiredIntent, event
+	}
+
+	t.Run("replaces the registration and intent", func(t *testing.T) {
+		expired, expiredIntent, event := setup(t)
+
+		reg := expired
+		reg.ID = uuid.New()
+		reg.Version = 2
+		reg.HomeCity = "New City"
+		regIntent := registration.RegistrationIntent{
+			Version:          2,
+			EventId:          expired.EventID,
+			PaymentSessionId: "stripe_session_new",
+			ClientSecret:     "stripe_secret_new",
+			Email:            "replace@example.com",
+			ExpiresAt:        time.Now().Add(30 * time.Minute).UTC().Truncate(time.Second),
+		}
+		event.Version++
+		require.NoError(t, db.ReplaceExpiredRegistrationWithPayment(ctx, &expired, expiredIntent, &reg, regIntent, event))
+
+		saved, err := db.GetRegistration(ctx, expired.EventID, "replace@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "New City", saved.(*registration.IndividualRegistration).HomeCity)
+		assert.Equal(t, 2, saved.GetVersion())
+
+		savedIntent, err := db.GetRegistrationIntent(ctx, expired.EventID, "replace@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, regIntent, savedIntent)
+
+		regs, err := db.GetRegistrationsByEmail(ctx, "replace@example.com")
+		require.NoError(t, err)
+		assert.Len(t, regs, 1)
+	})
+
+	t.Run("fails if the expired registration changed", func(t *testing.T) {
+		expired, expiredIntent, event := setup(t)
+
+		// Cleaned up by the sweeper in the meantime
+		cleanupEvent := event
+		cleanupEvent.Version++
+		require.NoError(t, db.DeleteExpiredRegistration(ctx, &expired, expiredIntent, cleanupEvent))
+
+		reg := expired
+		reg.Version = 2
+		regIntent := expiredIntent
+		regIntent.Version = 2
+		event.Version++
+		err := db.ReplaceExpiredRegistrationWithPayment(ctx, &expired, expiredIntent, &reg, regIntent, event)
+		var regError *registration.Error
+		require.ErrorAs(t, err, &regError)
+		assert.Equal(t, registration.REASON_FAILED_TO_WRITE, regError.Reason)
+	})
+}
+
+func TestUpdateRegistration(t *testing.T) {
+	ctx := context.Background()
+	a := assert.New(t)
+
+	t.Run("successfully update team roster", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.TeamRegistration{
+			ID:           uuid.New(),
+			EventID:      eventID,
+			Version:      1,
+			TeamName:     "Roster Team",
+			CaptainEmail: "roster@example.com",
+			Players: []registration.PlayerInfo{
+				{FirstName: "Invited", LastName: "Player", Email: ptr.String("invited@example.com"), RosterStatus: registration.ROSTER_INVITED, InviteToken: "token"},
+			},
+		}
+		require.NoError(t, db.CreateRegistration(ctx, &reg, events.Event{ID: eventID, Version: 2}, nil))
+
+		reg.Players[0].RosterStatus = registration.ROSTER_CONFIRMED
+		reg.Version = 2
+		err := db.UpdateRegistration(ctx, &reg)
+		a.NoError(err)
+
+		retrieved, err := db.GetRegistration(ctx, eventID, "roster@example.com")
+		a.NoError(err)
+		teamReg := retrieved.(*registration.TeamRegistration)
+		a.Equal(registration.ROSTER_CONFIRMED, teamReg.Players[0].RosterStatus)
+		a.Equal("token", teamReg.Players[0].InviteToken)
+		a.Equal(2, teamReg.Version)
+	})
+
+	t.Run("keeps admin notes and tags", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.IndividualRegistration{
+			ID:      uuid.New(),
+			EventID: eventID,
+			Version: 1,
+			Email:   "notes@example.com",
+		}
+		require.NoError(t, db.CreateRegistration(ctx, &reg, events.Event{ID: eventID, Version: 2}, nil))
+
+		note := registration.AdminNote{
+			ID:        uuid.New(),
+			Text:      "Needs loaner bow",
+			Author:    "admin@example.com",
+			CreatedAt: time.Now().UTC().Truncate(time.Second),
+		}
+		reg.AddAdminNote(note)
+		reg.AddTag("vip")
+		reg.Version = 2
+		require.NoError(t, db.UpdateRegistration(ctx, &reg))
+
+		retrieved, err := db.GetRegistration(ctx, eventID, "notes@example.com")
+		require.NoError(t, err)
+		a.Equal([]registration.AdminNote{note}, retrieved.GetAdminNotes())
+		a.Equal([]string{"vip"}, retrieved.GetTags())
+	})
+
+	t.Run("encrypts medical info at rest", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.TeamRegistration{
+			ID:           uuid.New(),
+			EventID:      eventID,
+			Version:      1,
+			TeamName:     "Medical Team",
+			CaptainEmail: "medical@example.com",
+			Players: []registration.PlayerInfo{
+				{
+					FirstName: "Hurt",
+					LastName:  "Player",
+					EmergencyContact: &registration.EmergencyContact{
+						Name:         "Parent Player",
+						Phone:        "555-0100",
+						Relationship: "Parent",
+					},
+					MedicalNotes: "Allergic to bees",
+				},
+				{FirstName: "Healthy", LastName: "Player"},
+			},
+		}
+		require.NoError(t, db.CreateRegistration(ctx, &reg, events.Event{ID: eventID, Version: 2}, nil))
+
+		key, err := attributevalue.MarshalMap(map[string]any{
+			"PK": registrationPK(eventID),
+			"SK": registrationSK("medical@example.com"),
+		})
+		require.NoError(t, err)
+		out, err := dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(tableName),
+			Key:       key,
+		})
+		require.NoError(t, err)
+
+		var saved registrationDynamo
+		require.NoError(t, attributevalue.UnmarshalMap(out.Item, &saved))
+		a.NotEmpty(saved.MedicalInfo)
+		a.NotContains(string(saved.MedicalInfo), "bees")
+		a.Nil(saved.Players[0].EmergencyContact)
+		a.Empty(saved.Players[0].MedicalNotes)
+
+		retrieved, err := db.GetRegistration(ctx, eventID, "medical@example.com")
+		require.NoError(t, err)
+		a.Equal(reg.Players, retrieved.(*registration.TeamRegistration).Players)
+	})
+
+	t.Run("fail when version conflict occurs", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.TeamRegistration{
+			ID:           uuid.New(),
+			EventID:      eventID,
+			Version:      1,
+			TeamName:     "Conflict Team",
+			CaptainEmail: "conflict@example.com",
+			Players:      []registration.PlayerInfo{{FirstName: "Conflict", LastName: "Player"}},
+		}
+		require.NoError(t, db.CreateRegistration(ctx, &reg, events.Event{ID: eventID, Version: 2}, nil))
+
+		reg.Version = 3
+		err := db.UpdateRegistration(ctx, &reg)
+		a.Error(err)
+		var regErr *registration.Error
+		a.ErrorAs(err, &regErr)
+		a.Equal(registration.REASON_FAILED_TO_WRITE, regErr.Reason)
+	})
+}
+
+func TestUpdateRegistrationWithEvent(t *testing.T) {
+	ctx := context.Background()
+	a := assert.New(t)
+
+	t.Run("successfully save a refund and release the spot", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.IndividualRegistration{
+			ID:         uuid.New(),
+			EventID:    eventID,
+			Version:    1,
+			Status:     registration.STATUS_PAID,
+			Email:      "refund@example.com",
+			PlayerInfo: registration.PlayerInfo{FirstName: "Refund", LastName: "User"},
+		}
+		event.Version = 2
+		event.NumTotalPlayers = 1
+		require.NoError(t, db.CreateRegistration(ctx, &reg, event, nil))
+
+		reg.AddRefund(registration.Refund{
+			ID:           uuid.New(),
+			PaymentID:    "ch_123",
+			Amount:       money.New(2500, "USD"),
+			Reason:       "Injured",
+			RefundedBy:   "admin@example.com",
+			RefundedAt:   time.Now().UTC().Truncate(time.Second),
+			ReleasedSpot: true,
+		})
+		reg.Version = 2
+		event.Version = 3
+		event.NumTotalPlayers = 0
+		a.NoError(db.UpdateRegistrationWithEvent(ctx, &reg, event))
+
+		retrieved, err := db.GetRegistration(ctx, eventID, "refund@example.com")
+		a.NoError(err)
+		refunds := retrieved.GetRefunds()
+		a.Len(refunds, 1)
+		a.Equal(int64(2500), refunds[0].Amount.Amount())
+		a.Equal("USD", refunds[0].Amount.Currency().Code)
+		a.True(refunds[0].ReleasedSpot)
+
+		retrievedEvent, err := db.GetEvent(ctx, eventID)
+		a.NoError(err)
+		a.Equal(0, retrievedEvent.NumTotalPlayers)
+		a.Equal(3, retrievedEvent.Version)
+	})
+
+	t.Run("fail when event version conflict occurs", func(t *testing.T) {
+		resetTable(ctx)
+		eventID := uuid.New()
+
+		event := events.Event{ID: eventID, Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, event))
+
+		reg := registration.IndividualRegistration{ID: uuid.New(), EventID: eventID, Version: 1, Email: "conflict@example.com"}
+		event.Version = 2
+		require.NoError(t, db.CreateRegistration(ctx, &reg, event, nil))
+
+		reg.Version = 2
+		event.Version = 5
+		err := db.UpdateRegistrationWithEvent(ctx, &reg, event)
+		var regErr *registration.Error
+		a.ErrorAs(err, &regErr)
+		a.Equal(registration.REASON_FAILED_TO_WRITE, regErr.Reason)
+	})
+}
+
+func TestAnonymizeRegistration(t *testing.T) {
+	ctx := context.Background()
+	resetTable(ctx)
+
+	event := events.Event{ID: uuid.New(), Version: 1}
+	require.NoError(t, db.CreateEvent(ctx, event))
+	from := &registration.TeamRegistration{
+		ID:           uuid.New(),
+		EventID:      event.ID,
+		Version:      1,
+		CaptainEmail: "captain@example.com",
+		TeamName:     "Team",
+		Players: []registration.PlayerInfo{
+			{FirstName: "Cap", Email: ptr.String("captain@example.com")},
+			{FirstName: "Jane", Email: ptr.String("jane@example.com"), MedicalNotes: "Asthma"},
+		},
+	}
+	event.Version++
+	event.NumTeams = 1
+	require.NoError(t, db.CreateRegistration(ctx, from, event, nil))
+
+	to := *from
+	to.Players = []registration.PlayerInfo{
+		from.Players[0],
+		{FirstName: "Erased"},
+	}
+	to.Version++
+	require.NoError(t, db.AnonymizeRegistration(ctx, from, &to))
+
+	reg, err := db.GetRegistration(ctx, event.ID, "captain@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 2, reg.(*registration.TeamRegistration).Version)
+	assert.Equal(t, registration.PlayerInfo{FirstName: "Erased"}, reg.(*registration.TeamRegistration).Players[1])
+
+	regs, err := db.GetRegistrationsByEmail(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Empty(t, regs)
+	regs, err = db.GetRegistrationsByEmail(ctx, "captain@example.com")
+	require.NoError(t, err)
+	assert.Len(t, regs, 1)
+
+	// Stale version
+	assert.Error(t, db.AnonymizeRegistration(ctx, from, &to))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/google/uuid"
@@ -20,12 +21,25 @@ type registrationIntentDynamo struct {
 	Version          int
 	EventId          uuid.UUID
 	PaymentSessionID string
-	Email            string
-	ExpiresAt        time.Time
+	// Only set for email verifications
+	VerificationToken string
+	// Only set for checkouts
+	ClientSecret string
+	Email        string
+	ExpiresAt    time.Time
+	// Only set for the checkout of a pending transfer's price difference
+	TransferID *uuid.UUID
+	// Epoch seconds DynamoDB deletes the item at, in case the sweeper never manages to
+	TTL int64
 }
 
 const (
 	registrationIntentEntityName = "REG_INTENT"
+
+	// Expired intents are deleted by the sweeper along with their registration, after checking
+	// the checkout wasn't paid, and the TTL only deletes the intent. So it's long enough after
+	// expiring that the sweeper and reconciliation have had plenty of chances to sort it out first.
+	registrationIntentTTLGracePeriod = 30 * 24 * time.Hour
 )
 
 func registrationIntentPK(eventId uuid.UUID) string {
@@ -38,23 +52,30 @@ func registrationIntentSK(email string) string {
 
 func regIntentToDynamo(regIntent registration.RegistrationIntent) registrationIntentDynamo {
 	return registrationIntentDynamo{
-		PK:               registrationPK(regIntent.EventId),
-		SK:               registrationIntentSK(regIntent.Email),
-		Version:          regIntent.Version,
-		Email:            regIntent.Email,
-		EventId:          regIntent.EventId,
-		PaymentSessionID: regIntent.PaymentSessionId,
-		ExpiresAt:        regIntent.ExpiresAt,
+		PK:                registrationPK(regIntent.EventId),
+		SK:                registrationIntentSK(regIntent.Email),
+		Version:           regIntent.Version,
+		Email:             regIntent.Email,
+		EventId:           regIntent.EventId,
+		PaymentSessionID:  regIntent.PaymentSessionId,
+		VerificationToken: regIntent.VerificationToken,
+		ClientSecret:      regIntent.ClientSecret,
+		ExpiresAt:         regIntent.ExpiresAt,
+		TransferID:        regIntent.TransferID,
+		TTL:               regIntent.ExpiresAt.Add(registrationIntentTTLGracePeriod).Unix(),
 	}
 }
 
 func dynamoRegIntentToRegIntent(regIntent registrationIntentDynamo) registration.RegistrationIntent {
 	return registration.RegistrationIntent{
-		Version:          regIntent.Version,
-		EventId:          regIntent.EventId,
-		PaymentSessionId: regIntent.PaymentSessionID,
-		Email:            regIntent.Email,
-		ExpiresAt:        regIntent.ExpiresAt,
+		Version:           regIntent.Version,
+		EventId:           regIntent.EventId,
+		PaymentSessionId:  regIntent.PaymentSessionID,
+		VerificationToken: regIntent.VerificationToken,
+		ClientSecret:      regIntent.ClientSecret,
+		Email:             regIntent.Email,
+		ExpiresAt:         regIntent.ExpiresAt,
+		TransferID:        regIntent.TransferID,
 	}
 }
 
@@ -87,3 +108,94 @@ func (d *DB) GetRegistrationIntent(ctx context.Context, eventId uuid.UUID, email
 	}
 	return dynamoRegIntentToRegIntent(reg), nil
 }
+
+func (d *DB) GetExpiredRegistrationIntents(ctx context.Context, eventId uuid.UUID, now time.Time) ([]registration.RegistrationIntent, error) {
+	intents, err := d.GetRegistrationIntentsForEvent(ctx, eventId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Filtered here rather than in the query since the stored times aren't guaranteed to sort as strings
+	var expired []registration.RegistrationIntent
+	for _, intent := range intents {
+		if intent.ExpiresAt.Before(now) {
+			expired = append(expired, intent)
+		}
+	}
+
+	return expired, nil
+}
+
+func (d *DB) GetRegistrationIntentsForEvent(ctx context.Context, eventId uuid.UUID) ([]registration.RegistrationIntent, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	keyCond := expression.Key("PK").Equal(expression.Value(registrationIntentPK(eventId))).
+		And(expression.Key("SK").BeginsWith(registrationIntentEntityName))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCond).Build()
+	if err != nil {
+		panic(fmt.Sprintf("failed to build dynamo key expression: %s", err))
+	}
+
+	paginator := dynamodb.NewQueryPaginator(d.dynamoClient, &dynamodb.QueryInput{
+		TableName:                 aws.String(d.tableName),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+
+	var intents []registration.RegistrationIntent
+	for paginator.HasMorePages() {
+		result, err := paginator.NextPage(ctx)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, registration.NewTimeoutError("GetRegistrationIntentsForEvent timed out")
+			}
+			return nil, registration.NewFailedToFetchError(fmt.Sprintf("Failed to fetch registration intents for event ID %q", eventId), err)
+		}
+
+		var dynamoItems []registrationIntentDynamo
+		err = attributevalue.UnmarshalListOfMaps(result.Items, &dynamoItems)
+		if err != nil {
+			panic(fmt.Sprintf("failed to unmarshal dynamo registration intents: %s", err))
+		}
+
+		for _, item := range dynamoItems {
+			intents = append(intents, dynamoRegIntentToRegIntent(item))
+		}
+	}
+
+	return intents, nil
+}
+
+func (d *DB) DeleteRegistrationIntent(ctx context.Context, intent registration.RegistrationIntent) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	expr := exprMustBuild(expression.NewBuilder().
+		WithCondition(deleteEntityVersionConditional(intent.Version)))
+
+	_, err := d.dynamoClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: registrationIntentPK(intent.EventId)},
+			"SK": &types.AttributeValueMemberS{Value: registrationIntentSK(intent.Email)},
+		},
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var condCheckFailedErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condCheckFailedErr) {
+			return registration.NewFailedToWriteError("Version conflict error", err)
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("DeleteRegistrationIntent timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed DeleteItem call", err)
+		}
+	}
+
+	return nil
+}
@@ -2,7 +2,9 @@ package dynamo
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
 	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
@@ -27,7 +29,7 @@ func TestGetRegistrationIntent(t *testing.T) {
 			EventID:    eventID,
 			Version:    1,
 			HomeCity:   "Intent City",
-			Paid:       false,
+			Status:     registration.STATUS_PENDING,
 			Email:      "intent@example.com",
 			PlayerInfo: registration.PlayerInfo{FirstName: "Intent", LastName: "User"},
 			Experience: registration.NOVICE,
@@ -37,6 +39,7 @@ func TestGetRegistrationIntent(t *testing.T) {
 			Version:          1,
 			EventId:          eventID,
 			PaymentSessionId: "stripe_session_intent",
+			ClientSecret:     "stripe_secret_intent",
 			Email:            "intent@example.com",
 		}
 
@@ -62,3 +65,121 @@ func TestGetRegistrationIntent(t *testing.T) {
 		a.Equal(registration.REASON_REGISTRATION_DOES_NOT_EXIST, regError.Reason)
 	})
 }
+
+func TestGetExpiredRegistrationIntents(t *testing.T) {
+	ctx := context.Background()
+	a := assert.New(t)
+
+	resetTable(ctx)
+	eventID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, db.CreateEvent(ctx, events.Event{ID: eventID, Version: 1}))
+
+	for i, expiresAt := range []time.Time{now.Add(-time.Minute), now.Add(time.Minute)} {
+		email := fmt.Sprintf("intent%d@example.com", i)
+		reg := registration.IndividualRegistration{
+			ID:         uuid.New(),
+			EventID:    eventID,
+			Version:    1,
+			Email:      email,
+			PlayerInfo: registration.PlayerInfo{FirstName: "Intent", LastName: "User"},
+		}
+		regIntent := registration.RegistrationIntent{
+			Version:          1,
+			EventId:          eventID,
+			PaymentSessionId: fmt.Sprintf("stripe_session_%d", i),
+			Email:            email,
+			ExpiresAt:        expiresAt,
+		}
+		require.NoError(t, db.CreateRegistrationWithPayment(ctx, &reg, regIntent, events.Event{ID: eventID, Version: i + 2}))
+	}
+
+	expired, err := db.GetExpiredRegistrationIntents(ctx, eventID, now)
+	a.NoError(err)
+	a.Len(expired, 1)
+	a.Equal("intent0@example.com", expired[0].Email)
+}
+
+func TestGetRegistrationIntentsForEvent(t *testing.T) {
+	ctx := context.Background()
+	a := assert.New(t)
+
+	resetTable(ctx)
+	eventID := uuid.New()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, db.CreateEvent(ctx, events.Event{ID: eventID, Version: 1}))
+
+	for i, expiresAt := range []time.Time{now.Add(-time.Minute), now.Add(time.Minute)} {
+		email := fmt.Sprintf("intent%d@example.com", i)
+		reg := registration.IndividualRegistration{
+			ID:         uuid.New(),
+			EventID:    eventID,
+			Version:    1,
+			Email:      email,
+			PlayerInfo: registration.PlayerInfo{FirstName: "Intent", LastName: "User"},
+		}
+		regIntent := registration.RegistrationIntent{
+			Version:          1,
+			EventId:          eventID,
+			PaymentSessionId: fmt.Sprintf("stripe_session_%d", i),
+			Email:            email,
+			ExpiresAt:        expiresAt,
+		}
+		require.NoError(t, db.CreateRegistrationWithPayment(ctx, &reg, regIntent, events.Event{ID: eventID, Version: i + 2}))
+	}
+
+	intents, err := db.GetRegistrationIntentsForEvent(ctx, eventID)
+	a.NoError(err)
+	a.Len(intents, 2)
+
+	none, err := db.GetRegistrationIntentsForEvent(ctx, uuid.New())
+	a.NoError(err)
+	a.Empty(none)
+}
+
+func TestDeleteRegistrationIntent(t *testing.T) {
+	ctx := context.Background()
+	a := assert.New(t)
+
+	resetTable(ctx)
+	eventID := uuid.New()
+	require.NoError(t, db.CreateEvent(ctx, events.Event{ID: eventID, Version: 1}))
+
+	reg := registration.IndividualRegistration{
+		ID:         uuid.New(),
+		EventID:    eventID,
+		Version:    1,
+		Email:      "intent@example.com",
+		PlayerInfo: registration.PlayerInfo{FirstName: "Intent", LastName: "User"},
+	}
+	regIntent := registration.RegistrationIntent{
+		Version:          1,
+		EventId:          eventID,
+		PaymentSessionId: "stripe_session",
+		Email:            "intent@example.com",
+	}
+	require.NoError(t, db.CreateRegistrationWithPayment(ctx, &reg, regIntent, events.Event{ID: eventID, Version: 2}))
+
+	t.Run("version conflict", func(t *testing.T) {
+		stale := regIntent
+		stale.Version = 2
+		err := db.DeleteRegistrationIntent(ctx, stale)
+		var regError *registration.Error
+		require.ErrorAs(t, err, &regError)
+		a.Equal(registration.REASON_FAILED_TO_WRITE, regError.Reason)
+	})
+
+	t.Run("deletes only the intent", func(t *testing.T) {
+		a.NoError(db.DeleteRegistrationIntent(ctx, regIntent))
+
+		_, err := db.GetRegistrationIntent(ctx, eventID, "intent@example.com")
+		var regError *registration.Error
+		require.ErrorAs(t, err, &regError)
+		a.Equal(registration.REASON_REGISTRATION_DOES_NOT_EXIST, regError.Reason)
+
+		_, err = db.GetRegistration(ctx, eventID, "intent@example.com")
+		a.NoError(err)
+	})
+}
@@ -0,0 +1,218 @@
+package dynamo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferRegistration(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (events.Event, events.Event, *registration.TeamRegistration) {
+		resetTable(ctx)
+
+		oldEvent := events.Event{ID: uuid.New(), Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, oldEvent))
+		newEvent := events.Event{ID: uuid.New(), Version: 1}
+		require.NoError(t, db.CreateEvent(ctx, newEvent))
+
+		team := &registration.TeamRegistration{
+			ID:           uuid.New(),
+			EventID:      oldEvent.ID,
+			Version:      1,
+			CaptainEmail: "captain@example.com",
+			TeamName:     "Team",
+			Players: []registration.PlayerInfo{
+				{Email: ptr.String("captain@example.com")},
+				{Email: ptr.String("player@example.com")},
+			},
+		}
+		oldEvent.Version++
+		oldEvent.NumTeams = 1
+		require.NoError(t, db.CreateRegistration(ctx, team, oldEvent, nil))
+		return oldEvent, newEvent, team
+	}
+
+	t.Run("new captain", func(t *testing.T) {
+		oldEvent, _, from := setup(t)
+
+		to := *from
+		to.Players = []registration.PlayerInfo{
+			{Email: ptr.String("newcap@example.com")},
+			{Email: ptr.String("player@example.com")},
+		}
+		to.CaptainEmail = "newcap@example.com"
+		to.Version++
+		to.AddTransfer(registration.Transfer{
+			ID:            uuid.New(),
+			FromEventID:   oldEvent.ID,
+			FromEmail:     "captain@example.com",
+			ToEventID:     oldEvent.ID,
+			ToEmail:       "newcap@example.com",
+			TransferredAt: time.Now(),
+		})
+		require.NoError(t, db.TransferRegistration(ctx, from, &to, nil))
+
+		_, err := db.GetRegistration(ctx, oldEvent.ID, "captain@example.com")
+		assert.Error(t, err)
+		reg, err := db.GetRegistration(ctx, oldEvent.ID, "newcap@example.com")
+		require.NoError(t, err)
+		require.Len(t, reg.GetTransfers(), 1)
+		assert.Equal(t, "captain@example.com", reg.GetTransfers()[0].FromEmail)
+
+		regs, err := db.GetRegistrationsByEmail(ctx, "captain@example.com")
+		require.NoError(t, err)
+		assert.Empty(t, regs)
+		regs, err = db.GetRegistrationsByEmail(ctx, "player@example.com")
+		require.NoError(t, err)
+		require.Len(t, regs, 1)
+		assert.Equal(t, "newcap@example.com", regs[0].GetEmail())
+	})
+
+	t.Run("new event updates both events", func(t *testing.T) {
+		oldEvent, newEvent, from := setup(t)
+
+		to := *from
+		to.EventID = newEvent.ID
+		to.Version++
+		refundId := uuid.New()
+		to.AddTransfer(registration.Transfer{
+			ID:              uuid.New(),
+			FromEventID:     oldEvent.ID,
+			FromEmail:       "captain@example.com",
+			ToEventID:       newEvent.ID,
+			ToEmail:         "captain@example.com",
+			PriceDifference: money.New(-1000, "USD"),
+			RefundID:        &refundId,
+			TransferredAt:   time.Now(),
+		})
+		oldEvent.NumTeams--
+		oldEvent.Version++
+		newEvent.NumTeams++
+		newEvent.Version++
+		require.NoError(t, db.TransferRegistration(ctx, from, &to, []events.Event{oldEvent, newEvent}))
+
+		savedOld, err := db.GetEvent(ctx, oldEvent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, savedOld.NumTeams)
+		savedNew, err := db.GetEvent(ctx, newEvent.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, savedNew.NumTeams)
+
+		reg, err := db.GetRegistration(ctx, newEvent.ID, "captain@example.com")
+		require.NoError(t, err)
+		transfer := reg.GetTransfers()[0]
+		assert.Equal(t, int64(-1000), transfer.PriceDifference.Amount())
+		assert.Equal(t, refundId, *transfer.RefundID)
+	})
+
+	t.Run("new captain already registered", func(t *testing.T) {
+		oldEvent, _, from := setup(t)
+
+		freeAgent := &registration.IndividualRegistration{ID: uuid.New(), EventID: oldEvent.ID, Version: 1, Email: "taken@example.com"}
+		oldEvent.Version++
+		require.NoError(t, db.CreateRegistration(ctx, freeAgent, oldEvent, nil))
+
+		to := *from
+		to.CaptainEmail = "taken@example.com"
+		to.Version++
+		err := db.TransferRegistration(ctx, from, &to, nil)
+		var regErr *registration.Error
+		require.ErrorAs(t, err, &regErr)
+		assert.Equal(t, registration.REASON_REGISTRATION_ALREADY_EXISTS, regErr.Reason)
+
+		_, err = db.GetRegistration(ctx, oldEvent.ID, "captain@example.com")
+		assert.NoError(t, err)
+	})
+
+	startPendingTransfer := func(t *testing.T, oldEvent events.Event, newEvent events.Event, from *registration.TeamRegistration) (*registration.TeamRegistration, registration.RegistrationIntent) {
+		transferId := uuid.New()
+		pending := *from
+		pending.Version++
+		pending.PendingTransfer = &registration.PendingTransfer{
+			Transfer: registration.Transfer{
+				ID:              transferId,
+				FromEventID:     oldEvent.ID,
+				FromEmail:       "captain@example.com",
+				ToEventID:       newEvent.ID,
+				ToEmail:         "captain@example.com",
+				PriceDifference: money.New(1000, "USD"),
+				TransferredAt:   time.Now(),
+			},
+		}
+		intent := registration.RegistrationIntent{
+			Version:          1,
+			EventId:          oldEvent.ID,
+			Email:            "captain@example.com",
+			PaymentSessionId: "cs_transfer",
+			ExpiresAt:        time.Now().Add(time.Hour),
+			TransferID:       &transferId,
+		}
+		require.NoError(t, db.CreateTransferWithPayment(ctx, &pending, intent))
+		return &pending, intent
+	}
+
+	t.Run("pending transfer is moved once paid", func(t *testing.T) {
+		oldEvent, newEvent, from := setup(t)
+		pending, intent := startPendingTransfer(t, oldEvent, newEvent, from)
+
+		saved, err := db.GetRegistration(ctx, oldEvent.ID, "captain@example.com")
+		require.NoError(t, err)
+		require.NotNil(t, saved.GetPendingTransfer())
+		assert.Equal(t, *intent.TransferID, saved.GetPendingTransfer().Transfer.ID)
+		assert.Equal(t, int64(1000), saved.GetPendingTransfer().Transfer.PriceDifference.Amount())
+		savedIntent, err := db.GetRegistrationIntent(ctx, oldEvent.ID, "captain@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, *intent.TransferID, *savedIntent.TransferID)
+
+		to := *pending
+		to.EventID = newEvent.ID
+		to.PendingTransfer = nil
+		transfer := pending.PendingTransfer.Transfer
+		transfer.ChargePaid = true
+		to.AddTransfer(transfer)
+		to.Version++
+		oldEvent.NumTeams--
+		oldEvent.Version++
+		newEvent.NumTeams++
+		newEvent.Version++
+		outboxItem := newTestOutboxItem(newEvent.ID, time.Now())
+		require.NoError(t, db.TransferRegistrationToPaid(ctx, pending, &to, []events.Event{oldEvent, newEvent}, []registration.OutboxItem{outboxItem}))
+
+		_, err = db.GetRegistrationIntent(ctx, oldEvent.ID, "captain@example.com")
+		assert.Error(t, err)
+		reg, err := db.GetRegistration(ctx, newEvent.ID, "captain@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, reg.GetPendingTransfer())
+		require.Len(t, reg.GetTransfers(), 1)
+		assert.True(t, reg.GetTransfers()[0].ChargePaid)
+		_, err = db.GetOutboxItem(ctx, outboxItem.ID)
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired transfer checkout is dropped", func(t *testing.T) {
+		oldEvent, newEvent, from := setup(t)
+		pending, intent := startPendingTransfer(t, oldEvent, newEvent, from)
+
+		dropped := *pending
+		dropped.PendingTransfer = nil
+		dropped.Version++
+		require.NoError(t, db.DeleteExpiredTransfer(ctx, &dropped, intent))
+
+		_, err := db.GetRegistrationIntent(ctx, oldEvent.ID, "captain@example.com")
+		assert.Error(t, err)
+		reg, err := db.GetRegistration(ctx, oldEvent.ID, "captain@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, reg.GetPendingTransfer())
+		assert.Equal(t, 3, reg.GetVersion())
+	})
+}
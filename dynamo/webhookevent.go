@@ -0,0 +1,135 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type webhookEventDynamo struct {
+	PK string
+	SK string
+
+	WebhookEventID string
+	ClaimedAt      time.Time
+	// Epoch milliseconds the claim lapses at if the event was never completed
+	LeasedUntil int64
+	// Left out until the event is completed, instead of being NULL, so claims can check it doesn't exist
+	CompletedAt *time.Time `dynamodbav:",omitempty"`
+	// Epoch seconds DynamoDB deletes the item at
+	TTL int64
+}
+
+const (
+	webhookEventEntityName = "WEBHOOK_EVENT"
+
+	// The payment provider only retries for a few days, but events can be resent by hand for up to 30
+	webhookEventTTL = 30 * 24 * time.Hour
+)
+
+func webhookEventKey(webhookEventId string) string {
+	return fmt.Sprintf("%s#%s", webhookEventEntityName, webhookEventId)
+}
+
+func (d *DB) ClaimWebhookEvent(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	item, err := attributevalue.MarshalMap(webhookEventDynamo{
+		PK:             webhookEventKey(webhookEventId),
+		SK:             webhookEventKey(webhookEventId),
+		WebhookEventID: webhookEventId,
+		ClaimedAt:      claimedAt,
+		LeasedUntil:    leasedUntil.UnixMilli(),
+		TTL:            claimedAt.Add(webhookEventTTL).Unix(),
+	})
+	if err != nil {
+		return registration.NewFailedToTranslateToDBModelError("Failed to translate webhook event to dynamo model", err)
+	}
+
+	// A claim that was never completed or released is taken over once its lease lapses
+	expr := exprMustBuild(expression.NewBuilder().
+		WithCondition(expression.Name("PK").AttributeNotExists().Or(
+			expression.Name("CompletedAt").AttributeNotExists().And(
+				expression.Name("LeasedUntil").LessThanEqual(expression.Value(claimedAt.UnixMilli()))))))
+
+	_, err = d.dynamoClient.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(d.tableName),
+		Item:                      item,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		var condCheckFailedErr *types.ConditionalCheckFailedException
+		if errors.As(err, &condCheckFailedErr) {
+			return registration.NewWebhookEventAlreadyHandledError(fmt.Sprintf("Webhook event %s was already handled", webhookEventId))
+		} else if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("ClaimWebhookEvent timed out")
+		} else {
+			return registration.NewFailedToWriteError("Failed PutItem call", err)
+		}
+	}
+
+	return nil
+}
+
+func (d *DB) CompleteWebhookEvent(ctx context.Context, webhookEventId string, completedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	// Also sets the TTL, in case the claim was lost and this ends up writing a new item
+	expr := exprMustBuild(expression.NewBuilder().
+		WithUpdate(expression.
+			Set(expression.Name("WebhookEventID"), expression.Value(webhookEventId)).
+			Set(expression.Name("CompletedAt"), expression.Value(completedAt)).
+			Set(expression.Name("TTL"), expression.Value(completedAt.Add(webhookEventTTL).Unix()))))
+
+	_, err := d.dynamoClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: webhookEventKey(webhookEventId)},
+			"SK": &types.AttributeValueMemberS{Value: webhookEventKey(webhookEventId)},
+		},
+		UpdateExpression:          expr.Update(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("CompleteWebhookEvent timed out")
+		}
+		return registration.NewFailedToWriteError("Failed UpdateItem call", err)
+	}
+
+	return nil
+}
+
+func (d *DB) ReleaseWebhookEvent(ctx context.Context, webhookEventId string) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	_, err := d.dynamoClient.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(d.tableName),
+		Key: map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{Value: webhookEventKey(webhookEventId)},
+			"SK": &types.AttributeValueMemberS{Value: webhookEventKey(webhookEventId)},
+		},
+	})
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return registration.NewTimeoutError("ReleaseWebhookEvent timed out")
+		}
+		return registration.NewFailedToWriteError("Failed DeleteItem call", err)
+	}
+
+	return nil
+}
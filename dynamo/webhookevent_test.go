@@ -0,0 +1,58 @@
+package dynamo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimWebhookEvent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("second claim of the same event fails", func(t *testing.T) {
+		resetTable(ctx)
+
+		require.NoError(t, db.ClaimWebhookEvent(ctx, "evt_1", now, now.Add(time.Minute)))
+
+		err := db.ClaimWebhookEvent(ctx, "evt_1", now, now.Add(time.Minute))
+		var registrationErr *registration.Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, registration.REASON_WEBHOOK_EVENT_ALREADY_HANDLED, registrationErr.Reason)
+
+		assert.NoError(t, db.ClaimWebhookEvent(ctx, "evt_2", now, now.Add(time.Minute)))
+	})
+
+	t.Run("released event can be claimed again", func(t *testing.T) {
+		resetTable(ctx)
+
+		require.NoError(t, db.ClaimWebhookEvent(ctx, "evt_1", now, now.Add(time.Minute)))
+		require.NoError(t, db.ReleaseWebhookEvent(ctx, "evt_1"))
+
+		assert.NoError(t, db.ClaimWebhookEvent(ctx, "evt_1", now, now.Add(time.Minute)))
+	})
+	t.Run("lapsed claim can be taken over", func(t *testing.T) {
+		resetTable(ctx)
+
+		require.NoError(t, db.ClaimWebhookEvent(ctx, "evt_1", now, now.Add(time.Minute)))
+
+		assert.NoError(t, db.ClaimWebhookEvent(ctx, "evt_1", now.Add(2*time.Minute), now.Add(3*time.Minute)))
+	})
+
+	t.Run("completed event is never claimed again", func(t *testing.T) {
+		resetTable(ctx)
+
+		require.NoError(t, db.ClaimWebhookEvent(ctx, "evt_1", now, now.Add(time.Minute)))
+		require.NoError(t, db.CompleteWebhookEvent(ctx, "evt_1", now))
+
+		err := db.ClaimWebhookEvent(ctx, "evt_1", now.Add(2*time.Minute), now.Add(3*time.Minute))
+		var registrationErr *registration.Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, registration.REASON_WEBHOOK_EVENT_ALREADY_HANDLED, registrationErr.Reason)
+	})
+}
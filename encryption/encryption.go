@@ -0,0 +1,180 @@
+// Package encryption encrypts sensitive fields before they are stored, using envelope encryption.
+//
+// Every value is encrypted with AES-256-GCM under a data key, and the data key is stored next to
+// it encrypted by a KeyProvider's master key. Data keys are reused for a while and decrypted ones
+// are cached, so the KeyProvider isn't called for every value.
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/redact"
+)
+
+var tracer = redact.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/encryption")
+
+const (
+	dataKeySize = 32
+	// How long one data key is used to encrypt before a new one is made
+	maxDataKeyAge = time.Hour
+	// Decrypted data keys kept around before the cache is cleared
+	maxCachedDataKeys = 1000
+
+	formatVersion byte = 1
+)
+
+var ErrInvalidCiphertext = errors.New("invalid ciphertext")
+
+// KeyProvider makes and unwraps the data keys that values are encrypted with.
+type KeyProvider interface {
+	// GenerateDataKey returns a new data key, and the same key encrypted under the provider's master key.
+	GenerateDataKey(ctx context.Context) (plaintext []byte, encrypted []byte, err error)
+	// DecryptDataKey turns a key from GenerateDataKey back into the plaintext key.
+	DecryptDataKey(ctx context.Context, encrypted []byte) ([]byte, error)
+}
+
+type dataKey struct {
+	plaintext []byte
+	encrypted []byte
+	createdAt time.Time
+}
+
+// Encrypter encrypts and decrypts values with data keys from a KeyProvider. Safe for concurrent use.
+type Encrypter struct {
+	keyProvider KeyProvider
+
+	mu         sync.Mutex
+	currentKey *dataKey
+	keyCache   map[string][]byte
+}
+
+func NewEncrypter(keyProvider KeyProvider) *Encrypter {
+	return &Encrypter{
+		keyProvider: keyProvider,
+		keyCache:    map[string][]byte{},
+	}
+}
+
+// Encrypt returns plaintext encrypted along with the encrypted data key needed to decrypt it.
+func (e *Encrypter) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "Encrypt")
+	defer span.End()
+
+	key, err := e.dataKeyForEncrypting(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key.plaintext)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to make nonce: %w", err)
+	}
+
+	// version | encrypted key length | encrypted key | nonce | ciphertext
+	out := make([]byte, 0, 3+len(key.encrypted)+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, formatVersion)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(key.encrypted)))
+	out = append(out, key.encrypted...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, nil), nil
+}
+
+// Decrypt reverses Encrypt.
+func (e *Encrypter) Decrypt(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "Decrypt")
+	defer span.End()
+
+	if len(ciphertext) < 3 || ciphertext[0] != formatVersion {
+		return nil, ErrInvalidCiphertext
+	}
+	keyLen := int(binary.BigEndian.Uint16(ciphertext[1:3]))
+	rest := ciphertext[3:]
+	if len(rest) < keyLen {
+		return nil, ErrInvalidCiphertext
+	}
+	encryptedKey, rest := rest[:keyLen], rest[keyLen:]
+
+	key, err := e.dataKeyForDecrypting(ctx, encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, ErrInvalidCiphertext
+	}
+	nonce, sealed := rest[:aead.NonceSize()], rest[aead.NonceSize():]
+
+	plaintext, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCiphertext, err)
+	}
+	return plaintext, nil
+}
+
+func (e *Encrypter) dataKeyForEncrypting(ctx context.Context) (*dataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.currentKey != nil && time.Since(e.currentKey.createdAt) < maxDataKeyAge {
+		return e.currentKey, nil
+	}
+
+	plaintext, encrypted, err := e.keyProvider.GenerateDataKey(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	e.currentKey = &dataKey{plaintext: plaintext, encrypted: encrypted, createdAt: time.Now()}
+	e.cacheKey(encrypted, plaintext)
+	return e.currentKey, nil
+}
+
+func (e *Encrypter) dataKeyForDecrypting(ctx context.Context, encrypted []byte) ([]byte, error) {
+	e.mu.Lock()
+	key, ok := e.keyCache[string(encrypted)]
+	e.mu.Unlock()
+	if ok {
+		return key, nil
+	}
+
+	key, err := e.keyProvider.DecryptDataKey(ctx, encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key: %w", err)
+	}
+
+	e.mu.Lock()
+	e.cacheKey(encrypted, key)
+	e.mu.Unlock()
+	return key, nil
+}
+
+// cacheKey must be called with mu held.
+func (e *Encrypter) cacheKey(encrypted []byte, plaintext []byte) {
+	if len(e.keyCache) >= maxCachedDataKeys {
+		clear(e.keyCache)
+	}
+	e.keyCache[string(encrypted)] = plaintext
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid data key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,145 @@
+package encryption
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMasterKey = []byte("0123456789abcdef0123456789abcdef")
+
+type countingKeyProvider struct {
+	KeyProvider
+	generated int
+	decrypted int
+}
+
+func (p *countingKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	p.generated++
+	return p.KeyProvider.GenerateDataKey(ctx)
+}
+
+func (p *countingKeyProvider) DecryptDataKey(ctx context.Context, encrypted []byte) ([]byte, error) {
+	p.decrypted++
+	return p.KeyProvider.DecryptDataKey(ctx, encrypted)
+}
+
+func TestEncrypter(t *testing.T) {
+	ctx := context.Background()
+	staticProvider, err := NewStaticKeyProvider(testMasterKey)
+	require.NoError(t, err)
+
+	t.Run("round trip", func(t *testing.T) {
+		e := NewEncrypter(staticProvider)
+
+		ciphertext, err := e.Encrypt(ctx, []byte("allergic to bees"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(ciphertext), "bees")
+
+		plaintext, err := e.Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "allergic to bees", string(plaintext))
+	})
+
+	t.Run("reuses data keys", func(t *testing.T) {
+		provider := &countingKeyProvider{KeyProvider: staticProvider}
+		e := NewEncrypter(provider)
+
+		first, err := e.Encrypt(ctx, []byte("one"))
+		require.NoError(t, err)
+		_, err = e.Encrypt(ctx, []byte("two"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, provider.generated)
+
+		// A new encrypter, like another lambda, has to unwrap the key once
+		other := NewEncrypter(provider)
+		for range 2 {
+			plaintext, err := other.Decrypt(ctx, first)
+			require.NoError(t, err)
+			assert.Equal(t, "one", string(plaintext))
+		}
+		assert.Equal(t, 1, provider.decrypted)
+	})
+
+	t.Run("wrong master key", func(t *testing.T) {
+		ciphertext, err := NewEncrypter(staticProvider).Encrypt(ctx, []byte("secret"))
+		require.NoError(t, err)
+
+		otherProvider, err := NewStaticKeyProvider([]byte("fedcba9876543210fedcba9876543210"))
+		require.NoError(t, err)
+		_, err = NewEncrypter(otherProvider).Decrypt(ctx, ciphertext)
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		e := NewEncrypter(staticProvider)
+		ciphertext, err := e.Encrypt(ctx, []byte("secret"))
+		require.NoError(t, err)
+
+		ciphertext[len(ciphertext)-1] ^= 0xff
+		_, err = e.Decrypt(ctx, ciphertext)
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+
+		_, err = e.Decrypt(ctx, []byte{0})
+		assert.ErrorIs(t, err, ErrInvalidCiphertext)
+	})
+}
+
+func TestNewStaticKeyProvider(t *testing.T) {
+	_, err := NewStaticKeyProvider([]byte("too short"))
+	assert.Error(t, err)
+}
+
+type mockKMSClient struct {
+	GenerateDataKeyFunc func(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error)
+	DecryptFunc         func(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+func (m *mockKMSClient) GenerateDataKey(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+	return m.GenerateDataKeyFunc(ctx, params, optFns...)
+}
+
+func (m *mockKMSClient) Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	return m.DecryptFunc(ctx, params, optFns...)
+}
+
+func TestKMSKeyProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("round trip", func(t *testing.T) {
+		dataKey := []byte("abcdef0123456789abcdef0123456789")
+		client := &mockKMSClient{
+			GenerateDataKeyFunc: func(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+				assert.Equal(t, "alias/field-encryption", *params.KeyId)
+				return &kms.GenerateDataKeyOutput{Plaintext: dataKey, CiphertextBlob: []byte("wrapped")}, nil
+			},
+			DecryptFunc: func(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+				assert.Equal(t, []byte("wrapped"), params.CiphertextBlob)
+				return &kms.DecryptOutput{Plaintext: dataKey}, nil
+			},
+		}
+		provider := NewKMSKeyProvider(client, "alias/field-encryption")
+
+		ciphertext, err := NewEncrypter(provider).Encrypt(ctx, []byte("call mom"))
+		require.NoError(t, err)
+
+		plaintext, err := NewEncrypter(provider).Decrypt(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "call mom", string(plaintext))
+	})
+
+	t.Run("KMS fails", func(t *testing.T) {
+		client := &mockKMSClient{
+			GenerateDataKeyFunc: func(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error) {
+				return nil, errors.New("access denied")
+			},
+		}
+
+		_, err := NewEncrypter(NewKMSKeyProvider(client, "key")).Encrypt(ctx, []byte("secret"))
+		assert.ErrorContains(t, err, "access denied")
+	})
+}
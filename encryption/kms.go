@@ -0,0 +1,54 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+// KMSClient is the part of the AWS KMS client the KMSKeyProvider uses.
+type KMSClient interface {
+	GenerateDataKey(ctx context.Context, params *kms.GenerateDataKeyInput, optFns ...func(*kms.Options)) (*kms.GenerateDataKeyOutput, error)
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+var _ KeyProvider = &KMSKeyProvider{}
+
+// KMSKeyProvider makes data keys with an AWS KMS key, so the master key never leaves KMS.
+type KMSKeyProvider struct {
+	client KMSClient
+	keyID  string
+}
+
+// NewKMSKeyProvider uses the KMS key with keyID, which can be a key ID, key ARN or alias.
+func NewKMSKeyProvider(client KMSClient, keyID string) *KMSKeyProvider {
+	return &KMSKeyProvider{
+		client: client,
+		keyID:  keyID,
+	}
+}
+
+func (p *KMSKeyProvider) GenerateDataKey(ctx context.Context) ([]byte, []byte, error) {
+	out, err := p.client.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(p.keyID),
+		KeySpec: types.DataKeySpecAes256,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate KMS data key: %w", err)
+	}
+	return out.Plaintext, out.CiphertextBlob, nil
+}
+
+func (p *KMSKeyProvider) DecryptDataKey(ctx context.Context, encrypted []byte) ([]byte, error) {
+	out, err := p.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(p.keyID),
+		CiphertextBlob: encrypted,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt KMS data key: %w", err)
+	}
+	return out.Plaintext, nil
+}
@@ -12,6 +12,7 @@ const (
 	REASON_FAILED_TO_FETCH                 ErrorReason = "FAILED_TO_FETCH"
 	REASON_INVALID_CURSOR                  ErrorReason = "INVALID_CURSOR"
 	REASON_TIMEOUT                         ErrorReason = "TIMEOUT"
+	REASON_INVALID_CHECKOUT_SETTINGS       ErrorReason = "INVALID_CHECKOUT_SETTINGS"
 )
 
 type Error struct {
@@ -67,3 +68,7 @@ func NewInvalidCursorError(message string, cause error) *Error {
 func NewTimeoutError(message string) *Error {
 	return newEventError(REASON_TIMEOUT, message, nil)
 }
+
+func NewInvalidCheckoutSettingsError(message string) *Error {
+	return newEventError(REASON_INVALID_CHECKOUT_SETTINGS, message, nil)
+}
@@ -2,16 +2,19 @@ package events
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/International-Combat-Archery-Alliance/event-registration/redact"
 	"github.com/Rhymond/go-money"
 	"github.com/google/uuid"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 )
 
-var tracer = otel.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/events")
+var tracer = redact.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/events")
 
 type Event struct {
 	ID                    uuid.UUID
@@ -27,9 +30,61 @@ type Event struct {
 	NumTeams              int
 	NumRosteredPlayers    int
 	NumTotalPlayers       int
-	RulesDocLink          *string
-	ImageName             *string
-	MailingListGroupID     *string
+	// Attendance on the day of the event. A team counts as checked in once any of its players is.
+	NumCheckedInPlayers int
+	NumCheckedInTeams   int
+	RulesDocLink        *string
+	ImageName           *string
+	MailingListGroupID  *string
+	// If set, team captains can split the team fee between the players on their roster.
+	// Every player has this long after the team signs up to pay their share.
+	SplitPaymentWindow *time.Duration
+	// If set, free sign ups only hold their spot until the registrant clicks the link emailed to them,
+	// so nobody can take spots with made up emails.
+	RequireEmailVerification bool
+	// How long a checkout holds the registrant's spot before it expires. Defaults to DefaultCheckoutHoldDuration.
+	CheckoutHoldDuration *time.Duration
+	// Shown as the item on the event's sign up checkouts instead of "<event name> Free Agent Sign Up" or "<event name> Team Sign Up".
+	CheckoutItemDescription *string
+	// If set, the payment provider won't offer to convert the price to the registrant's local currency.
+	DisableAdaptivePricing bool
+	// When the players' personal data on the event's registrations was anonymized by the retention policy
+	PersonalDataPurgedAt *time.Time
+}
+
+// Limits the payment provider puts on checkouts
+const (
+	DefaultCheckoutHoldDuration      = 30 * time.Minute
+	MinCheckoutHoldDuration          = 30 * time.Minute
+	MaxCheckoutHoldDuration          = 24 * time.Hour
+	MaxCheckoutItemDescriptionLength = 250
+)
+
+// CheckoutHold is how long the event's checkouts hold a spot.
+func (e Event) CheckoutHold() time.Duration {
+	if e.CheckoutHoldDuration == nil {
+		return DefaultCheckoutHoldDuration
+	}
+	return *e.CheckoutHoldDuration
+}
+
+// ValidateCheckoutSettings makes sure the payment provider will accept checkouts made with the event's settings.
+func ValidateCheckoutSettings(event Event) error {
+	if event.CheckoutHoldDuration != nil {
+		if *event.CheckoutHoldDuration < MinCheckoutHoldDuration || *event.CheckoutHoldDuration > MaxCheckoutHoldDuration {
+			return NewInvalidCheckoutSettingsError(fmt.Sprintf("Checkout hold duration must be between %s and %s", MinCheckoutHoldDuration, MaxCheckoutHoldDuration))
+		}
+	}
+	if event.CheckoutItemDescription != nil {
+		description := strings.TrimSpace(*event.CheckoutItemDescription)
+		if description == "" {
+			return NewInvalidCheckoutSettingsError("Checkout item description can't be blank")
+		}
+		if utf8.RuneCountInString(description) > MaxCheckoutItemDescriptionLength {
+			return NewInvalidCheckoutSettingsError(fmt.Sprintf("Checkout item description can't be longer than %d characters", MaxCheckoutItemDescriptionLength))
+		}
+	}
+	return nil
 }
 
 type EventRegistrationOption struct {
@@ -69,22 +124,30 @@ func UpdateEvent(ctx context.Context, repo Repository, id uuid.UUID, event Event
 	}
 
 	updatedEvent := Event{
-		ID:                    id,
-		Version:               existingEvent.Version + 1,
-		Name:                  event.Name,
-		StartTime:             event.StartTime,
-		EndTime:               event.EndTime,
-		TimeZone:              event.TimeZone,
-		EventLocation:         event.EventLocation,
-		RegistrationCloseTime: event.RegistrationCloseTime,
-		RegistrationOptions:   event.RegistrationOptions,
-		AllowedTeamSizeRange:  event.AllowedTeamSizeRange,
-		NumTeams:              existingEvent.NumTeams,
-		NumRosteredPlayers:    existingEvent.NumRosteredPlayers,
-		NumTotalPlayers:       existingEvent.NumTotalPlayers,
-		RulesDocLink:          event.RulesDocLink,
-		ImageName:             event.ImageName,
-		MailingListGroupID:     existingEvent.MailingListGroupID,
+		ID:                       id,
+		Version:                  existingEvent.Version + 1,
+		Name:                     event.Name,
+		StartTime:                event.StartTime,
+		EndTime:                  event.EndTime,
+		TimeZone:                 event.TimeZone,
+		EventLocation:            event.EventLocation,
+		RegistrationCloseTime:    event.RegistrationCloseTime,
+		RegistrationOptions:      event.RegistrationOptions,
+		AllowedTeamSizeRange:     event.AllowedTeamSizeRange,
+		NumTeams:                 existingEvent.NumTeams,
+		NumRosteredPlayers:       existingEvent.NumRosteredPlayers,
+		NumTotalPlayers:          existingEvent.NumTotalPlayers,
+		NumCheckedInPlayers:      existingEvent.NumCheckedInPlayers,
+		NumCheckedInTeams:        existingEvent.NumCheckedInTeams,
+		RulesDocLink:             event.RulesDocLink,
+		ImageName:                event.ImageName,
+		MailingListGroupID:       existingEvent.MailingListGroupID,
+		SplitPaymentWindow:       event.SplitPaymentWindow,
+		RequireEmailVerification: event.RequireEmailVerification,
+		CheckoutHoldDuration:     event.CheckoutHoldDuration,
+		CheckoutItemDescription:  event.CheckoutItemDescription,
+		DisableAdaptivePricing:   event.DisableAdaptivePricing,
+		PersonalDataPurgedAt:     existingEvent.PersonalDataPurgedAt,
 	}
 
 	err = repo.UpdateEvent(ctx, updatedEvent)
@@ -96,3 +159,26 @@ func UpdateEvent(ctx context.Context, repo Repository, id uuid.UUID, event Event
 
 	return updatedEvent, nil
 }
+
+// GetAllEvents pages through every event in the repository.
+func GetAllEvents(ctx context.Context, repo Repository) ([]Event, error) {
+	ctx, span := tracer.Start(ctx, "GetAllEvents")
+	defer span.End()
+
+	var allEvents []Event
+	var cursor *string
+	for {
+		resp, err := repo.GetEvents(ctx, 50, cursor)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		allEvents = append(allEvents, resp.Data...)
+
+		if !resp.HasNextPage {
+			return allEvents, nil
+		}
+		cursor = resp.Cursor
+	}
+}
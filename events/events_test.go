@@ -3,9 +3,11 @@ package events
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
 	"github.com/Rhymond/go-money"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -347,3 +349,35 @@ func TestUpdateEventTimeZone(t *testing.T) {
 		assert.Equal(t, "Asia/Tokyo", result.TimeZone.String())
 	})
 }
+
+func TestValidateCheckoutSettings(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		event := Event{}
+		assert.NoError(t, ValidateCheckoutSettings(event))
+		assert.Equal(t, DefaultCheckoutHoldDuration, event.CheckoutHold())
+	})
+
+	t.Run("within the payment provider's limits", func(t *testing.T) {
+		event := Event{
+			CheckoutHoldDuration:    ptr.Duration(MaxCheckoutHoldDuration),
+			CheckoutItemDescription: ptr.String("ICAA Cup 2025 Entry"),
+		}
+		assert.NoError(t, ValidateCheckoutSettings(event))
+		assert.Equal(t, MaxCheckoutHoldDuration, event.CheckoutHold())
+	})
+
+	invalid := map[string]Event{
+		"hold too short":       {CheckoutHoldDuration: ptr.Duration(10 * time.Minute)},
+		"hold too long":        {CheckoutHoldDuration: ptr.Duration(48 * time.Hour)},
+		"blank description":    {CheckoutItemDescription: ptr.String("  ")},
+		"description too long": {CheckoutItemDescription: ptr.String(strings.Repeat("a", MaxCheckoutItemDescriptionLength+1))},
+	}
+	for name, event := range invalid {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateCheckoutSettings(event)
+			var eventErr *Error
+			assert.True(t, errors.As(err, &eventErr))
+			assert.Equal(t, REASON_INVALID_CHECKOUT_SETTINGS, eventErr.Reason)
+		})
+	}
+}
@@ -6,6 +6,10 @@ func Int(i int) *int {
 	return &i
 }
 
+func Bool(b bool) *bool {
+	return &b
+}
+
 func String(s string) *string {
 	return &s
 }
@@ -13,3 +17,7 @@ func String(s string) *string {
 func Duration(d time.Duration) *time.Duration {
 	return &d
 }
+
+func Time(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,164 @@
+// Package redact keeps personal info, like registrants' emails and names, out of logs and traces.
+//
+// Values are either masked or swapped for a keyed hash. Both are consistent, so the same person
+// still shows up the same way everywhere and requests can be correlated without knowing who it was.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+type Mode int
+
+const (
+	// Leave everything as is, for local development
+	MODE_OFF Mode = iota
+	// Keep the first letter and the email domain, "j***@example.com"
+	MODE_MASK
+	// Swap values for a keyed hash, "email:3fa9c1d2e4ab"
+	MODE_HASH
+)
+
+func ParseMode(s string) (Mode, error) {
+	switch strings.ToLower(s) {
+	case "off":
+		return MODE_OFF, nil
+	case "mask":
+		return MODE_MASK, nil
+	case "hash":
+		return MODE_HASH, nil
+	}
+	return MODE_OFF, fmt.Errorf("unknown redaction mode %q, must be off, mask or hash", s)
+}
+
+// Emails in free text, including URL encoded ones from request paths
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._+\-]+(?:@|%40)[A-Za-z0-9\-]+(?:\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+
+// Attribute keys whose values are names. Keys ending in "email" and the ones in emailKeys
+// are emails, anything else is only searched for emails.
+var (
+	nameKeys = map[string]bool{
+		"name":      true,
+		"firstname": true,
+		"lastname":  true,
+		"teamname":  true,
+	}
+	emailKeys = map[string]bool{
+		"author":        true,
+		"accessedby":    true,
+		"checkedinby":   true,
+		"erasedby":      true,
+		"recordedby":    true,
+		"registeredby":  true,
+		"transferredby": true,
+		"undoneby":      true,
+	}
+)
+
+// Redactor redacts values for one Mode. Safe for concurrent use.
+type Redactor struct {
+	mode    Mode
+	hashKey []byte
+}
+
+// NewRedactor makes a Redactor. hashKey is only used by MODE_HASH, and has to be the same
+// everywhere for hashes to match up.
+func NewRedactor(mode Mode, hashKey []byte) *Redactor {
+	return &Redactor{
+		mode:    mode,
+		hashKey: hashKey,
+	}
+}
+
+func (r *Redactor) Mode() Mode {
+	return r.mode
+}
+
+func (r *Redactor) Email(email string) string {
+	if r.mode == MODE_OFF || email == "" {
+		return email
+	}
+	email = strings.ToLower(strings.Replace(strings.TrimSpace(email), "%40", "@", 1))
+
+	if r.mode == MODE_HASH {
+		return "email:" + r.hash(email)
+	}
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return mask(email)
+	}
+	return mask(local) + "@" + domain
+}
+
+func (r *Redactor) Name(name string) string {
+	if r.mode == MODE_OFF || name == "" {
+		return name
+	}
+
+	if r.mode == MODE_HASH {
+		return "name:" + r.hash(strings.ToLower(strings.TrimSpace(name)))
+	}
+	words := strings.Fields(name)
+	for i, word := range words {
+		words[i] = mask(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// Text redacts every email in s. Names can't be found in free text, so they are left alone.
+func (r *Redactor) Text(s string) string {
+	if r.mode == MODE_OFF {
+		return s
+	}
+	return emailPattern.ReplaceAllStringFunc(s, r.Email)
+}
+
+// Value redacts a value based on the key it's logged under.
+func (r *Redactor) Value(key string, value string) string {
+	key = strings.ToLower(strings.NewReplacer("-", "", "_", "", ".", "").Replace(key))
+	switch {
+	case strings.HasSuffix(key, "email") || emailKeys[key]:
+		return r.Email(value)
+	case nameKeys[key]:
+		return r.Name(value)
+	}
+	return r.Text(value)
+}
+
+func (r *Redactor) hash(s string) string {
+	h := hmac.New(sha256.New, r.hashKey)
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))[:12]
+}
+
+func mask(s string) string {
+	first, _ := utf8.DecodeRuneInString(s)
+	if first == utf8.RuneError {
+		return "***"
+	}
+	return string(first) + "***"
+}
+
+var defaultRedactor atomic.Pointer[Redactor]
+
+func init() {
+	// Masking doesn't need a key, so it's safe to use until the app is configured
+	defaultRedactor.Store(NewRedactor(MODE_MASK, nil))
+}
+
+// Default is the Redactor used by Handler, Tracer and TracerProvider.
+func Default() *Redactor {
+	return defaultRedactor.Load()
+}
+
+// SetDefault changes the Redactor used by Handler, Tracer and TracerProvider, once the environment is known.
+func SetDefault(r *Redactor) {
+	defaultRedactor.Store(r)
+}
@@ -0,0 +1,167 @@
+package redact
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func useRedactor(t *testing.T, r *Redactor) {
+	previous := Default()
+	SetDefault(r)
+	t.Cleanup(func() { SetDefault(previous) })
+}
+
+func TestRedactor(t *testing.T) {
+	t.Run("off", func(t *testing.T) {
+		r := NewRedactor(MODE_OFF, nil)
+		assert.Equal(t, "jane@example.com", r.Email("jane@example.com"))
+		assert.Equal(t, "Jane Doe", r.Name("Jane Doe"))
+		assert.Equal(t, "failed for jane@example.com", r.Text("failed for jane@example.com"))
+	})
+
+	t.Run("mask", func(t *testing.T) {
+		r := NewRedactor(MODE_MASK, nil)
+		assert.Equal(t, "j***@example.com", r.Email("Jane@Example.com"))
+		assert.Equal(t, "J*** D***", r.Name("Jane Doe"))
+		assert.Equal(t, "", r.Email(""))
+	})
+
+	t.Run("hash is consistent", func(t *testing.T) {
+		r := NewRedactor(MODE_HASH, []byte("key"))
+		hashed := r.Email("jane@example.com")
+		assert.NotContains(t, hashed, "jane")
+		assert.Equal(t, hashed, r.Email(" Jane@Example.com"))
+		assert.Equal(t, hashed, r.Email("jane%40example.com"))
+		assert.NotEqual(t, hashed, r.Email("john@example.com"))
+		assert.NotEqual(t, hashed, NewRedactor(MODE_HASH, []byte("other key")).Email("jane@example.com"))
+		assert.Equal(t, r.Name("Jane Doe"), r.Name("jane doe"))
+	})
+
+	t.Run("text", func(t *testing.T) {
+		r := NewRedactor(MODE_HASH, []byte("key"))
+		text := r.Text("registration for jane@example.com not found, /registrations/john.doe%40example.co.uk")
+		assert.NotContains(t, text, "jane")
+		assert.NotContains(t, text, "john")
+		assert.Contains(t, text, "registration for "+r.Email("jane@example.com")+" not found")
+	})
+
+	t.Run("value by key", func(t *testing.T) {
+		r := NewRedactor(MODE_MASK, nil)
+		assert.Equal(t, "a***", r.Value("checkedInBy", "admin"))
+		assert.Equal(t, "j***@example.com", r.Value("user-email", "jane@example.com"))
+		assert.Equal(t, "J***", r.Value("firstName", "Jane"))
+		assert.Equal(t, "Summer Games", r.Value("event", "Summer Games"))
+	})
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("HASH")
+	require.NoError(t, err)
+	assert.Equal(t, MODE_HASH, mode)
+
+	_, err = ParseMode("scramble")
+	assert.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	useRedactor(t, NewRedactor(MODE_MASK, nil))
+
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(slog.NewJSONHandler(&buf, nil))).With(slog.String("user-email", "admin@example.com"))
+
+	logger.Info("sent email to jane@example.com",
+		slog.String("email", "jane@example.com"),
+		slog.String("name", "Jane Doe"),
+		slog.String("eventId", "1234"),
+		slog.Any("error", fmt.Errorf("mailing list rejected jane@example.com")),
+		slog.Group("player", slog.String("lastName", "Doe")))
+
+	out := buf.String()
+	assert.NotContains(t, out, "jane@")
+	assert.NotContains(t, out, "admin@")
+	assert.NotContains(t, out, "Doe")
+	assert.Contains(t, out, `"msg":"sent email to j***@example.com"`)
+	assert.Contains(t, out, `"user-email":"a***@example.com"`)
+	assert.Contains(t, out, `"name":"J*** D***"`)
+	assert.Contains(t, out, `"eventId":"1234"`)
+	assert.Contains(t, out, `"error":"mailing list rejected j***@example.com"`)
+}
+
+func TestTracer(t *testing.T) {
+	useRedactor(t, NewRedactor(MODE_MASK, nil))
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := Tracer("test").Start(context.Background(), "parent")
+	_, child := Tracer("test").Start(ctx, "child")
+	child.End()
+
+	err := errors.New("registration for jane@example.com not found")
+	parent.SetAttributes(attribute.String("email", "jane@example.com"), attribute.Int("players", 2))
+	parent.RecordError(err)
+	parent.SetStatus(codes.Error, err.Error())
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+	recorded := spans[1]
+	assert.Equal(t, "registration for j***@example.com not found", recorded.Status().Description)
+	assert.Contains(t, recorded.Attributes(), attribute.String("email", "j***@example.com"))
+	assert.Contains(t, recorded.Attributes(), attribute.Int("players", 2))
+
+	require.Len(t, recorded.Events(), 1)
+	assert.Equal(t, "exception", recorded.Events()[0].Name)
+	assert.Contains(t, recorded.Events()[0].Attributes, attribute.String("exception.message", "registration for j***@example.com not found"))
+	assert.Contains(t, recorded.Events()[0].Attributes, attribute.String("exception.type", "*errors.errorString"))
+}
+
+func TestTracerProvider(t *testing.T) {
+	redactor := NewRedactor(MODE_HASH, []byte("key"))
+	useRedactor(t, redactor)
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(TracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	handler := middleware.OTELHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := Tracer("test").Start(r.Context(), "handler")
+		span.SetAttributes(attribute.String("email", "jane@example.com"))
+		span.End()
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/registrations/jane%40example.com/check-in", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	hashed := redactor.Email("jane@example.com")
+
+	// Only redacted once, even though the handler's span is from Tracer too
+	assert.Contains(t, spans[0].Attributes(), attribute.String("email", hashed))
+
+	server := spans[1]
+	assert.Equal(t, "POST /registrations/"+hashed+"/check-in", server.Name())
+	assert.Contains(t, server.Attributes(), attribute.String("url.path", "/registrations/"+hashed+"/check-in"))
+	for _, attr := range server.Attributes() {
+		assert.NotContains(t, attr.Value.Emit(), "jane")
+	}
+}
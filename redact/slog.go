@@ -0,0 +1,75 @@
+package redact
+
+import (
+	"context"
+	"log/slog"
+)
+
+var _ slog.Handler = &Handler{}
+
+// Handler redacts log messages and attributes with the Default Redactor before passing them on.
+type Handler struct {
+	next slog.Handler
+}
+
+func NewHandler(next slog.Handler) *Handler {
+	return &Handler{next: next}
+}
+
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+	r := Default()
+	if r.Mode() == MODE_OFF {
+		return h.next.Handle(ctx, record)
+	}
+
+	redacted := slog.NewRecord(record.Time, record.Level, r.Text(record.Message), record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		redacted.AddAttrs(redactAttr(r, attr))
+		return true
+	})
+	return h.next.Handle(ctx, redacted)
+}
+
+// WithAttrs redacts with the Default Redactor at the time it's called, which is fine because
+// loggers are only made with attributes after startup.
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	r := Default()
+	redacted := make([]slog.Attr, len(attrs))
+	for i, attr := range attrs {
+		redacted[i] = redactAttr(r, attr)
+	}
+	return &Handler{next: h.next.WithAttrs(redacted)}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return &Handler{next: h.next.WithGroup(name)}
+}
+
+func redactAttr(r *Redactor, attr slog.Attr) slog.Attr {
+	if r.Mode() == MODE_OFF {
+		return attr
+	}
+
+	value := attr.Value.Resolve()
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, r.Value(attr.Key, value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		redacted := make([]any, len(group))
+		for i, groupAttr := range group {
+			redacted[i] = redactAttr(r, groupAttr)
+		}
+		return slog.Group(attr.Key, redacted...)
+	case slog.KindAny:
+		// Errors often have the email of the registration they were about in them
+		if err, ok := value.Any().(error); ok {
+			return slog.String(attr.Key, r.Text(err.Error()))
+		}
+	}
+	return slog.Attr{Key: attr.Key, Value: value}
+}
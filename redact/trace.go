@@ -0,0 +1,162 @@
+package redact
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/embedded"
+)
+
+var (
+	_ trace.TracerProvider = &tracerProvider{}
+	_ trace.Tracer         = &tracer{}
+)
+
+type tracerProvider struct {
+	embedded.TracerProvider
+
+	tp trace.TracerProvider
+}
+
+// TracerProvider wraps tp so every span it makes is redacted like Tracer's are, including span names
+// and start attributes. Set it as the global TracerProvider so instrumentation libraries, like
+// otelhttp naming spans after request paths that have emails in them, are redacted too.
+func TracerProvider(tp trace.TracerProvider) trace.TracerProvider {
+	return &tracerProvider{tp: tp}
+}
+
+func (p *tracerProvider) Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	next := p.tp.Tracer(name, opts...)
+	return &tracer{
+		next: func() trace.Tracer { return next },
+	}
+}
+
+type tracer struct {
+	embedded.Tracer
+
+	// The tracer spans are started with before being redacted
+	next func() trace.Tracer
+}
+
+// Tracer is a drop in for otel.Tracer whose spans redact attributes, errors and statuses with
+// the Default Redactor. The global TracerProvider is looked up on every Start, so it can be
+// made before telemetry is set up.
+func Tracer(name string, opts ...trace.TracerOption) trace.Tracer {
+	return &tracer{
+		next: func() trace.Tracer { return otel.Tracer(name, opts...) },
+	}
+}
+
+func (t *tracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	next := t.next()
+	if redacting, ok := next.(*tracer); ok {
+		// The global TracerProvider already redacts, doing it twice would hash the hashes
+		return redacting.Start(ctx, spanName, opts...)
+	}
+
+	r := Default()
+	if r.Mode() == MODE_OFF {
+		return next.Start(ctx, spanName, opts...)
+	}
+
+	ctx, s := next.Start(ctx, r.Text(spanName), redactStartOptions(r, opts)...)
+	if !s.IsRecording() {
+		return ctx, s
+	}
+	redacted := &span{Span: s, redactor: r}
+	// So trace.SpanFromContext hands out the redacting span too
+	return trace.ContextWithSpan(ctx, redacted), redacted
+}
+
+func redactStartOptions(r *Redactor, opts []trace.SpanStartOption) []trace.SpanStartOption {
+	cfg := trace.NewSpanStartConfig(opts...)
+	redacted := []trace.SpanStartOption{
+		trace.WithAttributes(redactAttributes(r, cfg.Attributes())...),
+		trace.WithLinks(cfg.Links()...),
+		trace.WithSpanKind(cfg.SpanKind()),
+	}
+	if !cfg.Timestamp().IsZero() {
+		redacted = append(redacted, trace.WithTimestamp(cfg.Timestamp()))
+	}
+	if cfg.NewRoot() {
+		redacted = append(redacted, trace.WithNewRoot())
+	}
+	return redacted
+}
+
+type span struct {
+	trace.Span
+
+	redactor *Redactor
+}
+
+func (s *span) SetName(name string) {
+	s.Span.SetName(s.redactor.Text(name))
+}
+
+// TracerProvider is what instrumentation like otelhttp starts child spans with, so it has to redact too.
+func (s *span) TracerProvider() trace.TracerProvider {
+	return TracerProvider(s.Span.TracerProvider())
+}
+
+func (s *span) SetAttributes(kv ...attribute.KeyValue) {
+	s.Span.SetAttributes(redactAttributes(s.redactor, kv)...)
+}
+
+func (s *span) SetStatus(code codes.Code, description string) {
+	s.Span.SetStatus(code, s.redactor.Text(description))
+}
+
+func (s *span) AddEvent(name string, opts ...trace.EventOption) {
+	cfg := trace.NewEventConfig(opts...)
+	s.Span.AddEvent(name,
+		trace.WithAttributes(redactAttributes(s.redactor, cfg.Attributes())...),
+		trace.WithTimestamp(cfg.Timestamp()),
+		trace.WithStackTrace(cfg.StackTrace()))
+}
+
+// RecordError records the same exception event the SDK does, with the message redacted.
+func (s *span) RecordError(err error, opts ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+
+	cfg := trace.NewEventConfig(opts...)
+	attrs := []attribute.KeyValue{
+		attribute.String("exception.type", errorType(err)),
+		attribute.String("exception.message", s.redactor.Text(err.Error())),
+	}
+	if cfg.StackTrace() {
+		attrs = append(attrs, attribute.String("exception.stacktrace", string(debug.Stack())))
+	}
+	attrs = append(attrs, redactAttributes(s.redactor, cfg.Attributes())...)
+
+	s.Span.AddEvent("exception", trace.WithAttributes(attrs...), trace.WithTimestamp(cfg.Timestamp()))
+}
+
+func redactAttributes(r *Redactor, kv []attribute.KeyValue) []attribute.KeyValue {
+	redacted := make([]attribute.KeyValue, len(kv))
+	for i, attr := range kv {
+		if attr.Value.Type() == attribute.STRING {
+			attr = attribute.String(string(attr.Key), r.Value(string(attr.Key), attr.Value.AsString()))
+		}
+		redacted[i] = attr
+	}
+	return redacted
+}
+
+func errorType(err error) string {
+	t := reflect.TypeOf(err)
+	if t.PkgPath() == "" && t.Name() == "" {
+		// Pointers and other unnamed types
+		return t.String()
+	}
+	return fmt.Sprintf("%s.%s", t.PkgPath(), t.Name())
+}
@@ -0,0 +1,195 @@
+package registration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	maxAdminNoteLength = 2000
+	maxTagLength       = 50
+)
+
+// AdminNote is an organizer's note on a registration, like "needs a loaner bow".
+type AdminNote struct {
+	ID   uuid.UUID
+	Text string
+	// Email of the admin that wrote it
+	Author    string
+	CreatedAt time.Time
+}
+
+type AddAdminNoteParams struct {
+	EventID uuid.UUID
+	Email   string
+	Text    string
+	Author  string
+}
+
+func AddAdminNote(ctx context.Context, params AddAdminNoteParams, registrationRepo Repository) (Registration, AdminNote, error) {
+	ctx, span := tracer.Start(ctx, "AddAdminNote")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", params.EventID.String()))
+
+	text := strings.TrimSpace(params.Text)
+	if text == "" || utf8.RuneCountInString(text) > maxAdminNoteLength {
+		err := NewInvalidAdminAnnotationError(fmt.Sprintf("Notes must be between 1 and %d characters", maxAdminNoteLength))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, AdminNote{}, err
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, params.EventID, params.Email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, AdminNote{}, err
+	}
+
+	note := AdminNote{
+		ID:        uuid.New(),
+		Text:      text,
+		Author:    params.Author,
+		CreatedAt: time.Now(),
+	}
+	reg.AddAdminNote(note)
+	reg.BumpVersion()
+
+	err = registrationRepo.UpdateRegistration(ctx, reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, AdminNote{}, err
+	}
+	return reg, note, nil
+}
+
+func RemoveAdminNote(ctx context.Context, eventId uuid.UUID, email string, noteId uuid.UUID, registrationRepo Repository) (Registration, error) {
+	ctx, span := tracer.Start(ctx, "RemoveAdminNote")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if !reg.RemoveAdminNote(noteId) {
+		err = NewAdminNoteDoesNotExistError(fmt.Sprintf("No note with ID %q on the registration", noteId))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	reg.BumpVersion()
+
+	err = registrationRepo.UpdateRegistration(ctx, reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return reg, nil
+}
+
+// AddTag tags a registration. Tags are lower cased, so "VIP" and "vip" are the same tag.
+// Adding a tag the registration already has does nothing.
+func AddTag(ctx context.Context, eventId uuid.UUID, email string, tag string, registrationRepo Repository) (Registration, error) {
+	ctx, span := tracer.Start(ctx, "AddTag")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	tag = normalizeTag(tag)
+	if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+		err := NewInvalidAdminAnnotationError(fmt.Sprintf("Tags must be between 1 and %d characters", maxTagLength))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if slices.Contains(reg.GetTags(), tag) {
+		return reg, nil
+	}
+	reg.AddTag(tag)
+	reg.BumpVersion()
+
+	err = registrationRepo.UpdateRegistration(ctx, reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return reg, nil
+}
+
+// RemoveTag untags a registration. Removing a tag the registration doesn't have does nothing.
+func RemoveTag(ctx context.Context, eventId uuid.UUID, email string, tag string, registrationRepo Repository) (Registration, error) {
+	ctx, span := tracer.Start(ctx, "RemoveTag")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if !reg.RemoveTag(tag) {
+		return reg, nil
+	}
+	reg.BumpVersion()
+
+	err = registrationRepo.UpdateRegistration(ctx, reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return reg, nil
+}
+
+func normalizeTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+func removeAdminNote(notes []AdminNote, id uuid.UUID) ([]AdminNote, bool) {
+	idx := slices.IndexFunc(notes, func(n AdminNote) bool { return n.ID == id })
+	if idx == -1 {
+		return notes, false
+	}
+	return slices.Delete(notes, idx, idx+1), true
+}
+
+func appendTag(tags []string, tag string) []string {
+	tag = normalizeTag(tag)
+	if slices.Contains(tags, tag) {
+		return tags
+	}
+	return append(tags, tag)
+}
+
+func removeTag(tags []string, tag string) ([]string, bool) {
+	idx := slices.Index(tags, normalizeTag(tag))
+	if idx == -1 {
+		return tags, false
+	}
+	return slices.Delete(tags, idx, idx+1), true
+}
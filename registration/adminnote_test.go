@@ -0,0 +1,104 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminNotes(t *testing.T) {
+	eventId := uuid.New()
+	newRepo := func(reg Registration, saved *Registration) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return reg, nil
+			},
+			UpdateRegistrationFunc: func(ctx context.Context, registration Registration) error {
+				*saved = registration
+				return nil
+			},
+		}
+	}
+
+	t.Run("add and remove a note", func(t *testing.T) {
+		var saved Registration
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Version: 1, Email: "test@example.com"}, &saved)
+
+		reg, note, err := AddAdminNote(context.Background(), AddAdminNoteParams{
+			EventID: eventId,
+			Email:   "test@example.com",
+			Text:    "  Pending waiver ",
+			Author:  "admin@example.com",
+		}, repo)
+		require.NoError(t, err)
+		assert.Equal(t, "Pending waiver", note.Text)
+		assert.Equal(t, "admin@example.com", note.Author)
+		assert.Equal(t, []AdminNote{note}, saved.GetAdminNotes())
+		assert.Equal(t, 2, reg.(*IndividualRegistration).Version)
+
+		reg, err = RemoveAdminNote(context.Background(), eventId, "test@example.com", note.ID, repo)
+		require.NoError(t, err)
+		assert.Empty(t, reg.GetAdminNotes())
+		assert.Equal(t, 3, reg.(*IndividualRegistration).Version)
+	})
+
+	t.Run("empty note", func(t *testing.T) {
+		_, _, err := AddAdminNote(context.Background(), AddAdminNoteParams{EventID: eventId, Email: "test@example.com", Text: "  "}, &mockRegistrationRepository{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_ADMIN_ANNOTATION, registrationErr.Reason)
+	})
+
+	t.Run("removing a note that doesn't exist", func(t *testing.T) {
+		var saved Registration
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Email: "test@example.com"}, &saved)
+
+		_, err := RemoveAdminNote(context.Background(), eventId, "test@example.com", uuid.New(), repo)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_ADMIN_NOTE_DOES_NOT_EXIST, registrationErr.Reason)
+		assert.Nil(t, saved)
+	})
+}
+
+func TestTags(t *testing.T) {
+	eventId := uuid.New()
+
+	t.Run("tags are lower cased and not repeated", func(t *testing.T) {
+		reg := &TeamRegistration{EventID: eventId, Version: 1, CaptainEmail: "captain@example.com"}
+		var updates int
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return reg, nil
+			},
+			UpdateRegistrationFunc: func(ctx context.Context, registration Registration) error {
+				updates++
+				return nil
+			},
+		}
+
+		_, err := AddTag(context.Background(), eventId, "captain@example.com", " VIP ", repo)
+		require.NoError(t, err)
+		_, err = AddTag(context.Background(), eventId, "captain@example.com", "vip", repo)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vip"}, reg.Tags)
+		assert.Equal(t, 1, updates)
+
+		_, err = RemoveTag(context.Background(), eventId, "captain@example.com", "Vip", repo)
+		require.NoError(t, err)
+		assert.Empty(t, reg.Tags)
+		assert.Equal(t, 2, updates)
+	})
+
+	t.Run("tag too long", func(t *testing.T) {
+		_, err := AddTag(context.Background(), eventId, "captain@example.com", strings.Repeat("a", 51), &mockRegistrationRepository{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_ADMIN_ANNOTATION, registrationErr.Reason)
+	})
+}
@@ -0,0 +1,281 @@
+package registration
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// CheckInSigner makes and verifies the tokens in the check-in QR codes. Tokens are
+// signed instead of stored so nothing has to be written when they are handed out.
+type CheckInSigner struct {
+	key []byte
+}
+
+func NewCheckInSigner(key []byte) *CheckInSigner {
+	return &CheckInSigner{key: key}
+}
+
+// CheckInClaims is what a check-in token is for.
+type CheckInClaims struct {
+	EventID uuid.UUID `json:"e"`
+	// Email the registration is stored under
+	Email string `json:"r"`
+	// Index of the player on the registration, or nil for everyone on it
+	Player *int `json:"p,omitempty"`
+}
+
+func (s *CheckInSigner) Sign(claims CheckInClaims) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal check-in claims: %w", err)
+	}
+
+	encoding := base64.RawURLEncoding
+	return encoding.EncodeToString(payload) + "." + encoding.EncodeToString(s.mac(payload)), nil
+}
+
+func (s *CheckInSigner) Verify(token string) (CheckInClaims, error) {
+	encodedPayload, encodedMac, ok := strings.Cut(token, ".")
+	if !ok {
+		return CheckInClaims{}, NewInvalidCheckInTokenError("Check-in token is malformed", nil)
+	}
+
+	encoding := base64.RawURLEncoding
+	payload, err := encoding.DecodeString(encodedPayload)
+	if err != nil {
+		return CheckInClaims{}, NewInvalidCheckInTokenError("Check-in token is malformed", err)
+	}
+	mac, err := encoding.DecodeString(encodedMac)
+	if err != nil {
+		return CheckInClaims{}, NewInvalidCheckInTokenError("Check-in token is malformed", err)
+	}
+	if !hmac.Equal(mac, s.mac(payload)) {
+		return CheckInClaims{}, NewInvalidCheckInTokenError("Check-in token has an invalid signature", nil)
+	}
+
+	var claims CheckInClaims
+	err = json.Unmarshal(payload, &claims)
+	if err != nil {
+		return CheckInClaims{}, NewInvalidCheckInTokenError("Check-in token is malformed", err)
+	}
+	return claims, nil
+}
+
+func (s *CheckInSigner) mac(payload []byte) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write(payload)
+	return h.Sum(nil)
+}
+
+// CheckInCode is a check-in token and its QR code, for the confirmation email.
+type CheckInCode struct {
+	// Who the code checks in
+	Label string
+	Token string
+	// QR code of the token as a PNG image
+	QRCode []byte
+}
+
+// CheckInCodes makes a code for the whole registration and, for teams, one for every player
+// so they can check in without the rest of the team.
+func CheckInCodes(signer *CheckInSigner, reg Registration) ([]CheckInCode, error) {
+	claims := []CheckInClaims{{EventID: reg.GetEventID(), Email: reg.GetEmail()}}
+	labels := []string{}
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		labels = append(labels, fmt.Sprintf("%s %s", r.PlayerInfo.FirstName, r.PlayerInfo.LastName))
+	case *TeamRegistration:
+		labels = append(labels, fmt.Sprintf("All of %s", r.TeamName))
+		for i, player := range r.Players {
+			claims = append(claims, CheckInClaims{EventID: r.EventID, Email: r.CaptainEmail, Player: ptr.Int(i)})
+			labels = append(labels, fmt.Sprintf("%s %s", player.FirstName, player.LastName))
+		}
+	}
+
+	codes := make([]CheckInCode, 0, len(claims))
+	for i, c := range claims {
+		token, err := signer.Sign(c)
+		if err != nil {
+			return nil, err
+		}
+		png, err := qrcode.Encode(token, qrcode.Medium, 256)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make check-in QR code: %w", err)
+		}
+		codes = append(codes, CheckInCode{Label: labels[i], Token: token, QRCode: png})
+	}
+	return codes, nil
+}
+
+type CheckInParams struct {
+	EventID uuid.UUID
+	Email   string
+	// Indexes of the players on the registration to check in. Everyone on it if empty.
+	PlayerIndexes []int
+	// Email of the admin doing the check-in
+	CheckedInBy string
+}
+
+// CheckInWithToken checks in whoever a scanned QR code is for.
+func CheckInWithToken(ctx context.Context, signer *CheckInSigner, eventId uuid.UUID, token string, checkedInBy string, registrationRepo Repository, eventRepo events.Repository) (Registration, events.Event, error) {
+	ctx, span := tracer.Start(ctx, "CheckInWithToken")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	claims, err := signer.Verify(token)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, events.Event{}, err
+	}
+	if claims.EventID != eventId {
+		err = NewInvalidCheckInTokenError("Check-in token is for a different event", nil)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, events.Event{}, err
+	}
+
+	params := CheckInParams{
+		EventID:     claims.EventID,
+		Email:       claims.Email,
+		CheckedInBy: checkedInBy,
+	}
+	if claims.Player != nil {
+		params.PlayerIndexes = []int{*claims.Player}
+	}
+	return CheckIn(ctx, params, registrationRepo, eventRepo)
+}
+
+// CheckIn records players on a registration as being at the event. Players that were
+// already checked in keep their original check-in.
+func CheckIn(ctx context.Context, params CheckInParams, registrationRepo Repository, eventRepo events.Repository) (Registration, events.Event, error) {
+	ctx, span := tracer.Start(ctx, "CheckIn")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", params.EventID.String()))
+
+	reg, event, err := setCheckedIn(ctx, params, true, registrationRepo, eventRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, events.Event{}, err
+	}
+	return reg, event, nil
+}
+
+// UndoCheckIn clears the check-in of players on a registration, for when someone was checked in by mistake.
+func UndoCheckIn(ctx context.Context, params CheckInParams, registrationRepo Repository, eventRepo events.Repository) (Registration, events.Event, error) {
+	ctx, span := tracer.Start(ctx, "UndoCheckIn")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", params.EventID.String()))
+
+	reg, event, err := setCheckedIn(ctx, params, false, registrationRepo, eventRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, events.Event{}, err
+	}
+	return reg, event, nil
+}
+
+func setCheckedIn(ctx context.Context, params CheckInParams, checkIn bool, registrationRepo Repository, eventRepo events.Repository) (Registration, events.Event, error) {
+	reg, err := registrationRepo.GetRegistration(ctx, params.EventID, params.Email)
+	if err != nil {
+		return nil, events.Event{}, err
+	}
+
+	if checkIn && slices.Contains([]Status{STATUS_CANCELLED, STATUS_REFUNDED, STATUS_EXPIRED}, reg.GetStatus()) {
+		return nil, events.Event{}, NewCanNotCheckInError(fmt.Sprintf("Registration with status %s can not be checked in", reg.GetStatus()))
+	}
+
+	players := registrationPlayers(reg)
+	indexes := params.PlayerIndexes
+	if len(indexes) == 0 {
+		for i := range players {
+			indexes = append(indexes, i)
+		}
+	}
+	for _, i := range indexes {
+		if i < 0 || i >= len(players) {
+			return nil, events.Event{}, NewCanNotCheckInError(fmt.Sprintf("Registration does not have a player %d", i))
+		}
+	}
+
+	event, err := eventRepo.GetEvent(ctx, params.EventID)
+	if err != nil {
+		return nil, events.Event{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", params.EventID), err)
+	}
+
+	wasCheckedIn := anyCheckedIn(players)
+	changed := 0
+	now := time.Now()
+	for _, i := range indexes {
+		player := players[i]
+		switch {
+		case checkIn && player.CheckedInAt == nil:
+			player.CheckedInAt = ptr.Time(now)
+			player.CheckedInBy = params.CheckedInBy
+			event.NumCheckedInPlayers++
+			changed++
+		case !checkIn && player.CheckedInAt != nil:
+			player.CheckedInAt = nil
+			player.CheckedInBy = ""
+			event.NumCheckedInPlayers--
+			changed++
+		}
+	}
+	if changed == 0 {
+		return reg, event, nil
+	}
+
+	if reg.Type() == events.BY_TEAM {
+		switch isCheckedIn := anyCheckedIn(players); {
+		case isCheckedIn && !wasCheckedIn:
+			event.NumCheckedInTeams++
+		case !isCheckedIn && wasCheckedIn:
+			event.NumCheckedInTeams--
+		}
+	}
+
+	reg.BumpVersion()
+	event.Version++
+
+	err = registrationRepo.UpdateRegistrationWithEvent(ctx, reg, event)
+	if err != nil {
+		return nil, events.Event{}, err
+	}
+
+	return reg, event, nil
+}
+
+func registrationPlayers(reg Registration) []*PlayerInfo {
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		return []*PlayerInfo{&r.PlayerInfo}
+	case *TeamRegistration:
+		players := make([]*PlayerInfo, len(r.Players))
+		for i := range r.Players {
+			players[i] = &r.Players[i]
+		}
+		return players
+	}
+	return nil
+}
+
+func anyCheckedIn(players []*PlayerInfo) bool {
+	return slices.ContainsFunc(players, func(p *PlayerInfo) bool { return p.CheckedInAt != nil })
+}
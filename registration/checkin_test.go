@@ -0,0 +1,174 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInSigner(t *testing.T) {
+	signer := NewCheckInSigner([]byte("secret"))
+	claims := CheckInClaims{EventID: uuid.New(), Email: "captain@example.com", Player: ptr.Int(2)}
+
+	token, err := signer.Sign(claims)
+	assert.NoError(t, err)
+
+	verified, err := signer.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, claims, verified)
+
+	_, err = NewCheckInSigner([]byte("other secret")).Verify(token)
+	var registrationErr *Error
+	assert.True(t, errors.As(err, &registrationErr))
+	assert.Equal(t, REASON_INVALID_CHECK_IN_TOKEN, registrationErr.Reason)
+
+	_, err = signer.Verify("not-a-token")
+	assert.True(t, errors.As(err, &registrationErr))
+	assert.Equal(t, REASON_INVALID_CHECK_IN_TOKEN, registrationErr.Reason)
+}
+
+func TestCheckInCodes(t *testing.T) {
+	signer := NewCheckInSigner([]byte("secret"))
+	reg := &TeamRegistration{
+		EventID:      uuid.New(),
+		TeamName:     "Arrows",
+		CaptainEmail: "captain@example.com",
+		Players:      []PlayerInfo{{FirstName: "Cap", LastName: "Tain"}, {FirstName: "Play", LastName: "Er"}},
+	}
+
+	codes, err := CheckInCodes(signer, reg)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 3)
+	assert.Equal(t, "Play Er", codes[2].Label)
+	assert.NotEmpty(t, codes[0].QRCode)
+
+	claims, err := signer.Verify(codes[2].Token)
+	assert.NoError(t, err)
+	assert.Equal(t, ptr.Int(1), claims.Player)
+
+	claims, err = signer.Verify(codes[0].Token)
+	assert.NoError(t, err)
+	assert.Nil(t, claims.Player)
+}
+
+func TestCheckIn(t *testing.T) {
+	eventId := uuid.New()
+	signer := NewCheckInSigner([]byte("secret"))
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Version: 3, NumCheckedInPlayers: 4, NumCheckedInTeams: 1}, nil
+		},
+	}
+	newTeam := func() *TeamRegistration {
+		return &TeamRegistration{
+			EventID:      eventId,
+			Version:      2,
+			Status:       STATUS_PAID,
+			CaptainEmail: "captain@example.com",
+			Players:      []PlayerInfo{{FirstName: "Cap"}, {FirstName: "Play"}},
+		}
+	}
+	newRepo := func(reg Registration, updatedEvent *events.Event) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return reg, nil
+			},
+			UpdateRegistrationWithEventFunc: func(ctx context.Context, registration Registration, event events.Event) error {
+				*updatedEvent = event
+				return nil
+			},
+		}
+	}
+
+	t.Run("team token checks in everyone", func(t *testing.T) {
+		var updatedEvent events.Event
+		repo := newRepo(newTeam(), &updatedEvent)
+		token, _ := signer.Sign(CheckInClaims{EventID: eventId, Email: "captain@example.com"})
+
+		reg, event, err := CheckInWithToken(context.Background(), signer, eventId, token, "admin@example.com", repo, eventRepo)
+		assert.NoError(t, err)
+		for _, player := range reg.(*TeamRegistration).Players {
+			assert.NotNil(t, player.CheckedInAt)
+			assert.Equal(t, "admin@example.com", player.CheckedInBy)
+		}
+		assert.Equal(t, 6, event.NumCheckedInPlayers)
+		assert.Equal(t, 2, event.NumCheckedInTeams)
+		assert.Equal(t, 4, updatedEvent.Version)
+	})
+
+	t.Run("player token only checks in the player", func(t *testing.T) {
+		var updatedEvent events.Event
+		repo := newRepo(newTeam(), &updatedEvent)
+		token, _ := signer.Sign(CheckInClaims{EventID: eventId, Email: "captain@example.com", Player: ptr.Int(1)})
+
+		reg, event, err := CheckInWithToken(context.Background(), signer, eventId, token, "admin@example.com", repo, eventRepo)
+		assert.NoError(t, err)
+		assert.Nil(t, reg.(*TeamRegistration).Players[0].CheckedInAt)
+		assert.NotNil(t, reg.(*TeamRegistration).Players[1].CheckedInAt)
+		assert.Equal(t, 5, event.NumCheckedInPlayers)
+		assert.Equal(t, 2, event.NumCheckedInTeams)
+	})
+
+	t.Run("token for another event", func(t *testing.T) {
+		token, _ := signer.Sign(CheckInClaims{EventID: uuid.New(), Email: "captain@example.com"})
+
+		_, _, err := CheckInWithToken(context.Background(), signer, eventId, token, "admin@example.com", &mockRegistrationRepository{}, eventRepo)
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_CHECK_IN_TOKEN, registrationErr.Reason)
+	})
+
+	t.Run("already checked in keeps the first check-in", func(t *testing.T) {
+		checkedInAt := time.Now().Add(-time.Hour)
+		team := newTeam()
+		team.Players[0].CheckedInAt = ptr.Time(checkedInAt)
+		team.Players[0].CheckedInBy = "first@example.com"
+		var updatedEvent events.Event
+		repo := newRepo(team, &updatedEvent)
+
+		reg, event, err := CheckIn(context.Background(), CheckInParams{EventID: eventId, Email: "captain@example.com", PlayerIndexes: []int{0}, CheckedInBy: "admin@example.com"}, repo, eventRepo)
+		assert.NoError(t, err)
+		assert.Equal(t, "first@example.com", reg.(*TeamRegistration).Players[0].CheckedInBy)
+		assert.Equal(t, 4, event.NumCheckedInPlayers)
+		assert.Equal(t, 0, updatedEvent.Version)
+	})
+
+	t.Run("undo the last player un-counts the team", func(t *testing.T) {
+		team := newTeam()
+		team.Players[1].CheckedInAt = ptr.Time(time.Now())
+		var updatedEvent events.Event
+		repo := newRepo(team, &updatedEvent)
+
+		reg, event, err := UndoCheckIn(context.Background(), CheckInParams{EventID: eventId, Email: "captain@example.com", PlayerIndexes: []int{1}}, repo, eventRepo)
+		assert.NoError(t, err)
+		assert.Nil(t, reg.(*TeamRegistration).Players[1].CheckedInAt)
+		assert.Equal(t, 3, event.NumCheckedInPlayers)
+		assert.Equal(t, 0, event.NumCheckedInTeams)
+	})
+
+	t.Run("cancelled registration", func(t *testing.T) {
+		team := newTeam()
+		team.Status = STATUS_CANCELLED
+		repo := newRepo(team, &events.Event{})
+
+		_, _, err := CheckIn(context.Background(), CheckInParams{EventID: eventId, Email: "captain@example.com"}, repo, eventRepo)
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_CAN_NOT_CHECK_IN, registrationErr.Reason)
+	})
+
+	t.Run("player not on the registration", func(t *testing.T) {
+		repo := newRepo(newTeam(), &events.Event{})
+
+		_, _, err := CheckIn(context.Background(), CheckInParams{EventID: eventId, Email: "captain@example.com", PlayerIndexes: []int{5}}, repo, eventRepo)
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_CAN_NOT_CHECK_IN, registrationErr.Reason)
+	})
+}
@@ -0,0 +1,119 @@
+package registration
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// How long a free sign up holds its spot before its email has to be verified
+const emailVerificationHold = time.Hour
+
+// VerifyRegistrationEmail confirms a free sign up held by AttemptRegistration, once the registrant
+// clicks the link that was emailed to them. The link only works once.
+func VerifyRegistrationEmail(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, email string, verificationToken string, now time.Time) (Registration, error) {
+	ctx, span := tracer.Start(ctx, "VerifyRegistrationEmail")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	intent, found, err := getOpenIntent(ctx, &IndividualRegistration{EventID: eventId, Email: email}, registrationRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !found || !intent.IsEmailVerification() || subtle.ConstantTimeCompare([]byte(intent.VerificationToken), []byte(verificationToken)) != 1 {
+		err = NewInvalidEmailVerificationError("No sign up is waiting on this verification link")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	if !now.Before(intent.ExpiresAt) {
+		// The sweeper will release the spot
+		err = NewRegistrationExpiredError("Verification link expired", nil)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = reg.TransitionTo(STATUS_CONFIRMED, StatusChangedBySystem, "Email verified")
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	reg.BumpVersion()
+
+	// Saves the registration and drops its hold, same as when a checkout completes
+	err = registrationRepo.UpdateRegistrationToPaid(ctx, reg, signedUpOutbox(reg, now))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return reg, nil
+}
+
+// SendEmailVerificationEmail emails the registrant the link to confirm their sign up.
+func SendEmailVerificationEmail(ctx context.Context, emailSender email.Sender, from email.Address, reg Registration, intent RegistrationIntent, event events.Event, frontendBaseURL string) error {
+	ctx, span := tracer.Start(ctx, "SendEmailVerificationEmail")
+	defer span.End()
+
+	expiresAt := intent.ExpiresAt
+	if event.TimeZone != nil {
+		expiresAt = expiresAt.In(event.TimeZone)
+	}
+	data := map[string]any{
+		"Event":      event,
+		"ExpiresAt":  expiresAt,
+		"VerifyLink": EmailVerificationLink(frontendBaseURL, reg.GetEventID(), reg.GetEmail(), intent.VerificationToken),
+	}
+
+	htmlBody, err := executeEmailTemplate("email-verification.tmpl", data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	textOnlyBody, err := executeEmailTemplate("email-verification-textonly.tmpl", data)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+
+	err = emailSender.SendEmail(ctx, email.Email{
+		From:        from,
+		ToAddresses: []string{reg.GetEmail()},
+		Subject:     fmt.Sprintf("Verify your email to confirm your signup - %q", event.Name),
+		HTMLBody:    htmlBody,
+		TextBody:    textOnlyBody,
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+func EmailVerificationLink(frontendBaseURL string, eventId uuid.UUID, registrantEmail string, verificationToken string) string {
+	query := url.Values{}
+	query.Set("email", registrantEmail)
+	query.Set("token", verificationToken)
+
+	return fmt.Sprintf("%s/events/%s/verify-email?%s", frontendBaseURL, eventId, query.Encode())
+}
@@ -0,0 +1,176 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttemptRegistrationWithEmailVerification(t *testing.T) {
+	eventId := uuid.New()
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{
+				ID:                       id,
+				Version:                  1,
+				RegistrationOptions:      []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(0, "USD")}},
+				RequireEmailVerification: true,
+			}, nil
+		},
+	}
+	var savedIntent RegistrationIntent
+	var savedEvent events.Event
+	registrationRepo := &mockRegistrationRepository{
+		CreateRegistrationWithPaymentFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error {
+			savedIntent = intent
+			savedEvent = event
+			return nil
+		},
+	}
+
+	reg, intent, _, err := AttemptRegistration(context.Background(), &IndividualRegistration{EventID: eventId, Email: "jane@example.com"}, eventRepo, registrationRepo)
+	require.NoError(t, err)
+	assert.Equal(t, STATUS_PENDING, reg.GetStatus())
+	assert.True(t, intent.IsEmailVerification())
+	assert.Empty(t, intent.PaymentSessionId)
+	assert.Equal(t, "jane@example.com", intent.Email)
+	assert.WithinDuration(t, time.Now().Add(emailVerificationHold), intent.ExpiresAt, time.Minute)
+	assert.Equal(t, intent, savedIntent)
+	// The spot is held while waiting
+	assert.Equal(t, 1, savedEvent.NumTotalPlayers)
+}
+
+func TestVerifyRegistrationEmail(t *testing.T) {
+	eventId := uuid.New()
+	now := time.Now()
+	intent := RegistrationIntent{EventId: eventId, Email: "jane@example.com", VerificationToken: "token", ExpiresAt: now.Add(time.Minute)}
+	var savedOutbox []OutboxItem
+	newRepo := func(intent RegistrationIntent, saved *Registration) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return intent, nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: id, Email: email, Version: 1}, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+				*saved = registration
+				savedOutbox = outbox
+				return nil
+			},
+		}
+	}
+
+	t.Run("verified", func(t *testing.T) {
+		var saved Registration
+		reg, err := VerifyRegistrationEmail(context.Background(), newRepo(intent, &saved), eventId, "jane@example.com", "token", now)
+		require.NoError(t, err)
+		// Confirmation email and mailing list
+		require.Len(t, savedOutbox, 2)
+		assert.Equal(t, OUTBOX_CONFIRMATION_EMAIL, savedOutbox[0].Kind)
+		assert.Equal(t, STATUS_CONFIRMED, reg.GetStatus())
+		require.NotNil(t, saved)
+		assert.Equal(t, STATUS_CONFIRMED, saved.GetStatus())
+		require.Len(t, saved.GetStatusHistory(), 1)
+		assert.Equal(t, StatusChangedBySystem, saved.GetStatusHistory()[0].ChangedBy)
+		assert.Equal(t, 2, saved.(*IndividualRegistration).Version)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		var saved Registration
+		_, err := VerifyRegistrationEmail(context.Background(), newRepo(intent, &saved), eventId, "jane@example.com", "guess", now)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_EMAIL_VERIFICATION, registrationErr.Reason)
+		assert.Nil(t, saved)
+	})
+
+	t.Run("checkouts can't be verified", func(t *testing.T) {
+		var saved Registration
+		checkout := RegistrationIntent{EventId: eventId, Email: "jane@example.com", PaymentSessionId: "cs_123", ExpiresAt: now.Add(time.Minute)}
+		_, err := VerifyRegistrationEmail(context.Background(), newRepo(checkout, &saved), eventId, "jane@example.com", "", now)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_EMAIL_VERIFICATION, registrationErr.Reason)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		var saved Registration
+		repo := newRepo(intent, &saved)
+		repo.GetRegistrationIntentFunc = noIntent
+		_, err := VerifyRegistrationEmail(context.Background(), repo, eventId, "jane@example.com", "token", now)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_EMAIL_VERIFICATION, registrationErr.Reason)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		var saved Registration
+		_, err := VerifyRegistrationEmail(context.Background(), newRepo(intent, &saved), eventId, "jane@example.com", "token", intent.ExpiresAt)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_REGISTRATION_EXPIRED, registrationErr.Reason)
+		assert.Nil(t, saved)
+	})
+}
+
+func TestSendEmailVerificationEmail(t *testing.T) {
+	sender := &mockEmailSender{}
+	eventId := uuid.New()
+	reg := &IndividualRegistration{EventID: eventId, Email: "jane+archery@example.com"}
+	intent := RegistrationIntent{EventId: eventId, Email: reg.Email, VerificationToken: "token", ExpiresAt: time.Now()}
+
+	err := SendEmailVerificationEmail(context.Background(), sender, email.Address{Address: "info@icaa.world"}, reg, intent, events.Event{ID: eventId, Name: "Summer Games"}, "https://icaa.world")
+	require.NoError(t, err)
+	require.Len(t, sender.sent, 1)
+	assert.Equal(t, []string{"jane+archery@example.com"}, sender.sent[0].ToAddresses)
+	link := EmailVerificationLink("https://icaa.world", eventId, reg.Email, "token")
+	assert.Equal(t, "https://icaa.world/events/"+eventId.String()+"/verify-email?email=jane%2Barchery%40example.com&token=token", link)
+	assert.Contains(t, sender.sent[0].TextBody, "/events/"+eventId.String()+"/verify-email?email=jane%2Barchery%40example.com")
+}
+
+func TestSweepExpiredEmailVerification(t *testing.T) {
+	eventId := uuid.New()
+	now := time.Now()
+	intent := RegistrationIntent{Version: 1, EventId: eventId, VerificationToken: "token", Email: "jane@example.com", ExpiresAt: now.Add(-time.Minute)}
+
+	eventRepo := &mockEventRepository{
+		GetEventsFunc: func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+			return events.GetEventsResponse{Data: []events.Event{{ID: eventId}}}, nil
+		},
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Version: 3, NumTotalPlayers: 10}, nil
+		},
+	}
+	var deleted Registration
+	repo := &mockRegistrationRepository{
+		GetExpiredRegistrationIntentsFunc: func(ctx context.Context, id uuid.UUID, at time.Time) ([]RegistrationIntent, error) {
+			return []RegistrationIntent{intent}, nil
+		},
+		GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+			return &IndividualRegistration{EventID: id, Email: email, Version: 1, Status: STATUS_PENDING}, nil
+		},
+		GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+			return intent, nil
+		},
+		DeleteExpiredRegistrationFunc: func(ctx context.Context, reg Registration, regIntent RegistrationIntent, event events.Event) error {
+			deleted = reg
+			return nil
+		},
+	}
+
+	// The payment provider is never asked about it, a nil querier would panic
+	numDeleted, err := SweepExpiredRegistrationIntents(context.Background(), repo, eventRepo, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, numDeleted)
+	assert.Equal(t, STATUS_EXPIRED, deleted.GetStatus())
+	assert.Equal(t, "Email was never verified", deleted.GetStatusHistory()[0].Reason)
+}
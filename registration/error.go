@@ -27,6 +27,33 @@ const (
 	REASON_INVALID_PAYMENT_METADATA        ErrorReason = "INVALID_PAYMENT_METADATA"
 	REASON_REGISTRATION_EXPIRED            ErrorReason = "REGISTRATION_EXPIRED"
 	REASON_WRONG_TRANSACTION_TYPE          ErrorReason = "WRONG_TRANSACTION_TYPE"
+	REASON_NOT_A_TEAM_REGISTRATION         ErrorReason = "NOT_A_TEAM_REGISTRATION"
+	REASON_INVALID_ROSTER_INVITE           ErrorReason = "INVALID_ROSTER_INVITE"
+	REASON_REGISTRATION_NOT_PAID           ErrorReason = "REGISTRATION_NOT_PAID"
+	REASON_PAYMENT_NOT_FOUND               ErrorReason = "PAYMENT_NOT_FOUND"
+	REASON_INVALID_REFUND_AMOUNT           ErrorReason = "INVALID_REFUND_AMOUNT"
+	REASON_FAILED_TO_REFUND                ErrorReason = "FAILED_TO_REFUND"
+	REASON_SPLIT_PAYMENT_NOT_ALLOWED       ErrorReason = "SPLIT_PAYMENT_NOT_ALLOWED"
+	REASON_SHARE_ALREADY_PAID              ErrorReason = "SHARE_ALREADY_PAID"
+	REASON_SHARE_EXPIRED                   ErrorReason = "SHARE_EXPIRED"
+	REASON_SHARE_CHECKOUT_EXPIRED          ErrorReason = "SHARE_CHECKOUT_EXPIRED"
+	REASON_ILLEGAL_STATUS_TRANSITION       ErrorReason = "ILLEGAL_STATUS_TRANSITION"
+	REASON_INVALID_IMPORT                  ErrorReason = "INVALID_IMPORT"
+	REASON_PLAYER_ALREADY_REGISTERED       ErrorReason = "PLAYER_ALREADY_REGISTERED"
+	REASON_NOT_TRANSFERABLE                ErrorReason = "NOT_TRANSFERABLE"
+	REASON_TRANSFER_CHECKOUT_EXPIRED       ErrorReason = "TRANSFER_CHECKOUT_EXPIRED"
+	REASON_INVALID_CHECK_IN_TOKEN          ErrorReason = "INVALID_CHECK_IN_TOKEN"
+	REASON_CAN_NOT_CHECK_IN                ErrorReason = "CAN_NOT_CHECK_IN"
+	REASON_INVALID_OFFLINE_PAYMENT         ErrorReason = "INVALID_OFFLINE_PAYMENT"
+	REASON_INVALID_ADMIN_ANNOTATION        ErrorReason = "INVALID_ADMIN_ANNOTATION"
+	REASON_ADMIN_NOTE_DOES_NOT_EXIST       ErrorReason = "ADMIN_NOTE_DOES_NOT_EXIST"
+	REASON_CHECKOUT_IN_PROGRESS            ErrorReason = "CHECKOUT_IN_PROGRESS"
+	REASON_INVALID_EMAIL_VERIFICATION      ErrorReason = "INVALID_EMAIL_VERIFICATION"
+	REASON_MISMATCH_NOT_FOUND              ErrorReason = "MISMATCH_NOT_FOUND"
+	REASON_MISMATCH_NOT_FIXABLE            ErrorReason = "MISMATCH_NOT_FIXABLE"
+	REASON_WEBHOOK_EVENT_ALREADY_HANDLED   ErrorReason = "WEBHOOK_EVENT_ALREADY_HANDLED"
+	REASON_STALE_WEBHOOK_EVENT             ErrorReason = "STALE_WEBHOOK_EVENT"
+	REASON_OUTBOX_ITEM_DOES_NOT_EXIST      ErrorReason = "OUTBOX_ITEM_DOES_NOT_EXIST"
 )
 
 type Error struct {
@@ -118,3 +145,111 @@ func NewRegistrationExpiredError(message string, cause error) *Error {
 func NewWrongTransactionTypeError(message string) *Error {
 	return newRegistrationError(REASON_WRONG_TRANSACTION_TYPE, message, nil)
 }
+
+func NewNotATeamRegistrationError(message string) *Error {
+	return newRegistrationError(REASON_NOT_A_TEAM_REGISTRATION, message, nil)
+}
+
+func NewInvalidRosterInviteError(message string) *Error {
+	return newRegistrationError(REASON_INVALID_ROSTER_INVITE, message, nil)
+}
+
+func NewRegistrationNotPaidError(message string) *Error {
+	return newRegistrationError(REASON_REGISTRATION_NOT_PAID, message, nil)
+}
+
+func NewPaymentNotFoundError(message string) *Error {
+	return newRegistrationError(REASON_PAYMENT_NOT_FOUND, message, nil)
+}
+
+func NewInvalidRefundAmountError(message string) *Error {
+	return newRegistrationError(REASON_INVALID_REFUND_AMOUNT, message, nil)
+}
+
+func NewFailedToRefundError(message string, cause error) *Error {
+	return newRegistrationError(REASON_FAILED_TO_REFUND, message, cause)
+}
+
+func NewSplitPaymentNotAllowedError(message string) *Error {
+	return newRegistrationError(REASON_SPLIT_PAYMENT_NOT_ALLOWED, message, nil)
+}
+
+func NewShareAlreadyPaidError(message string) *Error {
+	return newRegistrationError(REASON_SHARE_ALREADY_PAID, message, nil)
+}
+
+func NewShareExpiredError(message string) *Error {
+	return newRegistrationError(REASON_SHARE_EXPIRED, message, nil)
+}
+
+func NewShareCheckoutExpiredError(message string, cause error) *Error {
+	return newRegistrationError(REASON_SHARE_CHECKOUT_EXPIRED, message, cause)
+}
+
+func NewIllegalStatusTransitionError(from Status, to Status) *Error {
+	return newRegistrationError(REASON_ILLEGAL_STATUS_TRANSITION, fmt.Sprintf("Registration can not go from %s to %s", from, to), nil)
+}
+
+func NewInvalidImportError(message string, cause error) *Error {
+	return newRegistrationError(REASON_INVALID_IMPORT, message, cause)
+}
+
+func NewPlayerAlreadyRegisteredError(playerEmail string, conflictingRegistrationEmail string, cause error) *Error {
+	return newRegistrationError(REASON_PLAYER_ALREADY_REGISTERED, fmt.Sprintf("%s is already registered for this event on the registration for %s", playerEmail, conflictingRegistrationEmail), cause)
+}
+
+func NewNotTransferableError(message string) *Error {
+	return newRegistrationError(REASON_NOT_TRANSFERABLE, message, nil)
+}
+
+func NewTransferCheckoutExpiredError(message string, cause error) *Error {
+	return newRegistrationError(REASON_TRANSFER_CHECKOUT_EXPIRED, message, cause)
+}
+
+func NewInvalidCheckInTokenError(message string, cause error) *Error {
+	return newRegistrationError(REASON_INVALID_CHECK_IN_TOKEN, message, cause)
+}
+
+func NewCanNotCheckInError(message string) *Error {
+	return newRegistrationError(REASON_CAN_NOT_CHECK_IN, message, nil)
+}
+
+func NewInvalidOfflinePaymentError(message string) *Error {
+	return newRegistrationError(REASON_INVALID_OFFLINE_PAYMENT, message, nil)
+}
+
+func NewInvalidAdminAnnotationError(message string) *Error {
+	return newRegistrationError(REASON_INVALID_ADMIN_ANNOTATION, message, nil)
+}
+
+func NewAdminNoteDoesNotExistError(message string) *Error {
+	return newRegistrationError(REASON_ADMIN_NOTE_DOES_NOT_EXIST, message, nil)
+}
+
+func NewCheckoutInProgressError(message string) *Error {
+	return newRegistrationError(REASON_CHECKOUT_IN_PROGRESS, message, nil)
+}
+
+func NewInvalidEmailVerificationError(message string) *Error {
+	return newRegistrationError(REASON_INVALID_EMAIL_VERIFICATION, message, nil)
+}
+
+func NewMismatchNotFoundError(message string) *Error {
+	return newRegistrationError(REASON_MISMATCH_NOT_FOUND, message, nil)
+}
+
+func NewMismatchNotFixableError(message string) *Error {
+	return newRegistrationError(REASON_MISMATCH_NOT_FIXABLE, message, nil)
+}
+
+func NewWebhookEventAlreadyHandledError(message string) *Error {
+	return newRegistrationError(REASON_WEBHOOK_EVENT_ALREADY_HANDLED, message, nil)
+}
+
+func NewStaleWebhookEventError(message string) *Error {
+	return newRegistrationError(REASON_STALE_WEBHOOK_EVENT, message, nil)
+}
+
+func NewOutboxItemDoesNotExistError(message string) *Error {
+	return newRegistrationError(REASON_OUTBOX_ITEM_DOES_NOT_EXIST, message, nil)
+}
@@ -0,0 +1,212 @@
+package registration
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type ExportRows int
+
+const (
+	EXPORT_ROW_PER_PLAYER ExportRows = iota
+	EXPORT_ROW_PER_REGISTRATION
+)
+
+const exportTimeFormat = "2006-01-02 15:04:05 MST"
+
+var (
+	playerExportHeader       = []string{"Registration Type", "Team Name", "Captain", "First Name", "Last Name", "Email", "Experience", "Home City", "Status", "Registered At"}
+	registrationExportHeader = []string{"Registration Type", "Team Name", "Name", "Email", "Number of Players", "Experience", "Home City", "Status", "Registered At"}
+)
+
+// ExportRegistrationsCSV writes every registration for the event to w as CSV, a page at a time.
+// Times are in the event's time zone.
+func ExportRegistrationsCSV(ctx context.Context, w io.Writer, registrationRepo Repository, event events.Event, rows ExportRows) error {
+	ctx, span := tracer.Start(ctx, "ExportRegistrationsCSV")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", event.ID.String()))
+
+	loc := event.TimeZone
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	csvWriter := csv.NewWriter(w)
+
+	header := playerExportHeader
+	if rows == EXPORT_ROW_PER_REGISTRATION {
+		header = registrationExportHeader
+	}
+	err := csvWriter.Write(header)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	var cursor *string
+	for {
+		resp, err := registrationRepo.GetAllRegistrationsForEvent(ctx, event.ID, 50, cursor)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return err
+		}
+
+		for _, reg := range resp.Data {
+			var records [][]string
+			if rows == EXPORT_ROW_PER_REGISTRATION {
+				records = [][]string{registrationExportRecord(reg, loc)}
+			} else {
+				records = playerExportRecords(reg, loc)
+			}
+
+			for _, record := range records {
+				err := csvWriter.Write(sanitizeCSVRecord(record))
+				if err != nil {
+					span.RecordError(err)
+					span.SetStatus(codes.Error, err.Error())
+					return fmt.Errorf("failed to write CSV row: %w", err)
+				}
+			}
+		}
+
+		// Send each page along instead of holding the whole export in memory
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return fmt.Errorf("failed to write CSV: %w", err)
+		}
+
+		if !resp.HasNextPage {
+			return nil
+		}
+		cursor = resp.Cursor
+	}
+}
+
+func playerExportRecords(reg Registration, loc *time.Location) [][]string {
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		return [][]string{{
+			"Individual",
+			"",
+			"",
+			r.PlayerInfo.FirstName,
+			r.PlayerInfo.LastName,
+			r.Email,
+			experienceLabel(r.Experience),
+			r.HomeCity,
+			statusLabel(r.Status),
+			r.RegisteredAt.In(loc).Format(exportTimeFormat),
+		}}
+	case *TeamRegistration:
+		captainIndex := captainPlayerIndex(r)
+		records := make([][]string, 0, len(r.Players))
+		for i, player := range r.Players {
+			var email string
+			if player.Email != nil {
+				email = *player.Email
+			}
+			records = append(records, []string{
+				"Team",
+				r.TeamName,
+				strconv.FormatBool(i == captainIndex),
+				player.FirstName,
+				player.LastName,
+				email,
+				"",
+				r.HomeCity,
+				statusLabel(r.Status),
+				r.RegisteredAt.In(loc).Format(exportTimeFormat),
+			})
+		}
+		return records
+	}
+	return nil
+}
+
+func registrationExportRecord(reg Registration, loc *time.Location) []string {
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		return []string{
+			"Individual",
+			"",
+			r.PlayerInfo.FirstName + " " + r.PlayerInfo.LastName,
+			r.Email,
+			"1",
+			experienceLabel(r.Experience),
+			r.HomeCity,
+			statusLabel(r.Status),
+			r.RegisteredAt.In(loc).Format(exportTimeFormat),
+		}
+	case *TeamRegistration:
+		return []string{
+			"Team",
+			r.TeamName,
+			"",
+			r.CaptainEmail,
+			strconv.Itoa(len(r.Players)),
+			"",
+			r.HomeCity,
+			statusLabel(r.Status),
+			r.RegisteredAt.In(loc).Format(exportTimeFormat),
+		}
+	}
+	return nil
+}
+
+func statusLabel(status Status) string {
+	switch status {
+	case STATUS_PENDING:
+		return "Pending"
+	case STATUS_PAID:
+		return "Paid"
+	case STATUS_COMPED:
+		return "Comped"
+	case STATUS_CANCELLED:
+		return "Cancelled"
+	case STATUS_REFUNDED:
+		return "Refunded"
+	case STATUS_EXPIRED:
+		return "Expired"
+	case STATUS_CONFIRMED:
+		return "Confirmed"
+	default:
+		return status.String()
+	}
+}
+
+func experienceLabel(exp ExperienceLevel) string {
+	switch exp {
+	case NOVICE:
+		return "Novice"
+	case INTERMEDIATE:
+		return "Intermediate"
+	case ADVANCED:
+		return "Advanced"
+	default:
+		return exp.String()
+	}
+}
+
+// sanitizeCSVRecord stops spreadsheet apps from running anything users typed in as a formula.
+func sanitizeCSVRecord(record []string) []string {
+	for i, field := range record {
+		if field != "" && strings.ContainsAny(field[:1], "=+-@\t\r") {
+			record[i] = "'" + field
+		}
+	}
+	return record
+}
@@ -0,0 +1,88 @@
+package registration
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportRegistrationsCSV(t *testing.T) {
+	tz, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	event := events.Event{ID: uuid.New(), TimeZone: tz}
+	registeredAt := time.Date(2025, 8, 19, 18, 0, 0, 0, time.UTC)
+
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			// Two pages, to make sure every page makes it into the export
+			if cursor == nil {
+				return GetAllRegistrationsResponse{
+					Data: []Registration{
+						&IndividualRegistration{
+							Email:        "solo@example.com",
+							HomeCity:     "Boston, MA",
+							RegisteredAt: registeredAt,
+							Status:       STATUS_PAID,
+							Experience:   ADVANCED,
+							PlayerInfo:   PlayerInfo{FirstName: "Solo", LastName: "Archer"},
+						},
+					},
+					Cursor:      ptr.String("next"),
+					HasNextPage: true,
+				}, nil
+			}
+			return GetAllRegistrationsResponse{
+				Data: []Registration{
+					&TeamRegistration{
+						CaptainEmail: "captain@example.com",
+						TeamName:     "=Arrowheads",
+						HomeCity:     "Denver, CO",
+						RegisteredAt: registeredAt,
+						Status:       STATUS_PENDING,
+						Players: []PlayerInfo{
+							{FirstName: "Cara", LastName: "Captain", Email: ptr.String("captain@example.com")},
+							{FirstName: "Dan", LastName: "Quiver"},
+						},
+					},
+				},
+			}, nil
+		},
+	}
+
+	t.Run("row per player", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportRegistrationsCSV(context.Background(), &buf, repo, event, EXPORT_ROW_PER_PLAYER)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			playerExportHeader,
+			{"Individual", "", "", "Solo", "Archer", "solo@example.com", "Advanced", "Boston, MA", "Paid", "2025-08-19 14:00:00 EDT"},
+			{"Team", "'=Arrowheads", "true", "Cara", "Captain", "captain@example.com", "", "Denver, CO", "Pending", "2025-08-19 14:00:00 EDT"},
+			{"Team", "'=Arrowheads", "false", "Dan", "Quiver", "", "", "Denver, CO", "Pending", "2025-08-19 14:00:00 EDT"},
+		}, records)
+	})
+
+	t.Run("row per registration", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := ExportRegistrationsCSV(context.Background(), &buf, repo, event, EXPORT_ROW_PER_REGISTRATION)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			registrationExportHeader,
+			{"Individual", "", "Solo Archer", "solo@example.com", "1", "Advanced", "Boston, MA", "Paid", "2025-08-19 14:00:00 EDT"},
+			{"Team", "'=Arrowheads", "", "captain@example.com", "2", "", "Denver, CO", "Pending", "2025-08-19 14:00:00 EDT"},
+		}, records)
+	})
+}
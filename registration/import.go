@@ -0,0 +1,463 @@
+package registration
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	importBatchSize = 25
+	// DynamoDB allows 100 items in a transaction. Every email on a registration is an item on top
+	// of the registration itself, and one is left for the event.
+	maxImportBatchItems = 99
+)
+
+// Column names of an import file. They match the export's "row per player" columns, so an
+// export can be imported into another event.
+const (
+	importColumnType       = "registration type"
+	importColumnTeamName   = "team name"
+	importColumnCaptain    = "captain"
+	importColumnFirstName  = "first name"
+	importColumnLastName   = "last name"
+	importColumnEmail      = "email"
+	importColumnExperience = "experience"
+	importColumnHomeCity   = "home city"
+	importColumnStatus     = "status"
+)
+
+var requiredImportColumns = []string{importColumnType, importColumnFirstName, importColumnLastName, importColumnEmail}
+
+type ImportRowError struct {
+	// Line of the file, the header being row 1. Errors for a team are on the team's first row.
+	Row     int
+	Email   string
+	Message string
+}
+
+type ImportResult struct {
+	// Registrations that passed validation
+	NumValid    int
+	NumImported int
+	Errors      []ImportRowError
+}
+
+type importEntry struct {
+	row    int
+	reg    Registration
+	status Status
+}
+
+// ImportRegistrations creates registrations from a CSV file of individuals and teams, with one
+// row for each player. Every row is checked with the same rules as signing up, and the ones that
+// pass are saved in batches that each update the event's counts in the same transaction.
+//
+// Players already on another registration for the event are rejected, unless allowDuplicatePlayers
+// is set. With dryRun nothing is saved, the result just reports what would happen.
+func ImportRegistrations(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, eventId uuid.UUID, file io.Reader, importedBy string, dryRun bool, allowDuplicatePlayers bool) (ImportResult, error) {
+	ctx, span := tracer.Start(ctx, "ImportRegistrations")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()), attribute.Bool("dry_run", dryRun))
+
+	event, err := getImportEvent(ctx, eventRepo, eventId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ImportResult{}, err
+	}
+
+	entries, rowErrs, err := parseImportFile(file, eventId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ImportResult{}, err
+	}
+
+	existing, err := GetAllRegistrations(ctx, registrationRepo, eventId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ImportResult{}, err
+	}
+	seenEmails := map[string]bool{}
+	// Every player email already in the event, to the email of the registration they're on
+	playerRegistrations := map[string]string{}
+	for _, reg := range existing {
+		seenEmails[reg.GetEmail()] = true
+		for _, email := range Emails(reg) {
+			playerRegistrations[email] = reg.GetEmail()
+		}
+	}
+
+	var valid []importEntry
+	for _, entry := range entries {
+		email := entry.reg.GetEmail()
+		if seenEmails[email] {
+			rowErrs = append(rowErrs, ImportRowError{Row: entry.row, Email: email, Message: "A registration already exists for this email"})
+			continue
+		}
+
+		err := checkImportDuplicatePlayers(entry.reg, playerRegistrations, allowDuplicatePlayers)
+		if err != nil {
+			rowErrs = append(rowErrs, importRowErrorFromErr(entry, err))
+			continue
+		}
+
+		err = registerImportEntry(&event, entry, importedBy)
+		if err != nil {
+			rowErrs = append(rowErrs, importRowErrorFromErr(entry, err))
+			continue
+		}
+
+		seenEmails[email] = true
+		for _, playerEmail := range Emails(entry.reg) {
+			if _, ok := playerRegistrations[playerEmail]; !ok {
+				playerRegistrations[playerEmail] = email
+			}
+		}
+		valid = append(valid, entry)
+	}
+
+	result := ImportResult{NumValid: len(valid), Errors: rowErrs}
+	if dryRun {
+		sortImportRowErrors(result.Errors)
+		return result, nil
+	}
+
+	for _, batch := range importBatches(valid) {
+		numImported, batchErrs := writeImportBatch(ctx, registrationRepo, eventRepo, eventId, batch, importedBy)
+		result.NumImported += numImported
+		result.Errors = append(result.Errors, batchErrs...)
+	}
+
+	sortImportRowErrors(result.Errors)
+	return result, nil
+}
+
+// importBatches splits entries into batches of at most importBatchSize registrations that also
+// fit in one transaction.
+func importBatches(entries []importEntry) [][]importEntry {
+	var batches [][]importEntry
+	var batch []importEntry
+	batchItems := 0
+	for _, entry := range entries {
+		entryItems := 1 + len(Emails(entry.reg))
+		if len(batch) == importBatchSize || batchItems+entryItems > maxImportBatchItems {
+			batches = append(batches, batch)
+			batch = nil
+			batchItems = 0
+		}
+		batch = append(batch, entry)
+		batchItems += entryItems
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func getImportEvent(ctx context.Context, eventRepo events.Repository, eventId uuid.UUID) (events.Event, error) {
+	event, err := eventRepo.GetEvent(ctx, eventId)
+	if err != nil {
+		var eventErr *events.Error
+		if errors.As(err, &eventErr) && eventErr.Reason == events.REASON_EVENT_DOES_NOT_EXIST {
+			return events.Event{}, NewAssociatedEventDoesNotExistError(fmt.Sprintf("Event does not exist with ID %q", eventId), err)
+		}
+		return events.Event{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
+	}
+	return event, nil
+}
+
+// checkImportDuplicatePlayers rejects a registration with a player that is already on another one,
+// or marks them as allowed to be.
+func checkImportDuplicatePlayers(reg Registration, playerRegistrations map[string]string, allowDuplicatePlayers bool) error {
+	for _, email := range Emails(reg) {
+		conflictingEmail, ok := playerRegistrations[email]
+		if !ok {
+			continue
+		}
+		if !allowDuplicatePlayers {
+			return NewPlayerAlreadyRegisteredError(email, conflictingEmail, nil)
+		}
+		reg.AllowDuplicatePlayer(email)
+	}
+	return nil
+}
+
+// writeImportBatch saves a batch against the latest version of the event, so its counts stay
+// right even if people signed up since the file was validated.
+func writeImportBatch(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, eventId uuid.UUID, batch []importEntry, importedBy string) (int, []ImportRowError) {
+	batchErrs := func(message string) []ImportRowError {
+		rowErrs := make([]ImportRowError, 0, len(batch))
+		for _, entry := range batch {
+			rowErrs = append(rowErrs, ImportRowError{Row: entry.row, Email: entry.reg.GetEmail(), Message: message})
+		}
+		return rowErrs
+	}
+
+	event, err := getImportEvent(ctx, eventRepo, eventId)
+	if err != nil {
+		return 0, batchErrs(fmt.Sprintf("Failed to save: %s", err))
+	}
+
+	var rowErrs []ImportRowError
+	regs := make([]Registration, 0, len(batch))
+	for _, entry := range batch {
+		// Validation already did this against the event, this just catches anything that changed since
+		err := registerImportEntry(&event, entry, importedBy)
+		if err != nil {
+			rowErrs = append(rowErrs, importRowErrorFromErr(entry, err))
+			continue
+		}
+		regs = append(regs, entry.reg)
+	}
+	if len(regs) == 0 {
+		return 0, rowErrs
+	}
+
+	event.Version++
+	err = registrationRepo.CreateRegistrations(ctx, regs, event)
+	if err != nil {
+		var registrationErr *Error
+		if errors.As(err, &registrationErr) && registrationErr.Reason == REASON_PLAYER_ALREADY_REGISTERED {
+			// Someone signed up since the file was validated
+			return 0, batchErrs(fmt.Sprintf("Failed to save: %s", registrationErr.Message))
+		}
+		return 0, batchErrs(fmt.Sprintf("Failed to save: %s", err))
+	}
+	return len(regs), rowErrs
+}
+
+// registerImportEntry signs an imported registration up for the event, the same as if they
+// had signed up themselves.
+func registerImportEntry(event *events.Event, entry importEntry, importedBy string) error {
+	var err error
+	switch reg := entry.reg.(type) {
+	case *IndividualRegistration:
+		err = registerIndividualAsFreeAgent(event, reg, false)
+	case *TeamRegistration:
+		err = registerTeam(event, reg, false)
+	default:
+		err = NewUnknownRegistrationTypeError(fmt.Sprintf("Unknown registration type: %d", entry.reg.Type()))
+	}
+	if err != nil {
+		return err
+	}
+
+	if entry.status != STATUS_PENDING && entry.reg.GetStatus() != entry.status {
+		return entry.reg.TransitionTo(entry.status, importedBy, "Imported")
+	}
+	return nil
+}
+
+func importRowErrorFromErr(entry importEntry, err error) ImportRowError {
+	message := err.Error()
+	var registrationErr *Error
+	if errors.As(err, &registrationErr) {
+		message = registrationErr.Message
+	}
+	return ImportRowError{Row: entry.row, Email: entry.reg.GetEmail(), Message: message}
+}
+
+func sortImportRowErrors(rowErrs []ImportRowError) {
+	slices.SortStableFunc(rowErrs, func(a, b ImportRowError) int {
+		return a.Row - b.Row
+	})
+}
+
+func parseImportFile(file io.Reader, eventId uuid.UUID) ([]importEntry, []ImportRowError, error) {
+	csvReader := csv.NewReader(file)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, nil, NewInvalidImportError("Failed to read the header row", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range requiredImportColumns {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, NewInvalidImportError(fmt.Sprintf("Missing the %q column", required), nil)
+		}
+	}
+
+	registeredAt := time.Now()
+	var entries []importEntry
+	var rowErrs []ImportRowError
+	teams := map[string]*importEntry{}
+
+	for row := 2; ; row++ {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, NewInvalidImportError(fmt.Sprintf("Failed to read row %d", row), err)
+		}
+
+		field := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return unsanitizeCSVField(strings.TrimSpace(record[i]))
+		}
+		email := strings.ToLower(field(importColumnEmail))
+
+		status, err := parseImportStatus(field(importColumnStatus))
+		if err != nil {
+			rowErrs = append(rowErrs, ImportRowError{Row: row, Email: email, Message: err.Error()})
+			continue
+		}
+
+		player := PlayerInfo{
+			FirstName: field(importColumnFirstName),
+			LastName:  field(importColumnLastName),
+		}
+		if email != "" {
+			player.Email = ptr.String(email)
+		}
+		if player.FirstName == "" {
+			rowErrs = append(rowErrs, ImportRowError{Row: row, Email: email, Message: "First name is required"})
+			continue
+		}
+
+		switch strings.ToLower(field(importColumnType)) {
+		case "individual":
+			if email == "" {
+				rowErrs = append(rowErrs, ImportRowError{Row: row, Message: "Email is required for individuals"})
+				continue
+			}
+			experience, err := parseImportExperience(field(importColumnExperience))
+			if err != nil {
+				rowErrs = append(rowErrs, ImportRowError{Row: row, Email: email, Message: err.Error()})
+				continue
+			}
+
+			entries = append(entries, importEntry{
+				row:    row,
+				status: status,
+				reg: &IndividualRegistration{
+					ID:           uuid.New(),
+					EventID:      eventId,
+					Version:      1,
+					RegisteredAt: registeredAt,
+					HomeCity:     field(importColumnHomeCity),
+					Email:        email,
+					PlayerInfo:   player,
+					Experience:   experience,
+				},
+			})
+		case "team":
+			teamName := field(importColumnTeamName)
+			if teamName == "" {
+				rowErrs = append(rowErrs, ImportRowError{Row: row, Email: email, Message: "Team name is required for teams"})
+				continue
+			}
+
+			team, ok := teams[strings.ToLower(teamName)]
+			if !ok {
+				team = &importEntry{
+					row:    row,
+					status: status,
+					reg: &TeamRegistration{
+						ID:           uuid.New(),
+						EventID:      eventId,
+						Version:      1,
+						RegisteredAt: registeredAt,
+						HomeCity:     field(importColumnHomeCity),
+						TeamName:     teamName,
+					},
+				}
+				teams[strings.ToLower(teamName)] = team
+			}
+			teamReg := team.reg.(*TeamRegistration)
+			teamReg.Players = append(teamReg.Players, player)
+
+			if isImportCaptain(field(importColumnCaptain)) {
+				if email == "" {
+					rowErrs = append(rowErrs, ImportRowError{Row: row, Message: "The captain needs an email"})
+					continue
+				}
+				teamReg.CaptainEmail = email
+			}
+		default:
+			rowErrs = append(rowErrs, ImportRowError{Row: row, Email: email, Message: `Registration type must be "Individual" or "Team"`})
+		}
+	}
+
+	for _, team := range teams {
+		teamReg := team.reg.(*TeamRegistration)
+		if teamReg.CaptainEmail == "" {
+			rowErrs = append(rowErrs, ImportRowError{Row: team.row, Message: fmt.Sprintf("Team %q has no captain", teamReg.TeamName)})
+			continue
+		}
+		entries = append(entries, *team)
+	}
+	slices.SortStableFunc(entries, func(a, b importEntry) int {
+		return a.row - b.row
+	})
+
+	return entries, rowErrs, nil
+}
+
+func parseImportStatus(status string) (Status, error) {
+	switch strings.ToLower(status) {
+	case "", "pending":
+		return STATUS_PENDING, nil
+	case "paid":
+		return STATUS_PAID, nil
+	case "comped":
+		return STATUS_COMPED, nil
+	case "confirmed":
+		return STATUS_CONFIRMED, nil
+	default:
+		return STATUS_PENDING, fmt.Errorf("Status must be Pending, Paid, Comped or Confirmed, got %q", status)
+	}
+}
+
+func parseImportExperience(experience string) (ExperienceLevel, error) {
+	switch strings.ToLower(experience) {
+	case "novice":
+		return NOVICE, nil
+	case "intermediate":
+		return INTERMEDIATE, nil
+	case "advanced":
+		return ADVANCED, nil
+	default:
+		return NOVICE, fmt.Errorf("Experience must be Novice, Intermediate or Advanced, got %q", experience)
+	}
+}
+
+func isImportCaptain(captain string) bool {
+	switch strings.ToLower(captain) {
+	case "true", "yes", "y", "x", "1":
+		return true
+	default:
+		return false
+	}
+}
+
+// unsanitizeCSVField undoes sanitizeCSVRecord, so exported files can be imported as is.
+func unsanitizeCSVField(field string) string {
+	if len(field) > 1 && field[0] == '\'' && strings.ContainsAny(field[1:2], "=+-@\t\r") {
+		return field[1:]
+	}
+	return field
+}
@@ -0,0 +1,184 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testImportFile = `Registration Type,Team Name,Captain,First Name,Last Name,Email,Experience,Home City,Status
+Individual,,,Solo,Archer,Solo@example.com,Advanced,"Boston, MA",Paid
+Team,Arrowheads,true,Cara,Captain,captain@example.com,,"Denver, CO",
+Team,Arrowheads,false,Dan,Quiver,dan@example.com,,"Denver, CO",
+Individual,,,Taken,Email,existing@example.com,Novice,,
+Individual,,,Bad,Experience,bad@example.com,Expert,,
+Team,Captainless,false,Nobody,Incharge,nobody@example.com,,,
+Team,Too Small,true,Lonely,Captain,lonely@example.com,,,
+`
+
+func TestImportRegistrations(t *testing.T) {
+	eventId := uuid.New()
+	newEventRepo := func() *mockEventRepository {
+		return &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{
+					ID:                    id,
+					Version:               4,
+					RegistrationCloseTime: time.Now().Add(time.Hour),
+					RegistrationOptions:   []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL}, {RegType: events.BY_TEAM}},
+					AllowedTeamSizeRange:  events.Range{Min: 2, Max: 4},
+					NumTotalPlayers:       1,
+				}, nil
+			},
+		}
+	}
+	newRepo := func() *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+				return GetAllRegistrationsResponse{Data: []Registration{&IndividualRegistration{Email: "existing@example.com"}}}, nil
+			},
+		}
+	}
+	expectedErrors := []ImportRowError{
+		{Row: 5, Email: "existing@example.com", Message: "A registration already exists for this email"},
+		{Row: 6, Email: "bad@example.com", Message: `Experience must be Novice, Intermediate or Advanced, got "Expert"`},
+		{Row: 7, Message: `Team "Captainless" has no captain`},
+		{Row: 8, Email: "lonely@example.com", Message: NewTeamSizeNotAllowedError(1, 2, 4).Message},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		repo := newRepo()
+
+		result, err := ImportRegistrations(context.Background(), repo, newEventRepo(), eventId, strings.NewReader(testImportFile), "admin@example.com", true, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.NumValid)
+		assert.Equal(t, 0, result.NumImported)
+		assert.Equal(t, expectedErrors, result.Errors)
+	})
+
+	t.Run("writes the valid rows with the event", func(t *testing.T) {
+		var created []Registration
+		var updatedEvent events.Event
+		repo := newRepo()
+		repo.CreateRegistrationsFunc = func(ctx context.Context, regs []Registration, event events.Event) error {
+			created = regs
+			updatedEvent = event
+			return nil
+		}
+
+		result, err := ImportRegistrations(context.Background(), repo, newEventRepo(), eventId, strings.NewReader(testImportFile), "admin@example.com", false, false)
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.NumImported)
+		assert.Equal(t, expectedErrors, result.Errors)
+
+		require.Len(t, created, 2)
+		indivReg := created[0].(*IndividualRegistration)
+		assert.Equal(t, "solo@example.com", indivReg.Email)
+		assert.Equal(t, ADVANCED, indivReg.Experience)
+		assert.Equal(t, STATUS_PAID, indivReg.Status)
+		assert.Equal(t, "admin@example.com", indivReg.StatusHistory[0].ChangedBy)
+
+		teamReg := created[1].(*TeamRegistration)
+		assert.Equal(t, "captain@example.com", teamReg.CaptainEmail)
+		assert.Len(t, teamReg.Players, 2)
+		assert.Equal(t, STATUS_PENDING, teamReg.Status)
+
+		assert.Equal(t, 5, updatedEvent.Version)
+		assert.Equal(t, 4, updatedEvent.NumTotalPlayers)
+		assert.Equal(t, 1, updatedEvent.NumTeams)
+	})
+
+	t.Run("failed batch is reported on its rows", func(t *testing.T) {
+		repo := newRepo()
+		repo.CreateRegistrationsFunc = func(ctx context.Context, regs []Registration, event events.Event) error {
+			return NewFailedToWriteError("Version conflict error", nil)
+		}
+
+		result, err := ImportRegistrations(context.Background(), repo, newEventRepo(), eventId, strings.NewReader(testImportFile), "admin@example.com", false, false)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.NumImported)
+		assert.Len(t, result.Errors, len(expectedErrors)+2)
+	})
+
+	t.Run("players already on a registration", func(t *testing.T) {
+		const file = `Registration Type,Team Name,Captain,First Name,Last Name,Email
+Team,Arrowheads,true,Cara,Captain,captain@example.com
+Team,Arrowheads,false,Taken,Player,existing@example.com
+Team,Quivers,true,Quinn,Captain,quinn@example.com
+Team,Quivers,false,Dan,Twice,captain@example.com
+`
+		t.Run("are rejected", func(t *testing.T) {
+			result, err := ImportRegistrations(context.Background(), newRepo(), newEventRepo(), eventId, strings.NewReader(file), "admin@example.com", true, false)
+			require.NoError(t, err)
+			assert.Equal(t, 1, result.NumValid)
+			assert.Equal(t, []ImportRowError{
+				{Row: 2, Email: "captain@example.com", Message: NewPlayerAlreadyRegisteredError("existing@example.com", "existing@example.com", nil).Message},
+			}, result.Errors)
+		})
+
+		t.Run("are allowed with the override", func(t *testing.T) {
+			var created []Registration
+			repo := newRepo()
+			repo.CreateRegistrationsFunc = func(ctx context.Context, regs []Registration, event events.Event) error {
+				created = regs
+				return nil
+			}
+
+			result, err := ImportRegistrations(context.Background(), repo, newEventRepo(), eventId, strings.NewReader(file), "admin@example.com", false, true)
+			require.NoError(t, err)
+			assert.Equal(t, 2, result.NumImported)
+			assert.Empty(t, result.Errors)
+
+			require.Len(t, created, 2)
+			assert.Equal(t, []string{"existing@example.com"}, created[0].GetDuplicatePlayerEmails())
+			assert.Equal(t, []string{"captain@example.com"}, created[1].GetDuplicatePlayerEmails())
+		})
+	})
+
+	t.Run("missing columns", func(t *testing.T) {
+		_, err := ImportRegistrations(context.Background(), newRepo(), newEventRepo(), eventId, strings.NewReader("First Name,Last Name\nA,B\n"), "admin@example.com", true, false)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_IMPORT, registrationErr.Reason)
+	})
+}
+
+func TestImportBatches(t *testing.T) {
+	t.Run("limits registrations per batch", func(t *testing.T) {
+		entries := make([]importEntry, 30)
+		for i := range entries {
+			entries[i] = importEntry{reg: &IndividualRegistration{Email: fmt.Sprintf("player%d@example.com", i)}}
+		}
+
+		batches := importBatches(entries)
+		require.Len(t, batches, 2)
+		assert.Len(t, batches[0], importBatchSize)
+		assert.Len(t, batches[1], 5)
+	})
+
+	t.Run("keeps each batch within one transaction", func(t *testing.T) {
+		entries := make([]importEntry, 6)
+		for i := range entries {
+			players := make([]PlayerInfo, 20)
+			for j := range players {
+				players[j] = PlayerInfo{Email: ptr.String(fmt.Sprintf("team%d-player%d@example.com", i, j))}
+			}
+			entries[i] = importEntry{reg: &TeamRegistration{CaptainEmail: *players[0].Email, Players: players}}
+		}
+
+		batches := importBatches(entries)
+		// 21 items per team, so 4 teams fit
+		require.Len(t, batches, 2)
+		assert.Len(t, batches[0], 4)
+		assert.Len(t, batches[1], 2)
+	})
+}
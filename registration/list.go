@@ -0,0 +1,270 @@
+package registration
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type SortBy int
+
+const (
+	// Same order the registrations are stored in
+	SORT_BY_EMAIL SortBy = iota
+	SORT_BY_REGISTERED_AT
+	// Team name for teams, player name for individuals
+	SORT_BY_NAME
+)
+
+// ListFilter narrows down which registrations are listed. Unset fields match everything.
+type ListFilter struct {
+	Type       *events.RegistrationType
+	Status     *Status
+	Experience *ExperienceLevel
+	// Matched case insensitively
+	HomeCity        *string
+	RegisteredAfter *time.Time
+	// Case insensitive substring of the team name, a player's name or any email on the registration
+	Search string
+	// If anyone on the registration has checked in at the event
+	CheckedIn *bool
+	// Only registrations an admin tagged with this, case insensitive
+	Tag *string
+}
+
+type ListParams struct {
+	Filter     ListFilter
+	SortBy     SortBy
+	Descending bool
+	Limit      int
+	Cursor     *string
+}
+
+// ListCounts are totals across every registration matching the filter, not just the current page.
+type ListCounts struct {
+	Total       int
+	Individuals int
+	Teams       int
+	// Individuals plus every player on a team
+	Players  int
+	ByStatus map[Status]int
+}
+
+type ListRegistrationsResponse struct {
+	Data        []Registration
+	Cursor      *string
+	HasNextPage bool
+	Counts      ListCounts
+}
+
+// ListRegistrations filters, searches and sorts an event's registrations for admins.
+//
+// It loads every registration for the event, since none of this can be done with the
+// repository's paging.
+func ListRegistrations(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, params ListParams) (ListRegistrationsResponse, error) {
+	ctx, span := tracer.Start(ctx, "ListRegistrations")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	offset := 0
+	if params.Cursor != nil {
+		var err error
+		offset, err = cursorToOffset(*params.Cursor)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return ListRegistrationsResponse{}, NewInvalidCursorError("Invalid cursor", err)
+		}
+	}
+
+	allRegs, err := GetAllRegistrations(ctx, registrationRepo, eventId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ListRegistrationsResponse{}, err
+	}
+
+	matching := slices.DeleteFunc(allRegs, func(reg Registration) bool {
+		return !params.Filter.matches(reg)
+	})
+	sortRegistrations(matching, params.SortBy, params.Descending)
+
+	resp := ListRegistrationsResponse{
+		Counts: countRegistrations(matching),
+	}
+
+	start := min(offset, len(matching))
+	end := min(start+params.Limit, len(matching))
+	resp.Data = matching[start:end]
+	resp.HasNextPage = end < len(matching)
+	if resp.HasNextPage {
+		cursor := offsetToCursor(end)
+		resp.Cursor = &cursor
+	}
+
+	return resp, nil
+}
+
+func (f ListFilter) matches(reg Registration) bool {
+	if f.Type != nil && reg.Type() != *f.Type {
+		return false
+	}
+	if f.Status != nil && reg.GetStatus() != *f.Status {
+		return false
+	}
+
+	var homeCity string
+	var registeredAt time.Time
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		if f.Experience != nil && r.Experience != *f.Experience {
+			return false
+		}
+		homeCity = r.HomeCity
+		registeredAt = r.RegisteredAt
+	case *TeamRegistration:
+		// Experience is only tracked for individuals
+		if f.Experience != nil {
+			return false
+		}
+		homeCity = r.HomeCity
+		registeredAt = r.RegisteredAt
+	}
+
+	if f.HomeCity != nil && !strings.EqualFold(strings.TrimSpace(homeCity), strings.TrimSpace(*f.HomeCity)) {
+		return false
+	}
+	if f.RegisteredAfter != nil && !registeredAt.After(*f.RegisteredAfter) {
+		return false
+	}
+	if f.CheckedIn != nil && anyCheckedIn(registrationPlayers(reg)) != *f.CheckedIn {
+		return false
+	}
+	if f.Tag != nil && !slices.Contains(reg.GetTags(), normalizeTag(*f.Tag)) {
+		return false
+	}
+
+	search := strings.ToLower(strings.TrimSpace(f.Search))
+	if search == "" {
+		return true
+	}
+	return slices.ContainsFunc(searchableText(reg), func(text string) bool {
+		return strings.Contains(strings.ToLower(text), search)
+	})
+}
+
+func searchableText(reg Registration) []string {
+	playerText := func(player PlayerInfo) []string {
+		text := []string{player.FirstName + " " + player.LastName}
+		if player.Email != nil {
+			text = append(text, *player.Email)
+		}
+		return text
+	}
+
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		return append(playerText(r.PlayerInfo), r.Email)
+	case *TeamRegistration:
+		text := []string{r.TeamName, r.CaptainEmail}
+		for _, player := range r.Players {
+			text = append(text, playerText(player)...)
+		}
+		return text
+	}
+	return nil
+}
+
+func sortRegistrations(regs []Registration, sortBy SortBy, descending bool) {
+	// The repository already returns them by email
+	if sortBy == SORT_BY_EMAIL {
+		if descending {
+			slices.Reverse(regs)
+		}
+		return
+	}
+
+	slices.SortStableFunc(regs, func(a, b Registration) int {
+		var c int
+		switch sortBy {
+		case SORT_BY_REGISTERED_AT:
+			c = registeredAt(a).Compare(registeredAt(b))
+		case SORT_BY_NAME:
+			c = cmp.Compare(strings.ToLower(displayName(a)), strings.ToLower(displayName(b)))
+		}
+		// Email breaks ties so paging through the results is stable
+		c = cmp.Or(c, cmp.Compare(a.GetEmail(), b.GetEmail()))
+		if descending {
+			return -c
+		}
+		return c
+	})
+}
+
+func registeredAt(reg Registration) time.Time {
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		return r.RegisteredAt
+	case *TeamRegistration:
+		return r.RegisteredAt
+	}
+	return time.Time{}
+}
+
+func displayName(reg Registration) string {
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		return r.PlayerInfo.FirstName + " " + r.PlayerInfo.LastName
+	case *TeamRegistration:
+		return r.TeamName
+	}
+	return ""
+}
+
+func countRegistrations(regs []Registration) ListCounts {
+	counts := ListCounts{
+		Total:    len(regs),
+		ByStatus: map[Status]int{},
+	}
+	for _, reg := range regs {
+		counts.ByStatus[reg.GetStatus()]++
+		switch r := reg.(type) {
+		case *IndividualRegistration:
+			counts.Individuals++
+			counts.Players++
+		case *TeamRegistration:
+			counts.Teams++
+			counts.Players += len(r.Players)
+		}
+	}
+	return counts
+}
+
+func offsetToCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+func cursorToOffset(cursor string) (int, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.Atoi(string(decoded))
+	if err != nil {
+		return 0, err
+	}
+	if offset < 0 {
+		return 0, strconv.ErrRange
+	}
+	return offset, nil
+}
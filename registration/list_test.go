@@ -0,0 +1,127 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRegistrations(t *testing.T) {
+	eventId := uuid.New()
+	now := time.Now()
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			return GetAllRegistrationsResponse{Data: []Registration{
+				&IndividualRegistration{
+					Email:        "alice@example.com",
+					HomeCity:     "Chicago, IL",
+					RegisteredAt: now.Add(-2 * time.Hour),
+					Status:       STATUS_PAID,
+					Experience:   ADVANCED,
+					PlayerInfo:   PlayerInfo{FirstName: "Alice", LastName: "Archer"},
+				},
+				&IndividualRegistration{
+					Email:        "bob@example.com",
+					HomeCity:     "Denver, CO",
+					RegisteredAt: now.Add(-time.Hour),
+					Status:       STATUS_PENDING,
+					Experience:   NOVICE,
+					PlayerInfo:   PlayerInfo{FirstName: "Bob", LastName: "Bowman"},
+					Tags:         []string{"needs loaner bow"},
+				},
+				&TeamRegistration{
+					CaptainEmail: "captain@example.com",
+					TeamName:     "Arrowheads",
+					HomeCity:     "chicago, il",
+					RegisteredAt: now,
+					Status:       STATUS_PAID,
+					Players: []PlayerInfo{
+						{FirstName: "Cara", LastName: "Captain", Email: ptr.String("captain@example.com")},
+						{FirstName: "Dan", LastName: "Quiver", Email: ptr.String("dan@example.com")},
+					},
+				},
+			}}, nil
+		},
+	}
+
+	emails := func(regs []Registration) []string {
+		var emails []string
+		for _, reg := range regs {
+			emails = append(emails, reg.GetEmail())
+		}
+		return emails
+	}
+
+	t.Run("counts every matching registration", func(t *testing.T) {
+		resp, err := ListRegistrations(context.Background(), repo, eventId, ListParams{Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice@example.com"}, emails(resp.Data))
+		assert.True(t, resp.HasNextPage)
+		assert.Equal(t, 3, resp.Counts.Total)
+		assert.Equal(t, 2, resp.Counts.Individuals)
+		assert.Equal(t, 1, resp.Counts.Teams)
+		assert.Equal(t, 4, resp.Counts.Players)
+		assert.Equal(t, map[Status]int{STATUS_PAID: 2, STATUS_PENDING: 1}, resp.Counts.ByStatus)
+
+		next, err := ListRegistrations(context.Background(), repo, eventId, ListParams{Limit: 5, Cursor: resp.Cursor})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bob@example.com", "captain@example.com"}, emails(next.Data))
+		assert.False(t, next.HasNextPage)
+		assert.Nil(t, next.Cursor)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		teamType := events.BY_TEAM
+		pending := STATUS_PENDING
+		advanced := ADVANCED
+
+		tests := []struct {
+			name     string
+			filter   ListFilter
+			expected []string
+		}{
+			{"type", ListFilter{Type: &teamType}, []string{"captain@example.com"}},
+			{"status", ListFilter{Status: &pending}, []string{"bob@example.com"}},
+			{"experience", ListFilter{Experience: &advanced}, []string{"alice@example.com"}},
+			{"home city", ListFilter{HomeCity: ptr.String("CHICAGO, IL")}, []string{"alice@example.com", "captain@example.com"}},
+			{"registered after", ListFilter{RegisteredAfter: ptr.Time(now.Add(-90 * time.Minute))}, []string{"bob@example.com", "captain@example.com"}},
+			{"search player name", ListFilter{Search: "quiver"}, []string{"captain@example.com"}},
+			{"search team name", ListFilter{Search: "ARROW"}, []string{"captain@example.com"}},
+			{"search email", ListFilter{Search: "bob@"}, []string{"bob@example.com"}},
+			{"tag", ListFilter{Tag: ptr.String("Needs Loaner Bow")}, []string{"bob@example.com"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resp, err := ListRegistrations(context.Background(), repo, eventId, ListParams{Limit: 10, Filter: tt.filter})
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, emails(resp.Data))
+				assert.Equal(t, len(tt.expected), resp.Counts.Total)
+			})
+		}
+	})
+
+	t.Run("sorts", func(t *testing.T) {
+		resp, err := ListRegistrations(context.Background(), repo, eventId, ListParams{Limit: 10, SortBy: SORT_BY_REGISTERED_AT, Descending: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"captain@example.com", "bob@example.com", "alice@example.com"}, emails(resp.Data))
+
+		resp, err = ListRegistrations(context.Background(), repo, eventId, ListParams{Limit: 10, SortBy: SORT_BY_NAME})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice@example.com", "captain@example.com", "bob@example.com"}, emails(resp.Data))
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, err := ListRegistrations(context.Background(), repo, eventId, ListParams{Limit: 10, Cursor: ptr.String("not a cursor")})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_CURSOR, registrationErr.Reason)
+	})
+}
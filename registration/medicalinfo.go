@@ -0,0 +1,32 @@
+package registration
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// GetMedicalInfo returns the players on a registration, in order, with their emergency
+// contacts and medical notes. Callers must make sure the reader is allowed to see them.
+func GetMedicalInfo(ctx context.Context, eventId uuid.UUID, email string, registrationRepo Repository) ([]PlayerInfo, error) {
+	ctx, span := tracer.Start(ctx, "GetMedicalInfo")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	players := registrationPlayers(reg)
+	info := make([]PlayerInfo, len(players))
+	for i, player := range players {
+		info[i] = *player
+	}
+	return info, nil
+}
@@ -0,0 +1,56 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type RegistrationWithEvent struct {
+	Registration Registration
+	Event        events.Event
+}
+
+// GetRegistrationsForEmail gets every registration email is on, whether they signed up themselves
+// or are on someone else's team, along with the event it's for. The newest events come first.
+func GetRegistrationsForEmail(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, email string) ([]RegistrationWithEvent, error) {
+	ctx, span := tracer.Start(ctx, "GetRegistrationsForEmail")
+	defer span.End()
+
+	regs, err := registrationRepo.GetRegistrationsByEmail(ctx, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	result := make([]RegistrationWithEvent, 0, len(regs))
+	for _, reg := range regs {
+		event, err := eventRepo.GetEvent(ctx, reg.GetEventID())
+		if err != nil {
+			var eventErr *events.Error
+			if errors.As(err, &eventErr) && eventErr.Reason == events.REASON_EVENT_DOES_NOT_EXIST {
+				// Nothing useful to show for a registration without its event
+				continue
+			}
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", reg.GetEventID()), err)
+		}
+
+		result = append(result, RegistrationWithEvent{
+			Registration: reg,
+			Event:        event,
+		})
+	}
+
+	slices.SortStableFunc(result, func(a, b RegistrationWithEvent) int {
+		return b.Event.StartTime.Compare(a.Event.StartTime)
+	})
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmails(t *testing.T) {
+	t.Run("individual", func(t *testing.T) {
+		reg := &IndividualRegistration{
+			Email:      "Solo@Example.com",
+			PlayerInfo: PlayerInfo{Email: ptr.String("solo@example.com")},
+		}
+		assert.Equal(t, []string{"solo@example.com"}, Emails(reg))
+	})
+
+	t.Run("team includes every player", func(t *testing.T) {
+		reg := &TeamRegistration{
+			CaptainEmail: "captain@example.com",
+			Players: []PlayerInfo{
+				{Email: ptr.String("captain@example.com")},
+				{Email: ptr.String("Player@example.com")},
+				{},
+			},
+		}
+		assert.Equal(t, []string{"captain@example.com", "player@example.com"}, Emails(reg))
+	})
+}
+
+func TestGetRegistrationsForEmail(t *testing.T) {
+	oldEventId := uuid.New()
+	newEventId := uuid.New()
+	deletedEventId := uuid.New()
+	now := time.Now()
+
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			switch id {
+			case oldEventId:
+				return events.Event{ID: id, StartTime: now.AddDate(-1, 0, 0)}, nil
+			case newEventId:
+				return events.Event{ID: id, StartTime: now}, nil
+			}
+			return events.Event{}, &events.Error{Reason: events.REASON_EVENT_DOES_NOT_EXIST}
+		},
+	}
+
+	t.Run("newest events first and missing events skipped", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+				assert.Equal(t, "player@example.com", email)
+				return []Registration{
+					&IndividualRegistration{EventID: oldEventId, Email: "player@example.com"},
+					&IndividualRegistration{EventID: deletedEventId, Email: "player@example.com"},
+					&TeamRegistration{EventID: newEventId, CaptainEmail: "captain@example.com"},
+				}, nil
+			},
+		}
+
+		result, err := GetRegistrationsForEmail(context.Background(), repo, eventRepo, "player@example.com")
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, newEventId, result[0].Event.ID)
+		assert.Equal(t, "captain@example.com", result[0].Registration.GetEmail())
+		assert.Equal(t, oldEventId, result[1].Event.ID)
+	})
+
+	t.Run("failed to fetch registrations", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+				return nil, NewFailedToFetchError("boom", nil)
+			},
+		}
+
+		_, err := GetRegistrationsForEmail(context.Background(), repo, eventRepo, "player@example.com")
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_FAILED_TO_FETCH, registrationErr.Reason)
+	})
+}
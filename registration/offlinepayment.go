@@ -0,0 +1,201 @@
+//go:generate go tool stringer -type=PaymentMethod
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type PaymentMethod int
+
+const (
+	PAYMENT_METHOD_CASH PaymentMethod = iota
+	// Let in for free
+	PAYMENT_METHOD_COMP
+	PAYMENT_METHOD_BANK_TRANSFER
+	// Paid through the payment provider, but not through a checkout this service made
+	PAYMENT_METHOD_ONLINE
+)
+
+// OfflinePayment is a payment an admin recorded by hand instead of it going through checkout.
+type OfflinePayment struct {
+	Method PaymentMethod
+	// What was paid, nil for comps
+	Amount *money.Money
+	Note   string
+	// Email of the admin that recorded it
+	RecordedBy string
+	RecordedAt time.Time
+}
+
+// status is the status a registration ends up in with the payment.
+func (p OfflinePayment) status() Status {
+	if p.Method == PAYMENT_METHOD_COMP {
+		return STATUS_COMPED
+	}
+	return STATUS_PAID
+}
+
+func (p OfflinePayment) validate() error {
+	if p.Method == PAYMENT_METHOD_COMP {
+		if p.Amount != nil && !p.Amount.IsZero() {
+			return NewInvalidOfflinePaymentError("Comps can not have an amount")
+		}
+		return nil
+	}
+	if p.Amount == nil || !p.Amount.IsPositive() {
+		return NewInvalidOfflinePaymentError(fmt.Sprintf("Payments by %s need an amount more than 0", p.Method))
+	}
+	return nil
+}
+
+type ManualRegistrationParams struct {
+	Registration Registration
+	// How the registration was paid for. It stays pending if nil, for anyone still paying at the door.
+	Payment *OfflinePayment
+	// Lets the registration in after registration has closed
+	IgnoreCloseTime bool
+}
+
+// RegisterManually signs someone up on behalf of an admin, without going through checkout.
+func RegisterManually(ctx context.Context, params ManualRegistrationParams, eventRepo events.Repository, registrationRepo Repository) (Registration, events.Event, error) {
+	ctx, span := tracer.Start(ctx, "RegisterManually")
+	defer span.End()
+
+	reg := params.Registration
+	eventId := reg.GetEventID()
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	if params.Payment != nil {
+		err := params.Payment.validate()
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return nil, events.Event{}, err
+		}
+	}
+
+	event, err := eventRepo.GetEvent(ctx, eventId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		var eventErr *events.Error
+		if errors.As(err, &eventErr) && eventErr.Reason == events.REASON_EVENT_DOES_NOT_EXIST {
+			return nil, events.Event{}, NewAssociatedEventDoesNotExistError(fmt.Sprintf("Event does not exist with ID %q", eventId), err)
+		}
+		return nil, events.Event{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
+	}
+
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		err = registerIndividualAsFreeAgent(&event, r, params.IgnoreCloseTime)
+	case *TeamRegistration:
+		if r.SplitPayment {
+			err = NewSplitPaymentNotAllowedError("Registrations made by an admin can not split the payment")
+			break
+		}
+		err = registerTeam(&event, r, params.IgnoreCloseTime)
+	default:
+		err = NewUnknownRegistrationTypeError(fmt.Sprintf("Unknown registration type: %d", reg.Type()))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, events.Event{}, err
+	}
+
+	if params.Payment != nil {
+		err = recordOfflinePayment(reg, *params.Payment)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, events.Event{}, err
+		}
+	}
+
+	event.Version++
+	err = registrationRepo.CreateRegistration(ctx, reg, event, signedUpOutbox(reg, time.Now()))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, events.Event{}, err
+	}
+	return reg, event, nil
+}
+
+type MarkPaidOfflineParams struct {
+	EventID uuid.UUID
+	Email   string
+	Payment OfflinePayment
+}
+
+// MarkPaidOffline records a payment made outside of checkout on a registration that hasn't been paid.
+// Any checkout still open for the registration is dropped, so it can't expire the registration later.
+func MarkPaidOffline(ctx context.Context, params MarkPaidOfflineParams, registrationRepo Repository) (Registration, error) {
+	ctx, span := tracer.Start(ctx, "MarkPaidOffline")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", params.EventID.String()))
+
+	err := params.Payment.validate()
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, params.EventID, params.Email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	if reg.GetStatus() != STATUS_PENDING {
+		err = NewInvalidOfflinePaymentError(fmt.Sprintf("Registration with status %s can not be marked as paid", reg.GetStatus()))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	err = recordOfflinePayment(reg, params.Payment)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	reg.BumpVersion()
+
+	// They were already told they were signed up when they registered
+	err = registrationRepo.UpdateRegistrationToPaid(ctx, reg, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+	return reg, nil
+}
+
+func recordOfflinePayment(reg Registration, payment OfflinePayment) error {
+	if payment.RecordedAt.IsZero() {
+		payment.RecordedAt = time.Now()
+	}
+
+	reason := fmt.Sprintf("Paid by %s", payment.Method)
+	if payment.Note != "" {
+		reason = fmt.Sprintf("%s: %s", reason, payment.Note)
+	}
+	err := reg.TransitionTo(payment.status(), payment.RecordedBy, reason)
+	if err != nil {
+		return err
+	}
+
+	reg.SetOfflinePayment(payment)
+	return nil
+}
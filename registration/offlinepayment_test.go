@@ -0,0 +1,130 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterManually(t *testing.T) {
+	eventId := uuid.New()
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{
+				ID:                    id,
+				Version:               1,
+				RegistrationCloseTime: time.Now().Add(-time.Hour),
+				RegistrationOptions:   []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(2000, "USD")}},
+			}, nil
+		},
+	}
+	newReg := func() *IndividualRegistration {
+		return &IndividualRegistration{EventID: eventId, Version: 1, RegisteredAt: time.Now(), Email: "walkup@example.com"}
+	}
+
+	t.Run("cash at the door after registration closed", func(t *testing.T) {
+		var savedEvent events.Event
+		repo := &mockRegistrationRepository{
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, event events.Event, outbox []OutboxItem) error {
+				savedEvent = event
+				return nil
+			},
+		}
+
+		reg, _, err := RegisterManually(context.Background(), ManualRegistrationParams{
+			Registration:    newReg(),
+			Payment:         &OfflinePayment{Method: PAYMENT_METHOD_CASH, Amount: money.New(2000, "USD"), Note: "Walk up", RecordedBy: "admin@example.com"},
+			IgnoreCloseTime: true,
+		}, eventRepo, repo)
+		assert.NoError(t, err)
+		assert.Equal(t, STATUS_PAID, reg.GetStatus())
+		assert.Equal(t, "admin@example.com", reg.GetStatusHistory()[0].ChangedBy)
+		assert.Equal(t, PAYMENT_METHOD_CASH, reg.GetOfflinePayment().Method)
+		assert.False(t, reg.GetOfflinePayment().RecordedAt.IsZero())
+		assert.Equal(t, 1, savedEvent.NumTotalPlayers)
+		assert.Equal(t, 2, savedEvent.Version)
+	})
+
+	t.Run("still checks the close time unless told not to", func(t *testing.T) {
+		_, _, err := RegisterManually(context.Background(), ManualRegistrationParams{Registration: newReg()}, eventRepo, &mockRegistrationRepository{})
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_REGISTRATION_IS_CLOSED, registrationErr.Reason)
+	})
+
+	t.Run("comp", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, event events.Event, outbox []OutboxItem) error {
+				return nil
+			},
+		}
+
+		reg, _, err := RegisterManually(context.Background(), ManualRegistrationParams{
+			Registration:    newReg(),
+			Payment:         &OfflinePayment{Method: PAYMENT_METHOD_COMP},
+			IgnoreCloseTime: true,
+		}, eventRepo, repo)
+		assert.NoError(t, err)
+		assert.Equal(t, STATUS_COMPED, reg.GetStatus())
+	})
+
+	t.Run("cash without an amount", func(t *testing.T) {
+		_, _, err := RegisterManually(context.Background(), ManualRegistrationParams{
+			Registration:    newReg(),
+			Payment:         &OfflinePayment{Method: PAYMENT_METHOD_CASH},
+			IgnoreCloseTime: true,
+		}, eventRepo, &mockRegistrationRepository{})
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_OFFLINE_PAYMENT, registrationErr.Reason)
+	})
+}
+
+func TestMarkPaidOffline(t *testing.T) {
+	eventId := uuid.New()
+	newRepo := func(reg Registration) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return reg, nil
+			},
+		}
+	}
+
+	t.Run("bank transfer", func(t *testing.T) {
+		var saved Registration
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Version: 2, Email: "test@example.com"})
+		repo.UpdateRegistrationToPaidFunc = func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+			saved = registration
+			return nil
+		}
+
+		reg, err := MarkPaidOffline(context.Background(), MarkPaidOfflineParams{
+			EventID: eventId,
+			Email:   "test@example.com",
+			Payment: OfflinePayment{Method: PAYMENT_METHOD_BANK_TRANSFER, Amount: money.New(2000, "USD"), Note: "Ref 1234", RecordedBy: "admin@example.com"},
+		}, repo)
+		assert.NoError(t, err)
+		assert.Equal(t, STATUS_PAID, saved.GetStatus())
+		assert.Equal(t, 3, reg.(*IndividualRegistration).Version)
+		assert.Equal(t, "Paid by PAYMENT_METHOD_BANK_TRANSFER: Ref 1234", reg.GetStatusHistory()[0].Reason)
+	})
+
+	t.Run("already paid", func(t *testing.T) {
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Status: STATUS_PAID, Email: "test@example.com"})
+
+		_, err := MarkPaidOffline(context.Background(), MarkPaidOfflineParams{
+			EventID: eventId,
+			Email:   "test@example.com",
+			Payment: OfflinePayment{Method: PAYMENT_METHOD_COMP},
+		}, repo)
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_OFFLINE_PAYMENT, registrationErr.Reason)
+	})
+}
@@ -0,0 +1,264 @@
+//go:generate go tool stringer -type=OutboxKind,OutboxStatus
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// OutboxKind is something that has to happen once a registration is saved, like emailing the
+// registrant. They are saved in the same write as the registration and delivered after.
+type OutboxKind int
+
+const (
+	OUTBOX_CONFIRMATION_EMAIL OutboxKind = iota
+	// Only for teams, emails the players to confirm their roster spot
+	OUTBOX_ROSTER_INVITATIONS
+	// Adds everyone on the registration to the event's mailing list, if it has one
+	OUTBOX_MAILING_LIST
+)
+
+type OutboxStatus int
+
+const (
+	// Waiting for its next attempt
+	OUTBOX_PENDING OutboxStatus = iota
+	// Failed every attempt, it's only tried again if an admin retries it
+	OUTBOX_DEAD
+)
+
+const (
+	// Attempts before an item is given up on. With the backoff doubling each time,
+	// the last one is about 4 hours after the first.
+	maxOutboxAttempts = 8
+	// Wait after the first attempt, long enough that a delivery still in progress
+	// isn't picked up again by the next run
+	outboxBaseBackoff = 2 * time.Minute
+)
+
+type OutboxItem struct {
+	ID      uuid.UUID
+	Version int
+	Kind    OutboxKind
+	EventID uuid.UUID
+	// Email the registration is stored under
+	Email  string
+	Status OutboxStatus
+	// Attempts made so far
+	Attempts      int
+	NextAttemptAt time.Time
+	// Why the last attempt failed
+	LastError string
+	// Who an item with several recipients, like the roster invitations, was already delivered to,
+	// so a retry after some of them failed only goes to the rest
+	DeliveredTo []string
+	CreatedAt   time.Time
+}
+
+type OutboxRepository interface {
+	// GetDueOutboxItems gets the pending items whose next attempt is at or before now, oldest first.
+	GetDueOutboxItems(ctx context.Context, now time.Time) ([]OutboxItem, error)
+	GetOutboxItemsWithStatus(ctx context.Context, status OutboxStatus) ([]OutboxItem, error)
+	GetOutboxItem(ctx context.Context, id uuid.UUID) (OutboxItem, error)
+	UpdateOutboxItem(ctx context.Context, item OutboxItem) error
+	DeleteOutboxItem(ctx context.Context, item OutboxItem) error
+}
+
+func newOutboxItem(kind OutboxKind, reg Registration, now time.Time) OutboxItem {
+	return OutboxItem{
+		ID:            uuid.New(),
+		Version:       1,
+		Kind:          kind,
+		EventID:       reg.GetEventID(),
+		Email:         reg.GetEmail(),
+		Status:        OUTBOX_PENDING,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+}
+
+// signedUpOutbox is everything that happens once a registration is signed up: the registrant's
+// confirmation email, the roster invitations for a team, and the event's mailing list.
+// Teams splitting the payment don't get roster invitations since paying a share confirms the spot.
+func signedUpOutbox(reg Registration, now time.Time) []OutboxItem {
+	outbox := []OutboxItem{newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, reg, now)}
+	if teamReg, ok := reg.(*TeamRegistration); ok && !teamReg.SplitPayment {
+		outbox = append(outbox, newOutboxItem(OUTBOX_ROSTER_INVITATIONS, reg, now))
+	}
+	return append(outbox, newOutboxItem(OUTBOX_MAILING_LIST, reg, now))
+}
+
+// outboxBackoff is how long to wait after the given attempt before trying again.
+func outboxBackoff(attempts int) time.Duration {
+	return outboxBaseBackoff << (attempts - 1)
+}
+
+// OutboxDeliveryReport is what a run of DeliverDueOutboxItems did.
+type OutboxDeliveryReport struct {
+	NumDelivered int
+	// Items that failed on this run, with the failure recorded. Dead ones were given up on.
+	Failed []OutboxItem
+}
+
+// DeliverDueOutboxItems delivers every outbox item that is due. Each item is claimed first by
+// recording the attempt and pushing back its next one, so a run that dies part way through
+// or overlaps another doesn't deliver it again until it would have been retried anyway.
+//
+// Delivered items are deleted. Failed ones are retried with exponential backoff until they
+// run out of attempts, then kept as dead for an admin to look at.
+func DeliverDueOutboxItems(ctx context.Context, outboxRepo OutboxRepository, registrationRepo Repository, eventRepo events.Repository, emailSender email.Sender, from email.Address, subscriberManager email.SubscriberManager, checkInSigner *CheckInSigner, frontendBaseURL string, now time.Time) (OutboxDeliveryReport, error) {
+	ctx, span := tracer.Start(ctx, "DeliverDueOutboxItems")
+	defer span.End()
+
+	items, err := outboxRepo.GetDueOutboxItems(ctx, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return OutboxDeliveryReport{}, err
+	}
+
+	report, err := deliverOutboxItems(ctx, items, outboxRepo, registrationRepo, eventRepo, emailSender, from, subscriberManager, checkInSigner, frontendBaseURL, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return report, err
+}
+
+// DeliverConfirmationEmailNow delivers the confirmation email of a registration that was just signed up
+// right away, instead of leaving it for the next delivery run. It's claimed the same way DeliverDueOutboxItems
+// claims items so the run doesn't send it again, and a failed attempt is left for the run to retry.
+func DeliverConfirmationEmailNow(ctx context.Context, outboxRepo OutboxRepository, registrationRepo Repository, eventRepo events.Repository, emailSender email.Sender, from email.Address, checkInSigner *CheckInSigner, frontendBaseURL string, reg Registration, now time.Time) (OutboxDeliveryReport, error) {
+	ctx, span := tracer.Start(ctx, "DeliverConfirmationEmailNow")
+	defer span.End()
+
+	items, err := outboxRepo.GetDueOutboxItems(ctx, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return OutboxDeliveryReport{}, err
+	}
+	items = slices.DeleteFunc(items, func(item OutboxItem) bool {
+		return item.Kind != OUTBOX_CONFIRMATION_EMAIL || item.EventID != reg.GetEventID() || item.Email != reg.GetEmail()
+	})
+
+	report, err := deliverOutboxItems(ctx, items, outboxRepo, registrationRepo, eventRepo, emailSender, from, nil, checkInSigner, frontendBaseURL, now)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return report, err
+}
+
+func deliverOutboxItems(ctx context.Context, items []OutboxItem, outboxRepo OutboxRepository, registrationRepo Repository, eventRepo events.Repository, emailSender email.Sender, from email.Address, subscriberManager email.SubscriberManager, checkInSigner *CheckInSigner, frontendBaseURL string, now time.Time) (OutboxDeliveryReport, error) {
+	var report OutboxDeliveryReport
+	var errs []error
+	for _, item := range items {
+		item.Attempts++
+		item.NextAttemptAt = now.Add(outboxBackoff(item.Attempts))
+		item.Version++
+		err := outboxRepo.UpdateOutboxItem(ctx, item)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to claim outbox item %q: %w", item.ID, err))
+			continue
+		}
+
+		delivered, deliveryErr := deliverOutboxItem(ctx, item, registrationRepo, eventRepo, emailSender, from, subscriberManager, checkInSigner, frontendBaseURL)
+		if deliveryErr == nil {
+			err = outboxRepo.DeleteOutboxItem(ctx, item)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to delete delivered outbox item %q: %w", item.ID, err))
+			}
+			report.NumDelivered++
+			continue
+		}
+
+		item.LastError = deliveryErr.Error()
+		item.DeliveredTo = append(item.DeliveredTo, delivered...)
+		if item.Attempts >= maxOutboxAttempts {
+			item.Status = OUTBOX_DEAD
+		}
+		item.Version++
+		err = outboxRepo.UpdateOutboxItem(ctx, item)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to record failed attempt on outbox item %q: %w", item.ID, err))
+		}
+		report.Failed = append(report.Failed, item)
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// deliverOutboxItem delivers an item, returning who it was delivered to on this attempt when it has several recipients.
+func deliverOutboxItem(ctx context.Context, item OutboxItem, registrationRepo Repository, eventRepo events.Repository, emailSender email.Sender, from email.Address, subscriberManager email.SubscriberManager, checkInSigner *CheckInSigner, frontendBaseURL string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "deliverOutboxItem")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("kind", item.Kind.String()), attribute.String("event_id", item.EventID.String()))
+
+	reg, err := registrationRepo.GetRegistration(ctx, item.EventID, item.Email)
+	if registrationDoesNotExist(err) {
+		// Deleted or transferred since, there's no one to tell anymore
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	event, err := eventRepo.GetEvent(ctx, item.EventID)
+	if err != nil {
+		return nil, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", item.EventID), err)
+	}
+
+	switch item.Kind {
+	case OUTBOX_CONFIRMATION_EMAIL:
+		return nil, SendRegistrationConfirmationEmail(ctx, emailSender, from, reg, event, checkInSigner)
+	case OUTBOX_ROSTER_INVITATIONS:
+		teamReg, ok := reg.(*TeamRegistration)
+		if !ok {
+			return nil, nil
+		}
+		return SendRosterInvitationEmails(ctx, emailSender, from, teamReg, event, frontendBaseURL, item.DeliveredTo)
+	case OUTBOX_MAILING_LIST:
+		if event.MailingListGroupID == nil {
+			return nil, nil
+		}
+		return AddToMailingList(ctx, subscriberManager, reg, *event.MailingListGroupID, item.DeliveredTo)
+	default:
+		return nil, fmt.Errorf("unknown outbox item kind %s", item.Kind)
+	}
+}
+
+// RetryOutboxItem makes an item due again with its attempts reset, so the next delivery run picks it up.
+// Dead items go back to pending.
+func RetryOutboxItem(ctx context.Context, outboxRepo OutboxRepository, id uuid.UUID, now time.Time) (OutboxItem, error) {
+	ctx, span := tracer.Start(ctx, "RetryOutboxItem")
+	defer span.End()
+
+	item, err := outboxRepo.GetOutboxItem(ctx, id)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return OutboxItem{}, err
+	}
+
+	item.Status = OUTBOX_PENDING
+	item.Attempts = 0
+	item.NextAttemptAt = now
+	item.Version++
+	err = outboxRepo.UpdateOutboxItem(ctx, item)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return OutboxItem{}, err
+	}
+	return item, nil
+}
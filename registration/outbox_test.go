@@ -0,0 +1,368 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOutboxRepository struct {
+	items   map[uuid.UUID]OutboxItem
+	updates []OutboxItem
+}
+
+func newMockOutboxRepository(items ...OutboxItem) *mockOutboxRepository {
+	m := &mockOutboxRepository{items: map[uuid.UUID]OutboxItem{}}
+	for _, item := range items {
+		m.items[item.ID] = item
+	}
+	return m
+}
+
+func (m *mockOutboxRepository) GetDueOutboxItems(ctx context.Context, now time.Time) ([]OutboxItem, error) {
+	var due []OutboxItem
+	for _, item := range m.items {
+		if item.Status == OUTBOX_PENDING && !item.NextAttemptAt.After(now) {
+			due = append(due, item)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockOutboxRepository) GetOutboxItemsWithStatus(ctx context.Context, status OutboxStatus) ([]OutboxItem, error) {
+	var items []OutboxItem
+	for _, item := range m.items {
+		if item.Status == status {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m *mockOutboxRepository) GetOutboxItem(ctx context.Context, id uuid.UUID) (OutboxItem, error) {
+	item, ok := m.items[id]
+	if !ok {
+		return OutboxItem{}, NewOutboxItemDoesNotExistError("not found")
+	}
+	return item, nil
+}
+
+func (m *mockOutboxRepository) UpdateOutboxItem(ctx context.Context, item OutboxItem) error {
+	m.items[item.ID] = item
+	m.updates = append(m.updates, item)
+	return nil
+}
+
+func (m *mockOutboxRepository) DeleteOutboxItem(ctx context.Context, item OutboxItem) error {
+	delete(m.items, item.ID)
+	return nil
+}
+
+type failingEmailSender struct{}
+
+func (f *failingEmailSender) SendEmail(ctx context.Context, e email.Email) error {
+	return errors.New("mail server is down")
+}
+
+// flakyEmailSender fails to send to the addresses in failFor and sends to everyone else
+type flakyEmailSender struct {
+	mockEmailSender
+	failFor map[string]bool
+}
+
+func (f *flakyEmailSender) SendEmail(ctx context.Context, e email.Email) error {
+	if f.failFor[e.ToAddresses[0]] {
+		return errors.New("mailbox is full")
+	}
+	return f.mockEmailSender.SendEmail(ctx, e)
+}
+
+type mockSubscriberManager struct {
+	email.SubscriberManager
+	added   []string
+	failFor map[string]bool
+}
+
+func (m *mockSubscriberManager) AddSubscriberToGroup(ctx context.Context, subscriberEmail, name, groupID string) error {
+	if m.failFor[subscriberEmail] {
+		return errors.New("rate limited")
+	}
+	m.added = append(m.added, subscriberEmail)
+	return nil
+}
+
+func TestSignedUpOutbox(t *testing.T) {
+	now := time.Now()
+	eventId := uuid.New()
+
+	t.Run("individual", func(t *testing.T) {
+		outbox := signedUpOutbox(&IndividualRegistration{EventID: eventId, Email: "jane@example.com"}, now)
+		require.Len(t, outbox, 2)
+		assert.Equal(t, OUTBOX_CONFIRMATION_EMAIL, outbox[0].Kind)
+		assert.Equal(t, OUTBOX_MAILING_LIST, outbox[1].Kind)
+		for _, item := range outbox {
+			assert.Equal(t, eventId, item.EventID)
+			assert.Equal(t, "jane@example.com", item.Email)
+			assert.Equal(t, OUTBOX_PENDING, item.Status)
+			assert.Equal(t, now, item.NextAttemptAt)
+			assert.Equal(t, 1, item.Version)
+		}
+	})
+
+	t.Run("team", func(t *testing.T) {
+		outbox := signedUpOutbox(&TeamRegistration{EventID: eventId, CaptainEmail: "captain@example.com"}, now)
+		require.Len(t, outbox, 3)
+		assert.Equal(t, OUTBOX_ROSTER_INVITATIONS, outbox[1].Kind)
+	})
+
+	t.Run("team splitting the payment", func(t *testing.T) {
+		outbox := signedUpOutbox(&TeamRegistration{EventID: eventId, CaptainEmail: "captain@example.com", SplitPayment: true}, now)
+		require.Len(t, outbox, 2)
+		assert.NotContains(t, []OutboxKind{outbox[0].Kind, outbox[1].Kind}, OUTBOX_ROSTER_INVITATIONS)
+	})
+}
+
+func TestDeliverDueOutboxItems(t *testing.T) {
+	now := time.Now()
+	eventId := uuid.New()
+	reg := &IndividualRegistration{EventID: eventId, Email: "jane@example.com", Status: STATUS_PAID}
+	registrationRepo := &mockRegistrationRepository{
+		GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+			return reg, nil
+		},
+	}
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Name: "Summer Games"}, nil
+		},
+	}
+	from := email.Address{Address: "info@icaa.world"}
+	signer := NewCheckInSigner([]byte("secret"))
+
+	t.Run("delivered items are deleted", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, reg, now)
+		outboxRepo := newMockOutboxRepository(item)
+		sender := &mockEmailSender{}
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, registrationRepo, eventRepo, sender, from, nil, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.NumDelivered)
+		assert.Empty(t, report.Failed)
+		assert.Len(t, sender.sent, 1)
+		assert.Empty(t, outboxRepo.items)
+		// Claimed before it was delivered
+		require.NotEmpty(t, outboxRepo.updates)
+		assert.Equal(t, 1, outboxRepo.updates[0].Attempts)
+	})
+
+	t.Run("items that aren't due are left alone", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, reg, now.Add(time.Minute))
+		outboxRepo := newMockOutboxRepository(item)
+		sender := &mockEmailSender{}
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, registrationRepo, eventRepo, sender, from, nil, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		assert.Equal(t, 0, report.NumDelivered)
+		assert.Empty(t, sender.sent)
+		assert.Len(t, outboxRepo.items, 1)
+	})
+
+	t.Run("failed items back off", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, reg, now)
+		outboxRepo := newMockOutboxRepository(item)
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, registrationRepo, eventRepo, &failingEmailSender{}, from, nil, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		require.Len(t, report.Failed, 1)
+
+		saved := outboxRepo.items[item.ID]
+		assert.Equal(t, OUTBOX_PENDING, saved.Status)
+		assert.Equal(t, 1, saved.Attempts)
+		assert.Equal(t, now.Add(outboxBaseBackoff), saved.NextAttemptAt)
+		assert.Contains(t, saved.LastError, "mail server is down")
+		assert.Equal(t, 3, saved.Version)
+
+		// The wait doubles on each failure
+		_, err = DeliverDueOutboxItems(context.Background(), outboxRepo, registrationRepo, eventRepo, &failingEmailSender{}, from, nil, signer, "https://icaa.world", saved.NextAttemptAt)
+		require.NoError(t, err)
+		assert.Equal(t, saved.NextAttemptAt.Add(2*outboxBaseBackoff), outboxRepo.items[item.ID].NextAttemptAt)
+	})
+
+	t.Run("item is dead after its last attempt", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, reg, now)
+		item.Attempts = maxOutboxAttempts - 1
+		outboxRepo := newMockOutboxRepository(item)
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, registrationRepo, eventRepo, &failingEmailSender{}, from, nil, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		require.Len(t, report.Failed, 1)
+		assert.Equal(t, OUTBOX_DEAD, report.Failed[0].Status)
+		assert.Equal(t, OUTBOX_DEAD, outboxRepo.items[item.ID].Status)
+
+		due, err := outboxRepo.GetDueOutboxItems(context.Background(), now.Add(24*time.Hour))
+		require.NoError(t, err)
+		assert.Empty(t, due)
+	})
+
+	t.Run("registration deleted since", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, reg, now)
+		outboxRepo := newMockOutboxRepository(item)
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return nil, NewRegistrationDoesNotExistsError("not found", nil)
+			},
+		}
+		sender := &mockEmailSender{}
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, repo, eventRepo, sender, from, nil, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.NumDelivered)
+		assert.Empty(t, sender.sent)
+		assert.Empty(t, outboxRepo.items)
+	})
+
+	team := &TeamRegistration{
+		EventID:      eventId,
+		CaptainEmail: "captain@example.com",
+		Status:       STATUS_PAID,
+		Players: []PlayerInfo{
+			{FirstName: "One", Email: ptr.String("one@example.com"), RosterStatus: ROSTER_INVITED, InviteToken: "a"},
+			{FirstName: "Two", Email: ptr.String("two@example.com"), RosterStatus: ROSTER_INVITED, InviteToken: "b"},
+		},
+	}
+	teamRepo := &mockRegistrationRepository{
+		GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+			return team, nil
+		},
+	}
+
+	t.Run("retried roster invitations only go to who didn't get one", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_ROSTER_INVITATIONS, team, now)
+		outboxRepo := newMockOutboxRepository(item)
+		sender := &flakyEmailSender{failFor: map[string]bool{"two@example.com": true}}
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, teamRepo, eventRepo, sender, from, nil, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		require.Len(t, report.Failed, 1)
+		assert.Equal(t, []string{"one@example.com"}, outboxRepo.items[item.ID].DeliveredTo)
+
+		retrySender := &mockEmailSender{}
+		report, err = DeliverDueOutboxItems(context.Background(), outboxRepo, teamRepo, eventRepo, retrySender, from, nil, signer, "https://icaa.world", outboxRepo.items[item.ID].NextAttemptAt)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.NumDelivered)
+		require.Len(t, retrySender.sent, 1)
+		assert.Equal(t, []string{"two@example.com"}, retrySender.sent[0].ToAddresses)
+		assert.Empty(t, outboxRepo.items)
+	})
+
+	t.Run("retried mailing list only adds who wasn't added", func(t *testing.T) {
+		mailingListEvents := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{ID: id, Name: "Summer Games", MailingListGroupID: ptr.String("group")}, nil
+			},
+		}
+		item := newOutboxItem(OUTBOX_MAILING_LIST, team, now)
+		outboxRepo := newMockOutboxRepository(item)
+		subscribers := &mockSubscriberManager{failFor: map[string]bool{"one@example.com": true}}
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, teamRepo, mailingListEvents, &mockEmailSender{}, from, subscribers, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		require.Len(t, report.Failed, 1)
+		assert.Equal(t, []string{"captain@example.com", "two@example.com"}, outboxRepo.items[item.ID].DeliveredTo)
+
+		subscribers.failFor = nil
+		_, err = DeliverDueOutboxItems(context.Background(), outboxRepo, teamRepo, mailingListEvents, &mockEmailSender{}, from, subscribers, signer, "https://icaa.world", outboxRepo.items[item.ID].NextAttemptAt)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"captain@example.com", "two@example.com", "one@example.com"}, subscribers.added)
+		assert.Empty(t, outboxRepo.items)
+	})
+
+	t.Run("event without a mailing list", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_MAILING_LIST, reg, now)
+		outboxRepo := newMockOutboxRepository(item)
+
+		report, err := DeliverDueOutboxItems(context.Background(), outboxRepo, registrationRepo, eventRepo, &mockEmailSender{}, from, nil, signer, "https://icaa.world", now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.NumDelivered)
+	})
+}
+
+func TestDeliverConfirmationEmailNow(t *testing.T) {
+	now := time.Now()
+	eventId := uuid.New()
+	reg := &IndividualRegistration{EventID: eventId, Email: "jane@example.com", Status: STATUS_CONFIRMED}
+	registrationRepo := &mockRegistrationRepository{
+		GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+			return reg, nil
+		},
+	}
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Name: "Summer Games"}, nil
+		},
+	}
+	signer := NewCheckInSigner([]byte("secret"))
+
+	t.Run("only the registration's confirmation is delivered", func(t *testing.T) {
+		outbox := signedUpOutbox(reg, now)
+		other := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, &IndividualRegistration{EventID: eventId, Email: "john@example.com"}, now)
+		outboxRepo := newMockOutboxRepository(append(outbox, other)...)
+		sender := &mockEmailSender{}
+
+		report, err := DeliverConfirmationEmailNow(context.Background(), outboxRepo, registrationRepo, eventRepo, sender, email.Address{Address: "info@icaa.world"}, signer, "https://icaa.world", reg, now)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.NumDelivered)
+		require.Len(t, sender.sent, 1)
+		assert.Equal(t, []string{"jane@example.com"}, sender.sent[0].ToAddresses)
+		assert.NotContains(t, outboxRepo.items, outbox[0].ID)
+		assert.Contains(t, outboxRepo.items, outbox[1].ID)
+		assert.Contains(t, outboxRepo.items, other.ID)
+	})
+
+	t.Run("failure is left for the delivery run", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, reg, now)
+		outboxRepo := newMockOutboxRepository(item)
+
+		report, err := DeliverConfirmationEmailNow(context.Background(), outboxRepo, registrationRepo, eventRepo, &failingEmailSender{}, email.Address{Address: "info@icaa.world"}, signer, "https://icaa.world", reg, now)
+		require.NoError(t, err)
+		require.Len(t, report.Failed, 1)
+		assert.Equal(t, OUTBOX_PENDING, outboxRepo.items[item.ID].Status)
+		assert.Equal(t, now.Add(outboxBaseBackoff), outboxRepo.items[item.ID].NextAttemptAt)
+	})
+}
+
+func TestRetryOutboxItem(t *testing.T) {
+	now := time.Now()
+
+	t.Run("dead item is pending again", func(t *testing.T) {
+		item := newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, &IndividualRegistration{EventID: uuid.New(), Email: "jane@example.com"}, now.Add(-time.Hour))
+		item.Status = OUTBOX_DEAD
+		item.Attempts = maxOutboxAttempts
+		item.LastError = "mail server is down"
+		outboxRepo := newMockOutboxRepository(item)
+
+		retried, err := RetryOutboxItem(context.Background(), outboxRepo, item.ID, now)
+		require.NoError(t, err)
+		assert.Equal(t, OUTBOX_PENDING, retried.Status)
+		assert.Equal(t, 0, retried.Attempts)
+		assert.Equal(t, now, retried.NextAttemptAt)
+		assert.Equal(t, item.Version+1, retried.Version)
+		// Kept so the next failure isn't a mystery
+		assert.Equal(t, "mail server is down", retried.LastError)
+	})
+
+	t.Run("missing item", func(t *testing.T) {
+		_, err := RetryOutboxItem(context.Background(), newMockOutboxRepository(), uuid.New(), now)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_OUTBOX_ITEM_DOES_NOT_EXIST, registrationErr.Reason)
+	})
+}
@@ -0,0 +1,294 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Name and email domain that erased people are replaced with
+const (
+	erasedName        = "Erased"
+	erasedEmailDomain = "erased.invalid"
+)
+
+// SubscriberForgetter is the part of the mailing list integration that erases a subscriber for good.
+// It shouldn't fail if the email was never subscribed.
+type SubscriberForgetter interface {
+	ForgetSubscriber(ctx context.Context, email string) error
+}
+
+// PersonalData is everything stored about one email across all events, for privacy requests.
+type PersonalData struct {
+	Email string
+	// Registrations the email is the registrant of
+	Registrations []Registration
+	// Checkouts still open for those registrations
+	Intents []RegistrationIntent
+	// Every spot on a roster with the email, including the registrant's own
+	PlayerEntries []PlayerEntry
+}
+
+type PlayerEntry struct {
+	EventID uuid.UUID
+	// Email the registration is stored under
+	RegistrationEmail string
+	// Only set for teams
+	TeamName string
+	Player   PlayerInfo
+}
+
+// ExportPersonalData finds every registration, intent and player entry with email on it.
+func ExportPersonalData(ctx context.Context, email string, registrationRepo Repository) (PersonalData, error) {
+	ctx, span := tracer.Start(ctx, "ExportPersonalData")
+	defer span.End()
+
+	email = strings.ToLower(email)
+	regs, err := registrationRepo.GetRegistrationsByEmail(ctx, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return PersonalData{}, err
+	}
+
+	data := PersonalData{Email: email}
+	for _, reg := range regs {
+		if strings.EqualFold(reg.GetEmail(), email) {
+			data.Registrations = append(data.Registrations, reg)
+
+			intent, found, err := getOpenIntent(ctx, reg, registrationRepo)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return PersonalData{}, err
+			}
+			if found {
+				data.Intents = append(data.Intents, intent)
+			}
+		}
+
+		var teamName string
+		if team, ok := reg.(*TeamRegistration); ok {
+			teamName = team.TeamName
+		}
+		for _, player := range registrationPlayers(reg) {
+			if player.Email == nil || !strings.EqualFold(*player.Email, email) {
+				continue
+			}
+			data.PlayerEntries = append(data.PlayerEntries, PlayerEntry{
+				EventID:           reg.GetEventID(),
+				RegistrationEmail: reg.GetEmail(),
+				TeamName:          teamName,
+				Player:            *player,
+			})
+		}
+	}
+	return data, nil
+}
+
+type ErasureResult struct {
+	// Registrations the email was the registrant of, which are now anonymous
+	AnonymizedRegistrations int
+	// Spots on other people's teams that are now anonymous
+	AnonymizedPlayerEntries int
+}
+
+// ErasePersonalData anonymizes everything stored about email and removes it from the mailing list.
+//
+// Registrations are kept, so event counters, statuses, refunds and offline payments stay intact,
+// but the email, names, home city, medical info and notes on them are removed. Registrations the
+// email was the registrant of are moved to a made up email. Their payments are recorded on them
+// first, so they can still be refunded. Nothing is changed while one of them still has an open
+// checkout, since the payment provider could still complete it.
+func ErasePersonalData(ctx context.Context, email string, erasedBy string, registrationRepo Repository, paymentQuerier payments.PaymentQuerier, subscriberForgetter SubscriberForgetter, now time.Time) (ErasureResult, error) {
+	ctx, span := tracer.Start(ctx, "ErasePersonalData")
+	defer span.End()
+
+	email = strings.ToLower(email)
+	regs, err := registrationRepo.GetRegistrationsByEmail(ctx, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ErasureResult{}, err
+	}
+
+	for _, reg := range regs {
+		_, found, err := getOpenIntent(ctx, reg, registrationRepo)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return ErasureResult{}, err
+		}
+		if found {
+			err := NewCheckoutInProgressError(fmt.Sprintf("Registration for event ID %q still has an open checkout", reg.GetEventID()))
+			span.SetStatus(codes.Error, err.Error())
+			return ErasureResult{}, err
+		}
+	}
+
+	// Done first, so it can be retried before anything has been erased
+	err = subscriberForgetter.ForgetSubscriber(ctx, email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ErasureResult{}, fmt.Errorf("failed to remove subscriber from the mailing list: %w", err)
+	}
+
+	var result ErasureResult
+	for _, reg := range regs {
+		anonymized := cloneRegistration(reg)
+		err := recordPaymentIDs(ctx, paymentQuerier, anonymized)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return result, err
+		}
+		isRegistrant := anonymizeRegistration(anonymized, func(e string) bool { return strings.EqualFold(e, email) })
+		text := "A player's personal data was erased"
+		if isRegistrant {
+			text = "The registrant's personal data was erased"
+		}
+		anonymized.AddAdminNote(AdminNote{
+			ID:        uuid.New(),
+			Text:      text,
+			Author:    erasedBy,
+			CreatedAt: now,
+		})
+		anonymized.BumpVersion()
+
+		err = registrationRepo.AnonymizeRegistration(ctx, reg, anonymized)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return result, err
+		}
+
+		if isRegistrant {
+			result.AnonymizedRegistrations++
+		} else {
+			result.AnonymizedPlayerEntries++
+		}
+	}
+	return result, nil
+}
+
+func getOpenIntent(ctx context.Context, reg Registration, registrationRepo Repository) (RegistrationIntent, bool, error) {
+	intent, err := registrationRepo.GetRegistrationIntent(ctx, reg.GetEventID(), reg.GetEmail())
+	if err != nil {
+		var registrationErr *Error
+		if errors.As(err, &registrationErr) && registrationErr.Reason == REASON_REGISTRATION_DOES_NOT_EXIST {
+			return RegistrationIntent{}, false, nil
+		}
+		return RegistrationIntent{}, false, err
+	}
+	return intent, true, nil
+}
+
+// recordPaymentIDs keeps the IDs of every payment made for reg on it, since they're found by the
+// registrant's email and the players' invite tokens, which anonymizing removes.
+func recordPaymentIDs(ctx context.Context, paymentQuerier payments.PaymentQuerier, reg Registration) error {
+	regPayments, err := findRegistrationPayments(ctx, paymentQuerier, reg)
+	if err != nil {
+		if paymentNotFound(err) {
+			// Free, or paid for offline
+			return nil
+		}
+		return err
+	}
+	paymentIds := make([]string, 0, len(regPayments))
+	for _, payment := range regPayments {
+		paymentIds = append(paymentIds, payment.ID)
+	}
+	reg.SetPaymentIDs(paymentIds)
+	return nil
+}
+
+// anonymizeRegistration takes the personal info of everyone isErased matches out of reg, which must
+// already be a clone. Players without an email are matched with "". Returns if the registrant was
+// erased, in which case the registration gets a made up email and its notes are removed.
+func anonymizeRegistration(reg Registration, isErased func(email string) bool) bool {
+	erasedEmail := fmt.Sprintf("erased-%s@%s", uuid.NewString(), erasedEmailDomain)
+	replaceEmail := func(e string) string {
+		if isErased(e) {
+			return erasedEmail
+		}
+		return e
+	}
+	anonymizeHistory := func(statusHistory []StatusChange, transfers []Transfer) ([]StatusChange, []Transfer) {
+		statusHistory = slices.Clone(statusHistory)
+		for i := range statusHistory {
+			statusHistory[i].ChangedBy = replaceEmail(statusHistory[i].ChangedBy)
+		}
+		transfers = slices.Clone(transfers)
+		for i := range transfers {
+			transfers[i].FromEmail = replaceEmail(transfers[i].FromEmail)
+			transfers[i].ToEmail = replaceEmail(transfers[i].ToEmail)
+		}
+		return statusHistory, transfers
+	}
+	isPlayerErased := func(player PlayerInfo) bool {
+		if player.Email == nil {
+			return isErased("")
+		}
+		return isErased(*player.Email)
+	}
+
+	var isRegistrant bool
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		// Only ever one person on it
+		isRegistrant = true
+		if !isErasedEmail(r.Email) {
+			r.Email = erasedEmail
+		}
+		r.HomeCity = ""
+		r.PlayerInfo = anonymizedPlayer(r.PlayerInfo)
+		r.StatusHistory, r.Transfers = anonymizeHistory(r.StatusHistory, r.Transfers)
+		r.DuplicatePlayerEmails = slices.DeleteFunc(r.DuplicatePlayerEmails, isErased)
+	case *TeamRegistration:
+		isRegistrant = isErased(r.CaptainEmail)
+		if isRegistrant {
+			r.CaptainEmail = erasedEmail
+			r.HomeCity = ""
+		}
+		for i, player := range r.Players {
+			if isPlayerErased(player) {
+				r.Players[i] = anonymizedPlayer(player)
+			}
+		}
+		r.StatusHistory, r.Transfers = anonymizeHistory(r.StatusHistory, r.Transfers)
+		r.DuplicatePlayerEmails = slices.DeleteFunc(r.DuplicatePlayerEmails, isErased)
+	}
+
+	if isRegistrant {
+		// Notes are about the registrant, so they go too
+		for _, note := range slices.Clone(reg.GetAdminNotes()) {
+			reg.RemoveAdminNote(note.ID)
+		}
+	}
+	return isRegistrant
+}
+
+// isErasedEmail is if email was already made up for someone erased.
+func isErasedEmail(email string) bool {
+	return strings.HasSuffix(email, "@"+erasedEmailDomain)
+}
+
+// anonymizedPlayer keeps what the event's counters and payments depend on, like roster, share
+// and check-in statuses, and drops everything that identifies the player.
+func anonymizedPlayer(player PlayerInfo) PlayerInfo {
+	player.FirstName = erasedName
+	player.LastName = erasedName
+	player.Email = nil
+	player.InviteToken = ""
+	player.EmergencyContact = nil
+	player.MedicalNotes = ""
+	return player
+}
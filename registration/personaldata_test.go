@@ -0,0 +1,216 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSubscriberForgetter struct {
+	ForgetSubscriberFunc func(ctx context.Context, email string) error
+}
+
+func (m *mockSubscriberForgetter) ForgetSubscriber(ctx context.Context, email string) error {
+	return m.ForgetSubscriberFunc(ctx, email)
+}
+
+func noIntent(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error) {
+	return RegistrationIntent{}, NewRegistrationDoesNotExistsError("no intent", nil)
+}
+
+func TestExportPersonalData(t *testing.T) {
+	ownEvent := uuid.New()
+	teamEvent := uuid.New()
+	intent := RegistrationIntent{EventId: ownEvent, Email: "jane@example.com", PaymentSessionId: "cs_123"}
+	own := &IndividualRegistration{
+		EventID: ownEvent,
+		Email:   "jane@example.com",
+		PlayerInfo: PlayerInfo{
+			FirstName:    "Jane",
+			LastName:     "Doe",
+			Email:        ptr.String("jane@example.com"),
+			MedicalNotes: "Asthma",
+		},
+	}
+	team := &TeamRegistration{
+		EventID:      teamEvent,
+		CaptainEmail: "captain@example.com",
+		TeamName:     "The Archers",
+		Players: []PlayerInfo{
+			{FirstName: "Cap", LastName: "Tain", Email: ptr.String("captain@example.com")},
+			{FirstName: "Jane", LastName: "Doe", Email: ptr.String("Jane@Example.com")},
+		},
+	}
+
+	repo := &mockRegistrationRepository{
+		GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+			assert.Equal(t, "jane@example.com", email)
+			return []Registration{own, team}, nil
+		},
+		GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error) {
+			if eventId == ownEvent {
+				return intent, nil
+			}
+			return noIntent(ctx, eventId, email)
+		},
+	}
+
+	data, err := ExportPersonalData(context.Background(), "JANE@example.com", repo)
+	require.NoError(t, err)
+	assert.Equal(t, "jane@example.com", data.Email)
+	assert.Equal(t, []Registration{own}, data.Registrations)
+	assert.Equal(t, []RegistrationIntent{intent}, data.Intents)
+	require.Len(t, data.PlayerEntries, 2)
+	assert.Equal(t, PlayerEntry{EventID: ownEvent, RegistrationEmail: "jane@example.com", Player: own.PlayerInfo}, data.PlayerEntries[0])
+	assert.Equal(t, PlayerEntry{EventID: teamEvent, RegistrationEmail: "captain@example.com", TeamName: "The Archers", Player: team.Players[1]}, data.PlayerEntries[1])
+}
+
+func TestErasePersonalData(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	forgetter := func(forgotten *[]string) *mockSubscriberForgetter {
+		return &mockSubscriberForgetter{
+			ForgetSubscriberFunc: func(ctx context.Context, email string) error {
+				*forgotten = append(*forgotten, email)
+				return nil
+			},
+		}
+	}
+
+	t.Run("registrant", func(t *testing.T) {
+		reg := &IndividualRegistration{
+			EventID:  uuid.New(),
+			Version:  3,
+			Email:    "jane@example.com",
+			HomeCity: "Springfield",
+			Status:   STATUS_PAID,
+			PlayerInfo: PlayerInfo{
+				FirstName:        "Jane",
+				LastName:         "Doe",
+				Email:            ptr.String("jane@example.com"),
+				EmergencyContact: &EmergencyContact{Name: "John Doe", Phone: "555-0100"},
+				MedicalNotes:     "Asthma",
+				RosterStatus:     ROSTER_CONFIRMED,
+			},
+			StatusHistory: []StatusChange{{To: STATUS_PAID, ChangedBy: "jane@example.com"}},
+			AdminNotes:    []AdminNote{{ID: uuid.New(), Text: "Jane asked for a refund"}},
+		}
+		var from, to Registration
+		repo := &mockRegistrationRepository{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+				return []Registration{reg}, nil
+			},
+			GetRegistrationIntentFunc: noIntent,
+			AnonymizeRegistrationFunc: func(ctx context.Context, f Registration, t Registration) error {
+				from, to = f, t
+				return nil
+			},
+		}
+		var forgotten []string
+
+		querier := &metadataPaymentQuerier{Payments: []payments.Payment{checkoutPayment("pi_1", reg.EventID, "jane@example.com")}}
+
+		result, err := ErasePersonalData(context.Background(), "jane@example.com", "admin@example.com", repo, querier, forgetter(&forgotten), now)
+		require.NoError(t, err)
+		assert.Equal(t, ErasureResult{AnonymizedRegistrations: 1}, result)
+		assert.Equal(t, []string{"jane@example.com"}, forgotten)
+		assert.Same(t, reg, from)
+		assert.Equal(t, "jane@example.com", reg.Email, "original must not be changed")
+
+		anonymized := to.(*IndividualRegistration)
+		assert.True(t, strings.HasSuffix(anonymized.Email, "@erased.invalid"))
+		assert.Equal(t, 4, anonymized.Version)
+		assert.Equal(t, STATUS_PAID, anonymized.Status)
+		assert.Empty(t, anonymized.HomeCity)
+		assert.Equal(t, PlayerInfo{FirstName: "Erased", LastName: "Erased", RosterStatus: ROSTER_CONFIRMED}, anonymized.PlayerInfo)
+		assert.Equal(t, anonymized.Email, anonymized.StatusHistory[0].ChangedBy)
+		assert.Equal(t, []string{"pi_1"}, anonymized.PaymentIDs)
+		require.Len(t, anonymized.AdminNotes, 1)
+		assert.Equal(t, "admin@example.com", anonymized.AdminNotes[0].Author)
+		assert.Equal(t, now, anonymized.AdminNotes[0].CreatedAt)
+	})
+
+	t.Run("player on someone else's team", func(t *testing.T) {
+		note := AdminNote{ID: uuid.New(), Text: "Captain paid in cash"}
+		reg := &TeamRegistration{
+			EventID:      uuid.New(),
+			Version:      1,
+			CaptainEmail: "captain@example.com",
+			HomeCity:     "Springfield",
+			Players: []PlayerInfo{
+				{FirstName: "Cap", LastName: "Tain", Email: ptr.String("captain@example.com")},
+				{FirstName: "Jane", LastName: "Doe", Email: ptr.String("jane@example.com"), InviteToken: "token"},
+			},
+			DuplicatePlayerEmails: []string{"jane@example.com"},
+			AdminNotes:            []AdminNote{note},
+		}
+		var to Registration
+		repo := &mockRegistrationRepository{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+				return []Registration{reg}, nil
+			},
+			GetRegistrationIntentFunc: noIntent,
+			AnonymizeRegistrationFunc: func(ctx context.Context, f Registration, t Registration) error {
+				to = t
+				return nil
+			},
+		}
+		var forgotten []string
+
+		result, err := ErasePersonalData(context.Background(), "jane@example.com", "admin@example.com", repo, &metadataPaymentQuerier{}, forgetter(&forgotten), now)
+		require.NoError(t, err)
+		assert.Equal(t, ErasureResult{AnonymizedPlayerEntries: 1}, result)
+
+		anonymized := to.(*TeamRegistration)
+		assert.Equal(t, "captain@example.com", anonymized.CaptainEmail)
+		assert.Equal(t, "Springfield", anonymized.HomeCity)
+		assert.Equal(t, reg.Players[0], anonymized.Players[0])
+		assert.Equal(t, PlayerInfo{FirstName: "Erased", LastName: "Erased"}, anonymized.Players[1])
+		assert.Empty(t, anonymized.DuplicatePlayerEmails)
+		require.Len(t, anonymized.AdminNotes, 2)
+		assert.Equal(t, note, anonymized.AdminNotes[0])
+		assert.Equal(t, "Jane", reg.Players[1].FirstName, "original must not be changed")
+	})
+
+	t.Run("open checkout", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+				return []Registration{&IndividualRegistration{EventID: uuid.New(), Email: "jane@example.com"}}, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error) {
+				return RegistrationIntent{EventId: eventId, Email: email}, nil
+			},
+		}
+		var forgotten []string
+
+		_, err := ErasePersonalData(context.Background(), "jane@example.com", "admin@example.com", repo, &metadataPaymentQuerier{}, forgetter(&forgotten), now)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_CHECKOUT_IN_PROGRESS, registrationErr.Reason)
+		assert.Empty(t, forgotten)
+	})
+
+	t.Run("mailing list fails", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+				return []Registration{&IndividualRegistration{EventID: uuid.New(), Email: "jane@example.com"}}, nil
+			},
+			GetRegistrationIntentFunc: noIntent,
+		}
+		failing := &mockSubscriberForgetter{
+			ForgetSubscriberFunc: func(ctx context.Context, email string) error {
+				return errors.New("mailing list is down")
+			},
+		}
+
+		_, err := ErasePersonalData(context.Background(), "jane@example.com", "admin@example.com", repo, &metadataPaymentQuerier{}, failing, now)
+		assert.ErrorContains(t, err, "mailing list is down")
+	})
+}
@@ -2,10 +2,41 @@
 
 package registration
 
+import "time"
+
 type PlayerInfo struct {
 	FirstName string
 	LastName  string
 	Email     *string
+
+	// Roster confirmation, only used for players on a team registration
+	RosterStatus RosterStatus
+	InviteToken  string
+	InvitedAt    *time.Time
+	ConfirmedAt  *time.Time
+
+	// Share of the team fee, only used for team registrations that split the payment
+	ShareStatus ShareStatus
+	SharePaidAt *time.Time
+
+	// Event-day check-in, with the email of the admin that checked them in
+	CheckedInAt *time.Time
+	CheckedInBy string
+
+	// Sensitive, encrypted before it's stored and only shown to admins allowed to see medical info
+	EmergencyContact *EmergencyContact
+	MedicalNotes     string
+}
+
+type EmergencyContact struct {
+	Name         string
+	Phone        string
+	Relationship string
+}
+
+// HasMedicalInfo is if the player gave an emergency contact or medical notes.
+func (p PlayerInfo) HasMedicalInfo() bool {
+	return p.EmergencyContact != nil || p.MedicalNotes != ""
 }
 
 type ExperienceLevel int
@@ -0,0 +1,424 @@
+//go:generate go tool stringer -type=MismatchKind
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type MismatchKind int
+
+const (
+	// The payment provider took the payment but the registration is still pending
+	MISMATCH_PAID_NOT_MARKED_PAID MismatchKind = iota
+	// Marked paid without a payment at the payment provider or one recorded offline
+	MISMATCH_MARKED_PAID_WITHOUT_PAYMENT
+	// Paid for a registration that doesn't exist, usually one that expired before the payment went through
+	MISMATCH_PAYMENT_WITHOUT_REGISTRATION
+	// An intent without a pending registration to go with it
+	MISMATCH_ORPHAN_INTENT
+)
+
+// Fixable is if FixPaymentMismatch can fix the mismatch, the rest need an admin to
+// refund the payment or record how it was paid.
+func (k MismatchKind) Fixable() bool {
+	return k == MISMATCH_PAID_NOT_MARKED_PAID || k == MISMATCH_ORPHAN_INTENT
+}
+
+type Mismatch struct {
+	Kind  MismatchKind
+	Email string
+	// Only set for the mismatches found from a payment
+	PaymentID         string
+	CheckoutSessionID string
+	Amount            *money.Money
+	// Nil when there is no registration
+	RegistrationStatus *Status
+	// Only set for orphan intents
+	IntentExpiresAt *time.Time
+}
+
+type ReconciliationReport struct {
+	EventID          uuid.UUID
+	NumPayments      int
+	NumRegistrations int
+	NumIntents       int
+	Mismatches       []Mismatch
+}
+
+// ReconcilePayments compares the event's checkout payments at the payment provider with its
+// registrations and intents, and reports everything that doesn't line up.
+//
+// Payments for a player's share of a team or a transfer's price difference count as the registration
+// being paid for, but only a checkout for the whole registration is expected to have marked it paid.
+func ReconcilePayments(ctx context.Context, eventId uuid.UUID, registrationRepo Repository, paymentQuerier payments.PaymentQuerier) (ReconciliationReport, error) {
+	ctx, span := tracer.Start(ctx, "ReconcilePayments")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	report, err := reconcilePayments(ctx, eventId, registrationRepo, paymentQuerier)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ReconciliationReport{}, err
+	}
+	return report, nil
+}
+
+func reconcilePayments(ctx context.Context, eventId uuid.UUID, registrationRepo Repository, paymentQuerier payments.PaymentQuerier) (ReconciliationReport, error) {
+	regs, err := GetAllRegistrations(ctx, registrationRepo, eventId)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+	intents, err := registrationRepo.GetRegistrationIntentsForEvent(ctx, eventId)
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+	eventPayments, err := listPayments(ctx, paymentQuerier, map[string]string{
+		eventIdKey:  eventId.String(),
+		itemTypeKey: itemTypeEvent,
+	})
+	if err != nil {
+		return ReconciliationReport{}, err
+	}
+
+	report := ReconciliationReport{
+		EventID:          eventId,
+		NumPayments:      len(eventPayments),
+		NumRegistrations: len(regs),
+		NumIntents:       len(intents),
+	}
+
+	regsByEmail := make(map[string]Registration, len(regs))
+	// Emails that moved to another registration at this event
+	transferredFrom := map[string]bool{}
+	for _, reg := range regs {
+		regsByEmail[strings.ToLower(reg.GetEmail())] = reg
+		for _, transfer := range reg.GetTransfers() {
+			if transfer.FromEventID == eventId {
+				transferredFrom[strings.ToLower(transfer.FromEmail)] = true
+			}
+		}
+	}
+	// Registrations whose emails were erased can only be matched to their payments by ID
+	regsByPaymentId := map[string]Registration{}
+	for _, reg := range regs {
+		for _, paymentId := range reg.GetPaymentIDs() {
+			regsByPaymentId[paymentId] = reg
+		}
+	}
+	paymentsByEmail := map[string][]payments.Payment{}
+	for _, payment := range eventPayments {
+		email := strings.ToLower(payment.Metadata[emailKey])
+		paymentsByEmail[email] = append(paymentsByEmail[email], payment)
+	}
+
+	for _, payment := range eventPayments {
+		if !isRegistrationCheckout(payment) {
+			continue
+		}
+		email := strings.ToLower(payment.Metadata[emailKey])
+
+		reg, ok := regsByEmail[email]
+		if !ok {
+			reg, ok = regsByPaymentId[payment.ID]
+		}
+		if !ok {
+			if transferredFrom[email] {
+				continue
+			}
+			transferredAway, err := wasTransferredAway(ctx, registrationRepo, eventId, email)
+			if err != nil {
+				return ReconciliationReport{}, err
+			}
+			if !transferredAway {
+				report.Mismatches = append(report.Mismatches, paymentMismatch(MISMATCH_PAYMENT_WITHOUT_REGISTRATION, email, payment, nil))
+			}
+			continue
+		}
+		if reg.GetStatus() == STATUS_PENDING {
+			report.Mismatches = append(report.Mismatches, paymentMismatch(MISMATCH_PAID_NOT_MARKED_PAID, reg.GetEmail(), payment, reg))
+		}
+	}
+
+	for _, reg := range regs {
+		if reg.GetStatus() != STATUS_PAID || reg.GetOfflinePayment() != nil {
+			continue
+		}
+		paid, err := hasPayment(ctx, paymentQuerier, eventId, reg, paymentsByEmail)
+		if err != nil {
+			return ReconciliationReport{}, err
+		}
+		if !paid {
+			status := reg.GetStatus()
+			report.Mismatches = append(report.Mismatches, Mismatch{
+				Kind:               MISMATCH_MARKED_PAID_WITHOUT_PAYMENT,
+				Email:              reg.GetEmail(),
+				RegistrationStatus: &status,
+			})
+		}
+	}
+
+	for _, intent := range intents {
+		reg, ok := regsByEmail[strings.ToLower(intent.Email)]
+		if ok && (reg.GetStatus() == STATUS_PENDING || waitsOnTransfer(reg, intent)) {
+			continue
+		}
+		mismatch := Mismatch{
+			Kind:            MISMATCH_ORPHAN_INTENT,
+			Email:           intent.Email,
+			IntentExpiresAt: &intent.ExpiresAt,
+		}
+		if ok {
+			status := reg.GetStatus()
+			mismatch.RegistrationStatus = &status
+		}
+		report.Mismatches = append(report.Mismatches, mismatch)
+	}
+
+	return report, nil
+}
+
+type FixPaymentMismatchParams struct {
+	EventID uuid.UUID
+	Email   string
+	Kind    MismatchKind
+	FixedBy string
+}
+
+// FixPaymentMismatch fixes one mismatch from ReconcilePayments. The mismatch is checked again first,
+// so a report that has gone stale since never changes anything that has already been sorted out.
+//
+// A registration that was paid for but still pending is marked paid. An orphan intent is deleted.
+func FixPaymentMismatch(ctx context.Context, params FixPaymentMismatchParams, registrationRepo Repository, paymentQuerier payments.PaymentQuerier) error {
+	ctx, span := tracer.Start(ctx, "FixPaymentMismatch")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", params.EventID.String()), attribute.String("kind", params.Kind.String()))
+
+	var err error
+	switch params.Kind {
+	case MISMATCH_PAID_NOT_MARKED_PAID:
+		err = markReconciledPaymentPaid(ctx, params, registrationRepo, paymentQuerier)
+	case MISMATCH_ORPHAN_INTENT:
+		err = deleteOrphanIntent(ctx, params, registrationRepo)
+	default:
+		err = NewMismatchNotFixableError(fmt.Sprintf("%s can't be fixed automatically", params.Kind))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	return nil
+}
+
+func markReconciledPaymentPaid(ctx context.Context, params FixPaymentMismatchParams, registrationRepo Repository, paymentQuerier payments.PaymentQuerier) error {
+	reg, err := registrationRepo.GetRegistration(ctx, params.EventID, params.Email)
+	if err != nil {
+		return err
+	}
+	if reg.GetStatus() != STATUS_PENDING {
+		return NewMismatchNotFoundError(fmt.Sprintf("Registration for %s is not pending anymore", params.Email))
+	}
+	// Only the registration's own checkout counts, not one from an earlier registration that expired
+	intent, err := registrationRepo.GetRegistrationIntent(ctx, params.EventID, params.Email)
+	if err != nil {
+		if registrationDoesNotExist(err) {
+			return NewMismatchNotFoundError(fmt.Sprintf("No checkout was found for the registration for %s", params.Email))
+		}
+		return err
+	}
+
+	found, err := listPayments(ctx, paymentQuerier, map[string]string{
+		emailKey:    reg.GetEmail(),
+		eventIdKey:  params.EventID.String(),
+		itemTypeKey: itemTypeEvent,
+	})
+	if err != nil {
+		return err
+	}
+	var paymentId string
+	for _, payment := range found {
+		if payment.CheckoutSessionID != "" && payment.CheckoutSessionID == intent.PaymentSessionId {
+			paymentId = payment.ID
+			break
+		}
+	}
+	if paymentId == "" {
+		return NewMismatchNotFoundError(fmt.Sprintf("No payment was found for the registration for %s", params.Email))
+	}
+
+	err = reg.TransitionTo(STATUS_PAID, params.FixedBy, fmt.Sprintf("Payment %s found when reconciling with the payment provider", paymentId))
+	if err != nil {
+		return err
+	}
+	reg.BumpVersion()
+
+	// Also deletes the intent and sends the sign up emails, same as when the checkout completes
+	return registrationRepo.UpdateRegistrationToPaid(ctx, reg, signedUpOutbox(reg, time.Now()))
+}
+
+func deleteOrphanIntent(ctx context.Context, params FixPaymentMismatchParams, registrationRepo Repository) error {
+	intent, err := registrationRepo.GetRegistrationIntent(ctx, params.EventID, params.Email)
+	if err != nil {
+		if registrationDoesNotExist(err) {
+			return NewMismatchNotFoundError(fmt.Sprintf("No intent was found for %s", params.Email))
+		}
+		return err
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, params.EventID, params.Email)
+	if err != nil && !registrationDoesNotExist(err) {
+		return err
+	}
+	if err == nil && reg.GetStatus() == STATUS_PENDING {
+		return NewMismatchNotFoundError(fmt.Sprintf("Intent for %s still has a pending registration", params.Email))
+	}
+	if err == nil && waitsOnTransfer(reg, intent) {
+		return NewMismatchNotFoundError(fmt.Sprintf("Intent for %s is still the checkout of a pending transfer", params.Email))
+	}
+
+	return registrationRepo.DeleteRegistrationIntent(ctx, intent)
+}
+
+func registrationDoesNotExist(err error) bool {
+	var registrationErr *Error
+	return errors.As(err, &registrationErr) && registrationErr.Reason == REASON_REGISTRATION_DOES_NOT_EXIST
+}
+
+func paymentNotFound(err error) bool {
+	var registrationErr *Error
+	return errors.As(err, &registrationErr) && registrationErr.Reason == REASON_PAYMENT_NOT_FOUND
+}
+
+// isRegistrationCheckout is if the payment was a checkout for a whole registration, rather than
+// a player's share or a transfer's price difference.
+func isRegistrationCheckout(payment payments.Payment) bool {
+	_, isShare := payment.Metadata[shareTokensKey]
+	_, isTransfer := payment.Metadata[transferIdKey]
+	return !isShare && !isTransfer
+}
+
+// hasPayment is if anything was paid for the registration at the payment provider. A transferred
+// registration was paid for by whoever it was first registered to, at the event it was first for.
+func hasPayment(ctx context.Context, paymentQuerier payments.PaymentQuerier, eventId uuid.UUID, reg Registration, paymentsByEmail map[string][]payments.Payment) (bool, error) {
+	if len(reg.GetPaymentIDs()) > 0 {
+		// Its emails were erased, so only the payments recorded on it can still be found
+		_, err := findRegistrationPayments(ctx, paymentQuerier, reg)
+		if paymentNotFound(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+	if len(paymentsByEmail[strings.ToLower(reg.GetEmail())]) > 0 {
+		return true, nil
+	}
+
+	transfers := reg.GetTransfers()
+	if len(transfers) == 0 {
+		return false, nil
+	}
+	original := transfers[0]
+	if original.FromEventID == eventId {
+		return len(paymentsByEmail[strings.ToLower(original.FromEmail)]) > 0, nil
+	}
+
+	found, err := listPayments(ctx, paymentQuerier, map[string]string{
+		emailKey:    original.FromEmail,
+		eventIdKey:  original.FromEventID.String(),
+		itemTypeKey: itemTypeEvent,
+	})
+	return len(found) > 0, err
+}
+
+// wasTransferredAway is if the email's registration at the event was moved to another event.
+func wasTransferredAway(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, email string) (bool, error) {
+	regs, err := registrationRepo.GetRegistrationsByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+	for _, reg := range regs {
+		for _, transfer := range reg.GetTransfers() {
+			if transfer.FromEventID == eventId && strings.EqualFold(transfer.FromEmail, email) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func listPayments(ctx context.Context, paymentQuerier payments.PaymentQuerier, metadataFilter map[string]string) ([]payments.Payment, error) {
+	var found []payments.Payment
+	for payment, err := range paymentQuerier.ListCharges(ctx, payments.ChargeListParams{
+		MetadataFilter: metadataFilter,
+	}) {
+		if err != nil {
+			return nil, NewFailedToFetchError("Failed to fetch payments", err)
+		}
+		found = append(found, payment)
+	}
+	return found, nil
+}
+
+func paymentMismatch(kind MismatchKind, email string, payment payments.Payment, reg Registration) Mismatch {
+	mismatch := Mismatch{
+		Kind:              kind,
+		Email:             email,
+		PaymentID:         payment.ID,
+		CheckoutSessionID: payment.CheckoutSessionID,
+		Amount:            payment.Amount,
+	}
+	if reg != nil {
+		status := reg.GetStatus()
+		mismatch.RegistrationStatus = &status
+	}
+	return mismatch
+}
+
+// ReconcileRecentPayments reconciles every event that hasn't ended yet or ended within the last lookback.
+// Older events have nothing left that is likely to change.
+func ReconcileRecentPayments(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, paymentQuerier payments.PaymentQuerier, lookback time.Duration, now time.Time) ([]ReconciliationReport, error) {
+	ctx, span := tracer.Start(ctx, "ReconcileRecentPayments")
+	defer span.End()
+
+	allEvents, err := events.GetAllEvents(ctx, eventRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, NewFailedToFetchError("Failed to fetch events", err)
+	}
+
+	var reports []ReconciliationReport
+	var errs []error
+	for _, event := range allEvents {
+		if event.EndTime.Before(now.Add(-lookback)) {
+			continue
+		}
+		report, err := reconcilePayments(ctx, event.ID, registrationRepo, paymentQuerier)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconcile payments for event ID %q: %w", event.ID, err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return reports, err
+}
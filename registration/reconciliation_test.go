@@ -0,0 +1,292 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// metadataPaymentQuerier only lists the payments that match the metadata filter, like the payment provider does.
+type metadataPaymentQuerier struct {
+	payments.PaymentQuerier
+	Payments []payments.Payment
+}
+
+func (m *metadataPaymentQuerier) ListCharges(ctx context.Context, params payments.ChargeListParams) iter.Seq2[payments.Payment, error] {
+	return func(yield func(payments.Payment, error) bool) {
+		for _, p := range m.Payments {
+			matches := true
+			for k, v := range params.MetadataFilter {
+				if p.Metadata[k] != v {
+					matches = false
+				}
+			}
+			if matches && !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
+
+func checkoutPayment(id string, eventId uuid.UUID, email string) payments.Payment {
+	return payments.Payment{
+		ID:                id,
+		Amount:            money.New(5000, "USD"),
+		CheckoutSessionID: "cs_" + id,
+		Metadata: map[string]string{
+			emailKey:    email,
+			eventIdKey:  eventId.String(),
+			itemTypeKey: itemTypeEvent,
+		},
+	}
+}
+
+func TestReconcilePayments(t *testing.T) {
+	eventId := uuid.New()
+	otherEventId := uuid.New()
+	transferId := uuid.New()
+
+	regs := []Registration{
+		// Webhook never arrived
+		&IndividualRegistration{EventID: eventId, Email: "pending@example.com", Status: STATUS_PENDING},
+		// All good
+		&IndividualRegistration{EventID: eventId, Email: "paid@example.com", Status: STATUS_PAID},
+		&IndividualRegistration{EventID: eventId, Email: "cash@example.com", Status: STATUS_PAID, OfflinePayment: &OfflinePayment{Method: PAYMENT_METHOD_CASH}},
+		&IndividualRegistration{EventID: eventId, Email: "moved@example.com", Status: STATUS_PAID, Transfers: []Transfer{{FromEventID: otherEventId, FromEmail: "moved@example.com", ToEventID: eventId, ToEmail: "moved@example.com"}}},
+		&IndividualRegistration{EventID: eventId, Email: "new-owner@example.com", Status: STATUS_PAID, Transfers: []Transfer{{FromEventID: eventId, FromEmail: "old-owner@example.com", ToEventID: eventId, ToEmail: "new-owner@example.com"}}},
+		&TeamRegistration{EventID: eventId, CaptainEmail: "split@example.com", Status: STATUS_PENDING, SplitPayment: true},
+		&IndividualRegistration{EventID: eventId, Email: "erased-1@erased.invalid", Status: STATUS_PAID, PaymentIDs: []string{"pi_erased"}},
+		// Nothing at the payment provider
+		&IndividualRegistration{EventID: eventId, Email: "unpaid@example.com", Status: STATUS_PAID},
+		// Checkout still open
+		&IndividualRegistration{EventID: eventId, Email: "checkout@example.com", Status: STATUS_PENDING},
+		&IndividualRegistration{EventID: eventId, Email: "transferring@example.com", Status: STATUS_PAID, PendingTransfer: &PendingTransfer{Transfer: Transfer{ID: transferId}}},
+		&IndividualRegistration{EventID: eventId, Email: "cancelled@example.com", Status: STATUS_CANCELLED},
+	}
+	share := checkoutPayment("pi_share", eventId, "split@example.com")
+	share.Metadata[shareTokensKey] = "token"
+	querier := &metadataPaymentQuerier{Payments: []payments.Payment{
+		checkoutPayment("pi_pending", eventId, "Pending@example.com"),
+		checkoutPayment("pi_paid", eventId, "paid@example.com"),
+		checkoutPayment("pi_moved", otherEventId, "moved@example.com"),
+		checkoutPayment("pi_old_owner", eventId, "old-owner@example.com"),
+		checkoutPayment("pi_expired", eventId, "expired@example.com"),
+		share,
+		checkoutPayment("pi_erased", eventId, "jane@example.com"),
+		checkoutPayment("pi_transferring", eventId, "transferring@example.com"),
+	}}
+	expiresAt := time.Now()
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			return GetAllRegistrationsResponse{Data: regs}, nil
+		},
+		GetRegistrationIntentsForEventFunc: func(ctx context.Context, id uuid.UUID) ([]RegistrationIntent, error) {
+			return []RegistrationIntent{
+				{EventId: eventId, Email: "checkout@example.com", ExpiresAt: expiresAt},
+				{EventId: eventId, Email: "transferring@example.com", ExpiresAt: expiresAt, TransferID: &transferId},
+				{EventId: eventId, Email: "cancelled@example.com", ExpiresAt: expiresAt},
+				{EventId: eventId, Email: "gone@example.com", ExpiresAt: expiresAt},
+			}, nil
+		},
+		GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+			return nil, nil
+		},
+	}
+
+	report, err := ReconcilePayments(context.Background(), eventId, repo, querier)
+	require.NoError(t, err)
+	assert.Equal(t, eventId, report.EventID)
+	assert.Equal(t, 7, report.NumPayments)
+	assert.Equal(t, len(regs), report.NumRegistrations)
+	assert.Equal(t, 4, report.NumIntents)
+
+	pending, cancelled := STATUS_PENDING, STATUS_CANCELLED
+	paid := STATUS_PAID
+	assert.Equal(t, []Mismatch{
+		{Kind: MISMATCH_PAID_NOT_MARKED_PAID, Email: "pending@example.com", PaymentID: "pi_pending", CheckoutSessionID: "cs_pi_pending", Amount: money.New(5000, "USD"), RegistrationStatus: &pending},
+		{Kind: MISMATCH_PAYMENT_WITHOUT_REGISTRATION, Email: "expired@example.com", PaymentID: "pi_expired", CheckoutSessionID: "cs_pi_expired", Amount: money.New(5000, "USD")},
+		{Kind: MISMATCH_MARKED_PAID_WITHOUT_PAYMENT, Email: "unpaid@example.com", RegistrationStatus: &paid},
+		{Kind: MISMATCH_ORPHAN_INTENT, Email: "cancelled@example.com", RegistrationStatus: &cancelled, IntentExpiresAt: &expiresAt},
+		{Kind: MISMATCH_ORPHAN_INTENT, Email: "gone@example.com", IntentExpiresAt: &expiresAt},
+	}, report.Mismatches)
+}
+
+func TestReconcilePaymentsTransferredToAnotherEvent(t *testing.T) {
+	eventId := uuid.New()
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			return GetAllRegistrationsResponse{}, nil
+		},
+		GetRegistrationIntentsForEventFunc: func(ctx context.Context, id uuid.UUID) ([]RegistrationIntent, error) {
+			return nil, nil
+		},
+		GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+			return []Registration{&IndividualRegistration{
+				EventID:   uuid.New(),
+				Email:     email,
+				Status:    STATUS_PAID,
+				Transfers: []Transfer{{FromEventID: eventId, FromEmail: email}},
+			}}, nil
+		},
+	}
+	querier := &metadataPaymentQuerier{Payments: []payments.Payment{checkoutPayment("pi_1", eventId, "jane@example.com")}}
+
+	report, err := ReconcilePayments(context.Background(), eventId, repo, querier)
+	require.NoError(t, err)
+	assert.Empty(t, report.Mismatches)
+}
+
+func TestFixPaymentMismatch(t *testing.T) {
+	eventId := uuid.New()
+
+	t.Run("marks a paid registration paid", func(t *testing.T) {
+		var saved Registration
+		var savedOutbox []OutboxItem
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: id, Email: email, Version: 2, Status: STATUS_PENDING}, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return RegistrationIntent{EventId: id, Email: email, PaymentSessionId: "cs_pi_2"}, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, reg Registration, outbox []OutboxItem) error {
+				saved = reg
+				savedOutbox = outbox
+				return nil
+			},
+		}
+		// The first is from an earlier checkout that expired
+		querier := &metadataPaymentQuerier{Payments: []payments.Payment{
+			checkoutPayment("pi_1", eventId, "jane@example.com"),
+			checkoutPayment("pi_2", eventId, "jane@example.com"),
+		}}
+
+		err := FixPaymentMismatch(context.Background(), FixPaymentMismatchParams{
+			EventID: eventId,
+			Email:   "jane@example.com",
+			Kind:    MISMATCH_PAID_NOT_MARKED_PAID,
+			FixedBy: "admin@example.com",
+		}, repo, querier)
+		require.NoError(t, err)
+		require.NotNil(t, saved)
+		assert.Equal(t, STATUS_PAID, saved.GetStatus())
+		assert.Equal(t, 3, saved.(*IndividualRegistration).Version)
+		assert.Equal(t, "admin@example.com", saved.GetStatusHistory()[0].ChangedBy)
+		assert.Contains(t, saved.GetStatusHistory()[0].Reason, "pi_2")
+		require.NotEmpty(t, savedOutbox)
+		assert.Equal(t, OUTBOX_CONFIRMATION_EMAIL, savedOutbox[0].Kind)
+	})
+
+	t.Run("no payment anymore", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: id, Email: email, Status: STATUS_PENDING}, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return RegistrationIntent{EventId: id, Email: email, PaymentSessionId: "cs_pi_1"}, nil
+			},
+		}
+
+		err := FixPaymentMismatch(context.Background(), FixPaymentMismatchParams{EventID: eventId, Email: "jane@example.com", Kind: MISMATCH_PAID_NOT_MARKED_PAID}, repo, &metadataPaymentQuerier{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_MISMATCH_NOT_FOUND, registrationErr.Reason)
+	})
+
+	t.Run("payment from another checkout", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: id, Email: email, Status: STATUS_PENDING}, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return RegistrationIntent{EventId: id, Email: email, PaymentSessionId: "cs_new"}, nil
+			},
+		}
+		querier := &metadataPaymentQuerier{Payments: []payments.Payment{checkoutPayment("pi_1", eventId, "jane@example.com")}}
+
+		err := FixPaymentMismatch(context.Background(), FixPaymentMismatchParams{EventID: eventId, Email: "jane@example.com", Kind: MISMATCH_PAID_NOT_MARKED_PAID}, repo, querier)
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_MISMATCH_NOT_FOUND, registrationErr.Reason)
+	})
+
+	t.Run("deletes an orphan intent", func(t *testing.T) {
+		intent := RegistrationIntent{EventId: eventId, Email: "jane@example.com", Version: 1}
+		var deleted RegistrationIntent
+		repo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return intent, nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return nil, NewRegistrationDoesNotExistsError("not found", nil)
+			},
+			DeleteRegistrationIntentFunc: func(ctx context.Context, i RegistrationIntent) error {
+				deleted = i
+				return nil
+			},
+		}
+
+		err := FixPaymentMismatch(context.Background(), FixPaymentMismatchParams{EventID: eventId, Email: "jane@example.com", Kind: MISMATCH_ORPHAN_INTENT}, repo, &metadataPaymentQuerier{})
+		require.NoError(t, err)
+		assert.Equal(t, intent, deleted)
+	})
+
+	t.Run("keeps an intent with a pending registration", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return RegistrationIntent{EventId: id, Email: email}, nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: id, Email: email, Status: STATUS_PENDING}, nil
+			},
+		}
+
+		err := FixPaymentMismatch(context.Background(), FixPaymentMismatchParams{EventID: eventId, Email: "jane@example.com", Kind: MISMATCH_ORPHAN_INTENT}, repo, &metadataPaymentQuerier{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_MISMATCH_NOT_FOUND, registrationErr.Reason)
+	})
+
+	t.Run("not fixable", func(t *testing.T) {
+		err := FixPaymentMismatch(context.Background(), FixPaymentMismatchParams{EventID: eventId, Email: "jane@example.com", Kind: MISMATCH_MARKED_PAID_WITHOUT_PAYMENT}, &mockRegistrationRepository{}, &metadataPaymentQuerier{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_MISMATCH_NOT_FIXABLE, registrationErr.Reason)
+	})
+}
+
+func TestReconcileRecentPayments(t *testing.T) {
+	now := time.Now()
+	recent := events.Event{ID: uuid.New(), EndTime: now.Add(-24 * time.Hour)}
+	old := events.Event{ID: uuid.New(), EndTime: now.Add(-60 * 24 * time.Hour)}
+	eventRepo := &mockEventRepository{
+		GetEventsFunc: func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+			return events.GetEventsResponse{Data: []events.Event{recent, old}}, nil
+		},
+	}
+	var reconciled []uuid.UUID
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			reconciled = append(reconciled, id)
+			return GetAllRegistrationsResponse{}, nil
+		},
+		GetRegistrationIntentsForEventFunc: func(ctx context.Context, id uuid.UUID) ([]RegistrationIntent, error) {
+			return nil, nil
+		},
+	}
+
+	reports, err := ReconcileRecentPayments(context.Background(), repo, eventRepo, &metadataPaymentQuerier{}, 30*24*time.Hour, now)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, recent.ID, reports[0].EventID)
+	assert.Equal(t, []uuid.UUID{recent.ID}, reconciled)
+}
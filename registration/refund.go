@@ -0,0 +1,397 @@
+package registration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Refunder gives back money for a payment that was made through the payment provider.
+type Refunder interface {
+	// RefundPayment refunds amount of the payment and returns the payment provider's ID for the refund.
+	// The idempotency key makes sure retrying the same refund only refunds it once, the payment provider
+	// remembers keys for a day.
+	RefundPayment(ctx context.Context, paymentId string, amount *money.Money, reason string, idempotencyKey string) (string, error)
+}
+
+type Refund struct {
+	ID               uuid.UUID
+	PaymentID        string
+	ProviderRefundID string
+	Amount           *money.Money
+	Reason           string
+	RefundedBy       string
+	RefundedAt       time.Time
+	// If the registration's spot at the event was given up with this refund
+	ReleasedSpot bool
+}
+
+type RefundParams struct {
+	EventID uuid.UUID
+	Email   string
+	// Amount to refund. Refunds everything that hasn't been refunded yet if nil.
+	Amount      *money.Money
+	Reason      string
+	RefundedBy  string
+	ReleaseSpot bool
+}
+
+// RefundRegistration gives back all or part of what was paid for a registration. A registration can have
+// been paid with several payments, like every player's share of a team's fee, so the amount is spread over
+// them and there's a refund for each payment that money was given back on.
+//
+// The refunds' idempotency keys only depend on what was already refunded, so retrying after the refunds
+// failed to be saved gives back the same refunds instead of refunding the payments again.
+func RefundRegistration(ctx context.Context, params RefundParams, registrationRepo Repository, eventRepo events.Repository, paymentQuerier payments.PaymentQuerier, refunder Refunder) (Registration, []Refund, error) {
+	ctx, span := tracer.Start(ctx, "RefundRegistration")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", params.EventID.String()))
+
+	reg, err := registrationRepo.GetRegistration(ctx, params.EventID, params.Email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	if reg.GetStatus() != STATUS_PAID && reg.GetStatus() != STATUS_REFUNDED {
+		err = NewRegistrationNotPaidError(fmt.Sprintf("Registration for %s has not been paid", params.Email))
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	regPayments, err := findRegistrationPayments(ctx, paymentQuerier, reg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	amount, err := refundAmount(regPayments, reg.GetRefunds(), params.Amount)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	releaseSpot := params.ReleaseSpot && !spotReleased(reg.GetRefunds())
+
+	var event events.Event
+	if releaseSpot {
+		event, err = eventRepo.GetEvent(ctx, params.EventID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", params.EventID), err)
+		}
+	}
+
+	refunds, err := refundPayments(ctx, refunder, reg, regPayments, amount, params.Reason, params.RefundedBy, time.Now(), releaseSpot)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, nil, err
+	}
+
+	for _, refund := range refunds {
+		reg.AddRefund(refund)
+	}
+	reg.BumpVersion()
+
+	// Partial refunds keep the registration paid, unless the spot is given up with them
+	if reg.GetStatus() == STATUS_PAID && (releaseSpot || fullyRefunded(regPayments, reg.GetRefunds())) {
+		err = reg.TransitionTo(STATUS_REFUNDED, params.RefundedBy, params.Reason)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, nil, err
+		}
+	}
+
+	if releaseSpot {
+		switch reg.Type() {
+		case events.BY_INDIVIDUAL:
+			unregisterIndividualFromEvent(&event)
+		case events.BY_TEAM:
+			unregisterTeamFromEvent(&event, reg.(*TeamRegistration))
+		}
+		event.Version++
+
+		err = registrationRepo.UpdateRegistrationWithEvent(ctx, reg, event)
+	} else {
+		err = registrationRepo.UpdateRegistration(ctx, reg)
+	}
+	if err != nil {
+		// The money was already given back at this point, so make sure whoever is looking
+		// at the error knows which refunds never got recorded.
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		providerIds := make([]string, 0, len(refunds))
+		for _, refund := range refunds {
+			providerIds = append(providerIds, refund.ProviderRefundID)
+		}
+		return nil, nil, NewFailedToWriteError(fmt.Sprintf("Refunds %q were issued but failed to be saved on the registration", providerIds), err)
+	}
+
+	return reg, refunds, nil
+}
+
+// findRegistrationPayments looks up every payment that was made for a registration using the metadata
+// that was put on its checkouts. That's the sign up's own checkout, or each player's share for a team
+// splitting the fee, plus the price difference of any transfer to a pricier event. Transferred
+// registrations were signed up for under the email and event they started with. Once personal data
+// is erased those emails are gone, so the payments recorded on the registration are found by ID.
+// Payments are returned oldest first.
+func findRegistrationPayments(ctx context.Context, paymentQuerier payments.PaymentQuerier, reg Registration) ([]payments.Payment, error) {
+	email, eventId := reg.GetEmail(), reg.GetEventID()
+	transfers := reg.GetTransfers()
+	if len(transfers) > 0 {
+		email, eventId = transfers[0].FromEmail, transfers[0].FromEventID
+	}
+
+	var found []payments.Payment
+	signUpPayments, err := listEventPayments(ctx, paymentQuerier, email, eventId)
+	if err != nil {
+		return nil, err
+	}
+	for _, payment := range signUpPayments {
+		if isRegistrationCheckout(payment) || isShareOf(payment, reg) {
+			found = append(found, payment)
+		}
+	}
+
+	for _, transfer := range transfers {
+		// The price difference is charged under where the registration was before it moved, but
+		// older transfers were charged under where it went
+		for _, key := range []struct {
+			email   string
+			eventId uuid.UUID
+		}{{transfer.FromEmail, transfer.FromEventID}, {transfer.ToEmail, transfer.ToEventID}} {
+			transferPayments, err := listEventPayments(ctx, paymentQuerier, key.email, key.eventId)
+			if err != nil {
+				return nil, err
+			}
+			for _, payment := range transferPayments {
+				// Someone transferred back to where they started would see the sign up's payments again
+				if !containsPayment(found, payment.ID) && payment.Metadata[transferIdKey] == transfer.ID.String() {
+					found = append(found, payment)
+				}
+			}
+		}
+	}
+
+	found, err = appendRecordedPayments(ctx, paymentQuerier, reg, eventId, found)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(found) == 0 {
+		return nil, NewPaymentNotFoundError(fmt.Sprintf("No payment found for %s", reg.GetEmail()))
+	}
+	slices.SortStableFunc(found, func(a, b payments.Payment) int { return a.Created.Compare(b.Created) })
+	return found, nil
+}
+
+func listEventPayments(ctx context.Context, paymentQuerier payments.PaymentQuerier, email string, eventId uuid.UUID) ([]payments.Payment, error) {
+	var found []payments.Payment
+	for payment, err := range paymentQuerier.ListCharges(ctx, payments.ChargeListParams{
+		MetadataFilter: map[string]string{
+			emailKey:    email,
+			eventIdKey:  eventId.String(),
+			itemTypeKey: itemTypeEvent,
+		},
+	}) {
+		if err != nil {
+			return nil, NewFailedToFetchError("Failed to fetch payments for registration", err)
+		}
+		found = append(found, payment)
+	}
+	return found, nil
+}
+
+// appendRecordedPayments adds the payments recorded on the registration that weren't already found.
+// They can only be listed by event, so that's the event it was signed up for and every event it was
+// transferred to.
+func appendRecordedPayments(ctx context.Context, paymentQuerier payments.PaymentQuerier, reg Registration, signUpEventId uuid.UUID, found []payments.Payment) ([]payments.Payment, error) {
+	paymentIds := reg.GetPaymentIDs()
+	if len(paymentIds) == 0 {
+		return found, nil
+	}
+
+	eventIds := []uuid.UUID{signUpEventId}
+	for _, transfer := range reg.GetTransfers() {
+		for _, eventId := range []uuid.UUID{transfer.FromEventID, transfer.ToEventID} {
+			if !slices.Contains(eventIds, eventId) {
+				eventIds = append(eventIds, eventId)
+			}
+		}
+	}
+	for _, eventId := range eventIds {
+		eventPayments, err := listPayments(ctx, paymentQuerier, map[string]string{
+			eventIdKey:  eventId.String(),
+			itemTypeKey: itemTypeEvent,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, payment := range eventPayments {
+			if slices.Contains(paymentIds, payment.ID) && !containsPayment(found, payment.ID) {
+				found = append(found, payment)
+			}
+		}
+	}
+	return found, nil
+}
+
+func containsPayment(found []payments.Payment, paymentId string) bool {
+	return slices.ContainsFunc(found, func(p payments.Payment) bool { return p.ID == paymentId })
+}
+
+// isShareOf is if the payment was for the shares of players on the team registration.
+func isShareOf(payment payments.Payment, reg Registration) bool {
+	shareTokens, ok := payment.Metadata[shareTokensKey]
+	team, isTeam := reg.(*TeamRegistration)
+	if !ok || !isTeam {
+		return false
+	}
+	for _, token := range strings.Split(shareTokens, ",") {
+		if slices.ContainsFunc(team.Players, func(p PlayerInfo) bool { return p.InviteToken != "" && p.InviteToken == token }) {
+			return true
+		}
+	}
+	return false
+}
+
+// refundPayments gives back amount, taking what's left on each payment in order until all of it is refunded.
+func refundPayments(ctx context.Context, refunder Refunder, reg Registration, regPayments []payments.Payment, amount *money.Money, reason string, refundedBy string, refundedAt time.Time, releasedSpot bool) ([]Refund, error) {
+	var refunds []Refund
+	left := amount
+	for _, payment := range regPayments {
+		if !left.IsPositive() {
+			break
+		}
+		remaining, err := remainingAmount(payment, reg.GetRefunds())
+		if err != nil {
+			return nil, err
+		}
+		if !remaining.IsPositive() {
+			continue
+		}
+
+		paymentAmount := left
+		if more, err := left.GreaterThan(remaining); err == nil && more {
+			paymentAmount = remaining
+		}
+
+		key := refundIdempotencyKey(reg, payment, remaining)
+		refund := Refund{
+			ID:           uuid.NewSHA1(uuid.NameSpaceOID, []byte(key)),
+			PaymentID:    payment.ID,
+			Amount:       paymentAmount,
+			Reason:       reason,
+			RefundedBy:   refundedBy,
+			RefundedAt:   refundedAt,
+			ReleasedSpot: releasedSpot,
+		}
+		refund.ProviderRefundID, err = refunder.RefundPayment(ctx, payment.ID, paymentAmount, reason, key)
+		if err != nil {
+			return nil, NewFailedToRefundError(fmt.Sprintf("Failed to refund payment %q", payment.ID), err)
+		}
+		refunds = append(refunds, refund)
+
+		left, err = left.Subtract(paymentAmount)
+		if err != nil {
+			return nil, NewInvalidRefundAmountError(fmt.Sprintf("Refund must be in the payment's currency %s", remaining.Currency().Code))
+		}
+	}
+	return refunds, nil
+}
+
+// refundIdempotencyKey only changes once a refund on the payment is saved, so retrying a refund that was
+// made but never recorded gets back the same refund from the payment provider.
+func refundIdempotencyKey(reg Registration, payment payments.Payment, remaining *money.Money) string {
+	refunded := payment.Amount.Amount() - remaining.Amount()
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s|%s|%s|%d", reg.GetEventID(), strings.ToLower(reg.GetEmail()), payment.ID, refunded))
+	return "refund-" + hex.EncodeToString(sum[:])
+}
+
+func refundAmount(regPayments []payments.Payment, previousRefunds []Refund, requested *money.Money) (*money.Money, error) {
+	remaining, err := totalRemainingAmount(regPayments, previousRefunds)
+	if err != nil {
+		return nil, err
+	}
+
+	if requested == nil {
+		if !remaining.IsPositive() {
+			return nil, NewInvalidRefundAmountError("Payment has already been fully refunded")
+		}
+		return remaining, nil
+	}
+
+	if !requested.IsPositive() {
+		return nil, NewInvalidRefundAmountError("Refund amount must be more than 0")
+	}
+	tooMuch, err := requested.GreaterThan(remaining)
+	if err != nil {
+		return nil, NewInvalidRefundAmountError(fmt.Sprintf("Refund must be in the payment's currency %s", remaining.Currency().Code))
+	}
+	if tooMuch {
+		return nil, NewInvalidRefundAmountError(fmt.Sprintf("Refund amount is more than the %s left on the payment", remaining.Display()))
+	}
+	return requested, nil
+}
+
+func remainingAmount(payment payments.Payment, refunds []Refund) (*money.Money, error) {
+	remaining := payment.Amount
+	for _, r := range refunds {
+		if r.PaymentID != payment.ID {
+			continue
+		}
+		var err error
+		remaining, err = remaining.Subtract(r.Amount)
+		if err != nil {
+			return nil, NewInvalidRefundAmountError("Previous refund is in a different currency than the payment")
+		}
+	}
+	return remaining, nil
+}
+
+// totalRemainingAmount is what's left to refund across all of the payments.
+func totalRemainingAmount(regPayments []payments.Payment, refunds []Refund) (*money.Money, error) {
+	var total *money.Money
+	for _, payment := range regPayments {
+		remaining, err := remainingAmount(payment, refunds)
+		if err != nil {
+			return nil, err
+		}
+		if total == nil {
+			total = remaining
+			continue
+		}
+		total, err = total.Add(remaining)
+		if err != nil {
+			return nil, NewInvalidRefundAmountError("Registration was paid in more than one currency")
+		}
+	}
+	return total, nil
+}
+
+func fullyRefunded(regPayments []payments.Payment, refunds []Refund) bool {
+	remaining, err := totalRemainingAmount(regPayments, refunds)
+	return err == nil && !remaining.IsPositive()
+}
+
+func spotReleased(refunds []Refund) bool {
+	return slices.ContainsFunc(refunds, func(r Refund) bool { return r.ReleasedSpot })
+}
@@ -0,0 +1,245 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPaymentQuerier struct {
+	payments.PaymentQuerier
+	Payments []payments.Payment
+}
+
+func (m *mockPaymentQuerier) ListCharges(ctx context.Context, params payments.ChargeListParams) iter.Seq2[payments.Payment, error] {
+	return func(yield func(payments.Payment, error) bool) {
+		for _, p := range m.Payments {
+			if !yield(p, nil) {
+				return
+			}
+		}
+	}
+}
+
+type mockRefunder struct {
+	refunded   []*money.Money
+	paymentIds []string
+	keys       []string
+}
+
+func (m *mockRefunder) RefundPayment(ctx context.Context, paymentId string, amount *money.Money, reason string, idempotencyKey string) (string, error) {
+	m.refunded = append(m.refunded, amount)
+	m.paymentIds = append(m.paymentIds, paymentId)
+	m.keys = append(m.keys, idempotencyKey)
+	return "re_123", nil
+}
+
+func TestRefundRegistration(t *testing.T) {
+	eventId := uuid.New()
+	paymentQuerier := &mockPaymentQuerier{
+		Payments: []payments.Payment{{ID: "ch_123", Amount: money.New(5000, "USD")}},
+	}
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Version: 3, NumTotalPlayers: 10}, nil
+		},
+	}
+	newRepo := func(reg Registration) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return reg, nil
+			},
+		}
+	}
+
+	t.Run("full refund", func(t *testing.T) {
+		refunder := &mockRefunder{}
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Version: 2, Status: STATUS_PAID, Email: "test@example.com"})
+
+		reg, refunds, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com", Reason: "sick", RefundedBy: "admin@example.com"}, repo, eventRepo, paymentQuerier, refunder)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		refund := refunds[0]
+		assert.Equal(t, int64(5000), refund.Amount.Amount())
+		assert.Equal(t, "re_123", refund.ProviderRefundID)
+		assert.Equal(t, "ch_123", refund.PaymentID)
+		assert.False(t, refund.ReleasedSpot)
+		assert.Len(t, reg.GetRefunds(), 1)
+		assert.Equal(t, 3, reg.(*IndividualRegistration).Version)
+		assert.Equal(t, STATUS_REFUNDED, reg.GetStatus())
+		assert.Equal(t, "admin@example.com", reg.GetStatusHistory()[0].ChangedBy)
+	})
+
+	t.Run("partial refund after previous refund", func(t *testing.T) {
+		refunder := &mockRefunder{}
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Version: 2, Status: STATUS_PAID, Email: "test@example.com", Refunds: []Refund{
+			{PaymentID: "ch_123", Amount: money.New(3000, "USD")},
+		}})
+
+		_, _, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com", Amount: money.New(2500, "USD")}, repo, eventRepo, paymentQuerier, refunder)
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_REFUND_AMOUNT, registrationErr.Reason)
+		assert.Empty(t, refunder.refunded)
+
+		_, refunds, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com"}, repo, eventRepo, paymentQuerier, refunder)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		assert.Equal(t, int64(2000), refunds[0].Amount.Amount())
+	})
+
+	t.Run("release spot updates the event", func(t *testing.T) {
+		refunder := &mockRefunder{}
+		var updatedEvent events.Event
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Version: 2, Status: STATUS_PAID, Email: "test@example.com"})
+		repo.UpdateRegistrationWithEventFunc = func(ctx context.Context, registration Registration, event events.Event) error {
+			updatedEvent = event
+			return nil
+		}
+
+		_, refunds, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com", ReleaseSpot: true}, repo, eventRepo, paymentQuerier, refunder)
+		require.NoError(t, err)
+		require.Len(t, refunds, 1)
+		assert.True(t, refunds[0].ReleasedSpot)
+		assert.Equal(t, 9, updatedEvent.NumTotalPlayers)
+		assert.Equal(t, 4, updatedEvent.Version)
+	})
+
+	t.Run("not paid", func(t *testing.T) {
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Email: "test@example.com"})
+
+		_, _, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com"}, repo, eventRepo, paymentQuerier, &mockRefunder{})
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_REGISTRATION_NOT_PAID, registrationErr.Reason)
+	})
+
+	t.Run("no payment found", func(t *testing.T) {
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Status: STATUS_PAID, Email: "test@example.com"})
+
+		_, _, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com"}, repo, eventRepo, &mockPaymentQuerier{}, &mockRefunder{})
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_PAYMENT_NOT_FOUND, registrationErr.Reason)
+	})
+
+	t.Run("retry after the refund failed to be saved", func(t *testing.T) {
+		refunder := &mockRefunder{}
+		repo := &mockRegistrationRepository{
+			// Nothing was saved, so every try starts from the same registration
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: eventId, Version: 2, Status: STATUS_PAID, Email: "test@example.com"}, nil
+			},
+			UpdateRegistrationFunc: func(ctx context.Context, registration Registration) error {
+				return errors.New("version conflict")
+			},
+		}
+
+		_, _, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com"}, repo, eventRepo, paymentQuerier, refunder)
+		require.Error(t, err)
+		_, _, err = RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com"}, repo, eventRepo, paymentQuerier, refunder)
+		require.Error(t, err)
+
+		// The payment provider hands back the first refund instead of making another
+		require.Len(t, refunder.keys, 2)
+		assert.Equal(t, refunder.keys[0], refunder.keys[1])
+	})
+
+	t.Run("next refund gets a new key", func(t *testing.T) {
+		first, second := &mockRefunder{}, &mockRefunder{}
+		_, _, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com", Amount: money.New(1000, "USD")}, newRepo(&IndividualRegistration{EventID: eventId, Status: STATUS_PAID, Email: "test@example.com"}), eventRepo, paymentQuerier, first)
+		require.NoError(t, err)
+		_, _, err = RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "test@example.com", Amount: money.New(1000, "USD")}, newRepo(&IndividualRegistration{EventID: eventId, Status: STATUS_PAID, Email: "test@example.com", Refunds: []Refund{
+			{PaymentID: "ch_123", Amount: money.New(1000, "USD")},
+		}}), eventRepo, paymentQuerier, second)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.keys[0], second.keys[0])
+	})
+
+	t.Run("team splitting the fee is refunded across its shares", func(t *testing.T) {
+		refunder := &mockRefunder{}
+		now := time.Now()
+		teamPayments := &mockPaymentQuerier{Payments: []payments.Payment{
+			{ID: "ch_share_2", Amount: money.New(2500, "USD"), Created: now.Add(time.Minute), Metadata: map[string]string{shareTokensKey: "token-2"}},
+			{ID: "ch_share_1", Amount: money.New(2500, "USD"), Created: now, Metadata: map[string]string{shareTokensKey: "token-1"}},
+			// Not a share of anyone on the roster
+			{ID: "ch_other_share", Amount: money.New(2500, "USD"), Created: now, Metadata: map[string]string{shareTokensKey: "token-9"}},
+			{ID: "ch_transfer", Amount: money.New(1000, "USD"), Created: now, Metadata: map[string]string{transferIdKey: uuid.NewString()}},
+		}}
+		repo := newRepo(&TeamRegistration{EventID: eventId, Status: STATUS_PAID, CaptainEmail: "captain@example.com", SplitPayment: true, Players: []PlayerInfo{
+			{InviteToken: "token-1"},
+			{InviteToken: "token-2"},
+		}})
+
+		reg, refunds, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "captain@example.com", Amount: money.New(3000, "USD")}, repo, eventRepo, teamPayments, refunder)
+		require.NoError(t, err)
+
+		require.Len(t, refunds, 2)
+		assert.Equal(t, []string{"ch_share_1", "ch_share_2"}, refunder.paymentIds)
+		assert.Equal(t, int64(2500), refunds[0].Amount.Amount())
+		assert.Equal(t, int64(500), refunds[1].Amount.Amount())
+		assert.Equal(t, STATUS_PAID, reg.GetStatus())
+	})
+
+	t.Run("transfer price difference is refunded with the sign up", func(t *testing.T) {
+		refunder := &mockRefunder{}
+		transferId := uuid.New()
+		transferred := &mockPaymentQuerier{Payments: []payments.Payment{
+			{ID: "ch_signup", Amount: money.New(5000, "USD")},
+			{ID: "ch_transfer", Amount: money.New(1000, "USD"), Metadata: map[string]string{transferIdKey: transferId.String()}},
+			{ID: "ch_other_transfer", Amount: money.New(1000, "USD"), Metadata: map[string]string{transferIdKey: uuid.NewString()}},
+		}}
+		repo := newRepo(&IndividualRegistration{EventID: eventId, Status: STATUS_PAID, Email: "new@example.com", Transfers: []Transfer{
+			{ID: transferId, FromEmail: "old@example.com", FromEventID: uuid.New(), ToEmail: "new@example.com", ToEventID: eventId},
+		}})
+
+		reg, refunds, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: "new@example.com"}, repo, eventRepo, transferred, refunder)
+		require.NoError(t, err)
+
+		require.Len(t, refunds, 2)
+		assert.ElementsMatch(t, []string{"ch_signup", "ch_transfer"}, refunder.paymentIds)
+		assert.Equal(t, STATUS_REFUNDED, reg.GetStatus())
+	})
+
+	t.Run("refunded after the registrant's personal data is erased", func(t *testing.T) {
+		querier := &metadataPaymentQuerier{Payments: []payments.Payment{
+			checkoutPayment("pi_jane", eventId, "jane@example.com"),
+			checkoutPayment("pi_other", eventId, "other@example.com"),
+		}}
+		var anonymized Registration
+		eraseRepo := &mockRegistrationRepository{
+			GetRegistrationsByEmailFunc: func(ctx context.Context, email string) ([]Registration, error) {
+				return []Registration{&IndividualRegistration{EventID: eventId, Version: 2, Status: STATUS_PAID, Email: "jane@example.com"}}, nil
+			},
+			GetRegistrationIntentFunc: noIntent,
+			AnonymizeRegistrationFunc: func(ctx context.Context, from Registration, to Registration) error {
+				anonymized = to
+				return nil
+			},
+		}
+		forgetter := &mockSubscriberForgetter{
+			ForgetSubscriberFunc: func(ctx context.Context, email string) error { return nil },
+		}
+		_, err := ErasePersonalData(context.Background(), "jane@example.com", "admin@example.com", eraseRepo, querier, forgetter, time.Now())
+		require.NoError(t, err)
+		require.NotNil(t, anonymized)
+
+		refunder := &mockRefunder{}
+		reg, refunds, err := RefundRegistration(context.Background(), RefundParams{EventID: eventId, Email: anonymized.GetEmail()}, newRepo(anonymized), eventRepo, querier, refunder)
+		require.NoError(t, err)
+
+		require.Len(t, refunds, 1)
+		assert.Equal(t, []string{"pi_jane"}, refunder.paymentIds)
+		assert.Equal(t, STATUS_REFUNDED, reg.GetStatus())
+	})
+}
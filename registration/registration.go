@@ -5,27 +5,61 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
 	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/event-registration/redact"
 	"github.com/International-Combat-Archery-Alliance/payments"
 	"github.com/google/uuid"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 )
 
-var tracer = otel.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/registration")
+var tracer = redact.Tracer("github.com/International-Combat-Archery-Alliance/event-registration/registration")
 
 type Repository interface {
-	CreateRegistration(ctx context.Context, registration Registration, event events.Event) error
+	// CreateRegistration creates the registration and updates the event in one transaction, along with
+	// the outbox items for what should happen after.
+	CreateRegistration(ctx context.Context, registration Registration, event events.Event, outbox []OutboxItem) error
+	// CreateRegistrations creates all of the registrations and updates the event in one transaction.
+	CreateRegistrations(ctx context.Context, registrations []Registration, event events.Event) error
 	GetRegistration(ctx context.Context, eventId uuid.UUID, email string) (Registration, error)
 	GetRegistrationIntent(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error)
+	GetExpiredRegistrationIntents(ctx context.Context, eventId uuid.UUID, now time.Time) ([]RegistrationIntent, error)
+	GetRegistrationIntentsForEvent(ctx context.Context, eventId uuid.UUID) ([]RegistrationIntent, error)
 	GetAllRegistrationsForEvent(ctx context.Context, eventId uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error)
+	// GetRegistrationsByEmail gets every registration, across all events, that has email on it as the
+	// registrant or one of the team's players.
+	GetRegistrationsByEmail(ctx context.Context, email string) ([]Registration, error)
 	CreateRegistrationWithPayment(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error
-	UpdateRegistrationToPaid(ctx context.Context, registration Registration) error
+	// ReplaceExpiredRegistrationWithPayment saves the registration and intent in place of the ones of a checkout that
+	// expired before they were cleaned up, updating the event in the same transaction.
+	ReplaceExpiredRegistrationWithPayment(ctx context.Context, expired Registration, expiredIntent RegistrationIntent, registration Registration, intent RegistrationIntent, event events.Event) error
+	// UpdateRegistrationToPaid saves the registration and deletes its intent in one transaction, along with
+	// the outbox items for what should happen after.
+	UpdateRegistrationToPaid(ctx context.Context, registration Registration, outbox []OutboxItem) error
+	UpdateRegistration(ctx context.Context, registration Registration) error
+	UpdateRegistrationWithEvent(ctx context.Context, registration Registration, event events.Event) error
 	DeleteExpiredRegistration(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error
+	// DeleteRegistrationIntent deletes only the intent, for when it was left behind without its registration.
+	DeleteRegistrationIntent(ctx context.Context, intent RegistrationIntent) error
+	// TransferRegistration replaces from with to in one transaction, since moving to another
+	// email or event changes the registration's key. Every event in eventUpdates is saved with it.
+	TransferRegistration(ctx context.Context, from Registration, to Registration, eventUpdates []events.Event) error
+	// CreateTransferWithPayment saves the registration holding a pending transfer along with the
+	// intent for the checkout of its price difference.
+	CreateTransferWithPayment(ctx context.Context, registration Registration, intent RegistrationIntent) error
+	// TransferRegistrationToPaid is TransferRegistration for a pending transfer that was paid, deleting
+	// from's intent and adding the outbox items in the same transaction.
+	TransferRegistrationToPaid(ctx context.Context, from Registration, to Registration, eventUpdates []events.Event, outbox []OutboxItem) error
+	// DeleteExpiredTransfer saves the registration with its pending transfer dropped and deletes the
+	// intent for the transfer's expired checkout.
+	DeleteExpiredTransfer(ctx context.Context, registration Registration, intent RegistrationIntent) error
+	// AnonymizeRegistration replaces from with to, a copy with someone's personal info taken out.
+	// The key changes if the registrant was erased. Emails no longer on it are removed from the index.
+	AnonymizeRegistration(ctx context.Context, from Registration, to Registration) error
 }
 
 type GetAllRegistrationsResponse struct {
@@ -38,22 +72,69 @@ type Registration interface {
 	GetEventID() uuid.UUID
 	GetEmail() string
 	Type() events.RegistrationType
-	SetToPaid()
+	GetStatus() Status
+	GetStatusHistory() []StatusChange
+	// TransitionTo moves the registration to a new status, recording who or what made the change.
+	// Fails if the registration can not go from its current status to the new one.
+	TransitionTo(status Status, changedBy string, reason string) error
 	BumpVersion()
+	GetVersion() int
+	GetRefunds() []Refund
+	AddRefund(refund Refund)
+	// GetDuplicatePlayerEmails is the emails on the registration that an admin allowed to also
+	// be on another registration for the event.
+	GetDuplicatePlayerEmails() []string
+	AllowDuplicatePlayer(email string)
+	GetTransfers() []Transfer
+	AddTransfer(transfer Transfer)
+	// GetPendingTransfer is the transfer waiting on its price difference to be paid, or nil if there isn't one.
+	GetPendingTransfer() *PendingTransfer
+	SetPendingTransfer(pending *PendingTransfer)
+	// GetOfflinePayment is how an admin recorded the registration being paid for outside of
+	// the payment provider, or nil if it wasn't.
+	GetOfflinePayment() *OfflinePayment
+	SetOfflinePayment(payment OfflinePayment)
+	// GetPaymentIDs is the payments made for the registration, only recorded when the personal
+	// data they are looked up by is erased.
+	GetPaymentIDs() []string
+	SetPaymentIDs(ids []string)
+	// Admin notes and tags are for organizers only and are never shown to the registrant.
+	GetAdminNotes() []AdminNote
+	AddAdminNote(note AdminNote)
+	// RemoveAdminNote returns false if the registration has no note with the ID.
+	RemoveAdminNote(id uuid.UUID) bool
+	GetTags() []string
+	AddTag(tag string)
+	// RemoveTag returns false if the registration isn't tagged with tag.
+	RemoveTag(tag string) bool
 }
 
 var _ Registration = &IndividualRegistration{}
 
 type IndividualRegistration struct {
-	ID           uuid.UUID
-	Version      int
-	EventID      uuid.UUID
-	RegisteredAt time.Time
-	HomeCity     string
-	Paid         bool
-	Email        string
-	PlayerInfo   PlayerInfo
-	Experience   ExperienceLevel
+	ID            uuid.UUID
+	Version       int
+	EventID       uuid.UUID
+	RegisteredAt  time.Time
+	HomeCity      string
+	Status        Status
+	StatusHistory []StatusChange
+	Email         string
+	PlayerInfo    PlayerInfo
+	Experience    ExperienceLevel
+	Refunds       []Refund
+	Transfers     []Transfer
+	// Only set while a transfer is waiting on its price difference to be paid
+	PendingTransfer *PendingTransfer
+	// Only set if an admin recorded a payment made outside of the payment provider
+	OfflinePayment *OfflinePayment
+	// Only set once personal data is erased, since the payments can't be found by email after that
+	PaymentIDs []string
+	// Internal to organizers, never shown to the registrant
+	AdminNotes []AdminNote
+	Tags       []string
+
+	DuplicatePlayerEmails []string
 }
 
 func (r IndividualRegistration) GetEventID() uuid.UUID {
@@ -68,26 +149,133 @@ func (r IndividualRegistration) Type() events.RegistrationType {
 	return events.BY_INDIVIDUAL
 }
 
-func (r *IndividualRegistration) SetToPaid() {
-	r.Paid = true
+func (r IndividualRegistration) GetStatus() Status {
+	return r.Status
+}
+
+func (r IndividualRegistration) GetStatusHistory() []StatusChange {
+	return r.StatusHistory
+}
+
+func (r *IndividualRegistration) TransitionTo(status Status, changedBy string, reason string) error {
+	return transitionStatus(&r.Status, &r.StatusHistory, status, changedBy, reason)
 }
 
 func (r *IndividualRegistration) BumpVersion() {
 	r.Version++
 }
 
+func (r IndividualRegistration) GetVersion() int {
+	return r.Version
+}
+
+func (r IndividualRegistration) GetRefunds() []Refund {
+	return r.Refunds
+}
+
+func (r *IndividualRegistration) AddRefund(refund Refund) {
+	r.Refunds = append(r.Refunds, refund)
+}
+
+func (r IndividualRegistration) GetDuplicatePlayerEmails() []string {
+	return r.DuplicatePlayerEmails
+}
+
+func (r *IndividualRegistration) AllowDuplicatePlayer(email string) {
+	r.DuplicatePlayerEmails = appendDuplicatePlayerEmail(r.DuplicatePlayerEmails, email)
+}
+
+func (r IndividualRegistration) GetTransfers() []Transfer {
+	return r.Transfers
+}
+
+func (r *IndividualRegistration) AddTransfer(transfer Transfer) {
+	r.Transfers = append(r.Transfers, transfer)
+}
+
+func (r IndividualRegistration) GetPendingTransfer() *PendingTransfer {
+	return r.PendingTransfer
+}
+
+func (r *IndividualRegistration) SetPendingTransfer(pending *PendingTransfer) {
+	r.PendingTransfer = pending
+}
+
+func (r IndividualRegistration) GetOfflinePayment() *OfflinePayment {
+	return r.OfflinePayment
+}
+
+func (r *IndividualRegistration) SetOfflinePayment(payment OfflinePayment) {
+	r.OfflinePayment = &payment
+}
+
+func (r IndividualRegistration) GetPaymentIDs() []string {
+	return r.PaymentIDs
+}
+
+func (r *IndividualRegistration) SetPaymentIDs(ids []string) {
+	r.PaymentIDs = ids
+}
+
+func (r IndividualRegistration) GetAdminNotes() []AdminNote {
+	return r.AdminNotes
+}
+
+func (r *IndividualRegistration) AddAdminNote(note AdminNote) {
+	r.AdminNotes = append(r.AdminNotes, note)
+}
+
+func (r *IndividualRegistration) RemoveAdminNote(id uuid.UUID) bool {
+	var removed bool
+	r.AdminNotes, removed = removeAdminNote(r.AdminNotes, id)
+	return removed
+}
+
+func (r IndividualRegistration) GetTags() []string {
+	return r.Tags
+}
+
+func (r *IndividualRegistration) AddTag(tag string) {
+	r.Tags = appendTag(r.Tags, tag)
+}
+
+func (r *IndividualRegistration) RemoveTag(tag string) bool {
+	var removed bool
+	r.Tags, removed = removeTag(r.Tags, tag)
+	return removed
+}
+
 var _ Registration = &TeamRegistration{}
 
 type TeamRegistration struct {
-	ID           uuid.UUID
-	Version      int
-	EventID      uuid.UUID
-	RegisteredAt time.Time
-	HomeCity     string
-	Paid         bool
-	TeamName     string
-	CaptainEmail string
-	Players      []PlayerInfo
+	ID            uuid.UUID
+	Version       int
+	EventID       uuid.UUID
+	RegisteredAt  time.Time
+	HomeCity      string
+	Status        Status
+	StatusHistory []StatusChange
+	TeamName      string
+	CaptainEmail  string
+	Players       []PlayerInfo
+	Refunds       []Refund
+	Transfers     []Transfer
+	// Only set while a transfer is waiting on its price difference to be paid
+	PendingTransfer *PendingTransfer
+	// Only set if an admin recorded a payment made outside of the payment provider
+	OfflinePayment *OfflinePayment
+	// Only set once personal data is erased, since the payments can't be found by email after that
+	PaymentIDs []string
+	// Internal to organizers, never shown to the registrant
+	AdminNotes []AdminNote
+	Tags       []string
+
+	DuplicatePlayerEmails []string
+
+	// If every player on the roster pays their own share of the team fee
+	SplitPayment bool
+	// When unpaid shares expire, only set if SplitPayment is true
+	PaymentDeadline *time.Time
 }
 
 func (r TeamRegistration) GetEventID() uuid.UUID {
@@ -102,22 +290,117 @@ func (r TeamRegistration) Type() events.RegistrationType {
 	return events.BY_TEAM
 }
 
-func (r *TeamRegistration) SetToPaid() {
-	r.Paid = true
+func (r TeamRegistration) GetStatus() Status {
+	return r.Status
+}
+
+func (r TeamRegistration) GetStatusHistory() []StatusChange {
+	return r.StatusHistory
+}
+
+func (r *TeamRegistration) TransitionTo(status Status, changedBy string, reason string) error {
+	return transitionStatus(&r.Status, &r.StatusHistory, status, changedBy, reason)
 }
 
 func (r *TeamRegistration) BumpVersion() {
 	r.Version++
 }
 
+func (r TeamRegistration) GetVersion() int {
+	return r.Version
+}
+
+func (r TeamRegistration) GetRefunds() []Refund {
+	return r.Refunds
+}
+
+func (r *TeamRegistration) AddRefund(refund Refund) {
+	r.Refunds = append(r.Refunds, refund)
+}
+
+func (r TeamRegistration) GetDuplicatePlayerEmails() []string {
+	return r.DuplicatePlayerEmails
+}
+
+func (r *TeamRegistration) AllowDuplicatePlayer(email string) {
+	r.DuplicatePlayerEmails = appendDuplicatePlayerEmail(r.DuplicatePlayerEmails, email)
+}
+
+func (r TeamRegistration) GetTransfers() []Transfer {
+	return r.Transfers
+}
+
+func (r *TeamRegistration) AddTransfer(transfer Transfer) {
+	r.Transfers = append(r.Transfers, transfer)
+}
+
+func (r TeamRegistration) GetPendingTransfer() *PendingTransfer {
+	return r.PendingTransfer
+}
+
+func (r *TeamRegistration) SetPendingTransfer(pending *PendingTransfer) {
+	r.PendingTransfer = pending
+}
+
+func (r TeamRegistration) GetOfflinePayment() *OfflinePayment {
+	return r.OfflinePayment
+}
+
+func (r *TeamRegistration) SetOfflinePayment(payment OfflinePayment) {
+	r.OfflinePayment = &payment
+}
+
+func (r TeamRegistration) GetPaymentIDs() []string {
+	return r.PaymentIDs
+}
+
+func (r *TeamRegistration) SetPaymentIDs(ids []string) {
+	r.PaymentIDs = ids
+}
+
+func (r TeamRegistration) GetAdminNotes() []AdminNote {
+	return r.AdminNotes
+}
+
+func (r *TeamRegistration) AddAdminNote(note AdminNote) {
+	r.AdminNotes = append(r.AdminNotes, note)
+}
+
+func (r *TeamRegistration) RemoveAdminNote(id uuid.UUID) bool {
+	var removed bool
+	r.AdminNotes, removed = removeAdminNote(r.AdminNotes, id)
+	return removed
+}
+
+func (r TeamRegistration) GetTags() []string {
+	return r.Tags
+}
+
+func (r *TeamRegistration) AddTag(tag string) {
+	r.Tags = appendTag(r.Tags, tag)
+}
+
+func (r *TeamRegistration) RemoveTag(tag string) bool {
+	var removed bool
+	r.Tags, removed = removeTag(r.Tags, tag)
+	return removed
+}
+
 const (
 	emailKey      = "EMAIL"
 	eventIdKey    = "EVENT_ID"
 	itemTypeKey   = "ITEM_TYPE"
 	itemTypeEvent = "event_registration"
+	// Comma separated invite tokens of the players whose shares a checkout pays for
+	shareTokensKey = "SHARE_TOKENS"
+	// ID of the transfer a checkout pays the price difference for
+	transferIdKey = "TRANSFER_ID"
 )
 
-func AttemptRegistration(ctx context.Context, registrationRequest Registration, eventRepo events.Repository, registrationRepo Repository) (Registration, events.Event, error) {
+// AttemptRegistration signs up for a free event. When the event requires email verification, the
+// registration only holds its spot until the returned intent expires, unless the link with its
+// VerificationToken is clicked first. Otherwise the intent is empty.
+func AttemptRegistration(ctx context.Context, registrationRequest Registration, eventRepo events.Repository, registrationRepo Repository) (Registration, RegistrationIntent, events.Event, error) {
 	ctx, span := tracer.Start(ctx, "AttemptRegistration")
 	defer span.End()
 
@@ -131,42 +414,95 @@ func AttemptRegistration(ctx context.Context, registrationRequest Registration,
 		if errors.As(err, &eventErr) {
 			switch eventErr.Reason {
 			case events.REASON_EVENT_DOES_NOT_EXIST:
-				return nil, events.Event{}, NewAssociatedEventDoesNotExistError(fmt.Sprintf("Event does not exist with ID %q", eventId), err)
+				return nil, RegistrationIntent{}, events.Event{}, NewAssociatedEventDoesNotExistError(fmt.Sprintf("Event does not exist with ID %q", eventId), err)
 			}
 		}
 
-		return nil, events.Event{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
+		return nil, RegistrationIntent{}, events.Event{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
 	}
 
+	regIntent, err := signUpWithoutPayment(ctx, &event, registrationRequest, registrationRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, RegistrationIntent{}, events.Event{}, err
+	}
+	return registrationRequest, regIntent, event, nil
+}
+
+// signUpWithoutPayment saves a sign up that has nothing to pay, holding it for email verification
+// if the event requires it.
+func signUpWithoutPayment(ctx context.Context, event *events.Event, registrationRequest Registration, registrationRepo Repository) (RegistrationIntent, error) {
+	var err error
 	switch registrationRequest.Type() {
 	case events.BY_INDIVIDUAL:
-		err = registerIndividualAsFreeAgent(&event, registrationRequest.(*IndividualRegistration))
+		err = registerIndividualAsFreeAgent(event, registrationRequest.(*IndividualRegistration), false)
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, events.Event{}, err
+			return RegistrationIntent{}, err
 		}
 	case events.BY_TEAM:
-		err = registerTeam(&event, registrationRequest.(*TeamRegistration))
+		teamReg := registrationRequest.(*TeamRegistration)
+		if teamReg.SplitPayment {
+			return RegistrationIntent{}, NewSplitPaymentNotAllowedError("There is nothing to split on a free sign up")
+		}
+		err = registerTeam(event, teamReg, false)
 		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, events.Event{}, err
+			return RegistrationIntent{}, err
 		}
 	default:
-		return nil, events.Event{}, NewUnknownRegistrationTypeError(fmt.Sprintf("Unknown registration type: %d", registrationRequest.Type()))
+		return RegistrationIntent{}, NewUnknownRegistrationTypeError(fmt.Sprintf("Unknown registration type: %d", registrationRequest.Type()))
 	}
 
 	event.Version++
-	err = registrationRepo.CreateRegistration(ctx, registrationRequest, event)
+	if event.RequireEmailVerification {
+		regIntent := RegistrationIntent{
+			EventId:           event.ID,
+			Version:           1,
+			VerificationToken: uuid.NewString(),
+			Email:             registrationRequest.GetEmail(),
+			ExpiresAt:         time.Now().Add(emailVerificationHold),
+		}
+		err = registrationRepo.CreateRegistrationWithPayment(ctx, registrationRequest, regIntent, *event)
+		if err != nil {
+			return RegistrationIntent{}, err
+		}
+		return regIntent, nil
+	}
+
+	// Paid types signed up for without a checkout stay pending, like they always have
+	if isFreeSignUp(*event, registrationRequest.Type()) {
+		err = registrationRequest.TransitionTo(STATUS_CONFIRMED, StatusChangedBySystem, "Free sign up")
+		if err != nil {
+			return RegistrationIntent{}, err
+		}
+	}
+
+	err = registrationRepo.CreateRegistration(ctx, registrationRequest, *event, signedUpOutbox(registrationRequest, time.Now()))
 	if err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, err.Error())
-		return nil, events.Event{}, err
+		return RegistrationIntent{}, err
 	}
-	return registrationRequest, event, nil
+	return RegistrationIntent{}, nil
+}
+
+// isFreeSignUp is whether signing up as the given type costs nothing. Events can mix free and paid types.
+func isFreeSignUp(event events.Event, regType events.RegistrationType) bool {
+	price, err := registrationPrice(event, regType)
+	return err == nil && (price == nil || price.IsZero())
 }
 
+// checkoutItemName is what the registrant sees they're paying for, the event's own description if it has one.
+func checkoutItemName(event events.Event, signUp string) string {
+	if event.CheckoutItemDescription != nil {
+		return *event.CheckoutItemDescription
+	}
+	return fmt.Sprintf("%s %s", event.Name, signUp)
+}
+
+// RegisterWithPayment starts a checkout for a sign up, holding its spot until the returned intent expires.
+//
+// Sign ups that cost nothing skip the checkout and are saved the same way as AttemptRegistration does,
+// so the client secret is empty and the intent is only set while waiting on email verification.
+// The checkout's hold, item and adaptive pricing come from the event's settings.
 func RegisterWithPayment(ctx context.Context, registrationRequest Registration, eventRepo events.Repository, registrationRepo Repository, checkoutManager payments.CheckoutManager, paymentReturnURL string) (Registration, RegistrationIntent, string, events.Event, error) {
 	ctx, span := tracer.Start(ctx, "RegisterWithPayment")
 	defer span.End()
@@ -188,31 +524,83 @@ func RegisterWithPayment(ctx context.Context, registrationRequest Registration,
 		return nil, RegistrationIntent{}, "", events.Event{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
 	}
 
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	if isFreeSignUp(event, registrationRequest.Type()) {
+		span.SetAttributes(attribute.Bool("free", true))
+
+		regIntent, err := signUpWithoutPayment(ctx, &event, registrationRequest, registrationRepo)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, RegistrationIntent{}, "", events.Event{}, err
+		}
+		return registrationRequest, regIntent, "", event, nil
+	}
+
+	var expired *startedCheckout
+	if teamReg, ok := registrationRequest.(*TeamRegistration); !ok || !teamReg.SplitPayment {
+		started, err := getStartedCheckout(ctx, registrationRepo, eventId, registrationRequest.GetEmail())
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, RegistrationIntent{}, "", events.Event{}, err
+		}
+		if started != nil && time.Now().Before(started.intent.ExpiresAt) {
+			if started.intent.ClientSecret == "" || started.reg.Type() != registrationRequest.Type() {
+				err = NewRegistrationAlreadyExistsError("A checkout for this email is still in progress", nil)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, RegistrationIntent{}, "", events.Event{}, err
+			}
+			// They left the checkout and came back, so they pick up where they left off
+			span.SetAttributes(attribute.Bool("resumed", true))
+			return started.reg, started.intent, started.intent.ClientSecret, event, nil
+		} else if started != nil {
+			// The checkout expired but wasn't cleaned up yet, so this sign up takes its spot
+			switch started.reg.Type() {
+			case events.BY_INDIVIDUAL:
+				unregisterIndividualFromEvent(&event)
+			case events.BY_TEAM:
+				unregisterTeamFromEvent(&event, started.reg.(*TeamRegistration))
+			}
+			expired = started
+		}
+	}
+
 	var paymentItem payments.Item
 	switch registrationRequest.Type() {
 	case events.BY_INDIVIDUAL:
 		regReq := registrationRequest.(*IndividualRegistration)
-		err = registerIndividualAsFreeAgent(&event, regReq)
+		err = registerIndividualAsFreeAgent(&event, regReq, false)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			return nil, RegistrationIntent{}, "", events.Event{}, err
 		}
 		paymentItem = payments.Item{
-			Name:     fmt.Sprintf("%s Free Agent Sign Up", event.Name),
+			Name:     checkoutItemName(event, "Free Agent Sign Up"),
 			Quantity: 1,
 			Price:    event.RegistrationOptions[slices.IndexFunc(event.RegistrationOptions, func(v events.EventRegistrationOption) bool { return v.RegType == events.BY_INDIVIDUAL })].Price,
 		}
 	case events.BY_TEAM:
 		regReq := registrationRequest.(*TeamRegistration)
-		err = registerTeam(&event, regReq)
+		if regReq.SplitPayment {
+			regIntent, clientSecret, err := registerTeamWithSplitPayment(ctx, &event, regReq, registrationRepo, checkoutManager, paymentReturnURL)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, RegistrationIntent{}, "", events.Event{}, err
+			}
+			return regReq, regIntent, clientSecret, event, nil
+		}
+		err = registerTeam(&event, regReq, false)
 		if err != nil {
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			return nil, RegistrationIntent{}, "", events.Event{}, err
 		}
 		paymentItem = payments.Item{
-			Name:     fmt.Sprintf("%s Team Sign Up", event.Name),
+			Name:     checkoutItemName(event, "Team Sign Up"),
 			Quantity: 1,
 			Price:    event.RegistrationOptions[slices.IndexFunc(event.RegistrationOptions, func(v events.EventRegistrationOption) bool { return v.RegType == events.BY_TEAM })].Price,
 		}
@@ -221,10 +609,8 @@ func RegisterWithPayment(ctx context.Context, registrationRequest Registration,
 		return nil, RegistrationIntent{}, "", events.Event{}, NewUnknownRegistrationTypeError(fmt.Sprintf("Unknown registration type: %d", registrationRequest.Type()))
 	}
 
-	span.SetAttributes(attribute.String("event_id", eventId.String()))
-
 	checkoutInfo, err := checkoutManager.CreateCheckout(ctx, payments.CheckoutParams{
-		SessionAliveDuration: ptr.Duration(30 * time.Minute),
+		SessionAliveDuration: ptr.Duration(event.CheckoutHold()),
 		ReturnURL:            paymentReturnURL,
 		Items: []payments.Item{
 			paymentItem,
@@ -234,7 +620,7 @@ func RegisterWithPayment(ctx context.Context, registrationRequest Registration,
 			eventIdKey:  event.ID.String(),
 			itemTypeKey: itemTypeEvent,
 		},
-		AllowAdaptivePricing: true,
+		AllowAdaptivePricing: !event.DisableAdaptivePricing,
 		CustomerEmail:        ptr.String(registrationRequest.GetEmail()),
 	})
 	if err != nil {
@@ -247,12 +633,22 @@ func RegisterWithPayment(ctx context.Context, registrationRequest Registration,
 		EventId:          eventId,
 		Version:          1,
 		PaymentSessionId: checkoutInfo.SessionId,
+		ClientSecret:     checkoutInfo.ClientSecret,
 		Email:            registrationRequest.GetEmail(),
-		ExpiresAt:        time.Now().Add(30 * time.Minute),
+		ExpiresAt:        time.Now().Add(event.CheckoutHold()),
 	}
 
 	event.Version++
-	err = registrationRepo.CreateRegistrationWithPayment(ctx, registrationRequest, regIntent, event)
+	if expired != nil {
+		// Newer versions than the expired ones, so a cleanup still holding those can't delete these
+		for registrationRequest.GetVersion() <= expired.reg.GetVersion() {
+			registrationRequest.BumpVersion()
+		}
+		regIntent.Version = expired.intent.Version + 1
+		err = registrationRepo.ReplaceExpiredRegistrationWithPayment(ctx, expired.reg, expired.intent, registrationRequest, regIntent, event)
+	} else {
+		err = registrationRepo.CreateRegistrationWithPayment(ctx, registrationRequest, regIntent, event)
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -261,13 +657,50 @@ func RegisterWithPayment(ctx context.Context, registrationRequest Registration,
 	return registrationRequest, regIntent, checkoutInfo.ClientSecret, event, nil
 }
 
-func ConfirmRegistrationPayment(ctx context.Context, payload []byte, signature string, registrationRepo Repository, eventRepo events.Repository, checkoutManager payments.CheckoutManager) (Registration, error) {
+// startedCheckout is a checkout someone already started for an event, with the registration holding its spot.
+type startedCheckout struct {
+	reg    Registration
+	intent RegistrationIntent
+}
+
+// getStartedCheckout gets the checkout the email already started for the event, or nil if there isn't
+// a pending one.
+func getStartedCheckout(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, email string) (*startedCheckout, error) {
+	intent, err := registrationRepo.GetRegistrationIntent(ctx, eventId, email)
+	if registrationDoesNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if intent.IsEmailVerification() {
+		return nil, nil
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if registrationDoesNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if reg.GetStatus() != STATUS_PENDING {
+		return nil, nil
+	}
+	return &startedCheckout{reg: reg, intent: intent}, nil
+}
+
+// ConfirmRegistrationPayment handles a checkout webhook event from the payment provider, marking what was paid
+// for as paid or cleaning up after an expired checkout.
+//
+// Each event is only handled once, since the payment provider can deliver it more than once. A failed event is
+// released again so the provider's retry of it still runs, and a claim that is never completed or released
+// lapses after webhookEventClaimLease. Handling is safe to repeat, so a retry that takes over a lapsed claim
+// after the state change was already saved doesn't change anything.
+func ConfirmRegistrationPayment(ctx context.Context, payload []byte, signature string, registrationRepo Repository, eventRepo events.Repository, webhookEventRepo WebhookEventRepository, checkoutManager payments.CheckoutManager) (Registration, error) {
 	ctx, span := tracer.Start(ctx, "ConfirmRegistrationPayment")
 	defer span.End()
 
 	metadata, checkoutErr := checkoutManager.ConfirmCheckout(ctx, payload, signature)
-	isExpired := checkoutIsExpired(checkoutErr)
-	if checkoutErr != nil && !isExpired {
+	if checkoutErr != nil && !checkoutIsExpired(checkoutErr) {
 		span.RecordError(checkoutErr)
 		span.SetStatus(codes.Error, checkoutErr.Error())
 		return nil, checkoutErr
@@ -298,18 +731,93 @@ func ConfirmRegistrationPayment(ctx context.Context, payload []byte, signature s
 		return nil, NewInvalidPaymentMetadata("Event ID is not a valid UUID", err)
 	}
 
-	if !isExpired {
-		return setRegistrationToPaid(ctx, registrationRepo, eventId, email)
+	delivery, err := parseWebhookDelivery(payload)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	claimedAt := time.Now()
+	err = webhookEventRepo.ClaimWebhookEvent(ctx, delivery.ID, claimedAt, claimedAt.Add(webhookEventClaimLease))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	reg, err := applyCheckoutEvent(ctx, registrationRepo, eventRepo, metadata, checkoutErr, eventId, email, delivery.Data.Object.ID)
+	// Not cancellable so the claim is still completed or released when the failure was the context timing out
+	if err == nil || keepsWebhookClaim(err) {
+		completeErr := webhookEventRepo.CompleteWebhookEvent(context.WithoutCancel(ctx), delivery.ID, time.Now())
+		if completeErr != nil {
+			// The claim lapses, and the provider's retry finds everything already done
+			err = errors.Join(err, completeErr)
+		}
 	} else {
-		reg, err := deleteExpiredRegistration(ctx, registrationRepo, eventRepo, eventId, email)
-		if err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			return nil, err
+		releaseErr := webhookEventRepo.ReleaseWebhookEvent(context.WithoutCancel(ctx), delivery.ID)
+		if releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return reg, err
+}
+
+func applyCheckoutEvent(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, metadata map[string]string, checkoutErr error, eventId uuid.UUID, email string, checkoutSessionId string) (Registration, error) {
+	isExpired := checkoutIsExpired(checkoutErr)
+
+	if transferId, ok := metadata[transferIdKey]; ok {
+		if isExpired {
+			// The registration was never moved, so dropping the pending transfer is all there is to do
+			reg, err := cancelPendingTransfer(ctx, registrationRepo, eventId, email, checkoutSessionId)
+			if err != nil {
+				return nil, err
+			}
+			return reg, NewTransferCheckoutExpiredError("Transfer checkout expired", checkoutErr)
 		}
-		span.SetStatus(codes.Error, "Registration expired")
-		return reg, NewRegistrationExpiredError("Registration expired", checkoutErr)
+		return completePendingTransfer(ctx, registrationRepo, eventRepo, eventId, email, transferId)
+	}
+
+	if shareTokens, ok := metadata[shareTokensKey]; ok {
+		if isExpired {
+			// The team keeps its spot until the payment deadline, so there is nothing to clean up
+			return nil, NewShareCheckoutExpiredError("Share checkout expired", checkoutErr)
+		}
+		return setSharesToPaid(ctx, registrationRepo, eventId, email, strings.Split(shareTokens, ","))
+	}
+
+	if !isExpired {
+		return setRegistrationToPaid(ctx, registrationRepo, eventId, email)
+	}
+
+	reg, err := expireCheckout(ctx, registrationRepo, eventRepo, eventId, email, checkoutSessionId)
+	if err != nil {
+		return nil, err
 	}
+	return reg, NewRegistrationExpiredError("Registration expired", checkoutErr)
+}
+
+// expireCheckout gives up a registration's spot after its checkout expired. Expiry events can arrive late,
+// after the registration was paid or a new checkout was started for it, so only the checkout the
+// registration is still waiting on can expire it.
+func expireCheckout(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, eventId uuid.UUID, email string, checkoutSessionId string) (Registration, error) {
+	regIntent, err := registrationRepo.GetRegistrationIntent(ctx, eventId, email)
+	if err == nil && regIntent.PaymentSessionId != checkoutSessionId {
+		return nil, NewStaleWebhookEventError(fmt.Sprintf("Checkout %s expired but the registration is waiting on checkout %s", checkoutSessionId, regIntent.PaymentSessionId))
+	} else if err != nil && !registrationDoesNotExist(err) {
+		return nil, err
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err == nil && reg.GetStatus() != STATUS_PENDING {
+		return nil, NewStaleWebhookEventError(fmt.Sprintf("Checkout %s expired but the registration is already %s", checkoutSessionId, reg.GetStatus()))
+	}
+
+	return deleteExpiredRegistration(ctx, registrationRepo, eventRepo, eventId, email, StatusChangedByPaymentProvider)
 }
 
 func setRegistrationToPaid(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, email string) (Registration, error) {
@@ -317,14 +825,22 @@ func setRegistrationToPaid(ctx context.Context, registrationRepo Repository, eve
 	if err != nil {
 		return nil, err
 	}
+	// The payment provider can send the same event more than once
+	if reg.GetStatus() == STATUS_PAID {
+		return reg, nil
+	}
+
+	err = reg.TransitionTo(STATUS_PAID, StatusChangedByPaymentProvider, "Checkout completed")
+	if err != nil {
+		return nil, err
+	}
 	reg.BumpVersion()
-	reg.SetToPaid()
 
-	err = registrationRepo.UpdateRegistrationToPaid(ctx, reg)
+	err = registrationRepo.UpdateRegistrationToPaid(ctx, reg, signedUpOutbox(reg, time.Now()))
 	return reg, err
 }
 
-func deleteExpiredRegistration(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, eventId uuid.UUID, email string) (Registration, error) {
+func deleteExpiredRegistration(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, eventId uuid.UUID, email string, changedBy string) (Registration, error) {
 	reg, getRegErr := registrationRepo.GetRegistration(ctx, eventId, email)
 	regIntent, getRegIntentErr := registrationRepo.GetRegistrationIntent(ctx, eventId, email)
 	if getRegErr != nil && getRegIntentErr != nil {
@@ -357,6 +873,16 @@ func deleteExpiredRegistration(ctx context.Context, registrationRepo Repository,
 		unregisterTeamFromEvent(&event, reg.(*TeamRegistration))
 	}
 
+	reason := "Checkout expired"
+	if regIntent.IsEmailVerification() {
+		reason = "Email was never verified"
+	}
+	// The registration gets deleted to free up the email, this just lets the caller see why
+	err = reg.TransitionTo(STATUS_EXPIRED, changedBy, reason)
+	if err != nil {
+		return nil, err
+	}
+
 	event.Version++
 	err = registrationRepo.DeleteExpiredRegistration(ctx, reg, regIntent, event)
 	if err != nil {
@@ -366,12 +892,71 @@ func deleteExpiredRegistration(ctx context.Context, registrationRepo Repository,
 	return reg, nil
 }
 
-func registerIndividualAsFreeAgent(event *events.Event, reg *IndividualRegistration) error {
+// GetAllRegistrations pages through every registration for an event.
+func GetAllRegistrations(ctx context.Context, registrationRepo Repository, eventId uuid.UUID) ([]Registration, error) {
+	ctx, span := tracer.Start(ctx, "GetAllRegistrations")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	var allRegs []Registration
+	var cursor *string
+	for {
+		resp, err := registrationRepo.GetAllRegistrationsForEvent(ctx, eventId, 50, cursor)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return nil, err
+		}
+		allRegs = append(allRegs, resp.Data...)
+
+		if !resp.HasNextPage {
+			return allRegs, nil
+		}
+		cursor = resp.Cursor
+	}
+}
+
+// Emails is every email on a registration: the registrant's and, for teams, every player's that has one.
+func Emails(reg Registration) []string {
+	emails := []string{strings.ToLower(reg.GetEmail())}
+	addEmail := func(email *string) {
+		if email == nil || *email == "" {
+			return
+		}
+		lower := strings.ToLower(*email)
+		if !slices.Contains(emails, lower) {
+			emails = append(emails, lower)
+		}
+	}
+
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		addEmail(r.PlayerInfo.Email)
+	case *TeamRegistration:
+		for _, player := range r.Players {
+			addEmail(player.Email)
+		}
+	}
+	return emails
+}
+
+func appendDuplicatePlayerEmail(emails []string, email string) []string {
+	email = strings.ToLower(email)
+	if slices.Contains(emails, email) {
+		return emails
+	}
+	return append(emails, email)
+}
+
+// registerIndividualAsFreeAgent adds the registration to the event's counts. Only admins get to
+// ignore the registration close time.
+func registerIndividualAsFreeAgent(event *events.Event, reg *IndividualRegistration, ignoreCloseTime bool) error {
 	if !slices.ContainsFunc(event.RegistrationOptions, func(v events.EventRegistrationOption) bool { return v.RegType == events.BY_INDIVIDUAL }) {
 		return NewNotAllowedToSignUpAsTypeError(events.BY_INDIVIDUAL)
 	}
 
-	if reg.RegisteredAt.After(event.RegistrationCloseTime) {
+	if !ignoreCloseTime && reg.RegisteredAt.After(event.RegistrationCloseTime) {
 		return NewRegistrationIsClosedError(event.RegistrationCloseTime)
 	}
 
@@ -384,12 +969,14 @@ func unregisterIndividualFromEvent(event *events.Event) {
 	event.NumTotalPlayers--
 }
 
-func registerTeam(event *events.Event, reg *TeamRegistration) error {
+// registerTeam adds the team to the event's counts and sets up its roster invites. Only admins
+// get to ignore the registration close time.
+func registerTeam(event *events.Event, reg *TeamRegistration, ignoreCloseTime bool) error {
 	if !slices.ContainsFunc(event.RegistrationOptions, func(v events.EventRegistrationOption) bool { return v.RegType == events.BY_TEAM }) {
 		return NewNotAllowedToSignUpAsTypeError(events.BY_TEAM)
 	}
 
-	if reg.RegisteredAt.After(event.RegistrationCloseTime) {
+	if !ignoreCloseTime && reg.RegisteredAt.After(event.RegistrationCloseTime) {
 		return NewRegistrationIsClosedError(event.RegistrationCloseTime)
 	}
 
@@ -399,6 +986,8 @@ func registerTeam(event *events.Event, reg *TeamRegistration) error {
 		return NewTeamSizeNotAllowedError(teamSize, event.AllowedTeamSizeRange.Min, event.AllowedTeamSizeRange.Max)
 	}
 
+	prepareRosterInvites(reg)
+
 	event.NumTeams++
 	event.NumTotalPlayers += teamSize
 	event.NumRosteredPlayers += teamSize
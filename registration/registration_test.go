@@ -3,35 +3,65 @@ package registration
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
 	"github.com/International-Combat-Archery-Alliance/payments"
 	"github.com/Rhymond/go-money"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type mockEventRepository struct {
 	events.Repository
-	GetEventFunc func(ctx context.Context, id uuid.UUID) (events.Event, error)
+	GetEventFunc    func(ctx context.Context, id uuid.UUID) (events.Event, error)
+	GetEventsFunc   func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error)
+	UpdateEventFunc func(ctx context.Context, event events.Event) error
 }
 
 func (m *mockEventRepository) GetEvent(ctx context.Context, id uuid.UUID) (events.Event, error) {
 	return m.GetEventFunc(ctx, id)
 }
 
+func (m *mockEventRepository) GetEvents(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+	return m.GetEventsFunc(ctx, limit, cursor)
+}
+
+func (m *mockEventRepository) UpdateEvent(ctx context.Context, event events.Event) error {
+	return m.UpdateEventFunc(ctx, event)
+}
+
 var _ Repository = &mockRegistrationRepository{}
 
 type mockRegistrationRepository struct {
-	CreateRegistrationFunc            func(ctx context.Context, registration Registration, event events.Event) error
-	GetAllRegistrationsForEventFunc   func(ctx context.Context, eventId uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error)
-	CreateRegistrationWithPaymentFunc func(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error
-	GetRegistrationFunc               func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error)
-	UpdateRegistrationToPaidFunc      func(ctx context.Context, registration Registration) error
-	DeleteExpiredRegistrationFunc     func(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error
-	GetRegistrationIntentFunc         func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error)
+	CreateRegistrationFunc                    func(ctx context.Context, registration Registration, event events.Event, outbox []OutboxItem) error
+	CreateRegistrationsFunc                   func(ctx context.Context, registrations []Registration, event events.Event) error
+	GetAllRegistrationsForEventFunc           func(ctx context.Context, eventId uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error)
+	GetRegistrationsByEmailFunc               func(ctx context.Context, email string) ([]Registration, error)
+	CreateRegistrationWithPaymentFunc         func(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error
+	GetRegistrationFunc                       func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error)
+	UpdateRegistrationToPaidFunc              func(ctx context.Context, registration Registration, outbox []OutboxItem) error
+	DeleteExpiredRegistrationFunc             func(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error
+	GetRegistrationIntentFunc                 func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error)
+	GetExpiredRegistrationIntentsFunc         func(ctx context.Context, eventId uuid.UUID, now time.Time) ([]RegistrationIntent, error)
+	GetRegistrationIntentsForEventFunc        func(ctx context.Context, eventId uuid.UUID) ([]RegistrationIntent, error)
+	DeleteRegistrationIntentFunc              func(ctx context.Context, intent RegistrationIntent) error
+	UpdateRegistrationFunc                    func(ctx context.Context, registration Registration) error
+	UpdateRegistrationWithEventFunc           func(ctx context.Context, registration Registration, event events.Event) error
+	TransferRegistrationFunc                  func(ctx context.Context, from Registration, to Registration, eventUpdates []events.Event) error
+	AnonymizeRegistrationFunc                 func(ctx context.Context, from Registration, to Registration) error
+	CreateTransferWithPaymentFunc             func(ctx context.Context, reg Registration, intent RegistrationIntent) error
+	TransferRegistrationToPaidFunc            func(ctx context.Context, from Registration, to Registration, eventUpdates []events.Event, outbox []OutboxItem) error
+	DeleteExpiredTransferFunc                 func(ctx context.Context, reg Registration, intent RegistrationIntent) error
+	ReplaceExpiredRegistrationWithPaymentFunc func(ctx context.Context, expired Registration, expiredIntent RegistrationIntent, registration Registration, intent RegistrationIntent, event events.Event) error
+}
+
+func (m *mockRegistrationRepository) ReplaceExpiredRegistrationWithPayment(ctx context.Context, expired Registration, expiredIntent RegistrationIntent, registration Registration, intent RegistrationIntent, event events.Event) error {
+	return m.ReplaceExpiredRegistrationWithPaymentFunc(ctx, expired, expiredIntent, registration, intent, event)
 }
 
 func (m *mockRegistrationRepository) DeleteExpiredRegistration(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error {
@@ -42,14 +72,34 @@ func (m *mockRegistrationRepository) GetRegistrationIntent(ctx context.Context,
 	return m.GetRegistrationIntentFunc(ctx, eventId, email)
 }
 
-func (m *mockRegistrationRepository) CreateRegistration(ctx context.Context, registration Registration, event events.Event) error {
-	return m.CreateRegistrationFunc(ctx, registration, event)
+func (m *mockRegistrationRepository) GetExpiredRegistrationIntents(ctx context.Context, eventId uuid.UUID, now time.Time) ([]RegistrationIntent, error) {
+	return m.GetExpiredRegistrationIntentsFunc(ctx, eventId, now)
+}
+
+func (m *mockRegistrationRepository) GetRegistrationIntentsForEvent(ctx context.Context, eventId uuid.UUID) ([]RegistrationIntent, error) {
+	return m.GetRegistrationIntentsForEventFunc(ctx, eventId)
+}
+
+func (m *mockRegistrationRepository) DeleteRegistrationIntent(ctx context.Context, intent RegistrationIntent) error {
+	return m.DeleteRegistrationIntentFunc(ctx, intent)
+}
+
+func (m *mockRegistrationRepository) CreateRegistration(ctx context.Context, registration Registration, event events.Event, outbox []OutboxItem) error {
+	return m.CreateRegistrationFunc(ctx, registration, event, outbox)
+}
+
+func (m *mockRegistrationRepository) CreateRegistrations(ctx context.Context, registrations []Registration, event events.Event) error {
+	return m.CreateRegistrationsFunc(ctx, registrations, event)
 }
 
 func (m *mockRegistrationRepository) GetAllRegistrationsForEvent(ctx context.Context, eventId uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
 	return m.GetAllRegistrationsForEventFunc(ctx, eventId, limit, cursor)
 }
 
+func (m *mockRegistrationRepository) GetRegistrationsByEmail(ctx context.Context, email string) ([]Registration, error) {
+	return m.GetRegistrationsByEmailFunc(ctx, email)
+}
+
 func (m *mockRegistrationRepository) CreateRegistrationWithPayment(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error {
 	if m.CreateRegistrationWithPaymentFunc != nil {
 		return m.CreateRegistrationWithPaymentFunc(ctx, registration, intent, event)
@@ -64,9 +114,58 @@ func (m *mockRegistrationRepository) GetRegistration(ctx context.Context, eventI
 	return nil, nil
 }
 
-func (m *mockRegistrationRepository) UpdateRegistrationToPaid(ctx context.Context, registration Registration) error {
+func (m *mockRegistrationRepository) UpdateRegistrationToPaid(ctx context.Context, registration Registration, outbox []OutboxItem) error {
 	if m.UpdateRegistrationToPaidFunc != nil {
-		return m.UpdateRegistrationToPaidFunc(ctx, registration)
+		return m.UpdateRegistrationToPaidFunc(ctx, registration, outbox)
+	}
+	return nil
+}
+
+func (m *mockRegistrationRepository) UpdateRegistration(ctx context.Context, registration Registration) error {
+	if m.UpdateRegistrationFunc != nil {
+		return m.UpdateRegistrationFunc(ctx, registration)
+	}
+	return nil
+}
+
+func (m *mockRegistrationRepository) UpdateRegistrationWithEvent(ctx context.Context, registration Registration, event events.Event) error {
+	if m.UpdateRegistrationWithEventFunc != nil {
+		return m.UpdateRegistrationWithEventFunc(ctx, registration, event)
+	}
+	return nil
+}
+
+func (m *mockRegistrationRepository) TransferRegistration(ctx context.Context, from Registration, to Registration, eventUpdates []events.Event) error {
+	if m.TransferRegistrationFunc != nil {
+		return m.TransferRegistrationFunc(ctx, from, to, eventUpdates)
+	}
+	return nil
+}
+
+func (m *mockRegistrationRepository) CreateTransferWithPayment(ctx context.Context, reg Registration, intent RegistrationIntent) error {
+	if m.CreateTransferWithPaymentFunc != nil {
+		return m.CreateTransferWithPaymentFunc(ctx, reg, intent)
+	}
+	return nil
+}
+
+func (m *mockRegistrationRepository) TransferRegistrationToPaid(ctx context.Context, from Registration, to Registration, eventUpdates []events.Event, outbox []OutboxItem) error {
+	if m.TransferRegistrationToPaidFunc != nil {
+		return m.TransferRegistrationToPaidFunc(ctx, from, to, eventUpdates, outbox)
+	}
+	return nil
+}
+
+func (m *mockRegistrationRepository) DeleteExpiredTransfer(ctx context.Context, reg Registration, intent RegistrationIntent) error {
+	if m.DeleteExpiredTransferFunc != nil {
+		return m.DeleteExpiredTransferFunc(ctx, reg, intent)
+	}
+	return nil
+}
+
+func (m *mockRegistrationRepository) AnonymizeRegistration(ctx context.Context, from Registration, to Registration) error {
+	if m.AnonymizeRegistrationFunc != nil {
+		return m.AnonymizeRegistrationFunc(ctx, from, to)
 	}
 	return nil
 }
@@ -83,7 +182,7 @@ func TestAttemptRegistration(t *testing.T) {
 			EventID: uuid.New(),
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -101,7 +200,7 @@ func TestAttemptRegistration(t *testing.T) {
 			EventID: uuid.New(),
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -121,7 +220,7 @@ func TestAttemptRegistration(t *testing.T) {
 			},
 		}
 		registrationRepo := &mockRegistrationRepository{
-			CreateRegistrationFunc: func(ctx context.Context, registration Registration, evt events.Event) error {
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, evt events.Event, outbox []OutboxItem) error {
 				assert.Equal(t, event.Version+1, evt.Version)
 				return nil
 			},
@@ -130,7 +229,7 @@ func TestAttemptRegistration(t *testing.T) {
 			EventID: eventID,
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.NoError(t, err)
 	})
 
@@ -148,7 +247,7 @@ func TestAttemptRegistration(t *testing.T) {
 			},
 		}
 		registrationRepo := &mockRegistrationRepository{
-			CreateRegistrationFunc: func(ctx context.Context, registration Registration, evt events.Event) error {
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, evt events.Event, outbox []OutboxItem) error {
 				assert.Equal(t, event.Version+1, evt.Version)
 				return nil
 			},
@@ -158,10 +257,59 @@ func TestAttemptRegistration(t *testing.T) {
 			Players: []PlayerInfo{{}},
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.NoError(t, err)
 	})
 
+	t.Run("free sign up is confirmed", func(t *testing.T) {
+		eventID := uuid.New()
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{
+					ID:                  eventID,
+					Version:             1,
+					RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(0, "USD")}},
+				}, nil
+			},
+		}
+		var saved Registration
+		registrationRepo := &mockRegistrationRepository{
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, evt events.Event, outbox []OutboxItem) error {
+				saved = registration
+				return nil
+			},
+		}
+
+		reg, _, _, err := AttemptRegistration(context.Background(), &IndividualRegistration{EventID: eventID}, eventRepo, registrationRepo)
+		require.NoError(t, err)
+		assert.Equal(t, STATUS_CONFIRMED, reg.GetStatus())
+		assert.Equal(t, STATUS_CONFIRMED, saved.GetStatus())
+		require.Len(t, saved.GetStatusHistory(), 1)
+		assert.Equal(t, StatusChangedBySystem, saved.GetStatusHistory()[0].ChangedBy)
+	})
+
+	t.Run("paid type without a checkout stays pending", func(t *testing.T) {
+		eventID := uuid.New()
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return events.Event{
+					ID:                  eventID,
+					Version:             1,
+					RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(5000, "USD")}},
+				}, nil
+			},
+		}
+		registrationRepo := &mockRegistrationRepository{
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, evt events.Event, outbox []OutboxItem) error {
+				return nil
+			},
+		}
+
+		reg, _, _, err := AttemptRegistration(context.Background(), &IndividualRegistration{EventID: eventID}, eventRepo, registrationRepo)
+		require.NoError(t, err)
+		assert.Equal(t, STATUS_PENDING, reg.GetStatus())
+	})
+
 	t.Run("individual registration not allowed", func(t *testing.T) {
 		eventID := uuid.New()
 		event := events.Event{
@@ -178,7 +326,7 @@ func TestAttemptRegistration(t *testing.T) {
 			EventID: eventID,
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -201,7 +349,7 @@ func TestAttemptRegistration(t *testing.T) {
 			EventID: eventID,
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -226,7 +374,7 @@ func TestAttemptRegistration(t *testing.T) {
 			Players: []PlayerInfo{{}},
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -256,7 +404,7 @@ func TestAttemptRegistration(t *testing.T) {
 			},
 		}
 
-		_, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
+		_, _, _, err := AttemptRegistration(context.Background(), registrationRequest, eventRepo, registrationRepo)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -265,11 +413,11 @@ func TestAttemptRegistration(t *testing.T) {
 }
 
 type mockRegistration struct {
-	GetEventIDFunc  func() uuid.UUID
-	GetEmailFunc    func() string
-	TypeFunc        func() events.RegistrationType
-	SetToPaidFunc   func()
-	BumpVersionFunc func()
+	GetEventIDFunc   func() uuid.UUID
+	GetEmailFunc     func() string
+	TypeFunc         func() events.RegistrationType
+	TransitionToFunc func(status Status, changedBy string, reason string) error
+	BumpVersionFunc  func()
 }
 
 func (m *mockRegistration) GetEventID() uuid.UUID {
@@ -284,10 +432,19 @@ func (m *mockRegistration) Type() events.RegistrationType {
 	return m.TypeFunc()
 }
 
-func (m *mockRegistration) SetToPaid() {
-	if m.SetToPaidFunc != nil {
-		m.SetToPaidFunc()
+func (m *mockRegistration) GetStatus() Status {
+	return STATUS_PENDING
+}
+
+func (m *mockRegistration) GetStatusHistory() []StatusChange {
+	return nil
+}
+
+func (m *mockRegistration) TransitionTo(status Status, changedBy string, reason string) error {
+	if m.TransitionToFunc != nil {
+		return m.TransitionToFunc(status, changedBy, reason)
 	}
+	return nil
 }
 
 func (m *mockRegistration) BumpVersion() {
@@ -296,6 +453,66 @@ func (m *mockRegistration) BumpVersion() {
 	}
 }
 
+func (m *mockRegistration) GetVersion() int {
+	return 0
+}
+
+func (m *mockRegistration) GetRefunds() []Refund {
+	return nil
+}
+
+func (m *mockRegistration) AddRefund(refund Refund) {}
+
+func (m *mockRegistration) GetDuplicatePlayerEmails() []string {
+	return nil
+}
+
+func (m *mockRegistration) AllowDuplicatePlayer(email string) {}
+
+func (m *mockRegistration) GetTransfers() []Transfer {
+	return nil
+}
+
+func (m *mockRegistration) AddTransfer(transfer Transfer) {}
+
+func (m *mockRegistration) GetPendingTransfer() *PendingTransfer {
+	return nil
+}
+
+func (m *mockRegistration) SetPendingTransfer(pending *PendingTransfer) {}
+
+func (m *mockRegistration) GetOfflinePayment() *OfflinePayment {
+	return nil
+}
+
+func (m *mockRegistration) SetOfflinePayment(payment OfflinePayment) {}
+
+func (m *mockRegistration) GetPaymentIDs() []string {
+	return nil
+}
+
+func (m *mockRegistration) SetPaymentIDs(ids []string) {}
+
+func (m *mockRegistration) GetAdminNotes() []AdminNote {
+	return nil
+}
+
+func (m *mockRegistration) AddAdminNote(note AdminNote) {}
+
+func (m *mockRegistration) RemoveAdminNote(id uuid.UUID) bool {
+	return false
+}
+
+func (m *mockRegistration) GetTags() []string {
+	return nil
+}
+
+func (m *mockRegistration) AddTag(tag string) {}
+
+func (m *mockRegistration) RemoveTag(tag string) bool {
+	return false
+}
+
 func TestRegisterIndividualAsFreeAgent(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		event := &events.Event{
@@ -303,7 +520,7 @@ func TestRegisterIndividualAsFreeAgent(t *testing.T) {
 		}
 		reg := &IndividualRegistration{}
 
-		err := registerIndividualAsFreeAgent(event, reg)
+		err := registerIndividualAsFreeAgent(event, reg, false)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, event.NumTotalPlayers)
 	})
@@ -314,7 +531,7 @@ func TestRegisterIndividualAsFreeAgent(t *testing.T) {
 		}
 		reg := &IndividualRegistration{}
 
-		err := registerIndividualAsFreeAgent(event, reg)
+		err := registerIndividualAsFreeAgent(event, reg, false)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -330,7 +547,7 @@ func TestRegisterIndividualAsFreeAgent(t *testing.T) {
 			RegisteredAt: time.Now(),
 		}
 
-		err := registerIndividualAsFreeAgent(event, reg)
+		err := registerIndividualAsFreeAgent(event, reg, false)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -348,7 +565,7 @@ func TestRegisterTeam(t *testing.T) {
 			Players: []PlayerInfo{{}},
 		}
 
-		err := registerTeam(event, reg)
+		err := registerTeam(event, reg, false)
 		assert.NoError(t, err)
 		assert.Equal(t, 1, event.NumTeams)
 		assert.Equal(t, 1, event.NumTotalPlayers)
@@ -361,7 +578,7 @@ func TestRegisterTeam(t *testing.T) {
 		}
 		reg := &TeamRegistration{}
 
-		err := registerTeam(event, reg)
+		err := registerTeam(event, reg, false)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -377,7 +594,7 @@ func TestRegisterTeam(t *testing.T) {
 			Players: []PlayerInfo{{}},
 		}
 
-		err := registerTeam(event, reg)
+		err := registerTeam(event, reg, false)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -393,7 +610,7 @@ func TestRegisterTeam(t *testing.T) {
 			Players: []PlayerInfo{{}, {}},
 		}
 
-		err := registerTeam(event, reg)
+		err := registerTeam(event, reg, false)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -411,7 +628,7 @@ func TestRegisterTeam(t *testing.T) {
 			Players:      []PlayerInfo{{}},
 		}
 
-		err := registerTeam(event, reg)
+		err := registerTeam(event, reg, false)
 		assert.Error(t, err)
 		var registrationErr *Error
 		assert.True(t, errors.As(err, &registrationErr))
@@ -445,6 +662,37 @@ func (m *mockCheckoutManager) ConfirmCheckout(ctx context.Context, payload []byt
 	}, nil
 }
 
+type mockWebhookEventRepository struct {
+	ClaimWebhookEventFunc    func(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error
+	CompleteWebhookEventFunc func(ctx context.Context, webhookEventId string, completedAt time.Time) error
+	ReleaseWebhookEventFunc  func(ctx context.Context, webhookEventId string) error
+}
+
+func (m *mockWebhookEventRepository) ClaimWebhookEvent(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error {
+	if m.ClaimWebhookEventFunc != nil {
+		return m.ClaimWebhookEventFunc(ctx, webhookEventId, claimedAt, leasedUntil)
+	}
+	return nil
+}
+
+func (m *mockWebhookEventRepository) CompleteWebhookEvent(ctx context.Context, webhookEventId string, completedAt time.Time) error {
+	if m.CompleteWebhookEventFunc != nil {
+		return m.CompleteWebhookEventFunc(ctx, webhookEventId, completedAt)
+	}
+	return nil
+}
+
+func (m *mockWebhookEventRepository) ReleaseWebhookEvent(ctx context.Context, webhookEventId string) error {
+	if m.ReleaseWebhookEventFunc != nil {
+		return m.ReleaseWebhookEventFunc(ctx, webhookEventId)
+	}
+	return nil
+}
+
+func webhookPayload(webhookEventId string, checkoutSessionId string) []byte {
+	return []byte(fmt.Sprintf(`{"id": %q, "data": {"object": {"id": %q}}}`, webhookEventId, checkoutSessionId))
+}
+
 func TestRegisterWithPayment(t *testing.T) {
 	t.Run("successful individual registration with payment", func(t *testing.T) {
 		eventID := uuid.New()
@@ -463,6 +711,7 @@ func TestRegisterWithPayment(t *testing.T) {
 			},
 		}
 		registrationRepo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: noIntent,
 			CreateRegistrationWithPaymentFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, evt events.Event) error {
 				assert.Equal(t, event.Version+1, evt.Version)
 				assert.Equal(t, event.ID, intent.EventId)
@@ -488,6 +737,7 @@ func TestRegisterWithPayment(t *testing.T) {
 		// Verify RegistrationIntent fields
 		assert.Equal(t, eventID, regIntent.EventId)
 		assert.Equal(t, "test_session_id", regIntent.PaymentSessionId)
+		assert.Equal(t, "test_client_secret", regIntent.ClientSecret)
 		assert.Equal(t, "test@example.com", regIntent.Email)
 		assert.Equal(t, 1, regIntent.Version)
 
@@ -516,6 +766,7 @@ func TestRegisterWithPayment(t *testing.T) {
 			},
 		}
 		registrationRepo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: noIntent,
 			CreateRegistrationWithPaymentFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, evt events.Event) error {
 				assert.Equal(t, event.ID, intent.EventId)
 				assert.Equal(t, event.Version+1, evt.Version)
@@ -551,6 +802,55 @@ func TestRegisterWithPayment(t *testing.T) {
 		assert.True(t, actualExpiration.Before(after.Add(30*time.Minute).Add(1*time.Second)), "ExpiresAt should be approximately 30 minutes from now")
 	})
 
+	t.Run("uses the event's checkout settings", func(t *testing.T) {
+		eventID := uuid.New()
+		event := events.Event{
+			ID:      eventID,
+			Name:    "Test Event",
+			Version: 1,
+			RegistrationOptions: []events.EventRegistrationOption{{
+				RegType: events.BY_INDIVIDUAL,
+				Price:   money.New(5000, "USD"),
+			}},
+			CheckoutHoldDuration:    ptr.Duration(2 * time.Hour),
+			CheckoutItemDescription: ptr.String("Test Event Entry"),
+			DisableAdaptivePricing:  true,
+		}
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		registrationRepo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: noIntent,
+			CreateRegistrationWithPaymentFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, evt events.Event) error {
+				return nil
+			},
+		}
+		var params payments.CheckoutParams
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, p payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				params = p
+				return payments.CheckoutInfo{SessionId: "test_session_id", ClientSecret: "test_client_secret"}, nil
+			},
+		}
+		registrationRequest := &IndividualRegistration{
+			EventID: eventID,
+			Email:   "test@example.com",
+		}
+
+		before := time.Now()
+		_, regIntent, _, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, registrationRepo, checkoutManager, "https://return.url")
+		require.NoError(t, err)
+
+		require.NotNil(t, params.SessionAliveDuration)
+		assert.Equal(t, 2*time.Hour, *params.SessionAliveDuration)
+		require.Len(t, params.Items, 1)
+		assert.Equal(t, "Test Event Entry", params.Items[0].Name)
+		assert.False(t, params.AllowAdaptivePricing)
+		assert.WithinDuration(t, before.Add(2*time.Hour), regIntent.ExpiresAt, time.Second)
+	})
+
 	t.Run("event does not exist", func(t *testing.T) {
 		eventRepo := &mockEventRepository{
 			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
@@ -586,7 +886,7 @@ func TestRegisterWithPayment(t *testing.T) {
 				return event, nil
 			},
 		}
-		registrationRepo := &mockRegistrationRepository{}
+		registrationRepo := &mockRegistrationRepository{GetRegistrationIntentFunc: noIntent}
 		checkoutManager := &mockCheckoutManager{
 			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
 				return payments.CheckoutInfo{}, errors.New("checkout creation failed")
@@ -615,7 +915,7 @@ func TestRegisterWithPayment(t *testing.T) {
 				return event, nil
 			},
 		}
-		registrationRepo := &mockRegistrationRepository{}
+		registrationRepo := &mockRegistrationRepository{GetRegistrationIntentFunc: noIntent}
 		checkoutManager := &mockCheckoutManager{}
 		registrationRequest := &mockRegistration{
 			GetEventIDFunc: func() uuid.UUID {
@@ -636,6 +936,213 @@ func TestRegisterWithPayment(t *testing.T) {
 		assert.True(t, errors.As(err, &registrationErr))
 		assert.Equal(t, REASON_UNKNOWN_REGISTRATION_TYPE, registrationErr.Reason)
 	})
+
+	mixedEvent := func(eventID uuid.UUID) events.Event {
+		return events.Event{
+			ID:      eventID,
+			Version: 1,
+			RegistrationOptions: []events.EventRegistrationOption{
+				{RegType: events.BY_INDIVIDUAL, Price: money.New(0, "USD")},
+				{RegType: events.BY_TEAM, Price: money.New(15000, "USD")},
+			},
+			AllowedTeamSizeRange: events.Range{Min: 1, Max: 5},
+		}
+	}
+	noCheckout := &mockCheckoutManager{
+		CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+			t.Fatal("should not create a checkout for a free sign up")
+			return payments.CheckoutInfo{}, nil
+		},
+	}
+
+	t.Run("free sign up skips the checkout", func(t *testing.T) {
+		eventID := uuid.New()
+		event := mixedEvent(eventID)
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		var savedOutbox []OutboxItem
+		registrationRepo := &mockRegistrationRepository{
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, evt events.Event, outbox []OutboxItem) error {
+				assert.Equal(t, event.Version+1, evt.Version)
+				savedOutbox = outbox
+				return nil
+			},
+		}
+		registrationRequest := &IndividualRegistration{EventID: eventID, Email: "test@example.com"}
+
+		reg, regIntent, clientSecret, evt, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, registrationRepo, noCheckout, "https://return.url")
+
+		require.NoError(t, err)
+		assert.Equal(t, registrationRequest, reg)
+		assert.Empty(t, clientSecret)
+		assert.Equal(t, RegistrationIntent{}, regIntent)
+		assert.Equal(t, 1, evt.NumTotalPlayers)
+		require.NotEmpty(t, savedOutbox)
+		assert.Equal(t, OUTBOX_CONFIRMATION_EMAIL, savedOutbox[0].Kind)
+	})
+
+	t.Run("free sign up waiting on email verification", func(t *testing.T) {
+		eventID := uuid.New()
+		event := mixedEvent(eventID)
+		event.RequireEmailVerification = true
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		registrationRepo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: noIntent,
+			CreateRegistrationWithPaymentFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, evt events.Event) error {
+				return nil
+			},
+		}
+		registrationRequest := &IndividualRegistration{EventID: eventID, Email: "test@example.com"}
+
+		_, regIntent, clientSecret, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, registrationRepo, noCheckout, "https://return.url")
+
+		require.NoError(t, err)
+		assert.Empty(t, clientSecret)
+		assert.True(t, regIntent.IsEmailVerification())
+	})
+
+	t.Run("paid type of a mixed event still checks out", func(t *testing.T) {
+		eventID := uuid.New()
+		event := mixedEvent(eventID)
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		registrationRepo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: noIntent,
+			CreateRegistrationWithPaymentFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, evt events.Event) error {
+				return nil
+			},
+		}
+		registrationRequest := &TeamRegistration{EventID: eventID, CaptainEmail: "captain@example.com", Players: []PlayerInfo{{}}}
+
+		_, regIntent, clientSecret, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, registrationRepo, &mockCheckoutManager{}, "https://return.url")
+
+		require.NoError(t, err)
+		assert.Equal(t, "test_client_secret", clientSecret)
+		assert.Equal(t, "test_session_id", regIntent.PaymentSessionId)
+	})
+
+	t.Run("free team can't split the payment", func(t *testing.T) {
+		eventID := uuid.New()
+		event := mixedEvent(eventID)
+		event.RegistrationOptions[1].Price = money.New(0, "USD")
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		registrationRequest := &TeamRegistration{EventID: eventID, CaptainEmail: "captain@example.com", Players: []PlayerInfo{{}}, SplitPayment: true}
+
+		_, _, _, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, &mockRegistrationRepository{}, noCheckout, "https://return.url")
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_SPLIT_PAYMENT_NOT_ALLOWED, registrationErr.Reason)
+	})
+
+	paidEvent := func(eventID uuid.UUID) events.Event {
+		return events.Event{
+			ID:              eventID,
+			Version:         4,
+			NumTotalPlayers: 1,
+			RegistrationOptions: []events.EventRegistrationOption{
+				{RegType: events.BY_INDIVIDUAL, Price: money.New(5000, "USD")},
+				{RegType: events.BY_TEAM, Price: money.New(15000, "USD")},
+			},
+			AllowedTeamSizeRange: events.Range{Min: 1, Max: 5},
+		}
+	}
+	startedCheckoutRepo := func(eventID uuid.UUID, intent RegistrationIntent) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return intent, nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: eventID, Email: email, Version: 3, Status: STATUS_PENDING, HomeCity: "Old City"}, nil
+			},
+		}
+	}
+
+	t.Run("coming back to an open checkout resumes it", func(t *testing.T) {
+		eventID := uuid.New()
+		event := paidEvent(eventID)
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		intent := RegistrationIntent{EventId: eventID, Email: "test@example.com", Version: 1, PaymentSessionId: "cs_open", ClientSecret: "open_secret", ExpiresAt: time.Now().Add(10 * time.Minute)}
+		registrationRequest := &IndividualRegistration{EventID: eventID, Email: "test@example.com", Version: 1}
+
+		reg, regIntent, clientSecret, evt, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, startedCheckoutRepo(eventID, intent), noCheckout, "https://return.url")
+
+		require.NoError(t, err)
+		assert.Equal(t, "open_secret", clientSecret)
+		assert.Equal(t, intent, regIntent)
+		assert.Equal(t, "Old City", reg.(*IndividualRegistration).HomeCity)
+		// Nothing was saved, the spot is still held by the first sign up
+		assert.Equal(t, event, evt)
+	})
+
+	t.Run("open checkout for another registration type", func(t *testing.T) {
+		eventID := uuid.New()
+		event := paidEvent(eventID)
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		intent := RegistrationIntent{EventId: eventID, Email: "captain@example.com", Version: 1, PaymentSessionId: "cs_open", ClientSecret: "open_secret", ExpiresAt: time.Now().Add(10 * time.Minute)}
+		registrationRequest := &TeamRegistration{EventID: eventID, CaptainEmail: "captain@example.com", Players: []PlayerInfo{{}}}
+
+		_, _, _, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, startedCheckoutRepo(eventID, intent), noCheckout, "https://return.url")
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_REGISTRATION_ALREADY_EXISTS, registrationErr.Reason)
+	})
+
+	t.Run("expired checkout that wasn't cleaned up is replaced", func(t *testing.T) {
+		eventID := uuid.New()
+		event := paidEvent(eventID)
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return event, nil
+			},
+		}
+		intent := RegistrationIntent{EventId: eventID, Email: "test@example.com", Version: 2, PaymentSessionId: "cs_expired", ClientSecret: "expired_secret", ExpiresAt: time.Now().Add(-time.Minute)}
+		registrationRepo := startedCheckoutRepo(eventID, intent)
+		replaced := false
+		registrationRepo.ReplaceExpiredRegistrationWithPaymentFunc = func(ctx context.Context, expired Registration, expiredIntent RegistrationIntent, registration Registration, newIntent RegistrationIntent, evt events.Event) error {
+			replaced = true
+			assert.Equal(t, 3, expired.GetVersion())
+			assert.Equal(t, intent, expiredIntent)
+			assert.Equal(t, 4, registration.GetVersion())
+			assert.Equal(t, 3, newIntent.Version)
+			assert.Equal(t, "test_session_id", newIntent.PaymentSessionId)
+			assert.Equal(t, "test_client_secret", newIntent.ClientSecret)
+			// The expired sign up's spot went to the new one
+			assert.Equal(t, 1, evt.NumTotalPlayers)
+			assert.Equal(t, event.Version+1, evt.Version)
+			return nil
+		}
+		registrationRequest := &IndividualRegistration{EventID: eventID, Email: "test@example.com", Version: 1}
+
+		_, _, clientSecret, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, registrationRepo, &mockCheckoutManager{}, "https://return.url")
+
+		require.NoError(t, err)
+		assert.True(t, replaced)
+		assert.Equal(t, "test_client_secret", clientSecret)
+	})
 }
 
 func TestConfirmRegistrationPayment(t *testing.T) {
@@ -647,7 +1154,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			EventID: eventID,
 			Email:   email,
 			Version: 1,
-			Paid:    false,
+			Status:  STATUS_PENDING,
 		}
 
 		eventRepo := &mockEventRepository{}
@@ -657,15 +1164,15 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 				assert.Equal(t, email, regEmail)
 				return reg, nil
 			},
-			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration) error {
-				assert.Equal(t, 2, registration.(*IndividualRegistration).Version) // Should be bumped
-				assert.True(t, registration.(*IndividualRegistration).Paid)        // Should be set to paid
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+				assert.Equal(t, 2, registration.(*IndividualRegistration).Version)          // Should be bumped
+				assert.Equal(t, STATUS_PAID, registration.(*IndividualRegistration).Status) // Should be set to paid
 				return nil
 			},
 		}
 		checkoutManager := &mockCheckoutManager{
 			ConfirmCheckoutFunc: func(ctx context.Context, payload []byte, signature string) (map[string]string, error) {
-				assert.Equal(t, []byte("test_payload"), payload)
+				assert.Equal(t, webhookPayload("evt_test", "cs_paid"), payload)
 				assert.Equal(t, "test_signature", signature)
 				return map[string]string{
 					"EMAIL":     email,
@@ -675,12 +1182,12 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			},
 		}
 
-		result, err := ConfirmRegistrationPayment(context.Background(), []byte("test_payload"), "test_signature", registrationRepo, eventRepo, checkoutManager)
+		result, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_paid"), "test_signature", registrationRepo, eventRepo, &mockWebhookEventRepository{}, checkoutManager)
 
 		assert.NoError(t, err)
 		assert.Equal(t, reg, result)
 		assert.Equal(t, 2, result.(*IndividualRegistration).Version)
-		assert.True(t, result.(*IndividualRegistration).Paid)
+		assert.Equal(t, STATUS_PAID, result.(*IndividualRegistration).Status)
 	})
 
 	t.Run("missing email in metadata", func(t *testing.T) {
@@ -695,7 +1202,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			},
 		}
 
-		_, err := ConfirmRegistrationPayment(context.Background(), []byte("test_payload"), "test_signature", registrationRepo, eventRepo, checkoutManager)
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_paid"), "test_signature", registrationRepo, eventRepo, &mockWebhookEventRepository{}, checkoutManager)
 
 		assert.Error(t, err)
 		var registrationErr *Error
@@ -717,7 +1224,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			},
 		}
 
-		_, err := ConfirmRegistrationPayment(context.Background(), []byte("test_payload"), "test_signature", registrationRepo, eventRepo, checkoutManager)
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_paid"), "test_signature", registrationRepo, eventRepo, &mockWebhookEventRepository{}, checkoutManager)
 
 		assert.Error(t, err)
 		var registrationErr *Error
@@ -733,7 +1240,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			EventID: eventID,
 			Email:   email,
 			Version: 1,
-			Paid:    false,
+			Status:  STATUS_PENDING,
 		}
 		regIntent := RegistrationIntent{
 			Version:          1,
@@ -780,7 +1287,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			},
 		}
 
-		result, err := ConfirmRegistrationPayment(context.Background(), []byte("test_payload"), "test_signature", registrationRepo, eventRepo, checkoutManager)
+		result, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "session_123"), "test_signature", registrationRepo, eventRepo, &mockWebhookEventRepository{}, checkoutManager)
 
 		assert.Error(t, err)
 		var registrationErr *Error
@@ -797,7 +1304,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			EventID:      eventID,
 			CaptainEmail: email,
 			Version:      1,
-			Paid:         false,
+			Status:       STATUS_PENDING,
 			Players:      []PlayerInfo{{}, {}, {}}, // 3 players
 		}
 		regIntent := RegistrationIntent{
@@ -847,7 +1354,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			},
 		}
 
-		result, err := ConfirmRegistrationPayment(context.Background(), []byte("test_payload"), "test_signature", registrationRepo, eventRepo, checkoutManager)
+		result, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "session_456"), "test_signature", registrationRepo, eventRepo, &mockWebhookEventRepository{}, checkoutManager)
 
 		assert.Error(t, err)
 		var registrationErr *Error
@@ -879,7 +1386,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			},
 		}
 
-		result, err := ConfirmRegistrationPayment(context.Background(), []byte("test_payload"), "test_signature", registrationRepo, eventRepo, checkoutManager)
+		result, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_deleted"), "test_signature", registrationRepo, eventRepo, &mockWebhookEventRepository{}, checkoutManager)
 
 		assert.Error(t, err)
 		var registrationErr *Error
@@ -927,7 +1434,7 @@ func TestConfirmRegistrationPayment(t *testing.T) {
 			},
 		}
 
-		result, err := ConfirmRegistrationPayment(context.Background(), []byte("test_payload"), "test_signature", registrationRepo, eventRepo, checkoutManager)
+		result, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "session_789"), "test_signature", registrationRepo, eventRepo, &mockWebhookEventRepository{}, checkoutManager)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get event")
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"embed"
+	"encoding/base64"
 	"fmt"
 	"html/template"
 
@@ -14,11 +15,16 @@ import (
 //go:embed templates
 var templates embed.FS
 
-func SendRegistrationConfirmationEmail(ctx context.Context, emailSender email.Sender, from email.Address, reg Registration, event events.Event) error {
+func SendRegistrationConfirmationEmail(ctx context.Context, emailSender email.Sender, from email.Address, reg Registration, event events.Event, checkInSigner *CheckInSigner) error {
 	ctx, span := tracer.Start(ctx, "SendRegistrationConfirmationEmail")
 	defer span.End()
 
-	htmlBody, err := makeHtmlBody(event, reg)
+	checkInCodes, err := CheckInCodes(checkInSigner, reg)
+	if err != nil {
+		return err
+	}
+
+	htmlBody, err := makeHtmlBody(event, reg, checkInCodes)
 	if err != nil {
 		return err
 	}
@@ -34,42 +40,63 @@ func SendRegistrationConfirmationEmail(ctx context.Context, emailSender email.Se
 		Subject:     fmt.Sprintf("Event signup confirmed - %q", event.Name),
 		HTMLBody:    htmlBody,
 		TextBody:    textOnlyBody,
+		Attachments: checkInAttachments(checkInCodes),
 	})
 }
 
-func makeHtmlBody(event events.Event, reg Registration) (string, error) {
-	tmpl, err := template.New("registration-confirmation.tmpl").Funcs(template.FuncMap{
-		"add": func(a, b int) int { return a + b },
-	}).ParseFS(templates, "templates/registration-confirmation.tmpl")
-	if err != nil {
-		return "", fmt.Errorf("failed to parse email template: %w", err)
+type checkInImage struct {
+	Label string
+	Image template.URL
+}
+
+func makeHtmlBody(event events.Event, reg Registration, checkInCodes []CheckInCode) (string, error) {
+	images := make([]checkInImage, 0, len(checkInCodes))
+	for _, code := range checkInCodes {
+		images = append(images, checkInImage{
+			Label: code.Label,
+			// Safe to trust since it is a PNG that was just made, not user input
+			Image: template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(code.QRCode)),
+		})
 	}
 
-	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, map[string]any{
+	return executeEmailTemplate("registration-confirmation.tmpl", map[string]any{
 		"Event":        event,
 		"Registration": reg,
+		"CheckInCodes": images,
 	})
-	if err != nil {
-		return "", fmt.Errorf("failed to execute email template: %w", err)
-	}
+}
 
-	return buf.String(), nil
+// checkInAttachments attaches the QR codes too, since some email clients don't show inline images.
+func checkInAttachments(checkInCodes []CheckInCode) []email.Attachment {
+	attachments := make([]email.Attachment, 0, len(checkInCodes))
+	for i, code := range checkInCodes {
+		attachments = append(attachments, email.Attachment{
+			FileName:    fmt.Sprintf("check-in-%d.png", i+1),
+			Content:     code.QRCode,
+			Description: fmt.Sprintf("Check-in code for %s", code.Label),
+			ContentType: "image/png",
+		})
+	}
+	return attachments
 }
 
 func makeTextOnlyBody(event events.Event, reg Registration) (string, error) {
-	tmpl, err := template.New("registration-confirmation-textonly.tmpl").Funcs(template.FuncMap{
+	return executeEmailTemplate("registration-confirmation-textonly.tmpl", map[string]any{
+		"Event":        event,
+		"Registration": reg,
+	})
+}
+
+func executeEmailTemplate(name string, data map[string]any) (string, error) {
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
 		"add": func(a, b int) int { return a + b },
-	}).ParseFS(templates, "templates/registration-confirmation-textonly.tmpl")
+	}).ParseFS(templates, "templates/"+name)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse email template: %w", err)
 	}
 
 	var buf bytes.Buffer
-	err = tmpl.Execute(&buf, map[string]any{
-		"Event":        event,
-		"Registration": reg,
-	})
+	err = tmpl.Execute(&buf, data)
 	if err != nil {
 		return "", fmt.Errorf("failed to execute email template: %w", err)
 	}
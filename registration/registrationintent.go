@@ -1,15 +1,136 @@
 package registration
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/payments"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/codes"
 )
 
 type RegistrationIntent struct {
-	Version          int
-	EventId          uuid.UUID
+	Version int
+	EventId uuid.UUID
+	// Empty when the intent is holding a free sign up's spot until its email is verified
 	PaymentSessionId string
-	Email            string
-	ExpiresAt        time.Time
+	// Token from the link emailed to verify the registrant's email, only set for email verifications
+	VerificationToken string
+	// Lets the registrant get back into the checkout if they leave it, only set for checkouts
+	ClientSecret string
+	Email        string
+	ExpiresAt    time.Time
+	// Only set for the checkout of a pending transfer's price difference
+	TransferID *uuid.UUID
+}
+
+// IsEmailVerification is if the intent is holding a free sign up's spot instead of a checkout's.
+func (i RegistrationIntent) IsEmailVerification() bool {
+	return i.VerificationToken != ""
+}
+
+// SweepExpiredRegistrationIntents cleans up the registrations whose checkout expired without the
+// payment provider's expiry webhook ever reaching us, so they stop holding a spot. Checkouts that
+// were actually paid are set to paid instead.
+//
+// Returns how many registrations were deleted.
+func SweepExpiredRegistrationIntents(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, paymentQuerier payments.PaymentQuerier, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "SweepExpiredRegistrationIntents")
+	defer span.End()
+
+	allEvents, err := events.GetAllEvents(ctx, eventRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, NewFailedToFetchError("Failed to fetch events", err)
+	}
+
+	numDeleted := 0
+	var errs []error
+	for _, event := range allEvents {
+		intents, err := registrationRepo.GetExpiredRegistrationIntents(ctx, event.ID, now)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, intent := range intents {
+			deleted, err := sweepExpiredRegistrationIntent(ctx, registrationRepo, eventRepo, paymentQuerier, intent)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to sweep registration intent for %s: %w", intent.Email, err))
+			}
+			if deleted {
+				numDeleted++
+			}
+		}
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return numDeleted, err
+}
+
+func sweepExpiredRegistrationIntent(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, paymentQuerier payments.PaymentQuerier, intent RegistrationIntent) (bool, error) {
+	if intent.IsEmailVerification() {
+		// Nothing was paid, the registrant just never clicked the link
+		reg, err := deleteExpiredRegistration(ctx, registrationRepo, eventRepo, intent.EventId, intent.Email, StatusChangedBySystem)
+		if err != nil {
+			return false, err
+		}
+		return reg != nil, nil
+	}
+
+	paid, err := checkoutWasPaid(ctx, paymentQuerier, intent)
+	if err != nil {
+		return false, err
+	}
+
+	if intent.TransferID != nil {
+		// A transfer only moves the registration once it's paid, so there is no spot to give up
+		if paid {
+			_, err := completePendingTransfer(ctx, registrationRepo, eventRepo, intent.EventId, intent.Email, intent.TransferID.String())
+			return false, err
+		}
+		_, err := cancelPendingTransfer(ctx, registrationRepo, intent.EventId, intent.Email, intent.PaymentSessionId)
+		return false, err
+	}
+
+	// The checkout went through but the webhook saying so never did
+	if paid {
+		_, err := setRegistrationToPaid(ctx, registrationRepo, intent.EventId, intent.Email)
+		return false, err
+	}
+
+	reg, err := deleteExpiredRegistration(ctx, registrationRepo, eventRepo, intent.EventId, intent.Email, StatusChangedBySystem)
+	if err != nil {
+		return false, err
+	}
+	return reg != nil, nil
+}
+
+// checkoutWasPaid asks the payment provider if there was a successful payment for the intent's checkout.
+// Only a payment from the intent's own checkout counts, since a share of a team's fee or a transfer's
+// price difference can be paid under the same email and event.
+func checkoutWasPaid(ctx context.Context, paymentQuerier payments.PaymentQuerier, intent RegistrationIntent) (bool, error) {
+	for payment, err := range paymentQuerier.ListCharges(ctx, payments.ChargeListParams{
+		MetadataFilter: map[string]string{
+			emailKey:    intent.Email,
+			eventIdKey:  intent.EventId.String(),
+			itemTypeKey: itemTypeEvent,
+		},
+	}) {
+		if err != nil {
+			return false, NewFailedToFetchError("Failed to fetch payments for registration intent", err)
+		}
+		if payment.CheckoutSessionID != "" && payment.CheckoutSessionID == intent.PaymentSessionId {
+			return true, nil
+		}
+	}
+	return false, nil
 }
@@ -0,0 +1,123 @@
+package registration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSweepExpiredRegistrationIntents(t *testing.T) {
+	eventId := uuid.New()
+	now := time.Now()
+	intent := RegistrationIntent{Version: 1, EventId: eventId, PaymentSessionId: "cs_123", Email: "test@example.com", ExpiresAt: now.Add(-time.Minute)}
+
+	eventRepo := &mockEventRepository{
+		GetEventsFunc: func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+			return events.GetEventsResponse{Data: []events.Event{{ID: eventId}}}, nil
+		},
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Version: 3, NumTotalPlayers: 10}, nil
+		},
+	}
+	newRepo := func() *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetExpiredRegistrationIntentsFunc: func(ctx context.Context, id uuid.UUID, at time.Time) ([]RegistrationIntent, error) {
+				assert.Equal(t, now, at)
+				return []RegistrationIntent{intent}, nil
+			},
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: id, Email: email, Version: 1, Status: STATUS_PENDING}, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return intent, nil
+			},
+		}
+	}
+
+	t.Run("unpaid checkout is deleted", func(t *testing.T) {
+		var deleted Registration
+		var updatedEvent events.Event
+		repo := newRepo()
+		repo.DeleteExpiredRegistrationFunc = func(ctx context.Context, reg Registration, regIntent RegistrationIntent, event events.Event) error {
+			deleted = reg
+			updatedEvent = event
+			return nil
+		}
+
+		numDeleted, err := SweepExpiredRegistrationIntents(context.Background(), repo, eventRepo, &mockPaymentQuerier{}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, numDeleted)
+		assert.Equal(t, STATUS_EXPIRED, deleted.GetStatus())
+		assert.Equal(t, StatusChangedBySystem, deleted.GetStatusHistory()[0].ChangedBy)
+		assert.Equal(t, 9, updatedEvent.NumTotalPlayers)
+	})
+
+	t.Run("paid checkout is set to paid", func(t *testing.T) {
+		var paid Registration
+		repo := newRepo()
+		repo.UpdateRegistrationToPaidFunc = func(ctx context.Context, reg Registration, outbox []OutboxItem) error {
+			paid = reg
+			return nil
+		}
+		paymentQuerier := &mockPaymentQuerier{
+			Payments: []payments.Payment{{ID: "ch_123", Amount: money.New(5000, "USD"), CheckoutSessionID: "cs_123"}},
+		}
+
+		numDeleted, err := SweepExpiredRegistrationIntents(context.Background(), repo, eventRepo, paymentQuerier, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, numDeleted)
+		assert.Equal(t, STATUS_PAID, paid.GetStatus())
+	})
+	t.Run("payment from another checkout doesn't keep the registration", func(t *testing.T) {
+		var deleted Registration
+		repo := newRepo()
+		repo.DeleteExpiredRegistrationFunc = func(ctx context.Context, reg Registration, regIntent RegistrationIntent, event events.Event) error {
+			deleted = reg
+			return nil
+		}
+		paymentQuerier := &mockPaymentQuerier{
+			Payments: []payments.Payment{
+				{ID: "ch_share", Amount: money.New(2500, "USD"), CheckoutSessionID: "cs_share"},
+				{ID: "ch_transfer", Amount: money.New(1000, "USD")},
+			},
+		}
+
+		numDeleted, err := SweepExpiredRegistrationIntents(context.Background(), repo, eventRepo, paymentQuerier, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, numDeleted)
+		assert.Equal(t, STATUS_EXPIRED, deleted.GetStatus())
+	})
+
+	t.Run("unpaid transfer checkout drops the pending transfer", func(t *testing.T) {
+		transferId := uuid.New()
+		transferIntent := intent
+		transferIntent.TransferID = &transferId
+		var saved Registration
+		repo := newRepo()
+		repo.GetExpiredRegistrationIntentsFunc = func(ctx context.Context, id uuid.UUID, at time.Time) ([]RegistrationIntent, error) {
+			return []RegistrationIntent{transferIntent}, nil
+		}
+		repo.GetRegistrationIntentFunc = func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+			return transferIntent, nil
+		}
+		repo.GetRegistrationFunc = func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+			return &IndividualRegistration{EventID: id, Email: email, Version: 2, Status: STATUS_PAID, PendingTransfer: &PendingTransfer{Transfer: Transfer{ID: transferId}}}, nil
+		}
+		repo.DeleteExpiredTransferFunc = func(ctx context.Context, reg Registration, regIntent RegistrationIntent) error {
+			saved = reg
+			return nil
+		}
+
+		numDeleted, err := SweepExpiredRegistrationIntents(context.Background(), repo, eventRepo, &mockPaymentQuerier{}, now)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, numDeleted)
+		assert.Nil(t, saved.GetPendingTransfer())
+		assert.Equal(t, STATUS_PAID, saved.GetStatus())
+	})
+}
@@ -2,37 +2,60 @@ package registration
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
+	"slices"
 
 	"github.com/International-Combat-Archery-Alliance/email"
 	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"go.opentelemetry.io/otel/codes"
 )
 
-func AddToMailingList(ctx context.Context, subscriberManager email.SubscriberManager, reg Registration, groupID string, logger *slog.Logger) {
+// AddToMailingList adds the registrant, and every player with an email on a team, to the mailing list group,
+// skipping anyone in alreadyAdded. It keeps going when one of them fails, returning who was added along with
+// all of the failures so a retry only goes to the rest.
+func AddToMailingList(ctx context.Context, subscriberManager email.SubscriberManager, reg Registration, groupID string, alreadyAdded []string) ([]string, error) {
 	ctx, span := tracer.Start(ctx, "AddToMailingList")
 	defer span.End()
 
+	type subscriber struct {
+		email string
+		name  string
+		role  string
+	}
+	var subscribers []subscriber
 	switch reg.Type() {
 	case events.BY_INDIVIDUAL:
 		indiv := reg.(*IndividualRegistration)
-		name := indiv.PlayerInfo.FirstName + " " + indiv.PlayerInfo.LastName
-		if err := subscriberManager.AddSubscriberToGroup(ctx, indiv.Email, name, groupID); err != nil {
-			logger.Warn("failed to add individual subscriber to mailerlite group", "email", indiv.Email, "error", err)
-		}
+		subscribers = append(subscribers, subscriber{indiv.Email, indiv.PlayerInfo.FirstName + " " + indiv.PlayerInfo.LastName, "individual subscriber"})
 	case events.BY_TEAM:
 		team := reg.(*TeamRegistration)
-		if err := subscriberManager.AddSubscriberToGroup(ctx, team.CaptainEmail, team.TeamName, groupID); err != nil {
-			logger.Warn("failed to add team captain to mailerlite group", "email", team.CaptainEmail, "error", err)
-		}
+		subscribers = append(subscribers, subscriber{team.CaptainEmail, team.TeamName, "team captain"})
 		for _, player := range team.Players {
 			if player.Email == nil {
 				continue
 			}
-			name := fmt.Sprintf("%s %s", player.FirstName, player.LastName)
-			if err := subscriberManager.AddSubscriberToGroup(ctx, *player.Email, name, groupID); err != nil {
-				logger.Warn("failed to add team player to mailerlite group", "email", *player.Email, "error", err)
-			}
+			subscribers = append(subscribers, subscriber{*player.Email, fmt.Sprintf("%s %s", player.FirstName, player.LastName), "team player"})
+		}
+	}
+
+	var added []string
+	var errs []error
+	for _, sub := range subscribers {
+		if slices.Contains(alreadyAdded, sub.email) {
+			continue
+		}
+		if err := subscriberManager.AddSubscriberToGroup(ctx, sub.email, sub.name, groupID); err != nil {
+			errs = append(errs, fmt.Errorf("failed to add %s %s to mailing list group: %w", sub.role, sub.email, err))
+			continue
 		}
+		added = append(added, sub.email)
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
+	return added, err
 }
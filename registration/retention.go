@@ -0,0 +1,147 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PurgeReport is what a retention purge did, or would do on a dry run.
+type PurgeReport struct {
+	DryRun bool
+	Events []PurgedEvent
+}
+
+type PurgedEvent struct {
+	EventID uuid.UUID
+	Name    string
+	EndTime time.Time
+	// Registrations that were, or would be, anonymized
+	NumRegistrations int
+}
+
+// PurgeExpiredPersonalData anonymizes the players' names, emails and home cities on the registrations
+// of every event that ended more than retentionMonths ago, then records on the event that it's done so
+// it's never purged again.
+//
+// Registrations are kept, so the event's counters, statuses, refunds and payment references stay intact,
+// and their payments are recorded on them first so they can still be refunded.
+// An event is only recorded as purged once all of its registrations were, so a failed purge is retried
+// on the next run. A dry run only reports which events would be purged.
+func PurgeExpiredPersonalData(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, paymentQuerier payments.PaymentQuerier, retentionMonths int, dryRun bool, now time.Time) (PurgeReport, error) {
+	ctx, span := tracer.Start(ctx, "PurgeExpiredPersonalData")
+	defer span.End()
+
+	allEvents, err := events.GetAllEvents(ctx, eventRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return PurgeReport{}, NewFailedToFetchError("Failed to fetch events", err)
+	}
+
+	report := PurgeReport{DryRun: dryRun}
+	var errs []error
+	for _, event := range allEvents {
+		if event.PersonalDataPurgedAt != nil || now.Before(event.EndTime.AddDate(0, retentionMonths, 0)) {
+			continue
+		}
+
+		regs, err := GetAllRegistrations(ctx, registrationRepo, event.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		purged := PurgedEvent{
+			EventID:          event.ID,
+			Name:             event.Name,
+			EndTime:          event.EndTime,
+			NumRegistrations: len(regs),
+		}
+		if dryRun {
+			report.Events = append(report.Events, purged)
+			continue
+		}
+
+		purged.NumRegistrations = 0
+		var eventErrs []error
+		for _, reg := range regs {
+			err := purgeRegistration(ctx, registrationRepo, paymentQuerier, reg, now)
+			if err != nil {
+				eventErrs = append(eventErrs, fmt.Errorf("failed to purge registration for %s for event ID %q: %w", reg.GetEmail(), event.ID, err))
+				continue
+			}
+			purged.NumRegistrations++
+		}
+
+		if len(eventErrs) == 0 {
+			event.PersonalDataPurgedAt = &now
+			event.Version++
+			err = eventRepo.UpdateEvent(ctx, event)
+			if err != nil {
+				eventErrs = append(eventErrs, fmt.Errorf("failed to record purge on event ID %q: %w", event.ID, err))
+			}
+		}
+		errs = append(errs, eventErrs...)
+		report.Events = append(report.Events, purged)
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return report, err
+}
+
+func purgeRegistration(ctx context.Context, registrationRepo Repository, paymentQuerier payments.PaymentQuerier, reg Registration, now time.Time) error {
+	_, found, err := getOpenIntent(ctx, reg, registrationRepo)
+	if err != nil {
+		return err
+	}
+	if found {
+		return NewCheckoutInProgressError("Registration still has an open checkout")
+	}
+
+	// Everyone on the registration, along with the players whose email was never given
+	people := map[string]bool{"": true}
+	addPerson := func(email string) {
+		// Already erased by a privacy request, no need to give them another made up email
+		if !isErasedEmail(email) {
+			people[strings.ToLower(email)] = true
+		}
+	}
+	addPerson(reg.GetEmail())
+	for _, player := range registrationPlayers(reg) {
+		if player.Email != nil {
+			addPerson(*player.Email)
+		}
+	}
+	for _, transfer := range reg.GetTransfers() {
+		addPerson(transfer.FromEmail)
+		addPerson(transfer.ToEmail)
+	}
+
+	anonymized := cloneRegistration(reg)
+	err = recordPaymentIDs(ctx, paymentQuerier, anonymized)
+	if err != nil {
+		return err
+	}
+	anonymizeRegistration(anonymized, func(email string) bool { return people[strings.ToLower(email)] })
+	anonymized.AddAdminNote(AdminNote{
+		ID:        uuid.New(),
+		Text:      "Personal data was purged by the retention policy",
+		Author:    StatusChangedBySystem,
+		CreatedAt: now,
+	})
+	anonymized.BumpVersion()
+
+	return registrationRepo.AnonymizeRegistration(ctx, reg, anonymized)
+}
@@ -0,0 +1,121 @@
+package registration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeExpiredPersonalData(t *testing.T) {
+	now := time.Date(2027, 6, 1, 0, 0, 0, 0, time.UTC)
+	purgedAt := now.AddDate(0, -1, 0)
+	oldEvent := events.Event{ID: uuid.New(), Version: 5, Name: "Old", EndTime: now.AddDate(-2, 0, -1), NumTeams: 1}
+	recentEvent := events.Event{ID: uuid.New(), Name: "Recent", EndTime: now.AddDate(-1, 0, 0)}
+	alreadyPurged := events.Event{ID: uuid.New(), Name: "Purged", EndTime: now.AddDate(-3, 0, 0), PersonalDataPurgedAt: &purgedAt}
+
+	eventRepo := func(updated *[]events.Event) *mockEventRepository {
+		return &mockEventRepository{
+			GetEventsFunc: func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+				return events.GetEventsResponse{Data: []events.Event{oldEvent, recentEvent, alreadyPurged}}, nil
+			},
+			UpdateEventFunc: func(ctx context.Context, event events.Event) error {
+				*updated = append(*updated, event)
+				return nil
+			},
+		}
+	}
+	registrationRepo := func(anonymized *[]Registration) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetAllRegistrationsForEventFunc: func(ctx context.Context, eventId uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+				require.Equal(t, oldEvent.ID, eventId)
+				return GetAllRegistrationsResponse{Data: []Registration{
+					&TeamRegistration{
+						EventID:      eventId,
+						Version:      1,
+						CaptainEmail: "captain@example.com",
+						TeamName:     "The Archers",
+						HomeCity:     "Springfield",
+						Status:       STATUS_PAID,
+						Players: []PlayerInfo{
+							{FirstName: "Cap", LastName: "Tain", Email: ptr.String("captain@example.com"), RosterStatus: ROSTER_CONFIRMED},
+							{FirstName: "No", LastName: "Email"},
+						},
+						Refunds: []Refund{{ID: uuid.New(), PaymentID: "pi_123"}},
+					},
+				}}, nil
+			},
+			GetRegistrationIntentFunc: noIntent,
+			AnonymizeRegistrationFunc: func(ctx context.Context, from Registration, to Registration) error {
+				*anonymized = append(*anonymized, to)
+				return nil
+			},
+		}
+	}
+
+	querier := &metadataPaymentQuerier{Payments: []payments.Payment{checkoutPayment("pi_123", oldEvent.ID, "captain@example.com")}}
+
+	t.Run("dry run", func(t *testing.T) {
+		var updated []events.Event
+		var anonymized []Registration
+
+		report, err := PurgeExpiredPersonalData(context.Background(), registrationRepo(&anonymized), eventRepo(&updated), querier, 24, true, now)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, []PurgedEvent{{EventID: oldEvent.ID, Name: "Old", EndTime: oldEvent.EndTime, NumRegistrations: 1}}, report.Events)
+		assert.Empty(t, updated)
+		assert.Empty(t, anonymized)
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		var updated []events.Event
+		var anonymized []Registration
+
+		report, err := PurgeExpiredPersonalData(context.Background(), registrationRepo(&anonymized), eventRepo(&updated), querier, 24, false, now)
+		require.NoError(t, err)
+		require.Len(t, report.Events, 1)
+		assert.Equal(t, 1, report.Events[0].NumRegistrations)
+
+		require.Len(t, anonymized, 1)
+		team := anonymized[0].(*TeamRegistration)
+		assert.True(t, strings.HasSuffix(team.CaptainEmail, "@erased.invalid"))
+		assert.Empty(t, team.HomeCity)
+		assert.Equal(t, "The Archers", team.TeamName)
+		assert.Equal(t, STATUS_PAID, team.Status)
+		assert.Equal(t, "pi_123", team.Refunds[0].PaymentID)
+		assert.Equal(t, []string{"pi_123"}, team.PaymentIDs)
+		assert.Equal(t, []PlayerInfo{
+			{FirstName: "Erased", LastName: "Erased", RosterStatus: ROSTER_CONFIRMED},
+			{FirstName: "Erased", LastName: "Erased"},
+		}, team.Players)
+		require.Len(t, team.AdminNotes, 1)
+		assert.Equal(t, StatusChangedBySystem, team.AdminNotes[0].Author)
+
+		require.Len(t, updated, 1)
+		assert.Equal(t, oldEvent.ID, updated[0].ID)
+		assert.Equal(t, 6, updated[0].Version)
+		assert.Equal(t, 1, updated[0].NumTeams)
+		assert.Equal(t, now, *updated[0].PersonalDataPurgedAt)
+	})
+
+	t.Run("event isn't recorded as purged when a registration fails", func(t *testing.T) {
+		var updated []events.Event
+		var anonymized []Registration
+		repo := registrationRepo(&anonymized)
+		repo.GetRegistrationIntentFunc = func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error) {
+			return RegistrationIntent{EventId: eventId, Email: email}, nil
+		}
+
+		report, err := PurgeExpiredPersonalData(context.Background(), repo, eventRepo(&updated), querier, 24, false, now)
+		assert.Error(t, err)
+		assert.Equal(t, 0, report.Events[0].NumRegistrations)
+		assert.Empty(t, updated)
+	})
+}
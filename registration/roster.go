@@ -0,0 +1,236 @@
+//go:generate go tool stringer -type=RosterStatus
+
+package registration
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type RosterStatus int
+
+const (
+	ROSTER_NOT_INVITED RosterStatus = iota
+	ROSTER_INVITED
+	ROSTER_CONFIRMED
+)
+
+// prepareRosterInvites gives every player with an email a token to confirm their roster spot with.
+// A player with the captain's email is confirmed right away since they made the registration.
+func prepareRosterInvites(reg *TeamRegistration) {
+	for i := range reg.Players {
+		player := &reg.Players[i]
+		if player.Email == nil {
+			continue
+		}
+
+		player.InviteToken = uuid.NewString()
+		if strings.EqualFold(*player.Email, reg.CaptainEmail) {
+			player.RosterStatus = ROSTER_CONFIRMED
+			player.ConfirmedAt = ptr.Time(reg.RegisteredAt)
+			continue
+		}
+		player.RosterStatus = ROSTER_INVITED
+		player.InvitedAt = ptr.Time(reg.RegisteredAt)
+	}
+}
+
+func ConfirmRosterSpot(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, captainEmail string, inviteToken string) (*TeamRegistration, PlayerInfo, error) {
+	ctx, span := tracer.Start(ctx, "ConfirmRosterSpot")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	teamReg, err := getTeamRegistration(ctx, registrationRepo, eventId, captainEmail)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, PlayerInfo{}, err
+	}
+
+	idx := slices.IndexFunc(teamReg.Players, func(p PlayerInfo) bool {
+		return p.InviteToken != "" && subtle.ConstantTimeCompare([]byte(p.InviteToken), []byte(inviteToken)) == 1
+	})
+	if idx == -1 {
+		err = NewInvalidRosterInviteError("No player on the roster matches the invite")
+		span.SetStatus(codes.Error, err.Error())
+		return nil, PlayerInfo{}, err
+	}
+
+	player := &teamReg.Players[idx]
+	if player.RosterStatus == ROSTER_CONFIRMED {
+		return teamReg, *player, nil
+	}
+
+	player.RosterStatus = ROSTER_CONFIRMED
+	player.ConfirmedAt = ptr.Time(time.Now())
+	teamReg.BumpVersion()
+
+	err = registrationRepo.UpdateRegistration(ctx, teamReg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, PlayerInfo{}, err
+	}
+
+	return teamReg, *player, nil
+}
+
+// ResendRosterInvitations re-sends the roster invitation to every player that has not confirmed yet.
+// If playerEmails is not empty, only players with one of those emails get a new invitation.
+func ResendRosterInvitations(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, emailSender email.Sender, from email.Address, eventId uuid.UUID, captainEmail string, playerEmails []string, frontendBaseURL string) (*TeamRegistration, int, error) {
+	ctx, span := tracer.Start(ctx, "ResendRosterInvitations")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	teamReg, err := getTeamRegistration(ctx, registrationRepo, eventId, captainEmail)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, err
+	}
+
+	event, err := eventRepo.GetEvent(ctx, eventId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
+	}
+
+	now := time.Now()
+	invited := map[string]bool{}
+	for i := range teamReg.Players {
+		player := &teamReg.Players[i]
+		if player.Email == nil || player.RosterStatus == ROSTER_CONFIRMED {
+			continue
+		}
+		if len(playerEmails) > 0 && !slices.ContainsFunc(playerEmails, func(e string) bool { return strings.EqualFold(e, *player.Email) }) {
+			continue
+		}
+
+		if player.InviteToken == "" {
+			player.InviteToken = uuid.NewString()
+		}
+		player.RosterStatus = ROSTER_INVITED
+		player.InvitedAt = ptr.Time(now)
+		invited[player.InviteToken] = true
+	}
+	numInvited := len(invited)
+
+	if numInvited == 0 {
+		return teamReg, 0, nil
+	}
+
+	teamReg.BumpVersion()
+	err = registrationRepo.UpdateRegistration(ctx, teamReg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, 0, err
+	}
+
+	_, err = sendRosterInvitationEmails(ctx, emailSender, from, teamReg, event, frontendBaseURL, func(p PlayerInfo) bool {
+		return invited[p.InviteToken]
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return teamReg, numInvited, err
+	}
+
+	return teamReg, numInvited, nil
+}
+
+// SendRosterInvitationEmails emails every invited player on the team asking them to confirm their spot, skipping
+// anyone in alreadySent. It returns who it was sent to along with any failures, so a retry only goes to the rest.
+func SendRosterInvitationEmails(ctx context.Context, emailSender email.Sender, from email.Address, reg *TeamRegistration, event events.Event, frontendBaseURL string, alreadySent []string) ([]string, error) {
+	ctx, span := tracer.Start(ctx, "SendRosterInvitationEmails")
+	defer span.End()
+
+	sent, err := sendRosterInvitationEmails(ctx, emailSender, from, reg, event, frontendBaseURL, func(p PlayerInfo) bool {
+		return !slices.Contains(alreadySent, *p.Email)
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return sent, err
+}
+
+func sendRosterInvitationEmails(ctx context.Context, emailSender email.Sender, from email.Address, reg *TeamRegistration, event events.Event, frontendBaseURL string, shouldSend func(p PlayerInfo) bool) ([]string, error) {
+	var sent []string
+	var errs []error
+	for _, player := range reg.Players {
+		if player.Email == nil || player.RosterStatus != ROSTER_INVITED || !shouldSend(player) {
+			continue
+		}
+
+		data := map[string]any{
+			"Event":        event,
+			"Registration": reg,
+			"Player":       player,
+			"ConfirmLink":  RosterConfirmationLink(frontendBaseURL, reg.EventID, reg.CaptainEmail, player.InviteToken),
+		}
+
+		htmlBody, err := executeEmailTemplate("roster-invitation.tmpl", data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		textOnlyBody, err := executeEmailTemplate("roster-invitation-textonly.tmpl", data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		err = emailSender.SendEmail(ctx, email.Email{
+			From:        from,
+			ToAddresses: []string{*player.Email},
+			Subject:     fmt.Sprintf("Confirm your spot on %q - %q", reg.TeamName, event.Name),
+			HTMLBody:    htmlBody,
+			TextBody:    textOnlyBody,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send roster invitation to %s: %w", *player.Email, err))
+			continue
+		}
+		sent = append(sent, *player.Email)
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func RosterConfirmationLink(frontendBaseURL string, eventId uuid.UUID, captainEmail string, inviteToken string) string {
+	query := url.Values{}
+	query.Set("captain", captainEmail)
+	query.Set("token", inviteToken)
+
+	return fmt.Sprintf("%s/events/%s/roster/confirm?%s", frontendBaseURL, eventId, query.Encode())
+}
+
+func getTeamRegistration(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, captainEmail string) (*TeamRegistration, error) {
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, captainEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	teamReg, ok := reg.(*TeamRegistration)
+	if !ok {
+		return nil, NewNotATeamRegistrationError(fmt.Sprintf("Registration for %s is not a team registration", captainEmail))
+	}
+	return teamReg, nil
+}
@@ -0,0 +1,185 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockEmailSender struct {
+	sent []email.Email
+}
+
+func (m *mockEmailSender) SendEmail(ctx context.Context, e email.Email) error {
+	m.sent = append(m.sent, e)
+	return nil
+}
+
+func TestPrepareRosterInvites(t *testing.T) {
+	registeredAt := time.Now()
+	reg := &TeamRegistration{
+		CaptainEmail: "captain@example.com",
+		RegisteredAt: registeredAt,
+		Players: []PlayerInfo{
+			{FirstName: "Cap", Email: ptr.String("Captain@example.com")},
+			{FirstName: "Player", Email: ptr.String("player@example.com")},
+			{FirstName: "No Email"},
+		},
+	}
+
+	prepareRosterInvites(reg)
+
+	assert.Equal(t, ROSTER_CONFIRMED, reg.Players[0].RosterStatus)
+	assert.Equal(t, registeredAt, *reg.Players[0].ConfirmedAt)
+	assert.NotEmpty(t, reg.Players[0].InviteToken)
+
+	assert.Equal(t, ROSTER_INVITED, reg.Players[1].RosterStatus)
+	assert.Equal(t, registeredAt, *reg.Players[1].InvitedAt)
+	assert.NotEmpty(t, reg.Players[1].InviteToken)
+
+	assert.Equal(t, ROSTER_NOT_INVITED, reg.Players[2].RosterStatus)
+	assert.Empty(t, reg.Players[2].InviteToken)
+}
+
+func TestConfirmRosterSpot(t *testing.T) {
+	eventId := uuid.New()
+
+	newTeamReg := func() *TeamRegistration {
+		return &TeamRegistration{
+			EventID:      eventId,
+			Version:      1,
+			CaptainEmail: "captain@example.com",
+			TeamName:     "Team",
+			Players: []PlayerInfo{
+				{FirstName: "Player", Email: ptr.String("player@example.com"), RosterStatus: ROSTER_INVITED, InviteToken: "token"},
+			},
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var updated Registration
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return newTeamReg(), nil
+			},
+			UpdateRegistrationFunc: func(ctx context.Context, registration Registration) error {
+				updated = registration
+				return nil
+			},
+		}
+
+		teamReg, player, err := ConfirmRosterSpot(context.Background(), repo, eventId, "captain@example.com", "token")
+		assert.NoError(t, err)
+		assert.Equal(t, ROSTER_CONFIRMED, player.RosterStatus)
+		assert.NotNil(t, player.ConfirmedAt)
+		assert.Equal(t, 2, teamReg.Version)
+		assert.Equal(t, teamReg, updated)
+	})
+
+	t.Run("already confirmed does not update", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				reg := newTeamReg()
+				reg.Players[0].RosterStatus = ROSTER_CONFIRMED
+				return reg, nil
+			},
+			UpdateRegistrationFunc: func(ctx context.Context, registration Registration) error {
+				t.Fatal("should not update an already confirmed player")
+				return nil
+			},
+		}
+
+		_, player, err := ConfirmRosterSpot(context.Background(), repo, eventId, "captain@example.com", "token")
+		assert.NoError(t, err)
+		assert.Equal(t, ROSTER_CONFIRMED, player.RosterStatus)
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return newTeamReg(), nil
+			},
+		}
+
+		_, _, err := ConfirmRosterSpot(context.Background(), repo, eventId, "captain@example.com", "wrong")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_ROSTER_INVITE, registrationErr.Reason)
+	})
+
+	t.Run("not a team registration", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return &IndividualRegistration{EventID: eventId, Email: "captain@example.com"}, nil
+			},
+		}
+
+		_, _, err := ConfirmRosterSpot(context.Background(), repo, eventId, "captain@example.com", "token")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_NOT_A_TEAM_REGISTRATION, registrationErr.Reason)
+	})
+}
+
+func TestResendRosterInvitations(t *testing.T) {
+	eventId := uuid.New()
+
+	newTeamReg := func() *TeamRegistration {
+		return &TeamRegistration{
+			EventID:      eventId,
+			Version:      1,
+			CaptainEmail: "captain@example.com",
+			TeamName:     "Team",
+			Players: []PlayerInfo{
+				{FirstName: "Captain", Email: ptr.String("captain@example.com"), RosterStatus: ROSTER_CONFIRMED, InviteToken: "a"},
+				{FirstName: "One", Email: ptr.String("one@example.com"), RosterStatus: ROSTER_INVITED, InviteToken: "b"},
+				{FirstName: "Two", Email: ptr.String("two@example.com")},
+				{FirstName: "No Email"},
+			},
+		}
+	}
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{ID: id, Name: "Event"}, nil
+		},
+	}
+
+	t.Run("invites every unconfirmed player", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return newTeamReg(), nil
+			},
+		}
+		sender := &mockEmailSender{}
+
+		teamReg, numInvited, err := ResendRosterInvitations(context.Background(), repo, eventRepo, sender, email.Address{}, eventId, "captain@example.com", nil, "http://localhost")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, numInvited)
+		assert.Len(t, sender.sent, 2)
+		assert.Equal(t, ROSTER_INVITED, teamReg.Players[2].RosterStatus)
+		assert.NotEmpty(t, teamReg.Players[2].InviteToken)
+		assert.Equal(t, 2, teamReg.Version)
+	})
+
+	t.Run("only invites given players", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return newTeamReg(), nil
+			},
+		}
+		sender := &mockEmailSender{}
+
+		_, numInvited, err := ResendRosterInvitations(context.Background(), repo, eventRepo, sender, email.Address{}, eventId, "captain@example.com", []string{"ONE@example.com"}, "http://localhost")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, numInvited)
+		assert.Len(t, sender.sent, 1)
+		assert.Equal(t, []string{"one@example.com"}, sender.sent[0].ToAddresses)
+	})
+}
@@ -0,0 +1,457 @@
+//go:generate go tool stringer -type=ShareStatus
+
+package registration
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type ShareStatus int
+
+const (
+	SHARE_NONE ShareStatus = iota
+	SHARE_UNPAID
+	SHARE_PAID
+	SHARE_EXPIRED
+)
+
+type ShareCheckout struct {
+	ClientSecret string
+	Amount       *money.Money
+	ExpiresAt    time.Time
+}
+
+// registerTeamWithSplitPayment signs up a team where every player pays their own share of the team fee.
+// The team holds its spot until the payment deadline, so unlike a normal checkout the returned intent
+// is never stored. The captain gets a checkout for their share right away.
+func registerTeamWithSplitPayment(ctx context.Context, event *events.Event, reg *TeamRegistration, registrationRepo Repository, checkoutManager payments.CheckoutManager, paymentReturnURL string) (RegistrationIntent, string, error) {
+	if event.SplitPaymentWindow == nil {
+		return RegistrationIntent{}, "", NewSplitPaymentNotAllowedError("Event does not allow teams to split the payment")
+	}
+	if slices.ContainsFunc(reg.Players, func(p PlayerInfo) bool { return p.Email == nil }) {
+		return RegistrationIntent{}, "", NewSplitPaymentNotAllowedError("Every player needs an email to split the payment")
+	}
+	captainIdx := captainPlayerIndex(reg)
+	if captainIdx == -1 {
+		return RegistrationIntent{}, "", NewSplitPaymentNotAllowedError("Captain must be on the roster to split the payment")
+	}
+
+	err := registerTeam(event, reg, false)
+	if err != nil {
+		return RegistrationIntent{}, "", err
+	}
+
+	reg.PaymentDeadline = ptr.Time(reg.RegisteredAt.Add(*event.SplitPaymentWindow))
+	for i := range reg.Players {
+		reg.Players[i].ShareStatus = SHARE_UNPAID
+	}
+
+	checkout, err := createShareCheckout(ctx, checkoutManager, *event, reg, []int{captainIdx}, paymentReturnURL)
+	if err != nil {
+		return RegistrationIntent{}, "", err
+	}
+
+	event.Version++
+	// The team isn't signed up until every share is paid
+	err = registrationRepo.CreateRegistration(ctx, reg, *event, nil)
+	if err != nil {
+		return RegistrationIntent{}, "", err
+	}
+
+	return RegistrationIntent{
+		EventId:   reg.EventID,
+		Email:     reg.CaptainEmail,
+		ExpiresAt: checkout.ExpiresAt,
+	}, checkout.ClientSecret, nil
+}
+
+// CreateShareCheckout starts a checkout for the share of the player with the given invite token.
+// The captain's checkout also covers every share that expired without being paid.
+func CreateShareCheckout(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, checkoutManager payments.CheckoutManager, eventId uuid.UUID, captainEmail string, shareToken string, paymentReturnURL string) (ShareCheckout, error) {
+	ctx, span := tracer.Start(ctx, "CreateShareCheckout")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", eventId.String()))
+
+	teamReg, err := getTeamRegistration(ctx, registrationRepo, eventId, captainEmail)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ShareCheckout{}, err
+	}
+	if !teamReg.SplitPayment {
+		err = NewSplitPaymentNotAllowedError("Team is not splitting the payment")
+		span.SetStatus(codes.Error, err.Error())
+		return ShareCheckout{}, err
+	}
+
+	idx := slices.IndexFunc(teamReg.Players, func(p PlayerInfo) bool {
+		return p.InviteToken != "" && subtle.ConstantTimeCompare([]byte(p.InviteToken), []byte(shareToken)) == 1
+	})
+	if idx == -1 {
+		err = NewInvalidRosterInviteError("No player on the roster matches the share token")
+		span.SetStatus(codes.Error, err.Error())
+		return ShareCheckout{}, err
+	}
+
+	sharesToPay, err := sharesPaidBy(teamReg, idx, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return ShareCheckout{}, err
+	}
+
+	event, err := eventRepo.GetEvent(ctx, eventId)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ShareCheckout{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
+	}
+
+	checkout, err := createShareCheckout(ctx, checkoutManager, event, teamReg, sharesToPay, paymentReturnURL)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return ShareCheckout{}, err
+	}
+	return checkout, nil
+}
+
+// sharesPaidBy gives the indexes of the players whose shares the player at payerIdx can pay for.
+func sharesPaidBy(reg *TeamRegistration, payerIdx int, now time.Time) ([]int, error) {
+	if payerIdx != captainPlayerIndex(reg) {
+		switch shareStatusAt(reg, reg.Players[payerIdx], now) {
+		case SHARE_PAID:
+			return nil, NewShareAlreadyPaidError("Share has already been paid")
+		case SHARE_EXPIRED:
+			return nil, NewShareExpiredError(fmt.Sprintf("Share expired at %s, the team captain has to pay it now", reg.PaymentDeadline))
+		}
+		return []int{payerIdx}, nil
+	}
+
+	var shares []int
+	for i, p := range reg.Players {
+		status := shareStatusAt(reg, p, now)
+		if status == SHARE_EXPIRED || (i == payerIdx && status == SHARE_UNPAID) {
+			shares = append(shares, i)
+		}
+	}
+	if len(shares) == 0 {
+		return nil, NewShareAlreadyPaidError("Nothing is left for the captain to pay")
+	}
+	return shares, nil
+}
+
+// shareStatusAt treats unpaid shares past the payment deadline as expired, even if
+// ExpireUnpaidShares has not gotten to them yet.
+func shareStatusAt(reg *TeamRegistration, player PlayerInfo, now time.Time) ShareStatus {
+	if player.ShareStatus == SHARE_UNPAID && reg.PaymentDeadline != nil && now.After(*reg.PaymentDeadline) {
+		return SHARE_EXPIRED
+	}
+	return player.ShareStatus
+}
+
+func createShareCheckout(ctx context.Context, checkoutManager payments.CheckoutManager, event events.Event, reg *TeamRegistration, shares []int, paymentReturnURL string) (ShareCheckout, error) {
+	amount, err := sharesAmount(event, reg, shares)
+	if err != nil {
+		return ShareCheckout{}, err
+	}
+
+	tokens := make([]string, 0, len(shares))
+	for _, i := range shares {
+		tokens = append(tokens, reg.Players[i].InviteToken)
+	}
+
+	payer := reg.Players[shares[0]]
+	if len(shares) > 1 {
+		payer = reg.Players[captainPlayerIndex(reg)]
+	}
+
+	checkoutInfo, err := checkoutManager.CreateCheckout(ctx, payments.CheckoutParams{
+		SessionAliveDuration: ptr.Duration(event.CheckoutHold()),
+		ReturnURL:            paymentReturnURL,
+		Items: []payments.Item{
+			{
+				Name:     fmt.Sprintf("%s Team Sign Up - %s share", event.Name, reg.TeamName),
+				Quantity: 1,
+				Price:    amount,
+			},
+		},
+		Metadata: map[string]string{
+			emailKey:       reg.CaptainEmail,
+			eventIdKey:     event.ID.String(),
+			itemTypeKey:    itemTypeEvent,
+			shareTokensKey: strings.Join(tokens, ","),
+		},
+		AllowAdaptivePricing: !event.DisableAdaptivePricing,
+		CustomerEmail:        payer.Email,
+	})
+	if err != nil {
+		return ShareCheckout{}, NewFailedToCreateCheckoutError("Failed to create share checkout", err)
+	}
+
+	return ShareCheckout{
+		ClientSecret: checkoutInfo.ClientSecret,
+		Amount:       amount,
+		ExpiresAt:    time.Now().Add(event.CheckoutHold()),
+	}, nil
+}
+
+// sharesAmount adds up what the given players owe. The team price is split evenly between
+// every player on the roster, with any leftover cents going to the first players.
+func sharesAmount(event events.Event, reg *TeamRegistration, shares []int) (*money.Money, error) {
+	optionIdx := slices.IndexFunc(event.RegistrationOptions, func(v events.EventRegistrationOption) bool { return v.RegType == events.BY_TEAM })
+	if optionIdx == -1 {
+		return nil, NewNotAllowedToSignUpAsTypeError(events.BY_TEAM)
+	}
+	price := event.RegistrationOptions[optionIdx].Price
+
+	split, err := price.Split(len(reg.Players))
+	if err != nil {
+		return nil, NewFailedToCreateCheckoutError("Failed to split the team price", err)
+	}
+
+	amount := money.New(0, price.Currency().Code)
+	for _, i := range shares {
+		amount, err = amount.Add(split[i])
+		if err != nil {
+			return nil, NewFailedToCreateCheckoutError("Failed to add up the share price", err)
+		}
+	}
+	return amount, nil
+}
+
+// setSharesToPaid marks the shares with the given tokens as paid. Paying your share also confirms
+// your roster spot. Once every share is paid the whole registration is paid.
+func setSharesToPaid(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, captainEmail string, shareTokens []string) (Registration, error) {
+	teamReg, err := getTeamRegistration(ctx, registrationRepo, eventId, captainEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range teamReg.Players {
+		player := &teamReg.Players[i]
+		if player.InviteToken == "" || !slices.Contains(shareTokens, player.InviteToken) || player.ShareStatus == SHARE_PAID {
+			continue
+		}
+
+		player.ShareStatus = SHARE_PAID
+		player.SharePaidAt = ptr.Time(now)
+		if player.RosterStatus != ROSTER_CONFIRMED {
+			player.RosterStatus = ROSTER_CONFIRMED
+			player.ConfirmedAt = ptr.Time(now)
+		}
+	}
+
+	teamReg.BumpVersion()
+	if teamReg.Status != STATUS_PAID && !slices.ContainsFunc(teamReg.Players, func(p PlayerInfo) bool { return p.ShareStatus != SHARE_PAID }) {
+		err = teamReg.TransitionTo(STATUS_PAID, StatusChangedByPaymentProvider, "Every share was paid")
+		if err != nil {
+			return nil, err
+		}
+		err = registrationRepo.UpdateRegistrationToPaid(ctx, teamReg, signedUpOutbox(teamReg, now))
+	} else {
+		err = registrationRepo.UpdateRegistration(ctx, teamReg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return teamReg, nil
+}
+
+// SendSharePaymentEmails emails every player other than the captain a link to pay their share.
+func SendSharePaymentEmails(ctx context.Context, emailSender email.Sender, from email.Address, reg *TeamRegistration, event events.Event, frontendBaseURL string) error {
+	ctx, span := tracer.Start(ctx, "SendSharePaymentEmails")
+	defer span.End()
+
+	captainIdx := captainPlayerIndex(reg)
+
+	var errs []error
+	for i, player := range reg.Players {
+		if i == captainIdx || player.Email == nil || player.ShareStatus != SHARE_UNPAID {
+			continue
+		}
+
+		amount, err := sharesAmount(event, reg, []int{i})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		data := map[string]any{
+			"Event":        event,
+			"Registration": reg,
+			"Player":       player,
+			"Amount":       amount.Display(),
+			"PayLink":      SharePaymentLink(frontendBaseURL, reg.EventID, reg.CaptainEmail, player.InviteToken),
+		}
+
+		htmlBody, err := executeEmailTemplate("share-payment.tmpl", data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		textOnlyBody, err := executeEmailTemplate("share-payment-textonly.tmpl", data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		err = emailSender.SendEmail(ctx, email.Email{
+			From:        from,
+			ToAddresses: []string{*player.Email},
+			Subject:     fmt.Sprintf("Pay your share for %q - %q", reg.TeamName, event.Name),
+			HTMLBody:    htmlBody,
+			TextBody:    textOnlyBody,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send share payment email to %s: %w", *player.Email, err))
+		}
+	}
+
+	err := errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
+// ExpireUnpaidShares marks every unpaid share that is past its team's payment deadline as expired
+// and lets the captain know what is left for them to pay. Returns how many teams had shares expire.
+func ExpireUnpaidShares(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, emailSender email.Sender, from email.Address, frontendBaseURL string, now time.Time) (int, error) {
+	ctx, span := tracer.Start(ctx, "ExpireUnpaidShares")
+	defer span.End()
+
+	allEvents, err := events.GetAllEvents(ctx, eventRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, NewFailedToFetchError("Failed to fetch events", err)
+	}
+
+	numExpired := 0
+	var errs []error
+	for _, event := range allEvents {
+		if event.SplitPaymentWindow == nil {
+			continue
+		}
+
+		regs, err := GetAllRegistrations(ctx, registrationRepo, event.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, reg := range regs {
+			teamReg, ok := reg.(*TeamRegistration)
+			if !ok || !teamReg.SplitPayment || teamReg.Status != STATUS_PENDING {
+				continue
+			}
+
+			expired, err := expireTeamShares(ctx, registrationRepo, emailSender, from, teamReg, event, frontendBaseURL, now)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to expire shares for %s: %w", teamReg.CaptainEmail, err))
+			}
+			if expired {
+				numExpired++
+			}
+		}
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return numExpired, err
+}
+
+func expireTeamShares(ctx context.Context, registrationRepo Repository, emailSender email.Sender, from email.Address, reg *TeamRegistration, event events.Event, frontendBaseURL string, now time.Time) (bool, error) {
+	var expiredPlayers []PlayerInfo
+	for i := range reg.Players {
+		player := &reg.Players[i]
+		if player.ShareStatus == SHARE_UNPAID && shareStatusAt(reg, *player, now) == SHARE_EXPIRED {
+			player.ShareStatus = SHARE_EXPIRED
+			expiredPlayers = append(expiredPlayers, *player)
+		}
+	}
+	if len(expiredPlayers) == 0 {
+		return false, nil
+	}
+
+	reg.BumpVersion()
+	err := registrationRepo.UpdateRegistration(ctx, reg)
+	if err != nil {
+		return false, err
+	}
+
+	captainIdx := captainPlayerIndex(reg)
+	shares, err := sharesPaidBy(reg, captainIdx, now)
+	if err != nil {
+		return true, err
+	}
+	amount, err := sharesAmount(event, reg, shares)
+	if err != nil {
+		return true, err
+	}
+
+	data := map[string]any{
+		"Event":          event,
+		"Registration":   reg,
+		"ExpiredPlayers": expiredPlayers,
+		"Amount":         amount.Display(),
+		"PayLink":        SharePaymentLink(frontendBaseURL, reg.EventID, reg.CaptainEmail, reg.Players[captainIdx].InviteToken),
+	}
+
+	htmlBody, err := executeEmailTemplate("shares-expired.tmpl", data)
+	if err != nil {
+		return true, err
+	}
+	textOnlyBody, err := executeEmailTemplate("shares-expired-textonly.tmpl", data)
+	if err != nil {
+		return true, err
+	}
+
+	err = emailSender.SendEmail(ctx, email.Email{
+		From:        from,
+		ToAddresses: []string{reg.CaptainEmail},
+		Subject:     fmt.Sprintf("Unpaid shares for %q - %q", reg.TeamName, event.Name),
+		HTMLBody:    htmlBody,
+		TextBody:    textOnlyBody,
+	})
+	if err != nil {
+		return true, fmt.Errorf("failed to send expired shares email to %s: %w", reg.CaptainEmail, err)
+	}
+	return true, nil
+}
+
+func SharePaymentLink(frontendBaseURL string, eventId uuid.UUID, captainEmail string, shareToken string) string {
+	query := url.Values{}
+	query.Set("captain", captainEmail)
+	query.Set("token", shareToken)
+
+	return fmt.Sprintf("%s/events/%s/pay-share?%s", frontendBaseURL, eventId, query.Encode())
+}
+
+func captainPlayerIndex(reg *TeamRegistration) int {
+	return slices.IndexFunc(reg.Players, func(p PlayerInfo) bool {
+		return p.Email != nil && strings.EqualFold(*p.Email, reg.CaptainEmail)
+	})
+}
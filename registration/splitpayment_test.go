@@ -0,0 +1,374 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/email"
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func splitPaymentEvent(eventId uuid.UUID) events.Event {
+	return events.Event{
+		ID:                    eventId,
+		Name:                  "Split Event",
+		Version:               1,
+		RegistrationCloseTime: time.Now().Add(24 * time.Hour),
+		RegistrationOptions: []events.EventRegistrationOption{{
+			RegType: events.BY_TEAM,
+			Price:   money.New(10000, "USD"),
+		}},
+		AllowedTeamSizeRange: events.Range{Min: 1, Max: 5},
+		SplitPaymentWindow:   ptr.Duration(72 * time.Hour),
+	}
+}
+
+func splitTeamReg(eventId uuid.UUID) *TeamRegistration {
+	deadline := time.Now().Add(time.Hour)
+	return &TeamRegistration{
+		EventID:         eventId,
+		Version:         1,
+		CaptainEmail:    "captain@example.com",
+		TeamName:        "Team",
+		SplitPayment:    true,
+		PaymentDeadline: &deadline,
+		Players: []PlayerInfo{
+			{FirstName: "Captain", Email: ptr.String("captain@example.com"), InviteToken: "a", ShareStatus: SHARE_UNPAID, RosterStatus: ROSTER_CONFIRMED},
+			{FirstName: "One", Email: ptr.String("one@example.com"), InviteToken: "b", ShareStatus: SHARE_UNPAID, RosterStatus: ROSTER_INVITED},
+			{FirstName: "Two", Email: ptr.String("two@example.com"), InviteToken: "c", ShareStatus: SHARE_UNPAID, RosterStatus: ROSTER_INVITED},
+		},
+	}
+}
+
+func TestRegisterWithPaymentSplitPayment(t *testing.T) {
+	eventId := uuid.New()
+
+	t.Run("captain pays their share", func(t *testing.T) {
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return splitPaymentEvent(eventId), nil
+			},
+		}
+		var created Registration
+		registrationRepo := &mockRegistrationRepository{
+			CreateRegistrationFunc: func(ctx context.Context, registration Registration, event events.Event, outbox []OutboxItem) error {
+				assert.Equal(t, 2, event.Version)
+				created = registration
+				return nil
+			},
+			CreateRegistrationWithPaymentFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error {
+				t.Fatal("should not store an intent for a split payment")
+				return nil
+			},
+		}
+		var checkoutParams payments.CheckoutParams
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				checkoutParams = params
+				return payments.CheckoutInfo{ClientSecret: "secret"}, nil
+			},
+		}
+		registrationRequest := &TeamRegistration{
+			EventID:      eventId,
+			RegisteredAt: time.Now(),
+			CaptainEmail: "captain@example.com",
+			SplitPayment: true,
+			Players: []PlayerInfo{
+				{FirstName: "Captain", Email: ptr.String("captain@example.com")},
+				{FirstName: "One", Email: ptr.String("one@example.com")},
+				{FirstName: "Two", Email: ptr.String("two@example.com")},
+			},
+		}
+
+		reg, _, clientSecret, event, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, registrationRepo, checkoutManager, "https://return.url")
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", clientSecret)
+		assert.Equal(t, 1, event.NumTeams)
+		assert.Equal(t, registrationRequest, created)
+
+		teamReg := reg.(*TeamRegistration)
+		assert.Equal(t, teamReg.RegisteredAt.Add(72*time.Hour), *teamReg.PaymentDeadline)
+		for _, p := range teamReg.Players {
+			assert.Equal(t, SHARE_UNPAID, p.ShareStatus)
+		}
+
+		// 100 split 3 ways gives the leftover cent to the first player
+		assert.Equal(t, int64(3334), checkoutParams.Items[0].Price.Amount())
+		assert.Equal(t, teamReg.Players[0].InviteToken, checkoutParams.Metadata[shareTokensKey])
+		assert.Equal(t, "captain@example.com", checkoutParams.Metadata[emailKey])
+	})
+
+	t.Run("event does not allow split payments", func(t *testing.T) {
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				event := splitPaymentEvent(eventId)
+				event.SplitPaymentWindow = nil
+				return event, nil
+			},
+		}
+		registrationRequest := &TeamRegistration{
+			EventID:      eventId,
+			CaptainEmail: "captain@example.com",
+			SplitPayment: true,
+			Players:      []PlayerInfo{{FirstName: "Captain", Email: ptr.String("captain@example.com")}},
+		}
+
+		_, _, _, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, &mockRegistrationRepository{}, &mockCheckoutManager{}, "https://return.url")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_SPLIT_PAYMENT_NOT_ALLOWED, registrationErr.Reason)
+	})
+
+	t.Run("captain must be on the roster", func(t *testing.T) {
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				return splitPaymentEvent(eventId), nil
+			},
+		}
+		registrationRequest := &TeamRegistration{
+			EventID:      eventId,
+			CaptainEmail: "captain@example.com",
+			SplitPayment: true,
+			Players:      []PlayerInfo{{FirstName: "One", Email: ptr.String("one@example.com")}},
+		}
+
+		_, _, _, _, err := RegisterWithPayment(context.Background(), registrationRequest, eventRepo, &mockRegistrationRepository{}, &mockCheckoutManager{}, "https://return.url")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_SPLIT_PAYMENT_NOT_ALLOWED, registrationErr.Reason)
+	})
+}
+
+func TestCreateShareCheckout(t *testing.T) {
+	eventId := uuid.New()
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return splitPaymentEvent(eventId), nil
+		},
+	}
+
+	t.Run("player pays their share", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return splitTeamReg(eventId), nil
+			},
+		}
+		var checkoutParams payments.CheckoutParams
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				checkoutParams = params
+				return payments.CheckoutInfo{ClientSecret: "secret"}, nil
+			},
+		}
+
+		checkout, err := CreateShareCheckout(context.Background(), repo, eventRepo, checkoutManager, eventId, "captain@example.com", "b", "https://return.url")
+		assert.NoError(t, err)
+		assert.Equal(t, "secret", checkout.ClientSecret)
+		assert.Equal(t, int64(3333), checkout.Amount.Amount())
+		assert.Equal(t, "b", checkoutParams.Metadata[shareTokensKey])
+		assert.Equal(t, "one@example.com", *checkoutParams.CustomerEmail)
+	})
+
+	t.Run("already paid", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				reg := splitTeamReg(eventId)
+				reg.Players[1].ShareStatus = SHARE_PAID
+				return reg, nil
+			},
+		}
+
+		_, err := CreateShareCheckout(context.Background(), repo, eventRepo, &mockCheckoutManager{}, eventId, "captain@example.com", "b", "https://return.url")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_SHARE_ALREADY_PAID, registrationErr.Reason)
+	})
+
+	t.Run("player past the deadline", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				reg := splitTeamReg(eventId)
+				reg.PaymentDeadline = ptr.Time(time.Now().Add(-time.Minute))
+				return reg, nil
+			},
+		}
+
+		_, err := CreateShareCheckout(context.Background(), repo, eventRepo, &mockCheckoutManager{}, eventId, "captain@example.com", "b", "https://return.url")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_SHARE_EXPIRED, registrationErr.Reason)
+	})
+
+	t.Run("captain covers expired shares", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				reg := splitTeamReg(eventId)
+				reg.Players[0].ShareStatus = SHARE_PAID
+				reg.Players[1].ShareStatus = SHARE_EXPIRED
+				reg.Players[2].ShareStatus = SHARE_EXPIRED
+				return reg, nil
+			},
+		}
+		var checkoutParams payments.CheckoutParams
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				checkoutParams = params
+				return payments.CheckoutInfo{ClientSecret: "secret"}, nil
+			},
+		}
+
+		checkout, err := CreateShareCheckout(context.Background(), repo, eventRepo, checkoutManager, eventId, "captain@example.com", "a", "https://return.url")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6666), checkout.Amount.Amount())
+		assert.Equal(t, "b,c", checkoutParams.Metadata[shareTokensKey])
+		assert.Equal(t, "captain@example.com", *checkoutParams.CustomerEmail)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return splitTeamReg(eventId), nil
+			},
+		}
+
+		_, err := CreateShareCheckout(context.Background(), repo, eventRepo, &mockCheckoutManager{}, eventId, "captain@example.com", "wrong", "https://return.url")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_ROSTER_INVITE, registrationErr.Reason)
+	})
+}
+
+func TestConfirmRegistrationPaymentShares(t *testing.T) {
+	eventId := uuid.New()
+
+	shareCheckoutManager := func(tokens string, err error) *mockCheckoutManager {
+		return &mockCheckoutManager{
+			ConfirmCheckoutFunc: func(ctx context.Context, payload []byte, signature string) (map[string]string, error) {
+				return map[string]string{
+					"EMAIL":        "captain@example.com",
+					"EVENT_ID":     eventId.String(),
+					"ITEM_TYPE":    "event_registration",
+					"SHARE_TOKENS": tokens,
+				}, err
+			},
+		}
+	}
+
+	t.Run("paying a share confirms the roster spot", func(t *testing.T) {
+		var updated Registration
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return splitTeamReg(eventId), nil
+			},
+			UpdateRegistrationFunc: func(ctx context.Context, registration Registration) error {
+				updated = registration
+				return nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+				t.Fatal("team should not be paid until every share is")
+				return nil
+			},
+		}
+
+		reg, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_share"), "", repo, &mockEventRepository{}, &mockWebhookEventRepository{}, shareCheckoutManager("b", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, reg, updated)
+
+		teamReg := reg.(*TeamRegistration)
+		assert.Equal(t, STATUS_PENDING, teamReg.Status)
+		assert.Equal(t, 2, teamReg.Version)
+		assert.Equal(t, SHARE_PAID, teamReg.Players[1].ShareStatus)
+		assert.NotNil(t, teamReg.Players[1].SharePaidAt)
+		assert.Equal(t, ROSTER_CONFIRMED, teamReg.Players[1].RosterStatus)
+		assert.Equal(t, SHARE_UNPAID, teamReg.Players[2].ShareStatus)
+	})
+
+	t.Run("paying the last shares pays the team", func(t *testing.T) {
+		var paid Registration
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				reg := splitTeamReg(eventId)
+				reg.Players[0].ShareStatus = SHARE_PAID
+				return reg, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+				paid = registration
+				return nil
+			},
+		}
+
+		reg, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_share"), "", repo, &mockEventRepository{}, &mockWebhookEventRepository{}, shareCheckoutManager("b,c", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, reg, paid)
+		assert.Equal(t, STATUS_PAID, reg.(*TeamRegistration).Status)
+	})
+
+	t.Run("expired share checkout keeps the registration", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			DeleteExpiredRegistrationFunc: func(ctx context.Context, registration Registration, intent RegistrationIntent, event events.Event) error {
+				t.Fatal("should not delete the team when a share checkout expires")
+				return nil
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_share"), "", repo, &mockEventRepository{}, &mockWebhookEventRepository{}, shareCheckoutManager("b", payments.NewCheckoutExpiredError("expired")))
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_SHARE_CHECKOUT_EXPIRED, registrationErr.Reason)
+	})
+}
+
+func TestExpireUnpaidShares(t *testing.T) {
+	eventId := uuid.New()
+	otherEventId := uuid.New()
+
+	eventRepo := &mockEventRepository{
+		GetEventsFunc: func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+			event := splitPaymentEvent(eventId)
+			otherEvent := splitPaymentEvent(otherEventId)
+			otherEvent.SplitPaymentWindow = nil
+			return events.GetEventsResponse{Data: []events.Event{event, otherEvent}}, nil
+		},
+	}
+
+	pastDeadline := splitTeamReg(eventId)
+	pastDeadline.PaymentDeadline = ptr.Time(time.Now().Add(-time.Minute))
+	pastDeadline.Players[1].ShareStatus = SHARE_PAID
+
+	var updated []Registration
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			assert.Equal(t, eventId, id)
+			return GetAllRegistrationsResponse{
+				Data: []Registration{pastDeadline, splitTeamReg(eventId), &IndividualRegistration{EventID: eventId}},
+			}, nil
+		},
+		UpdateRegistrationFunc: func(ctx context.Context, registration Registration) error {
+			updated = append(updated, registration)
+			return nil
+		},
+	}
+	sender := &mockEmailSender{}
+
+	numExpired, err := ExpireUnpaidShares(context.Background(), repo, eventRepo, sender, email.Address{}, "http://localhost", time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numExpired)
+	assert.Equal(t, []Registration{pastDeadline}, updated)
+
+	assert.Equal(t, SHARE_EXPIRED, pastDeadline.Players[0].ShareStatus)
+	assert.Equal(t, SHARE_PAID, pastDeadline.Players[1].ShareStatus)
+	assert.Equal(t, SHARE_EXPIRED, pastDeadline.Players[2].ShareStatus)
+
+	assert.Len(t, sender.sent, 1)
+	assert.Equal(t, []string{"captain@example.com"}, sender.sent[0].ToAddresses)
+	assert.True(t, strings.Contains(sender.sent[0].TextBody, "$66.67"))
+	assert.True(t, strings.Contains(sender.sent[0].TextBody, "Two"))
+}
@@ -0,0 +1,160 @@
+//go:generate go tool stringer -type=Status
+
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"go.opentelemetry.io/otel/codes"
+)
+
+type Status int
+
+const (
+	// Signed up but has not paid yet
+	STATUS_PENDING Status = iota
+	STATUS_PAID
+	// Let in without paying
+	STATUS_COMPED
+	STATUS_CANCELLED
+	STATUS_REFUNDED
+	// Never paid before the checkout expired
+	STATUS_EXPIRED
+	// Signed up for free, with the email verified if the event asks for it
+	STATUS_CONFIRMED
+)
+
+// Who or what changed a registration's status when it wasn't a person
+const (
+	StatusChangedByPaymentProvider = "payment_provider"
+	StatusChangedBySystem          = "system"
+)
+
+type StatusChange struct {
+	From Status
+	To   Status
+	// Email of whoever made the change, or one of the StatusChangedBy constants
+	ChangedBy string
+	Reason    string
+	ChangedAt time.Time
+}
+
+var legalStatusTransitions = map[Status][]Status{
+	STATUS_PENDING:   {STATUS_PAID, STATUS_COMPED, STATUS_CONFIRMED, STATUS_CANCELLED, STATUS_EXPIRED},
+	STATUS_PAID:      {STATUS_REFUNDED, STATUS_CANCELLED},
+	STATUS_COMPED:    {STATUS_CANCELLED},
+	STATUS_CONFIRMED: {STATUS_CANCELLED},
+	STATUS_CANCELLED: {},
+	STATUS_REFUNDED:  {},
+	STATUS_EXPIRED:   {},
+}
+
+func CanTransition(from Status, to Status) bool {
+	return slices.Contains(legalStatusTransitions[from], to)
+}
+
+// transitionStatus moves status to the new status and records the change in history,
+// as long as it is a legal transition.
+func transitionStatus(status *Status, history *[]StatusChange, to Status, changedBy string, reason string) error {
+	if !CanTransition(*status, to) {
+		return NewIllegalStatusTransitionError(*status, to)
+	}
+
+	*history = append(*history, StatusChange{
+		From:      *status,
+		To:        to,
+		ChangedBy: changedBy,
+		Reason:    reason,
+		ChangedAt: time.Now(),
+	})
+	*status = to
+
+	return nil
+}
+
+// StatusFromPaid is the status of a registration saved before statuses existed, when all
+// that was tracked was if it was paid. Unpaid ones were either free sign ups or still waiting
+// on their checkout, which takes knowing the event's prices to tell apart.
+func StatusFromPaid(paid bool, freeSignUp bool) Status {
+	switch {
+	case paid:
+		return STATUS_PAID
+	case freeSignUp:
+		return STATUS_CONFIRMED
+	default:
+		return STATUS_PENDING
+	}
+}
+
+// RewriteRegistrations rewrites every registration so the ones saved before statuses and the
+// email index existed get them stored. Free sign ups from back then are confirmed, since the
+// stored item alone can't tell them apart from an unpaid checkout. It is safe to run more than once.
+func RewriteRegistrations(ctx context.Context, registrationRepo Repository, eventRepo events.Repository) (int, error) {
+	ctx, span := tracer.Start(ctx, "RewriteRegistrations")
+	defer span.End()
+
+	allEvents, err := events.GetAllEvents(ctx, eventRepo)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return 0, NewFailedToFetchError("Failed to fetch events", err)
+	}
+
+	numRewritten := 0
+	var errs []error
+	for _, event := range allEvents {
+		regs, err := GetAllRegistrations(ctx, registrationRepo, event.ID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, reg := range regs {
+			err := confirmFreeSignUpFromBeforeStatuses(ctx, registrationRepo, event, reg)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to confirm %s: %w", reg.GetEmail(), err))
+				continue
+			}
+
+			reg.BumpVersion()
+			err = registrationRepo.UpdateRegistration(ctx, reg)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to rewrite %s: %w", reg.GetEmail(), err))
+				continue
+			}
+			numRewritten++
+		}
+	}
+
+	err = errors.Join(errs...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return numRewritten, err
+}
+
+// confirmFreeSignUpFromBeforeStatuses confirms a pending registration that was a free sign up. Pending ones
+// that are still waiting on a checkout or an email verification have an intent and are left alone.
+func confirmFreeSignUpFromBeforeStatuses(ctx context.Context, registrationRepo Repository, event events.Event, reg Registration) error {
+	if reg.GetStatus() != STATUS_PENDING || len(reg.GetStatusHistory()) > 0 {
+		return nil
+	}
+
+	_, err := registrationRepo.GetRegistrationIntent(ctx, reg.GetEventID(), reg.GetEmail())
+	if err == nil {
+		return nil
+	} else if !registrationDoesNotExist(err) {
+		return err
+	}
+
+	if StatusFromPaid(false, isFreeSignUp(event, reg.Type())) != STATUS_CONFIRMED {
+		return nil
+	}
+	return reg.TransitionTo(STATUS_CONFIRMED, StatusChangedBySystem, "Free sign up from before statuses were tracked")
+}
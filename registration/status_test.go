@@ -0,0 +1,147 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitionTo(t *testing.T) {
+	t.Run("legal transition records history", func(t *testing.T) {
+		reg := &IndividualRegistration{Status: STATUS_PENDING}
+
+		err := reg.TransitionTo(STATUS_PAID, StatusChangedByPaymentProvider, "Checkout completed")
+		assert.NoError(t, err)
+		assert.Equal(t, STATUS_PAID, reg.GetStatus())
+		assert.Len(t, reg.GetStatusHistory(), 1)
+		assert.Equal(t, STATUS_PENDING, reg.StatusHistory[0].From)
+		assert.Equal(t, STATUS_PAID, reg.StatusHistory[0].To)
+		assert.Equal(t, StatusChangedByPaymentProvider, reg.StatusHistory[0].ChangedBy)
+		assert.Equal(t, "Checkout completed", reg.StatusHistory[0].Reason)
+		assert.False(t, reg.StatusHistory[0].ChangedAt.IsZero())
+	})
+
+	t.Run("illegal transition is rejected", func(t *testing.T) {
+		reg := &TeamRegistration{Status: STATUS_EXPIRED}
+
+		err := reg.TransitionTo(STATUS_PAID, StatusChangedBySystem, "")
+		var registrationErr *Error
+		assert.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_ILLEGAL_STATUS_TRANSITION, registrationErr.Reason)
+		assert.Equal(t, STATUS_EXPIRED, reg.GetStatus())
+		assert.Empty(t, reg.GetStatusHistory())
+	})
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from  Status
+		to    Status
+		legal bool
+	}{
+		{STATUS_PENDING, STATUS_PAID, true},
+		{STATUS_PENDING, STATUS_COMPED, true},
+		{STATUS_PENDING, STATUS_EXPIRED, true},
+		{STATUS_PENDING, STATUS_REFUNDED, false},
+		{STATUS_PAID, STATUS_REFUNDED, true},
+		{STATUS_PAID, STATUS_PENDING, false},
+		{STATUS_COMPED, STATUS_CANCELLED, true},
+		{STATUS_COMPED, STATUS_REFUNDED, false},
+		{STATUS_REFUNDED, STATUS_PAID, false},
+		{STATUS_CANCELLED, STATUS_PENDING, false},
+		{STATUS_PENDING, STATUS_CONFIRMED, true},
+		{STATUS_CONFIRMED, STATUS_CANCELLED, true},
+		{STATUS_CONFIRMED, STATUS_PAID, false},
+		{STATUS_CONFIRMED, STATUS_PENDING, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+" to "+tt.to.String(), func(t *testing.T) {
+			assert.Equal(t, tt.legal, CanTransition(tt.from, tt.to))
+		})
+	}
+}
+
+func TestRewriteRegistrations(t *testing.T) {
+	eventId := uuid.New()
+	eventRepo := &mockEventRepository{
+		GetEventsFunc: func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+			return events.GetEventsResponse{Data: []events.Event{{ID: eventId}}}, nil
+		},
+	}
+	var updated []Registration
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			return GetAllRegistrationsResponse{Data: []Registration{
+				&IndividualRegistration{EventID: id, Version: 1, Status: STATUS_PAID},
+			}}, nil
+		},
+		UpdateRegistrationFunc: func(ctx context.Context, reg Registration) error {
+			updated = append(updated, reg)
+			return nil
+		},
+	}
+
+	numRewritten, err := RewriteRegistrations(context.Background(), repo, eventRepo)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numRewritten)
+	assert.Len(t, updated, 1)
+	assert.Equal(t, 2, updated[0].(*IndividualRegistration).Version)
+}
+
+func TestStatusFromPaid(t *testing.T) {
+	assert.Equal(t, STATUS_PAID, StatusFromPaid(true, false))
+	assert.Equal(t, STATUS_CONFIRMED, StatusFromPaid(false, true))
+	assert.Equal(t, STATUS_PENDING, StatusFromPaid(false, false))
+}
+
+func TestRewriteRegistrationsConfirmsFreeSignUps(t *testing.T) {
+	freeEvent := uuid.New()
+	paidEvent := uuid.New()
+	eventRepo := &mockEventRepository{
+		GetEventsFunc: func(ctx context.Context, limit int32, cursor *string) (events.GetEventsResponse, error) {
+			return events.GetEventsResponse{Data: []events.Event{
+				{ID: freeEvent, RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(0, "USD")}}},
+				{ID: paidEvent, RegistrationOptions: []events.EventRegistrationOption{{RegType: events.BY_INDIVIDUAL, Price: money.New(5000, "USD")}}},
+			}}, nil
+		},
+	}
+	updated := map[string]Registration{}
+	repo := &mockRegistrationRepository{
+		GetAllRegistrationsForEventFunc: func(ctx context.Context, id uuid.UUID, limit int32, cursor *string) (GetAllRegistrationsResponse, error) {
+			if id == freeEvent {
+				return GetAllRegistrationsResponse{Data: []Registration{
+					&IndividualRegistration{EventID: id, Version: 1, Email: "free@example.com"},
+					&IndividualRegistration{EventID: id, Version: 1, Email: "verifying@example.com"},
+				}}, nil
+			}
+			return GetAllRegistrationsResponse{Data: []Registration{
+				&IndividualRegistration{EventID: id, Version: 1, Email: "checkout@example.com"},
+			}}, nil
+		},
+		GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error) {
+			if email == "verifying@example.com" {
+				return RegistrationIntent{EventId: eventId, Email: email, VerificationToken: "token"}, nil
+			}
+			return noIntent(ctx, eventId, email)
+		},
+		UpdateRegistrationFunc: func(ctx context.Context, reg Registration) error {
+			updated[reg.GetEmail()] = reg
+			return nil
+		},
+	}
+
+	numRewritten, err := RewriteRegistrations(context.Background(), repo, eventRepo)
+	require.NoError(t, err)
+	assert.Equal(t, 3, numRewritten)
+	assert.Equal(t, STATUS_CONFIRMED, updated["free@example.com"].GetStatus())
+	// Still waiting on their email to be verified
+	assert.Equal(t, STATUS_PENDING, updated["verifying@example.com"].GetStatus())
+	assert.Equal(t, STATUS_PENDING, updated["checkout@example.com"].GetStatus())
+}
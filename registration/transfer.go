@@ -0,0 +1,536 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// Transfer records a registration being handed to another person, moved to another event, or both.
+type Transfer struct {
+	ID          uuid.UUID
+	FromEventID uuid.UUID
+	FromEmail   string
+	ToEventID   uuid.UUID
+	ToEmail     string
+	// New event's price minus the old one's, only set when a paid registration moved events.
+	// More than 0 is charged through a checkout, less than 0 is refunded.
+	PriceDifference *money.Money
+	// If the checkout for a positive price difference has been paid
+	ChargePaid bool
+	// Refund on the registration that gave back a negative price difference
+	RefundID      *uuid.UUID
+	Reason        string
+	TransferredBy string
+	TransferredAt time.Time
+}
+
+type TransferParams struct {
+	EventID uuid.UUID
+	Email   string
+	// Person taking over the registration, keeps the current registrant if nil.
+	// For teams they replace the captain.
+	NewPlayer *PlayerInfo
+	// Event to move the registration to, stays at the current event if nil
+	NewEventID       *uuid.UUID
+	Reason           string
+	TransferredBy    string
+	PaymentReturnURL string
+}
+
+// PendingTransfer is a transfer waiting on its price difference to be paid. The registration
+// stays where it is until the payment provider says the checkout was paid.
+type PendingTransfer struct {
+	Transfer Transfer
+	// Person taking over the registration, nil if the registrant stays the same.
+	// Only their name and email are kept, that's all changeRegistrant uses.
+	NewPlayer *PlayerInfo
+}
+
+type TransferResult struct {
+	// The transferred registration, or the original one holding the pending transfer
+	// when the price difference has to be paid first
+	Registration Registration
+	Transfer     Transfer
+	// Event the registration is at now
+	Event events.Event
+	// Only set when the new event costs more and the difference has to be paid before
+	// the registration is moved
+	ClientSecret string
+}
+
+// TransferRegistration hands a registration to someone else and/or moves it to another event.
+// Moving events updates both events' counts, and for paid registrations either refunds the
+// price difference from the original payment or charges it with a new checkout. A transfer that
+// has to be charged is only saved as pending, and the registration is moved once it's paid.
+func TransferRegistration(ctx context.Context, params TransferParams, registrationRepo Repository, eventRepo events.Repository, checkoutManager payments.CheckoutManager, paymentQuerier payments.PaymentQuerier, refunder Refunder) (TransferResult, error) {
+	ctx, span := tracer.Start(ctx, "TransferRegistration")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("event_id", params.EventID.String()))
+
+	from, err := registrationRepo.GetRegistration(ctx, params.EventID, params.Email)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return TransferResult{}, err
+	}
+
+	err = checkTransferable(ctx, registrationRepo, from, params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return TransferResult{}, err
+	}
+
+	transfer := Transfer{
+		ID:            uuid.New(),
+		FromEventID:   from.GetEventID(),
+		FromEmail:     from.GetEmail(),
+		ToEventID:     from.GetEventID(),
+		ToEmail:       from.GetEmail(),
+		Reason:        params.Reason,
+		TransferredBy: params.TransferredBy,
+		TransferredAt: time.Now(),
+	}
+
+	oldEvent, err := getTransferEvent(ctx, eventRepo, from.GetEventID())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return TransferResult{}, err
+	}
+	newEvent := oldEvent
+	if params.NewEventID != nil && *params.NewEventID != from.GetEventID() {
+		newEvent, err = getTransferEvent(ctx, eventRepo, *params.NewEventID)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return TransferResult{}, err
+		}
+	}
+
+	to, eventUpdates, err := applyTransfer(from, &transfer, params.NewPlayer, oldEvent, newEvent)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return TransferResult{}, err
+	}
+
+	if from.GetStatus() == STATUS_PAID && transfer.ToEventID != transfer.FromEventID {
+		transfer.PriceDifference, err = priceDifference(oldEvent, newEvent, from.Type())
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			return TransferResult{}, err
+		}
+	}
+
+	if transfer.PriceDifference != nil && transfer.PriceDifference.IsPositive() {
+		// Nothing is moved until the difference is paid, so abandoning the checkout can't
+		// get someone into a pricier event for free
+		pending, clientSecret, err := holdTransferForPayment(ctx, registrationRepo, checkoutManager, from, transfer, params.NewPlayer, newEvent, params.PaymentReturnURL)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return TransferResult{}, err
+		}
+		return TransferResult{
+			Registration: pending,
+			Transfer:     transfer,
+			Event:        oldEvent,
+			ClientSecret: clientSecret,
+		}, nil
+	}
+
+	if transfer.PriceDifference != nil && transfer.PriceDifference.IsNegative() {
+		err = refundPriceDifference(ctx, &transfer, newEvent, from, to, paymentQuerier, refunder)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return TransferResult{}, err
+		}
+	}
+
+	to.AddTransfer(transfer)
+	to.BumpVersion()
+
+	err = registrationRepo.TransferRegistration(ctx, from, to, eventUpdates)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if transfer.RefundID != nil {
+			// The money was already given back at this point, so make sure whoever is looking
+			// at the error knows which refund never got recorded.
+			return TransferResult{}, NewFailedToWriteError(fmt.Sprintf("Transfer refund %q was issued but the transfer failed to be saved", transfer.RefundID.String()), err)
+		}
+		return TransferResult{}, err
+	}
+
+	return TransferResult{
+		Registration: to,
+		Transfer:     transfer,
+		Event:        newEvent,
+	}, nil
+}
+
+// applyTransfer makes the transferred copy of from, along with both events' updated counts if it
+// moves events. It's done again from a pending transfer once its price difference is paid, checked
+// against when the transfer was asked for.
+func applyTransfer(from Registration, transfer *Transfer, newPlayer *PlayerInfo, oldEvent events.Event, newEvent events.Event) (Registration, []events.Event, error) {
+	to := cloneRegistration(from)
+	if newPlayer != nil {
+		err := changeRegistrant(to, *newPlayer, transfer.TransferredAt)
+		if err != nil {
+			return nil, nil, err
+		}
+		transfer.ToEmail = to.GetEmail()
+	}
+
+	if oldEvent.ID == newEvent.ID {
+		return to, nil, nil
+	}
+
+	err := moveRegistration(&oldEvent, &newEvent, to, transfer.TransferredAt)
+	if err != nil {
+		return nil, nil, err
+	}
+	transfer.ToEventID = newEvent.ID
+
+	oldEvent.Version++
+	newEvent.Version++
+	return to, []events.Event{oldEvent, newEvent}, nil
+}
+
+// holdTransferForPayment starts the checkout for a transfer's price difference and saves the
+// transfer as pending on from, behind an intent for the checkout. The checkout is under from's
+// email and event, since that's where the registration is until it's paid.
+func holdTransferForPayment(ctx context.Context, registrationRepo Repository, checkoutManager payments.CheckoutManager, from Registration, transfer Transfer, newPlayer *PlayerInfo, newEvent events.Event, paymentReturnURL string) (Registration, string, error) {
+	checkoutInfo, err := checkoutManager.CreateCheckout(ctx, payments.CheckoutParams{
+		SessionAliveDuration: ptr.Duration(newEvent.CheckoutHold()),
+		ReturnURL:            paymentReturnURL,
+		Items: []payments.Item{
+			{
+				Name:     fmt.Sprintf("%s Transfer Price Difference", newEvent.Name),
+				Quantity: 1,
+				Price:    transfer.PriceDifference,
+			},
+		},
+		Metadata: map[string]string{
+			emailKey:      transfer.FromEmail,
+			eventIdKey:    transfer.FromEventID.String(),
+			itemTypeKey:   itemTypeEvent,
+			transferIdKey: transfer.ID.String(),
+		},
+		AllowAdaptivePricing: !newEvent.DisableAdaptivePricing,
+		CustomerEmail:        ptr.String(transfer.ToEmail),
+	})
+	if err != nil {
+		return nil, "", NewFailedToCreateCheckoutError("Failed to create transfer checkout", err)
+	}
+
+	intent := RegistrationIntent{
+		Version:          1,
+		EventId:          transfer.FromEventID,
+		PaymentSessionId: checkoutInfo.SessionId,
+		ClientSecret:     checkoutInfo.ClientSecret,
+		Email:            transfer.FromEmail,
+		ExpiresAt:        transfer.TransferredAt.Add(newEvent.CheckoutHold()),
+		TransferID:       &transfer.ID,
+	}
+
+	pending := PendingTransfer{Transfer: transfer}
+	if newPlayer != nil {
+		pending.NewPlayer = &PlayerInfo{
+			FirstName: newPlayer.FirstName,
+			LastName:  newPlayer.LastName,
+			Email:     newPlayer.Email,
+		}
+	}
+	reg := cloneRegistration(from)
+	reg.SetPendingTransfer(&pending)
+	reg.BumpVersion()
+
+	err = registrationRepo.CreateTransferWithPayment(ctx, reg, intent)
+	if err != nil {
+		return nil, "", err
+	}
+	return reg, checkoutInfo.ClientSecret, nil
+}
+
+// completePendingTransfer moves the registration once its pending transfer's price difference
+// was paid, and queues the confirmation email for wherever it ended up.
+func completePendingTransfer(ctx context.Context, registrationRepo Repository, eventRepo events.Repository, eventId uuid.UUID, email string, transferId string) (Registration, error) {
+	from, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err != nil {
+		if registrationDoesNotExist(err) {
+			// Already moved, the payment provider can send the same event more than once
+			return nil, NewStaleWebhookEventError(fmt.Sprintf("Transfer %s is not pending anymore", transferId))
+		}
+		return nil, err
+	}
+	pending := from.GetPendingTransfer()
+	if pending == nil || pending.Transfer.ID.String() != transferId {
+		return nil, NewStaleWebhookEventError(fmt.Sprintf("Transfer %s is not pending anymore", transferId))
+	}
+
+	oldEvent, err := getTransferEvent(ctx, eventRepo, pending.Transfer.FromEventID)
+	if err != nil {
+		return nil, err
+	}
+	newEvent, err := getTransferEvent(ctx, eventRepo, pending.Transfer.ToEventID)
+	if err != nil {
+		return nil, err
+	}
+
+	transfer := pending.Transfer
+	to, eventUpdates, err := applyTransfer(from, &transfer, pending.NewPlayer, oldEvent, newEvent)
+	if err != nil {
+		return nil, err
+	}
+	transfer.ChargePaid = true
+	to.SetPendingTransfer(nil)
+	to.AddTransfer(transfer)
+	to.BumpVersion()
+
+	err = registrationRepo.TransferRegistrationToPaid(ctx, from, to, eventUpdates, []OutboxItem{newOutboxItem(OUTBOX_CONFIRMATION_EMAIL, to, time.Now())})
+	if err != nil {
+		return nil, err
+	}
+	return to, nil
+}
+
+// cancelPendingTransfer drops a transfer whose price difference checkout expired, leaving the
+// registration where it was. Only the checkout the registration is still waiting on can drop it.
+func cancelPendingTransfer(ctx context.Context, registrationRepo Repository, eventId uuid.UUID, email string, checkoutSessionId string) (Registration, error) {
+	regIntent, err := registrationRepo.GetRegistrationIntent(ctx, eventId, email)
+	if err != nil {
+		if registrationDoesNotExist(err) {
+			return nil, NewStaleWebhookEventError(fmt.Sprintf("Checkout %s expired but the registration isn't waiting on it", checkoutSessionId))
+		}
+		return nil, err
+	}
+	if regIntent.PaymentSessionId != checkoutSessionId {
+		return nil, NewStaleWebhookEventError(fmt.Sprintf("Checkout %s expired but the registration is waiting on checkout %s", checkoutSessionId, regIntent.PaymentSessionId))
+	}
+
+	reg, err := registrationRepo.GetRegistration(ctx, eventId, email)
+	if err != nil {
+		return nil, err
+	}
+	reg.SetPendingTransfer(nil)
+	reg.BumpVersion()
+
+	err = registrationRepo.DeleteExpiredTransfer(ctx, reg, regIntent)
+	if err != nil {
+		return nil, err
+	}
+	return reg, nil
+}
+
+// waitsOnTransfer is if intent is the checkout for reg's pending transfer.
+func waitsOnTransfer(reg Registration, intent RegistrationIntent) bool {
+	pending := reg.GetPendingTransfer()
+	return pending != nil && intent.TransferID != nil && *intent.TransferID == pending.Transfer.ID
+}
+
+func checkTransferable(ctx context.Context, registrationRepo Repository, reg Registration, params TransferParams) error {
+	switch reg.GetStatus() {
+	case STATUS_CANCELLED, STATUS_REFUNDED, STATUS_EXPIRED:
+		return NewNotTransferableError(fmt.Sprintf("Registration with status %s can not be transferred", reg.GetStatus()))
+	}
+	if teamReg, ok := reg.(*TeamRegistration); ok && teamReg.SplitPayment {
+		return NewNotTransferableError("Teams splitting the payment can not be transferred")
+	}
+
+	// A checkout that is still open would pay for the registration under its old key
+	_, err := registrationRepo.GetRegistrationIntent(ctx, reg.GetEventID(), reg.GetEmail())
+	if err == nil {
+		return NewNotTransferableError("Registration has a checkout in progress")
+	}
+	var regErr *Error
+	if !errors.As(err, &regErr) || regErr.Reason != REASON_REGISTRATION_DOES_NOT_EXIST {
+		return err
+	}
+
+	if params.NewPlayer != nil && params.NewPlayer.Email == nil {
+		return NewNotTransferableError("The person taking over the registration needs an email")
+	}
+	changesPerson := params.NewPlayer != nil && !strings.EqualFold(*params.NewPlayer.Email, reg.GetEmail())
+	changesEvent := params.NewEventID != nil && *params.NewEventID != reg.GetEventID()
+	if !changesPerson && !changesEvent {
+		return NewNotTransferableError("Transfer needs a new person or a new event")
+	}
+	return nil
+}
+
+func getTransferEvent(ctx context.Context, eventRepo events.Repository, eventId uuid.UUID) (events.Event, error) {
+	event, err := eventRepo.GetEvent(ctx, eventId)
+	if err != nil {
+		var eventErr *events.Error
+		if errors.As(err, &eventErr) && eventErr.Reason == events.REASON_EVENT_DOES_NOT_EXIST {
+			return events.Event{}, NewAssociatedEventDoesNotExistError(fmt.Sprintf("Event does not exist with ID %q", eventId), err)
+		}
+		return events.Event{}, NewFailedToFetchError(fmt.Sprintf("Failed to fetch event with ID %q", eventId), err)
+	}
+	return event, nil
+}
+
+// cloneRegistration copies a registration so the transferred one can be changed while the
+// original is still around to be deleted.
+func cloneRegistration(reg Registration) Registration {
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		c := *r
+		c.DuplicatePlayerEmails = slices.Clone(r.DuplicatePlayerEmails)
+		c.AdminNotes = slices.Clone(r.AdminNotes)
+		c.Tags = slices.Clone(r.Tags)
+		return &c
+	case *TeamRegistration:
+		c := *r
+		c.Players = slices.Clone(r.Players)
+		c.DuplicatePlayerEmails = slices.Clone(r.DuplicatePlayerEmails)
+		c.AdminNotes = slices.Clone(r.AdminNotes)
+		c.Tags = slices.Clone(r.Tags)
+		return &c
+	}
+	panic("unknown registration type")
+}
+
+// changeRegistrant makes player the registrant. On a team they take the captain's spot on the roster.
+func changeRegistrant(reg Registration, player PlayerInfo, now time.Time) error {
+	newEmail := strings.ToLower(*player.Email)
+	oldEmail := strings.ToLower(reg.GetEmail())
+
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		r.Email = newEmail
+		r.PlayerInfo = PlayerInfo{
+			FirstName: player.FirstName,
+			LastName:  player.LastName,
+			Email:     ptr.String(newEmail),
+		}
+		r.DuplicatePlayerEmails = slices.DeleteFunc(r.DuplicatePlayerEmails, func(e string) bool { return e == oldEmail })
+	case *TeamRegistration:
+		captainIdx := captainPlayerIndex(r)
+		if slices.ContainsFunc(r.Players, func(p PlayerInfo) bool { return p.Email != nil && strings.EqualFold(*p.Email, newEmail) }) {
+			return NewNotTransferableError(fmt.Sprintf("%s is already on the team", newEmail))
+		}
+
+		r.CaptainEmail = newEmail
+		if captainIdx != -1 {
+			r.Players[captainIdx] = PlayerInfo{
+				FirstName:    player.FirstName,
+				LastName:     player.LastName,
+				Email:        ptr.String(newEmail),
+				RosterStatus: ROSTER_CONFIRMED,
+				InviteToken:  uuid.NewString(),
+				ConfirmedAt:  ptr.Time(now),
+			}
+		}
+		r.DuplicatePlayerEmails = slices.DeleteFunc(r.DuplicatePlayerEmails, func(e string) bool { return e == oldEmail })
+	}
+	return nil
+}
+
+// moveRegistration takes the registration out of oldEvent's counts and puts it in newEvent's,
+// checking the new event is still taking registrations like it.
+func moveRegistration(oldEvent *events.Event, newEvent *events.Event, reg Registration, now time.Time) error {
+	switch r := reg.(type) {
+	case *IndividualRegistration:
+		// Checked on a copy so the registration keeps when it first signed up
+		check := *r
+		check.RegisteredAt = now
+		err := registerIndividualAsFreeAgent(newEvent, &check, false)
+		if err != nil {
+			return err
+		}
+		unregisterIndividualFromEvent(oldEvent)
+		r.EventID = newEvent.ID
+		// Whoever was allowed to be on two registrations was allowed for the old event only
+		r.DuplicatePlayerEmails = nil
+	case *TeamRegistration:
+		// registerTeam sends out new roster invites, which players who already confirmed don't need
+		check := *r
+		check.RegisteredAt = now
+		check.Players = slices.Clone(r.Players)
+		err := registerTeam(newEvent, &check, false)
+		if err != nil {
+			return err
+		}
+		unregisterTeamFromEvent(oldEvent, r)
+		r.EventID = newEvent.ID
+		r.DuplicatePlayerEmails = nil
+	}
+	return nil
+}
+
+// priceDifference is the new event's price minus the old one's, or nil if they cost the same.
+func priceDifference(oldEvent events.Event, newEvent events.Event, regType events.RegistrationType) (*money.Money, error) {
+	oldPrice, err := registrationPrice(oldEvent, regType)
+	if err != nil {
+		return nil, err
+	}
+	newPrice, err := registrationPrice(newEvent, regType)
+	if err != nil {
+		return nil, err
+	}
+	difference, err := newPrice.Subtract(oldPrice)
+	if err != nil {
+		return nil, NewNotTransferableError("Events are priced in different currencies")
+	}
+	if difference.IsZero() {
+		return nil, nil
+	}
+	return difference, nil
+}
+
+// refundPriceDifference gives back a negative price difference from the original payment and
+// records the refund on to.
+func refundPriceDifference(ctx context.Context, transfer *Transfer, newEvent events.Event, from Registration, to Registration, paymentQuerier payments.PaymentQuerier, refunder Refunder) error {
+	regPayments, err := findRegistrationPayments(ctx, paymentQuerier, from)
+	if err != nil {
+		return err
+	}
+	amount := transfer.PriceDifference.Absolute()
+	remaining, err := totalRemainingAmount(regPayments, from.GetRefunds())
+	if err != nil {
+		return err
+	}
+	// Whatever was already refunded can't be given back twice
+	if tooMuch, err := amount.GreaterThan(remaining); err == nil && tooMuch {
+		amount = remaining
+	}
+	if !amount.IsPositive() {
+		return nil
+	}
+
+	refunds, err := refundPayments(ctx, refunder, from, regPayments, amount, fmt.Sprintf("Transferred to %s", newEvent.Name), transfer.TransferredBy, transfer.TransferredAt, false)
+	if err != nil {
+		return err
+	}
+
+	for _, refund := range refunds {
+		to.AddRefund(refund)
+	}
+	// The first of them if the difference was spread over several payments, they're all on the registration
+	transfer.RefundID = &refunds[0].ID
+	return nil
+}
+
+func registrationPrice(event events.Event, regType events.RegistrationType) (*money.Money, error) {
+	idx := slices.IndexFunc(event.RegistrationOptions, func(v events.EventRegistrationOption) bool { return v.RegType == regType })
+	if idx == -1 {
+		return nil, NewNotAllowedToSignUpAsTypeError(regType)
+	}
+	return event.RegistrationOptions[idx].Price, nil
+}
@@ -0,0 +1,447 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/event-registration/events"
+	"github.com/International-Combat-Archery-Alliance/event-registration/ptr"
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/Rhymond/go-money"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferRegistration(t *testing.T) {
+	oldEventId := uuid.New()
+	newEventId := uuid.New()
+	now := time.Now()
+
+	eventWithPrice := func(id uuid.UUID, price int64) events.Event {
+		return events.Event{
+			ID:                    id,
+			Name:                  "Event",
+			Version:               4,
+			NumTotalPlayers:       10,
+			NumTeams:              2,
+			NumRosteredPlayers:    8,
+			RegistrationCloseTime: now.Add(time.Hour),
+			AllowedTeamSizeRange:  events.Range{Min: 2, Max: 6},
+			RegistrationOptions: []events.EventRegistrationOption{
+				{RegType: events.BY_INDIVIDUAL, Price: money.New(price, "USD")},
+				{RegType: events.BY_TEAM, Price: money.New(price*4, "USD")},
+			},
+		}
+	}
+	newEventRepo := func(oldPrice, newPrice int64) *mockEventRepository {
+		return &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				switch id {
+				case oldEventId:
+					return eventWithPrice(id, oldPrice), nil
+				case newEventId:
+					return eventWithPrice(id, newPrice), nil
+				}
+				return events.Event{}, &events.Error{Reason: events.REASON_EVENT_DOES_NOT_EXIST}
+			},
+		}
+	}
+	newRepo := func(reg Registration, saved func(from, to Registration, eventUpdates []events.Event)) *mockRegistrationRepository {
+		return &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, eventId uuid.UUID, email string) (Registration, error) {
+				return reg, nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error) {
+				return RegistrationIntent{}, NewRegistrationDoesNotExistsError("no intent", nil)
+			},
+			TransferRegistrationFunc: func(ctx context.Context, from, to Registration, eventUpdates []events.Event) error {
+				if saved != nil {
+					saved(from, to, eventUpdates)
+				}
+				return nil
+			},
+		}
+	}
+	paidIndividual := func() *IndividualRegistration {
+		return &IndividualRegistration{
+			EventID:    oldEventId,
+			Version:    2,
+			Status:     STATUS_PAID,
+			Email:      "old@example.com",
+			PlayerInfo: PlayerInfo{FirstName: "Old", LastName: "Player", Email: ptr.String("old@example.com")},
+		}
+	}
+
+	t.Run("hand an individual registration to someone else", func(t *testing.T) {
+		var from, to Registration
+		var eventUpdates []events.Event
+		repo := newRepo(paidIndividual(), func(f, tr Registration, e []events.Event) { from, to, eventUpdates = f, tr, e })
+
+		result, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:       oldEventId,
+			Email:         "old@example.com",
+			NewPlayer:     &PlayerInfo{FirstName: "New", LastName: "Player", Email: ptr.String("New@example.com")},
+			Reason:        "Can't make it",
+			TransferredBy: "old@example.com",
+		}, repo, newEventRepo(5000, 5000), &mockCheckoutManager{}, &mockPaymentQuerier{}, &mockRefunder{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "old@example.com", from.GetEmail())
+		assert.Equal(t, "new@example.com", to.GetEmail())
+		assert.Equal(t, "New", to.(*IndividualRegistration).PlayerInfo.FirstName)
+		assert.Equal(t, 3, to.(*IndividualRegistration).Version)
+		assert.Empty(t, eventUpdates)
+		assert.Empty(t, result.ClientSecret)
+
+		require.Len(t, to.GetTransfers(), 1)
+		transfer := to.GetTransfers()[0]
+		assert.Equal(t, "old@example.com", transfer.FromEmail)
+		assert.Equal(t, "new@example.com", transfer.ToEmail)
+		assert.Equal(t, oldEventId, transfer.ToEventID)
+		assert.Nil(t, transfer.PriceDifference)
+		assert.Equal(t, "Can't make it", transfer.Reason)
+	})
+
+	t.Run("new captain takes over the captain's roster spot", func(t *testing.T) {
+		var to Registration
+		repo := newRepo(&TeamRegistration{
+			EventID:      oldEventId,
+			Version:      1,
+			Status:       STATUS_PAID,
+			CaptainEmail: "captain@example.com",
+			Players: []PlayerInfo{
+				{FirstName: "Cap", Email: ptr.String("captain@example.com"), RosterStatus: ROSTER_CONFIRMED},
+				{FirstName: "Player", Email: ptr.String("player@example.com"), RosterStatus: ROSTER_CONFIRMED},
+			},
+		}, func(_, tr Registration, _ []events.Event) { to = tr })
+
+		_, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:   oldEventId,
+			Email:     "captain@example.com",
+			NewPlayer: &PlayerInfo{FirstName: "NewCap", Email: ptr.String("newcap@example.com")},
+		}, repo, newEventRepo(5000, 5000), &mockCheckoutManager{}, &mockPaymentQuerier{}, &mockRefunder{})
+		require.NoError(t, err)
+
+		teamReg := to.(*TeamRegistration)
+		assert.Equal(t, "newcap@example.com", teamReg.CaptainEmail)
+		assert.Equal(t, "NewCap", teamReg.Players[0].FirstName)
+		assert.Equal(t, ROSTER_CONFIRMED, teamReg.Players[0].RosterStatus)
+		assert.Equal(t, ROSTER_CONFIRMED, teamReg.Players[1].RosterStatus)
+	})
+
+	t.Run("new captain already on the team", func(t *testing.T) {
+		repo := newRepo(&TeamRegistration{
+			EventID:      oldEventId,
+			Status:       STATUS_PAID,
+			CaptainEmail: "captain@example.com",
+			Players: []PlayerInfo{
+				{Email: ptr.String("captain@example.com")},
+				{Email: ptr.String("player@example.com")},
+			},
+		}, nil)
+
+		_, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:   oldEventId,
+			Email:     "captain@example.com",
+			NewPlayer: &PlayerInfo{Email: ptr.String("player@example.com")},
+		}, repo, newEventRepo(5000, 5000), &mockCheckoutManager{}, &mockPaymentQuerier{}, &mockRefunder{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_NOT_TRANSFERABLE, registrationErr.Reason)
+	})
+
+	t.Run("move to a more expensive event waits on the difference being paid", func(t *testing.T) {
+		var pending Registration
+		var intent RegistrationIntent
+		var checkoutParams payments.CheckoutParams
+		repo := newRepo(paidIndividual(), func(_, _ Registration, _ []events.Event) {
+			t.Fatal("registration was moved before the difference was paid")
+		})
+		repo.CreateTransferWithPaymentFunc = func(ctx context.Context, reg Registration, regIntent RegistrationIntent) error {
+			pending = reg
+			intent = regIntent
+			return nil
+		}
+		checkoutManager := &mockCheckoutManager{
+			CreateCheckoutFunc: func(ctx context.Context, params payments.CheckoutParams) (payments.CheckoutInfo, error) {
+				checkoutParams = params
+				return payments.CheckoutInfo{ClientSecret: "transfer_secret", SessionId: "cs_transfer"}, nil
+			},
+		}
+
+		result, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:    oldEventId,
+			Email:      "old@example.com",
+			NewEventID: &newEventId,
+			NewPlayer:  &PlayerInfo{FirstName: "New", Email: ptr.String("new@example.com"), MedicalNotes: "Asthma"},
+		}, repo, newEventRepo(5000, 7000), checkoutManager, &mockPaymentQuerier{}, &mockRefunder{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "transfer_secret", result.ClientSecret)
+		assert.Equal(t, int64(2000), result.Transfer.PriceDifference.Amount())
+		assert.False(t, result.Transfer.ChargePaid)
+		assert.Equal(t, int64(2000), checkoutParams.Items[0].Price.Amount())
+		assert.Equal(t, result.Transfer.ID.String(), checkoutParams.Metadata[transferIdKey])
+		// The checkout is under where the registration still is
+		assert.Equal(t, oldEventId.String(), checkoutParams.Metadata[eventIdKey])
+		assert.Equal(t, "old@example.com", checkoutParams.Metadata[emailKey])
+
+		require.NotNil(t, pending)
+		assert.Equal(t, oldEventId, pending.GetEventID())
+		assert.Equal(t, "old@example.com", pending.GetEmail())
+		assert.Equal(t, 3, pending.GetVersion())
+		assert.Empty(t, pending.GetTransfers())
+		require.NotNil(t, pending.GetPendingTransfer())
+		assert.Equal(t, result.Transfer.ID, pending.GetPendingTransfer().Transfer.ID)
+		assert.Equal(t, newEventId, pending.GetPendingTransfer().Transfer.ToEventID)
+		assert.Equal(t, "new@example.com", pending.GetPendingTransfer().Transfer.ToEmail)
+		assert.Equal(t, "New", pending.GetPendingTransfer().NewPlayer.FirstName)
+		assert.Empty(t, pending.GetPendingTransfer().NewPlayer.MedicalNotes)
+
+		assert.Equal(t, oldEventId, intent.EventId)
+		assert.Equal(t, "old@example.com", intent.Email)
+		assert.Equal(t, "cs_transfer", intent.PaymentSessionId)
+		assert.Equal(t, "transfer_secret", intent.ClientSecret)
+		assert.Equal(t, result.Transfer.ID, *intent.TransferID)
+
+		assert.Equal(t, oldEventId, result.Registration.GetEventID())
+		assert.Equal(t, oldEventId, result.Event.ID)
+	})
+
+	t.Run("move to a cheaper event refunds the difference", func(t *testing.T) {
+		refunder := &mockRefunder{}
+		paymentQuerier := &mockPaymentQuerier{
+			Payments: []payments.Payment{{ID: "ch_123", Amount: money.New(5000, "USD")}},
+		}
+		repo := newRepo(paidIndividual(), nil)
+
+		result, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:    oldEventId,
+			Email:      "old@example.com",
+			NewEventID: &newEventId,
+		}, repo, newEventRepo(5000, 3000), &mockCheckoutManager{}, paymentQuerier, refunder)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(-2000), result.Transfer.PriceDifference.Amount())
+		require.Len(t, refunder.refunded, 1)
+		assert.Equal(t, int64(2000), refunder.refunded[0].Amount())
+		require.Len(t, result.Registration.GetRefunds(), 1)
+		assert.Equal(t, result.Registration.GetRefunds()[0].ID, *result.Transfer.RefundID)
+		assert.Equal(t, STATUS_PAID, result.Registration.GetStatus())
+	})
+
+	t.Run("new event is closed", func(t *testing.T) {
+		eventRepo := &mockEventRepository{
+			GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+				event := eventWithPrice(id, 5000)
+				if id == newEventId {
+					event.RegistrationCloseTime = now.Add(-time.Hour)
+				}
+				return event, nil
+			},
+		}
+
+		_, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:    oldEventId,
+			Email:      "old@example.com",
+			NewEventID: &newEventId,
+		}, newRepo(paidIndividual(), nil), eventRepo, &mockCheckoutManager{}, &mockPaymentQuerier{}, &mockRefunder{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_REGISTRATION_IS_CLOSED, registrationErr.Reason)
+	})
+
+	t.Run("nothing changes", func(t *testing.T) {
+		_, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:    oldEventId,
+			Email:      "old@example.com",
+			NewEventID: &oldEventId,
+		}, newRepo(paidIndividual(), nil), newEventRepo(5000, 5000), &mockCheckoutManager{}, &mockPaymentQuerier{}, &mockRefunder{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_NOT_TRANSFERABLE, registrationErr.Reason)
+	})
+
+	t.Run("refunded registration", func(t *testing.T) {
+		reg := paidIndividual()
+		reg.Status = STATUS_REFUNDED
+
+		_, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:    oldEventId,
+			Email:      "old@example.com",
+			NewEventID: &newEventId,
+		}, newRepo(reg, nil), newEventRepo(5000, 5000), &mockCheckoutManager{}, &mockPaymentQuerier{}, &mockRefunder{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_NOT_TRANSFERABLE, registrationErr.Reason)
+	})
+
+	t.Run("checkout in progress", func(t *testing.T) {
+		repo := newRepo(paidIndividual(), nil)
+		repo.GetRegistrationIntentFunc = func(ctx context.Context, eventId uuid.UUID, email string) (RegistrationIntent, error) {
+			return RegistrationIntent{EventId: eventId, Email: email}, nil
+		}
+
+		_, err := TransferRegistration(context.Background(), TransferParams{
+			EventID:    oldEventId,
+			Email:      "old@example.com",
+			NewEventID: &newEventId,
+		}, repo, newEventRepo(5000, 5000), &mockCheckoutManager{}, &mockPaymentQuerier{}, &mockRefunder{})
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_NOT_TRANSFERABLE, registrationErr.Reason)
+	})
+}
+
+func TestConfirmTransferPayment(t *testing.T) {
+	oldEventId := uuid.New()
+	newEventId := uuid.New()
+	transferId := uuid.New()
+	now := time.Now()
+
+	eventRepo := &mockEventRepository{
+		GetEventFunc: func(ctx context.Context, id uuid.UUID) (events.Event, error) {
+			return events.Event{
+				ID:                    id,
+				Version:               4,
+				NumTotalPlayers:       10,
+				RegistrationCloseTime: now.Add(time.Hour),
+				RegistrationOptions: []events.EventRegistrationOption{
+					{RegType: events.BY_INDIVIDUAL, Price: money.New(5000, "USD")},
+				},
+			}, nil
+		},
+	}
+	newCheckoutManager := func(checkoutErr error) *mockCheckoutManager {
+		return &mockCheckoutManager{
+			ConfirmCheckoutFunc: func(ctx context.Context, payload []byte, signature string) (map[string]string, error) {
+				return map[string]string{
+					emailKey:      "old@example.com",
+					eventIdKey:    oldEventId.String(),
+					itemTypeKey:   itemTypeEvent,
+					transferIdKey: transferId.String(),
+				}, checkoutErr
+			},
+		}
+	}
+	pendingIndividual := func() *IndividualRegistration {
+		return &IndividualRegistration{
+			EventID:    oldEventId,
+			Version:    3,
+			Status:     STATUS_PAID,
+			Email:      "old@example.com",
+			PlayerInfo: PlayerInfo{FirstName: "Old", Email: ptr.String("old@example.com")},
+			PendingTransfer: &PendingTransfer{
+				Transfer: Transfer{
+					ID:              transferId,
+					FromEventID:     oldEventId,
+					FromEmail:       "old@example.com",
+					ToEventID:       newEventId,
+					ToEmail:         "new@example.com",
+					PriceDifference: money.New(2000, "USD"),
+					TransferredAt:   now,
+				},
+				NewPlayer: &PlayerInfo{FirstName: "New", Email: ptr.String("new@example.com")},
+			},
+		}
+	}
+	transferIntent := RegistrationIntent{Version: 1, EventId: oldEventId, Email: "old@example.com", PaymentSessionId: "cs_transfer", TransferID: &transferId}
+
+	t.Run("moves the registration once the difference is paid", func(t *testing.T) {
+		var to Registration
+		var eventUpdates []events.Event
+		var outbox []OutboxItem
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return pendingIndividual(), nil
+			},
+			TransferRegistrationToPaidFunc: func(ctx context.Context, from Registration, moved Registration, e []events.Event, o []OutboxItem) error {
+				to = moved
+				eventUpdates = e
+				outbox = o
+				return nil
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_transfer"), "", repo, eventRepo, &mockWebhookEventRepository{}, newCheckoutManager(nil))
+		require.NoError(t, err)
+
+		require.NotNil(t, to)
+		assert.Equal(t, newEventId, to.GetEventID())
+		assert.Equal(t, "new@example.com", to.GetEmail())
+		assert.Equal(t, 4, to.GetVersion())
+		assert.Nil(t, to.GetPendingTransfer())
+		require.Len(t, to.GetTransfers(), 1)
+		assert.True(t, to.GetTransfers()[0].ChargePaid)
+		assert.Equal(t, STATUS_PAID, to.GetStatus())
+
+		require.Len(t, eventUpdates, 2)
+		assert.Equal(t, 9, eventUpdates[0].NumTotalPlayers)
+		assert.Equal(t, 11, eventUpdates[1].NumTotalPlayers)
+
+		require.Len(t, outbox, 1)
+		assert.Equal(t, OUTBOX_CONFIRMATION_EMAIL, outbox[0].Kind)
+		assert.Equal(t, newEventId, outbox[0].EventID)
+		assert.Equal(t, "new@example.com", outbox[0].Email)
+	})
+
+	t.Run("transfer that is no longer pending is stale", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return nil, NewRegistrationDoesNotExistsError("already moved", nil)
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_transfer"), "", repo, eventRepo, &mockWebhookEventRepository{}, newCheckoutManager(nil))
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_STALE_WEBHOOK_EVENT, registrationErr.Reason)
+	})
+
+	t.Run("expired checkout drops the pending transfer", func(t *testing.T) {
+		var saved Registration
+		var deletedIntent RegistrationIntent
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, email string) (Registration, error) {
+				return pendingIndividual(), nil
+			},
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return transferIntent, nil
+			},
+			DeleteExpiredTransferFunc: func(ctx context.Context, reg Registration, intent RegistrationIntent) error {
+				saved = reg
+				deletedIntent = intent
+				return nil
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_transfer"), "", repo, eventRepo, &mockWebhookEventRepository{}, newCheckoutManager(&payments.Error{Reason: payments.ErrorReasonCheckoutExpired}))
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_TRANSFER_CHECKOUT_EXPIRED, registrationErr.Reason)
+
+		require.NotNil(t, saved)
+		assert.Equal(t, oldEventId, saved.GetEventID())
+		assert.Equal(t, "old@example.com", saved.GetEmail())
+		assert.Equal(t, 4, saved.GetVersion())
+		assert.Nil(t, saved.GetPendingTransfer())
+		assert.Empty(t, saved.GetTransfers())
+		assert.Equal(t, transferIntent, deletedIntent)
+	})
+
+	t.Run("expiry of a checkout the registration isn't waiting on is stale", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, email string) (RegistrationIntent, error) {
+				return transferIntent, nil
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_test", "cs_older"), "", repo, eventRepo, &mockWebhookEventRepository{}, newCheckoutManager(&payments.Error{Reason: payments.ErrorReasonCheckoutExpired}))
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_STALE_WEBHOOK_EVENT, registrationErr.Reason)
+	})
+}
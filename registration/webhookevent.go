@@ -0,0 +1,62 @@
+package registration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// How long a claim on a webhook event keeps other deliveries of it away while it's handled. If handling
+// never finishes, like when the process is killed partway through, the claim lapses so the provider's
+// retry can take it over instead of being turned away as already handled.
+const webhookEventClaimLease = time.Minute
+
+// WebhookEventRepository remembers which of the payment provider's webhook events were already handled,
+// since it can deliver the same event more than once.
+type WebhookEventRepository interface {
+	// ClaimWebhookEvent records that the event is being handled until leasedUntil, failing with
+	// REASON_WEBHOOK_EVENT_ALREADY_HANDLED if it was completed or another claim on it hasn't lapsed yet.
+	ClaimWebhookEvent(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error
+	// CompleteWebhookEvent records that a claimed event was handled, so it's never handled again.
+	CompleteWebhookEvent(ctx context.Context, webhookEventId string, completedAt time.Time) error
+	// ReleaseWebhookEvent forgets a claimed event so the payment provider's retry of it is handled again.
+	ReleaseWebhookEvent(ctx context.Context, webhookEventId string) error
+}
+
+// webhookDelivery is the part of the payment provider's webhook payload the checkout manager doesn't give back.
+type webhookDelivery struct {
+	ID   string `json:"id"`
+	Data struct {
+		Object struct {
+			// The checkout session the event is about
+			ID string `json:"id"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+func parseWebhookDelivery(payload []byte) (webhookDelivery, error) {
+	var delivery webhookDelivery
+	err := json.Unmarshal(payload, &delivery)
+	if err != nil {
+		return webhookDelivery{}, NewInvalidPaymentMetadata("Webhook payload is not valid JSON", err)
+	}
+	if delivery.ID == "" {
+		return webhookDelivery{}, NewInvalidPaymentMetadata("Webhook payload has no event ID", nil)
+	}
+	return delivery, nil
+}
+
+// keepsWebhookClaim is if handling a webhook event failed in a way that retrying it won't change.
+func keepsWebhookClaim(err error) bool {
+	var regErr *Error
+	if !errors.As(err, &regErr) {
+		return false
+	}
+	switch regErr.Reason {
+	case REASON_REGISTRATION_EXPIRED, REASON_SHARE_CHECKOUT_EXPIRED, REASON_TRANSFER_CHECKOUT_EXPIRED, REASON_STALE_WEBHOOK_EVENT:
+		return true
+	default:
+		return false
+	}
+}
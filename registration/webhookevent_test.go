@@ -0,0 +1,195 @@
+package registration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/International-Combat-Archery-Alliance/payments"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registrationCheckoutManager(eventId uuid.UUID, email string, checkoutErr error) *mockCheckoutManager {
+	return &mockCheckoutManager{
+		ConfirmCheckoutFunc: func(ctx context.Context, payload []byte, signature string) (map[string]string, error) {
+			return map[string]string{
+				"EMAIL":     email,
+				"EVENT_ID":  eventId.String(),
+				"ITEM_TYPE": "event_registration",
+			}, checkoutErr
+		},
+	}
+}
+
+func TestConfirmRegistrationPaymentWebhookEvents(t *testing.T) {
+	eventId := uuid.New()
+	email := "webhook@example.com"
+
+	t.Run("claims the event before handling it", func(t *testing.T) {
+		var claimed, completed []string
+		webhookEvents := &mockWebhookEventRepository{
+			ClaimWebhookEventFunc: func(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error {
+				claimed = append(claimed, webhookEventId)
+				assert.Equal(t, claimedAt.Add(webhookEventClaimLease), leasedUntil)
+				return nil
+			},
+			CompleteWebhookEventFunc: func(ctx context.Context, webhookEventId string, completedAt time.Time) error {
+				assert.Equal(t, []string{"evt_paid"}, claimed, "completed before it was claimed")
+				completed = append(completed, webhookEventId)
+				return nil
+			},
+			ReleaseWebhookEventFunc: func(ctx context.Context, webhookEventId string) error {
+				t.Fatal("should not release a handled event")
+				return nil
+			},
+		}
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, regEmail string) (Registration, error) {
+				return &IndividualRegistration{EventID: eventId, Email: email, Version: 1, Status: STATUS_PENDING}, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+				return nil
+			},
+		}
+
+		reg, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_paid", "cs_1"), "", repo, &mockEventRepository{}, webhookEvents, registrationCheckoutManager(eventId, email, nil))
+		require.NoError(t, err)
+		assert.Equal(t, STATUS_PAID, reg.GetStatus())
+		assert.Equal(t, []string{"evt_paid"}, claimed)
+		assert.Equal(t, []string{"evt_paid"}, completed)
+	})
+
+	t.Run("duplicate delivery does nothing", func(t *testing.T) {
+		webhookEvents := &mockWebhookEventRepository{
+			ClaimWebhookEventFunc: func(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error {
+				return NewWebhookEventAlreadyHandledError("already handled")
+			},
+		}
+
+		// The repository mocks panic if anything is read or written
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_paid", "cs_1"), "", &mockRegistrationRepository{}, &mockEventRepository{}, webhookEvents, registrationCheckoutManager(eventId, email, nil))
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_WEBHOOK_EVENT_ALREADY_HANDLED, registrationErr.Reason)
+	})
+
+	t.Run("payload without an event ID", func(t *testing.T) {
+		webhookEvents := &mockWebhookEventRepository{
+			ClaimWebhookEventFunc: func(ctx context.Context, webhookEventId string, claimedAt time.Time, leasedUntil time.Time) error {
+				t.Fatal("should not claim an event without an ID")
+				return nil
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), []byte(`{"data": {}}`), "", &mockRegistrationRepository{}, &mockEventRepository{}, webhookEvents, registrationCheckoutManager(eventId, email, nil))
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_INVALID_PAYMENT_METADATA, registrationErr.Reason)
+	})
+
+	t.Run("failure releases the event for the retry", func(t *testing.T) {
+		var released []string
+		webhookEvents := &mockWebhookEventRepository{
+			CompleteWebhookEventFunc: func(ctx context.Context, webhookEventId string, completedAt time.Time) error {
+				t.Fatal("should not complete a failed event")
+				return nil
+			},
+			ReleaseWebhookEventFunc: func(ctx context.Context, webhookEventId string) error {
+				released = append(released, webhookEventId)
+				return nil
+			},
+		}
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, regEmail string) (Registration, error) {
+				return &IndividualRegistration{EventID: eventId, Email: email, Version: 1, Status: STATUS_PENDING}, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+				return NewTimeoutError("UpdateRegistrationToPaid timed out")
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_paid", "cs_1"), "", repo, &mockEventRepository{}, webhookEvents, registrationCheckoutManager(eventId, email, nil))
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_TIMEOUT, registrationErr.Reason)
+		assert.Equal(t, []string{"evt_paid"}, released)
+	})
+
+	t.Run("failing to complete the event leaves the claim to lapse", func(t *testing.T) {
+		webhookEvents := &mockWebhookEventRepository{
+			CompleteWebhookEventFunc: func(ctx context.Context, webhookEventId string, completedAt time.Time) error {
+				return NewTimeoutError("CompleteWebhookEvent timed out")
+			},
+			ReleaseWebhookEventFunc: func(ctx context.Context, webhookEventId string) error {
+				t.Fatal("should not release an event that was handled")
+				return nil
+			},
+		}
+		repo := &mockRegistrationRepository{
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, regEmail string) (Registration, error) {
+				return &IndividualRegistration{EventID: eventId, Email: email, Version: 1, Status: STATUS_PENDING}, nil
+			},
+			UpdateRegistrationToPaidFunc: func(ctx context.Context, registration Registration, outbox []OutboxItem) error {
+				return nil
+			},
+		}
+
+		reg, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_paid", "cs_1"), "", repo, &mockEventRepository{}, webhookEvents, registrationCheckoutManager(eventId, email, nil))
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_TIMEOUT, registrationErr.Reason)
+		assert.Equal(t, STATUS_PAID, reg.GetStatus())
+	})
+
+	t.Run("late expiry after the registration was paid", func(t *testing.T) {
+		var completed []string
+		webhookEvents := &mockWebhookEventRepository{
+			CompleteWebhookEventFunc: func(ctx context.Context, webhookEventId string, completedAt time.Time) error {
+				completed = append(completed, webhookEventId)
+				return nil
+			},
+			ReleaseWebhookEventFunc: func(ctx context.Context, webhookEventId string) error {
+				t.Fatal("should keep the claim on a stale event")
+				return nil
+			},
+		}
+		repo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, regEmail string) (RegistrationIntent, error) {
+				// Deleted when the registration was paid
+				return RegistrationIntent{}, NewRegistrationDoesNotExistsError("not found", nil)
+			},
+			GetRegistrationFunc: func(ctx context.Context, id uuid.UUID, regEmail string) (Registration, error) {
+				return &IndividualRegistration{EventID: eventId, Email: email, Version: 2, Status: STATUS_PAID}, nil
+			},
+		}
+
+		reg, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_expired", "cs_1"), "", repo, &mockEventRepository{}, webhookEvents, registrationCheckoutManager(eventId, email, payments.NewCheckoutExpiredError("expired")))
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_STALE_WEBHOOK_EVENT, registrationErr.Reason)
+		assert.Nil(t, reg)
+		assert.Equal(t, []string{"evt_expired"}, completed)
+	})
+
+	t.Run("expiry of an earlier checkout than the open one", func(t *testing.T) {
+		repo := &mockRegistrationRepository{
+			GetRegistrationIntentFunc: func(ctx context.Context, id uuid.UUID, regEmail string) (RegistrationIntent, error) {
+				return RegistrationIntent{EventId: eventId, Email: email, Version: 1, PaymentSessionId: "cs_2"}, nil
+			},
+		}
+
+		_, err := ConfirmRegistrationPayment(context.Background(), webhookPayload("evt_expired", "cs_1"), "", repo, &mockEventRepository{}, &mockWebhookEventRepository{}, registrationCheckoutManager(eventId, email, payments.NewCheckoutExpiredError("expired")))
+
+		var registrationErr *Error
+		require.True(t, errors.As(err, &registrationErr))
+		assert.Equal(t, REASON_STALE_WEBHOOK_EVENT, registrationErr.Reason)
+	})
+}